@@ -2,24 +2,35 @@
 // like sunrise and sunset for a given location and date.
 //
 // The public API is designed to remain stable while the internal
-// implementations evolve from simple approximate algorithms (Level 1)
-// to high-precision ephemeris-grade models (Level 3).
+// implementations evolve from simple approximate algorithms to
+// high-precision ephemeris-grade models. Callers pick the trade-off with
+// WithPrecision: Level1 uses closed-form approximations, Level2 (the
+// default) a sampled numerical solver, and Level3 is reserved for the
+// high-precision series.
 //
-// Currently implemented:
-//   - Sun rise/set via SlideIntoSunset and RiseSetFor(Sun, ...)
+// The package does no I/O and never loads time zones itself: dates are
+// interpreted in whatever location the caller attaches to them. That keeps
+// it buildable for js/wasm, wasip1 and TinyGo (see cmd/astroglide-wasm).
 //
-// Future plans include:
-//   - High-precision solar calculations
-//   - Lunar rise/set and additional bodies
-//   - Twilight, altitude solvers, and more.
+// Implemented so far:
+//   - Sun and Moon rise, set and transit (RiseSetFor, SlideIntoSunset,
+//     NextEvent)
+//   - Twilight, golden and blue hour and other altitude windows
+//   - Moon phases, positions and eclipse seasons
+//   - Stars, comets and asteroids through an EphemerisProvider (see the
+//     stars package and OrbitalElements)
+//
+// The high-precision series behind Level3 are still to come.
 package astroglide
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"time"
 
+	"github.com/thurmanmarka/astroglide/internal/coord"
 	"github.com/thurmanmarka/astroglide/internal/moon"
 	"github.com/thurmanmarka/astroglide/internal/sun"
 	"github.com/thurmanmarka/astroglide/internal/timeutil"
@@ -41,13 +52,53 @@ const (
 
 	// TwilightAstronomical corresponds to the Sun's center at -18 degrees altitude.
 	TwilightAstronomical
+
+	// TwilightSunCenter corresponds to the Sun's center on the geometric
+	// horizon (0 degrees, no refraction), a definition some aviation and
+	// military tables use for sunrise/sunset.
+	TwilightSunCenter
 )
 
 const (
 	Sun Body = iota
 	Moon
+
+	// CustomBody names an object whose positions an EphemerisProvider
+	// supplies, in the errors of ObjectRiseSetFor and ObjectTransitFor.
+	// Functions that take a Body do not accept it.
+	CustomBody
 )
 
+// String returns the body's name.
+func (b Body) String() string {
+	switch b {
+	case Sun:
+		return "Sun"
+	case Moon:
+		return "Moon"
+	case CustomBody:
+		return "custom body"
+	default:
+		return fmt.Sprintf("Body(%d)", int(b))
+	}
+}
+
+// String returns the twilight name, e.g. "civil twilight".
+func (k TwilightKind) String() string {
+	switch k {
+	case TwilightCivil:
+		return "civil twilight"
+	case TwilightNautical:
+		return "nautical twilight"
+	case TwilightAstronomical:
+		return "astronomical twilight"
+	case TwilightSunCenter:
+		return "sun-center horizon"
+	default:
+		return fmt.Sprintf("TwilightKind(%d)", int(k))
+	}
+}
+
 // Coordinates represent an observer's location.
 type Coordinates struct {
 	Lat       float64 // degrees, north positive
@@ -56,9 +107,81 @@ type Coordinates struct {
 }
 
 // RiseSet holds rise and set times of a body on a given date.
+//
+// A body can rise without setting on a date (or vice versa), e.g. the Moon
+// on the day its rise moves past midnight. HasRise and HasSet tell which
+// events exist; a missing event is left as the zero time and is encoded as
+// null in JSON.
 type RiseSet struct {
 	Rise time.Time
 	Set  time.Time
+
+	HasRise bool
+	HasSet  bool
+
+	// Uncertainty estimates how far Rise and Set may be from the instants
+	// the models predict: the model and solver error, which depends on the
+	// precision level and on how steeply the body crosses the horizon, plus
+	// half of any WithRounding step. Show seconds only when it is under a
+	// few seconds. Real-world refraction adds a minute or more near the
+	// horizon and is not included.
+	Uncertainty time.Duration
+
+	// Date is local midnight of the calendar date the events were computed
+	// for. With WithTrueInstants, Rise or Set may fall on a neighbouring
+	// date; Date still says which day they belong to.
+	Date time.Time
+}
+
+// riseSetJSON is the JSON form of a RiseSet.
+type riseSetJSON struct {
+	Rise        *time.Time
+	Set         *time.Time
+	HasRise     bool
+	HasSet      bool
+	Uncertainty string
+	Date        time.Time
+}
+
+// MarshalJSON encodes missing events as null rather than the zero time
+// (0001-01-01T00:00:00Z), and Uncertainty as a duration string such as
+// "4s" or "1m30s" rather than a count of nanoseconds.
+func (rs RiseSet) MarshalJSON() ([]byte, error) {
+	out := riseSetJSON{HasRise: rs.HasRise, HasSet: rs.HasSet, Uncertainty: rs.Uncertainty.String(), Date: rs.Date}
+
+	if rs.HasRise {
+		out.Rise = &rs.Rise
+	}
+	if rs.HasSet {
+		out.Set = &rs.Set
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON reads the form written by MarshalJSON. A null Rise or Set
+// leaves the zero time, and Uncertainty is parsed with time.ParseDuration.
+func (rs *RiseSet) UnmarshalJSON(b []byte) error {
+	var in riseSetJSON
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	out := RiseSet{HasRise: in.HasRise, HasSet: in.HasSet, Date: in.Date}
+	if in.Rise != nil {
+		out.Rise = *in.Rise
+	}
+	if in.Set != nil {
+		out.Set = *in.Set
+	}
+	if in.Uncertainty != "" {
+		d, err := time.ParseDuration(in.Uncertainty)
+		if err != nil {
+			return fmt.Errorf("invalid RiseSet Uncertainty: %w", err)
+		}
+		out.Uncertainty = d
+	}
+	*rs = out
+	return nil
 }
 
 // MoonPhase describes the illuminated fraction and qualitative phase
@@ -101,14 +224,27 @@ var (
 )
 
 // RiseSetFor returns rise and set times for the given body and location on a date.
-// For Level 1, only the Sun is implemented with decent accuracy (~±1 minute).
+// Sun times are accurate to about ±1 minute; pass WithPrecision(Level1) for
+// a faster closed-form solution.
 // The date's time zone is used for the returned times.
-func RiseSetFor(body Body, loc Coordinates, date time.Time) (RiseSet, error) {
+//
+// By default event times are pinned to the requested local calendar date;
+// pass WithTrueInstants to get the exact instants instead. Rise and set
+// refer to the upper limb; use WithLimb or WithZenith to change that.
+func RiseSetFor(body Body, loc Coordinates, date time.Time, opts ...Option) (RiseSet, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return RiseSet{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return RiseSet{}, err
+	}
+
 	switch body {
 	case Sun:
-		return sunRiseSet(loc, date)
+		return sunRiseSet(loc, date, cfg)
 	case Moon:
-		return moonRiseSet(loc, date)
+		return moonRiseSet(loc, date, cfg)
 	default:
 		return RiseSet{}, fmt.Errorf("unknown body %v", body)
 	}
@@ -116,98 +252,140 @@ func RiseSetFor(body Body, loc Coordinates, date time.Time) (RiseSet, error) {
 
 // moonRiseSet wraps the internal/moon implementation and converts UTC to the
 // caller's desired time zone (taken from date.Location()).
-func moonRiseSet(loc Coordinates, date time.Time) (RiseSet, error) {
+func moonRiseSet(loc Coordinates, date time.Time, cfg config) (RiseSet, error) {
 	locTZ := date.Location()
 	year, month, day := date.Date()
 
 	// internal/moon returns a RiseSet (UTC times) plus ok flags
-	rsMoonUTC, okRise, okSet := moon.RiseSetForDate(loc.Lat, loc.Lon, date)
+	rsMoonUTC, okRise, okSet := cfg.moonRiseSetUTC(loc, date)
 
 	if !okRise && !okSet {
-		return RiseSet{}, ErrNoRiseNoSet
+		return RiseSet{}, noCrossing(Moon, "rise/set", loc, date, cfg.moonUpAllDay(loc, date))
 	}
 
-	var rs RiseSet
+	rs := RiseSet{Date: timeutil.StartOfDay(date)}
 
 	if okRise {
 		riseLocal := rsMoonUTC.Rise.In(locTZ)
 		// Force the local calendar date to the requested one
-		riseLocal = withLocalDate(riseLocal, year, month, day)
+		riseLocal = cfg.pin(riseLocal, year, month, day)
 		rs.Rise = riseLocal
+		rs.HasRise = true
 	}
 
 	if okSet {
 		setLocal := rsMoonUTC.Set.In(locTZ)
 		// Same date-forcing for set
-		setLocal = withLocalDate(setLocal, year, month, day)
+		setLocal = cfg.pin(setLocal, year, month, day)
 		rs.Set = setLocal
+		rs.HasSet = true
 	}
 
+	rs.Uncertainty = cfg.uncertainty(Moon, loc, 0, foundEvents(rsMoonUTC.Rise, okRise, rsMoonUTC.Set, okSet)...)
 	return rs, nil
 }
 
 // SlideIntoSunset is your glorious convenience helper:
 // it returns sunrise and sunset for the Sun at the given location and date.
-func SlideIntoSunset(loc Coordinates, date time.Time) (RiseSet, error) {
-	return RiseSetFor(Sun, loc, date)
+func SlideIntoSunset(loc Coordinates, date time.Time, opts ...Option) (RiseSet, error) {
+	return RiseSetFor(Sun, loc, date, opts...)
 }
 
 // DaylightHours calculates the duration of daylight (time between sunrise and
 // sunset) for the Sun at the given location and date. Returns the duration in
 // hours as a float64.
 //
+// Near the polar circles the Sun can set just after midnight, before it
+// rises again; the daylight on either side of the night is then added up.
+// Likewise, when only one of the events falls on the date, the daylight
+// runs to or from local midnight.
+//
 // If the sun does not rise or set on the given date (e.g., polar regions), it
-// returns 0 and ErrNoRiseNoSet. For polar day (sun never sets), you can detect
-// this by checking if the sun is always above the horizon separately.
+// returns 0 and ErrNoRiseNoSet. The error also matches ErrAlwaysUp during
+// polar day (the sun never sets) and ErrAlwaysDown during polar night.
 func DaylightHours(loc Coordinates, date time.Time) (float64, error) {
-	rs, err := SlideIntoSunset(loc, date)
+	d, err := daylightDuration(loc, date)
 	if err != nil {
 		return 0, err
 	}
+	return d.Hours(), nil
+}
 
-	duration := rs.Set.Sub(rs.Rise)
-	return duration.Hours(), nil
+// daylightDuration is DaylightHours as a time.Duration.
+func daylightDuration(loc Coordinates, date time.Time) (time.Duration, error) {
+	rs, err := SlideIntoSunset(loc, date, WithTrueInstants())
+	if err != nil {
+		return 0, err
+	}
+	return timeUp(rs, date), nil
+}
+
+// timeUp returns how long a body is up on the local calendar date of date,
+// given its rise and set on that date (at least one of which is present).
+func timeUp(rs RiseSet, date time.Time) time.Duration {
+	start, end := timeutil.LocalDay(date)
+
+	switch {
+	case !rs.HasSet:
+		return end.Sub(rs.Rise)
+	case !rs.HasRise:
+		return rs.Set.Sub(start)
+	case rs.Set.Before(rs.Rise):
+		return rs.Set.Sub(start) + end.Sub(rs.Rise)
+	default:
+		return rs.Set.Sub(rs.Rise)
+	}
 }
 
 // -----------------------------
 // Sun wrapper around internal/sun
 // -----------------------------
 
-func sunRiseSet(loc Coordinates, date time.Time) (RiseSet, error) {
+func sunRiseSet(loc Coordinates, date time.Time, cfg config) (RiseSet, error) {
 	locTZ := date.Location()
 	year, month, day := date.Date()
 
 	// Delegate to internal/sun which returns UTC times + flags.
-	sunriseUTC, sunsetUTC, okRise, okSet := sun.RiseSetForDate(loc.Lat, loc.Lon, date, sun.StandardZenith)
+	sunriseUTC, sunsetUTC, okRise, okSet := cfg.sunCrossings(loc, date, 90-cfg.sunZenith())
 
 	if !okRise && !okSet {
-		return RiseSet{}, ErrNoRiseNoSet
+		return RiseSet{}, noCrossing(Sun, "rise/set", loc, date, cfg.sunUpAllDay(loc, date, 90-cfg.sunZenith()))
 	}
 
-	var rs RiseSet
+	rs := RiseSet{Date: timeutil.StartOfDay(date)}
 
 	if okRise {
 		riseLocal := sunriseUTC.In(locTZ)
 		// Force the date to match the requested local calendar date.
-		riseLocal = withLocalDate(riseLocal, year, month, day)
+		riseLocal = cfg.pin(riseLocal, year, month, day)
 		rs.Rise = riseLocal
+		rs.HasRise = true
 	}
 
 	if okSet {
 		setLocal := sunsetUTC.In(locTZ)
 		// Same: ensure the local date is the requested date.
-		setLocal = withLocalDate(setLocal, year, month, day)
+		setLocal = cfg.pin(setLocal, year, month, day)
 		rs.Set = setLocal
+		rs.HasSet = true
 	}
 
+	rs.Uncertainty = cfg.uncertainty(Sun, loc, 0, foundEvents(sunriseUTC, okRise, sunsetUTC, okSet)...)
 	return rs, nil
 }
 
 // withLocalDate returns a copy of t but with its calendar date
 // forced to (year, month, day), keeping the same clock time and location.
+// A t already on that date is returned unchanged: rebuilding it from its
+// clock reading would move an event in the hour repeated when clocks go
+// back (01:30 EST) to the first occurrence of that reading (01:30 EDT).
 func withLocalDate(t time.Time, year int, month time.Month, day int) time.Time {
-	loc := t.Location()
-	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	if y, m, d := t.Date(); y == year && m == month && d == day {
+		return t
+	}
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	sub := time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	return timeutil.WallClock(year, month, day, clock, t.Location()).Add(sub)
 }
 
 // TwilightFor computes twilight times (dawn and dusk) of the given kind for
@@ -217,44 +395,83 @@ func withLocalDate(t time.Time, year int, month time.Month, day int) time.Time {
 //
 // For example, TwilightCivil returns civil dawn (Rise) and civil dusk (Set)
 // where the Sun's altitude crosses -6 degrees.
-func TwilightFor(loc Coordinates, date time.Time, kind TwilightKind) (RiseSet, error) {
-	locTZ := date.Location()
-	year, month, day := date.Date()
+func TwilightFor(loc Coordinates, date time.Time, kind TwilightKind, opts ...Option) (RiseSet, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return RiseSet{}, err
+	}
 
-	// Map TwilightKind to target altitude (degrees).
-	var targetAlt float64
-	switch kind {
+	targetAlt, ok := kind.altitude()
+	if !ok {
+		return RiseSet{}, fmt.Errorf("unknown TwilightKind: %d", kind)
+	}
+	if err := cfg.validate(); err != nil {
+		return RiseSet{}, err
+	}
+
+	rs, okDawn, okDusk := sunAltitudeCrossings(loc, date, targetAlt, opts...)
+	if !okDawn && !okDusk {
+		return RiseSet{}, noCrossing(Sun, kind.String(), loc, date, cfg.sunUpAllDay(loc, date, targetAlt))
+	}
+
+	return rs, nil
+}
+
+// altitude returns the Sun's center altitude (degrees) that defines k.
+func (k TwilightKind) altitude() (float64, bool) {
+	switch k {
 	case TwilightCivil:
-		targetAlt = -6.0
+		return -6.0, true
 	case TwilightNautical:
-		targetAlt = -12.0
+		return -12.0, true
 	case TwilightAstronomical:
-		targetAlt = -18.0
+		return -18.0, true
+	case TwilightSunCenter:
+		return 0.0, true
 	default:
-		return RiseSet{}, fmt.Errorf("unknown TwilightKind: %d", kind)
+		return 0, false
 	}
+}
 
-	dawnUTC, duskUTC, okDawn, okDusk := sun.TwilightForDate(loc.Lat, loc.Lon, date, targetAlt)
-	if !okDawn && !okDusk {
-		return RiseSet{}, ErrNoRiseNoSet
-	}
+// sunAltitudeCrossings returns the upward (Rise) and downward (Set) crossings
+// of targetAlt by the Sun on the local calendar date, converted to the date's
+// time zone, along with flags telling which crossings were found.
+func sunAltitudeCrossings(loc Coordinates, date time.Time, targetAlt float64, opts ...Option) (rs RiseSet, okUp, okDown bool) {
+	cfg := newConfig(opts)
+	locTZ := date.Location()
+	year, month, day := date.Date()
+	rs.Date = timeutil.StartOfDay(date)
 
-	var rs RiseSet
+	upUTC, downUTC, okUp, okDown := cfg.sunCrossings(loc, date, targetAlt)
 
-	if okDawn {
-		dawnLocal := dawnUTC.In(locTZ)
+	if okUp {
+		upLocal := upUTC.In(locTZ)
 		// Pin to the requested local calendar date for consistency.
-		dawnLocal = withLocalDate(dawnLocal, year, month, day)
-		rs.Rise = dawnLocal
+		upLocal = cfg.pin(upLocal, year, month, day)
+		rs.Rise = upLocal
+		rs.HasRise = true
 	}
 
-	if okDusk {
-		duskLocal := duskUTC.In(locTZ)
-		duskLocal = withLocalDate(duskLocal, year, month, day)
-		rs.Set = duskLocal
+	if okDown {
+		downLocal := downUTC.In(locTZ)
+		downLocal = cfg.pin(downLocal, year, month, day)
+		rs.Set = downLocal
+		rs.HasSet = true
 	}
 
-	return rs, nil
+	rs.Uncertainty = cfg.uncertainty(Sun, loc, 0, foundEvents(upUTC, okUp, downUTC, okDown)...)
+	return rs, okUp, okDown
+}
+
+// SolarNoon returns the time of local apparent noon (the Sun's upper transit
+// across the meridian) on the given local calendar date, in the date's time
+// zone.
+func SolarNoon(loc Coordinates, date time.Time) (time.Time, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return time.Time{}, err
+	}
+
+	return sun.TransitForDate(loc.Lon, date).In(date.Location()), nil
 }
 
 // GoldenHourFor computes the golden hour intervals for the given local
@@ -267,60 +484,8 @@ func TwilightFor(loc Coordinates, date time.Time, kind TwilightKind) (RiseSet, e
 //
 // If neither morning nor evening golden hour exists (e.g. extreme
 // high-latitude edge cases), ErrNoRiseNoSet is returned.
-func GoldenHourFor(loc Coordinates, date time.Time) (DaylightPhases, error) {
-	const (
-		lowAlt  = -4.0 // degrees
-		highAlt = 6.0  // degrees
-	)
-
-	locTZ := date.Location()
-	year, month, day := date.Date()
-
-	// We can reuse the Sun "Twilight" solver for arbitrary altitudes:
-	// it returns the upward crossing (dawn-like) and downward crossing
-	// (dusk-like) of targetAlt.
-	mLow, eLow, okMLow, okELow := sun.TwilightForDate(loc.Lat, loc.Lon, date, lowAlt)
-	mHigh, eHigh, okMHigh, okEHigh := sun.TwilightForDate(loc.Lat, loc.Lon, date, highAlt)
-
-	var phases DaylightPhases
-
-	// Morning golden hour: Sun climbing from lowAlt -> highAlt.
-	if okMLow && okMHigh {
-		start := mLow.In(locTZ)
-		end := mHigh.In(locTZ)
-		start = withLocalDate(start, year, month, day)
-		end = withLocalDate(end, year, month, day)
-
-		if end.After(start) {
-			phases.Morning = PhaseWindow{
-				Start: start,
-				End:   end,
-			}
-			phases.HasMorning = true
-		}
-	}
-
-	// Evening golden hour: Sun descending from highAlt -> lowAlt.
-	if okEHigh && okELow {
-		start := eHigh.In(locTZ)
-		end := eLow.In(locTZ)
-		start = withLocalDate(start, year, month, day)
-		end = withLocalDate(end, year, month, day)
-
-		if end.After(start) {
-			phases.Evening = PhaseWindow{
-				Start: start,
-				End:   end,
-			}
-			phases.HasEvening = true
-		}
-	}
-
-	if !phases.HasMorning && !phases.HasEvening {
-		return DaylightPhases{}, ErrNoRiseNoSet
-	}
-
-	return phases, nil
+func GoldenHourFor(loc Coordinates, date time.Time, opts ...Option) (DaylightPhases, error) {
+	return SunAltitudeWindowFor(loc, date, -4.0, 6.0, opts...)
 }
 
 // BlueHourFor computes the blue hour intervals for the given local calendar
@@ -331,26 +496,48 @@ func GoldenHourFor(loc Coordinates, date time.Time) (DaylightPhases, error) {
 // blue hour is between the -4° and -6° downward crossings.
 //
 // If neither morning nor evening blue hour exists, ErrNoRiseNoSet is returned.
-func BlueHourFor(loc Coordinates, date time.Time) (DaylightPhases, error) {
-	const (
-		lowAlt  = -6.0 // degrees
-		highAlt = -4.0 // degrees
-	)
+func BlueHourFor(loc Coordinates, date time.Time, opts ...Option) (DaylightPhases, error) {
+	return SunAltitudeWindowFor(loc, date, -6.0, -4.0, opts...)
+}
 
+// SunAltitudeWindowFor computes the intervals on the given local calendar
+// date during which the Sun's center altitude lies between lowAlt and
+// highAlt (degrees). GoldenHourFor and BlueHourFor are fixed bands of this
+// function; use it directly for custom bands such as a "deep blue hour"
+// (-8° to -4°) or local cinematography conventions.
+//
+// Morning is the interval while the Sun climbs from lowAlt to highAlt and
+// Evening the interval while it descends from highAlt to lowAlt.
+//
+// If neither window exists, ErrNoRiseNoSet is returned.
+func SunAltitudeWindowFor(loc Coordinates, date time.Time, lowAlt, highAlt float64, opts ...Option) (DaylightPhases, error) {
+	if !(lowAlt < highAlt) {
+		return DaylightPhases{}, fmt.Errorf("invalid altitude band: low %.3f° must be below high %.3f°", lowAlt, highAlt)
+	}
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return DaylightPhases{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return DaylightPhases{}, err
+	}
 	locTZ := date.Location()
 	year, month, day := date.Date()
 
-	mLow, eLow, okMLow, okELow := sun.TwilightForDate(loc.Lat, loc.Lon, date, lowAlt)
-	mHigh, eHigh, okMHigh, okEHigh := sun.TwilightForDate(loc.Lat, loc.Lon, date, highAlt)
+	// We can reuse the Sun "Twilight" solver for arbitrary altitudes:
+	// it returns the upward crossing (dawn-like) and downward crossing
+	// (dusk-like) of targetAlt.
+	mLow, eLow, okMLow, okELow := cfg.sunCrossings(loc, date, lowAlt)
+	mHigh, eHigh, okMHigh, okEHigh := cfg.sunCrossings(loc, date, highAlt)
 
 	var phases DaylightPhases
 
-	// Morning blue hour: Sun climbing from lowAlt (-6°) -> highAlt (-4°).
+	// Morning window: Sun climbing from lowAlt -> highAlt.
 	if okMLow && okMHigh {
 		start := mLow.In(locTZ)
 		end := mHigh.In(locTZ)
-		start = withLocalDate(start, year, month, day)
-		end = withLocalDate(end, year, month, day)
+		start = cfg.pin(start, year, month, day)
+		end = cfg.pin(end, year, month, day)
 
 		if end.After(start) {
 			phases.Morning = PhaseWindow{
@@ -361,12 +548,12 @@ func BlueHourFor(loc Coordinates, date time.Time) (DaylightPhases, error) {
 		}
 	}
 
-	// Evening blue hour: Sun descending from highAlt (-4°) -> lowAlt (-6°).
+	// Evening window: Sun descending from highAlt -> lowAlt.
 	if okEHigh && okELow {
 		start := eHigh.In(locTZ)
 		end := eLow.In(locTZ)
-		start = withLocalDate(start, year, month, day)
-		end = withLocalDate(end, year, month, day)
+		start = cfg.pin(start, year, month, day)
+		end = cfg.pin(end, year, month, day)
 
 		if end.After(start) {
 			phases.Evening = PhaseWindow{
@@ -378,7 +565,7 @@ func BlueHourFor(loc Coordinates, date time.Time) (DaylightPhases, error) {
 	}
 
 	if !phases.HasMorning && !phases.HasEvening {
-		return DaylightPhases{}, ErrNoRiseNoSet
+		return DaylightPhases{}, noEvent(Sun, fmt.Sprintf("%.1f° to %.1f° window", lowAlt, highAlt), loc, date)
 	}
 
 	return phases, nil
@@ -387,8 +574,33 @@ func BlueHourFor(loc Coordinates, date time.Time) (DaylightPhases, error) {
 // MoonPhaseAt computes the Moon's illuminated fraction and qualitative phase
 // at the given time. Phase is a global property (independent of observer
 // location), so we work in UTC internally and return the original time.
-func MoonPhaseAt(t time.Time) (MoonPhase, error) {
-	utc := t.UTC()
+//
+// With WithPrecision(Level1) the phase comes from the Moon's mean
+// elongation plus its six largest periodic terms instead of full Sun and
+// Moon positions; the fraction is then good to about 0.5%.
+func MoonPhaseAt(t time.Time, opts ...Option) (MoonPhase, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkRange(t); err != nil {
+		return MoonPhase{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return MoonPhase{}, err
+	}
+
+	// The models run at TT, ΔT ahead of t in historical mode.
+	utc := t.UTC().Add(cfg.deltaT(t))
+
+	if cfg.level() == Level1 {
+		elongDeg, waxing := meanPhaseElongation(utc)
+		fraction := 0.5 * (1 - timeutil.CosD(elongDeg))
+		return MoonPhase{
+			Time:       t,
+			Fraction:   fraction,
+			Elongation: elongDeg,
+			Waxing:     waxing,
+			Name:       classifyMoonPhase(fraction, waxing).String(),
+		}, nil
+	}
 
 	// Moon: geocentric RA/Dec + distance (we only need RA/Dec here).
 	mEq := moon.GeocentricEquatorialWithDistanceApprox(utc)
@@ -396,30 +608,12 @@ func MoonPhaseAt(t time.Time) (MoonPhase, error) {
 	// Sun: geocentric RA/Dec from the internal sun model.
 	sEq := sun.GeocentricEquatorialApprox(utc)
 
-	raSun := timeutil.Deg2Rad(sEq.RA)
-	decSun := timeutil.Deg2Rad(sEq.Dec)
-	raMoon := timeutil.Deg2Rad(mEq.RA)
-	decMoon := timeutil.Deg2Rad(mEq.Dec)
-
-	// Angular separation ψ between Sun and Moon:
-	// cos ψ = sin δs sin δm + cos δs cos δm cos(αs - αm)
-	dRA := raSun - raMoon
-	cosPsi := math.Sin(decSun)*math.Sin(decMoon) +
-		math.Cos(decSun)*math.Cos(decMoon)*math.Cos(dRA)
-
-	// Clamp to handle numerical noise
-	if cosPsi > 1 {
-		cosPsi = 1
-	} else if cosPsi < -1 {
-		cosPsi = -1
-	}
-
-	psi := math.Acos(cosPsi)          // radians
-	elongDeg := timeutil.Rad2Deg(psi) // 0..180 degrees
+	// Angular separation ψ between Sun and Moon (0..180 degrees).
+	elongDeg := coord.Separation(sEq.RA, sEq.Dec, mEq.RA, mEq.Dec)
 
 	// Illuminated fraction:
 	// k = (1 - cos ψ) / 2
-	fraction := 0.5 * (1 - cosPsi)
+	fraction := 0.5 * (1 - timeutil.CosD(elongDeg))
 	if fraction < 0 {
 		fraction = 0
 	} else if fraction > 1 {
@@ -431,7 +625,7 @@ func MoonPhaseAt(t time.Time) (MoonPhase, error) {
 	sepDeg := timeutil.Normalize360(mEq.RA - sEq.RA)
 	waxing := sepDeg < 180.0
 
-	name := classifyMoonPhaseName(fraction, waxing)
+	name := classifyMoonPhase(fraction, waxing).String()
 
 	return MoonPhase{
 		Time:       t,
@@ -442,7 +636,37 @@ func MoonPhaseAt(t time.Time) (MoonPhase, error) {
 	}, nil
 }
 
-func classifyMoonPhaseName(f float64, waxing bool) string {
+// meanPhaseElongation returns the Sun-Moon elongation (degrees, 0..180) and
+// whether the Moon is waxing, from the Moon's mean elongation D corrected by
+// the largest periodic terms of its phase angle (Meeus, Astronomical
+// Algorithms, eq. 48.4).
+func meanPhaseElongation(t time.Time) (float64, bool) {
+	T := timeutil.JulianCenturies(t)
+
+	D := timeutil.Normalize360(297.8501921 + 445267.1114034*T)  // mean elongation
+	M := timeutil.Normalize360(357.5291092 + 35999.0502909*T)   // Sun mean anomaly
+	Mp := timeutil.Normalize360(134.9633964 + 477198.8675055*T) // Moon mean anomaly
+
+	// Phase angle i: the Sun-Moon angle seen from the Moon.
+	i := 180 - D -
+		6.289*timeutil.SinD(Mp) +
+		2.100*timeutil.SinD(M) -
+		1.274*timeutil.SinD(2*D-Mp) -
+		0.658*timeutil.SinD(2*D) -
+		0.214*timeutil.SinD(2*Mp) -
+		0.110*timeutil.SinD(D)
+
+	i = timeutil.Normalize360(i)
+	waxing := i < 180
+	if i > 180 {
+		i = 360 - i
+	}
+	return 180 - i, waxing
+}
+
+// classifyMoonPhase returns the phase of the eight-phase classification
+// for illuminated fraction f.
+func classifyMoonPhase(f float64, waxing bool) PhaseKind {
 	const (
 		eps        = 0.01 // near 0 or 1
 		quarterTol = 0.05 // fraction window around 0.5
@@ -450,23 +674,23 @@ func classifyMoonPhaseName(f float64, waxing bool) string {
 
 	switch {
 	case f < eps:
-		return "New Moon"
+		return PhaseNewMoon
 	case f > 1-eps:
-		return "Full Moon"
+		return PhaseFullMoon
 	case math.Abs(f-0.5) < quarterTol:
 		if waxing {
-			return "First Quarter"
+			return PhaseFirstQuarter
 		}
-		return "Last Quarter"
+		return PhaseLastQuarter
 	case f < 0.5:
 		if waxing {
-			return "Waxing Crescent"
+			return PhaseWaxingCrescent
 		}
-		return "Waning Crescent"
+		return PhaseWaningCrescent
 	default: // f > 0.5 but not near 1
 		if waxing {
-			return "Waxing Gibbous"
+			return PhaseWaxingGibbous
 		}
-		return "Waning Gibbous"
+		return PhaseWaningGibbous
 	}
 }
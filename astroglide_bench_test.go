@@ -0,0 +1,97 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+// Benchmarks for the main entry points. `make bench` runs them and compares
+// the result with testdata/bench/baseline.txt; `make bench-baseline`
+// records a new baseline after an intentional change.
+
+var (
+	benchPHX  = Coordinates{Lat: 33.4484, Lon: -112.0740}
+	benchDate = time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+)
+
+func BenchmarkRiseSetFor_Sun(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = RiseSetFor(Sun, benchPHX, benchDate)
+	}
+}
+
+func BenchmarkRiseSetFor_Moon(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = RiseSetFor(Moon, benchPHX, benchDate)
+	}
+}
+
+func BenchmarkRiseSetFor_SunPolar(b *testing.B) {
+	// Tromsø in late November: a day of under half an hour that only the
+	// solver's hidden-crossing check finds.
+	tromso := Coordinates{Lat: 69.6492, Lon: 18.9553}
+	date := time.Date(2025, 11, 27, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = RiseSetFor(Sun, tromso, date)
+	}
+}
+
+func BenchmarkTwilightFor(b *testing.B) {
+	for _, kind := range []TwilightKind{TwilightCivil, TwilightNautical, TwilightAstronomical} {
+		b.Run(kind.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = TwilightFor(benchPHX, benchDate, kind)
+			}
+		})
+	}
+}
+
+func BenchmarkMoonPhaseAt(b *testing.B) {
+	t := time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = MoonPhaseAt(t)
+	}
+}
+
+func BenchmarkMoonPhaseAt_Level1(b *testing.B) {
+	t := time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = MoonPhaseAt(t, WithPrecision(Level1))
+	}
+}
+
+func BenchmarkNextEvent_FullMoon(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NextEvent(Moon, benchPHX, benchDate, EventFullMoon)
+	}
+}
+
+// The year benchmarks compute a whole calendar year, the typical batch
+// job behind almanac tables and charts; they report time per year.
+
+func BenchmarkYear_SunRiseSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for d := 0; d < 365; d++ {
+			_, _ = RiseSetFor(Sun, benchPHX, time.Date(2025, 1, 1+d, 0, 0, 0, 0, time.UTC))
+		}
+	}
+}
+
+func BenchmarkYear_MoonRiseSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for d := 0; d < 365; d++ {
+			_, _ = RiseSetFor(Moon, benchPHX, time.Date(2025, 1, 1+d, 0, 0, 0, 0, time.UTC))
+		}
+	}
+}
+
+func BenchmarkYear_SunSession(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s := NewSession(benchPHX)
+		for d := 0; d < 365; d++ {
+			date := time.Date(2025, 1, 1+d, 0, 0, 0, 0, time.UTC)
+			_, _ = s.SlideIntoSunset(date)
+			_, _ = s.TwilightFor(date, TwilightCivil)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package astrotime converts between time.Time and the day counts used in
+// astronomy: Julian Day (JD), Modified Julian Day (MJD), days since the
+// J2000.0 epoch and Julian centuries since J2000.0.
+//
+// These are the same conversions astroglide uses internally. Times are taken
+// as UTC and the day counts are continuous, ignoring leap seconds (each day
+// has 86400 seconds), so they are UTC-based rather than TT-based: use
+// Convert to TT first where a dynamical time is needed. A leap second,
+// 23:59:60, cannot be held in a time.Time and has no Julian Day of its
+// own; the JD of the following midnight is that of 23:59:59 plus one second.
+//
+// Dates are proleptic Gregorian, like time.Time, for every year, including
+// negative (astronomical) years. For dates before the Gregorian reform of
+// 1582, which historical sources give in the Julian calendar, use Date and
+// CalendarDate with the Julian or Historical Calendar.
+package astrotime
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+const (
+	// J2000 is the Julian Day of the J2000.0 epoch, 2000-01-01 12:00 UTC.
+	J2000 = 2451545.0
+
+	// MJDOffset is the difference between a Julian Day and a Modified
+	// Julian Day: MJD = JD - MJDOffset, so MJD 0 is 1858-11-17 00:00.
+	MJDOffset = 2400000.5
+
+	// DaysPerCentury is the length of a Julian century in days.
+	DaysPerCentury = 36525.0
+)
+
+// J2000Epoch is the J2000.0 epoch as a time.Time.
+var J2000Epoch = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// JulianDay returns the Julian Day of t, e.g. 2451545.0 for J2000Epoch.
+func JulianDay(t time.Time) float64 {
+	return timeutil.JulianDay(t)
+}
+
+// ModifiedJulianDay returns the Modified Julian Day of t, which starts at
+// midnight rather than noon.
+func ModifiedJulianDay(t time.Time) float64 {
+	return timeutil.JulianDay(t) - MJDOffset
+}
+
+// DaysSinceJ2000 returns the days since J2000Epoch (negative before it).
+func DaysSinceJ2000(t time.Time) float64 {
+	return timeutil.JulianDay(t) - J2000
+}
+
+// JulianCenturies returns the Julian centuries since J2000Epoch, the time
+// argument of most series in Meeus and the IAU models.
+func JulianCenturies(t time.Time) float64 {
+	return timeutil.JulianCenturies(t)
+}
+
+// FromJulianDay returns the UTC time of Julian Day jd. A float64 Julian Day
+// resolves about 40 microseconds, so JulianDay followed by FromJulianDay
+// is only that close to the original time.
+func FromJulianDay(jd float64) time.Time {
+	return timeutil.TimeFromJulianDay(jd)
+}
+
+// FromModifiedJulianDay returns the UTC time of Modified Julian Day mjd.
+func FromModifiedJulianDay(mjd float64) time.Time {
+	return timeutil.TimeFromJulianDay(mjd + MJDOffset)
+}
+
+// FromDaysSinceJ2000 returns the UTC time d days after J2000Epoch.
+func FromDaysSinceJ2000(d float64) time.Time {
+	return timeutil.TimeFromJulianDay(d + J2000)
+}
+
+// FromJulianCenturies returns the UTC time c Julian centuries after
+// J2000Epoch.
+func FromJulianCenturies(c float64) time.Time {
+	return timeutil.TimeFromJulianDay(c*DaysPerCentury + J2000)
+}
@@ -0,0 +1,61 @@
+package astrotime
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestJulianDay(t *testing.T) {
+	cases := []struct {
+		t    time.Time
+		want float64
+	}{
+		{J2000Epoch, 2451545.0},
+		// Meeus, Astronomical Algorithms, example 7.a: Sputnik 1.
+		{time.Date(1957, time.October, 4, 19, 26, 24, 0, time.UTC), 2436116.31},
+		{time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC), MJDOffset},
+		{time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC), 2440587.5},
+		// Time zones do not matter.
+		{time.Date(2000, time.January, 1, 5, 0, 0, 0, time.FixedZone("EST", -5*3600)), 2451545 - 2.0/24}, // 10:00 UTC
+	}
+	for _, c := range cases {
+		if got := JulianDay(c.t); math.Abs(got-c.want) > 1e-6 {
+			t.Errorf("JulianDay(%v) = %.6f, want %.6f", c.t, got, c.want)
+		}
+	}
+
+	if got := ModifiedJulianDay(J2000Epoch); got != 51544.5 {
+		t.Errorf("ModifiedJulianDay(J2000) = %v, want 51544.5", got)
+	}
+	if got := DaysSinceJ2000(J2000Epoch.Add(36 * time.Hour)); math.Abs(got-1.5) > 1e-9 {
+		t.Errorf("DaysSinceJ2000 = %v, want 1.5", got)
+	}
+	if got := JulianCenturies(time.Date(2100, time.January, 1, 12, 0, 0, 0, time.UTC)); math.Abs(got-1) > 1e-9 {
+		t.Errorf("JulianCenturies(2100) = %v, want 1", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, tm := range []time.Time{
+		J2000Epoch,
+		time.Date(1800, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1957, time.October, 4, 19, 26, 24, 0, time.UTC),
+		time.Date(2025, time.June, 21, 2, 42, 17, 123456789, time.UTC),
+		time.Date(2199, time.December, 31, 23, 59, 59, 0, time.UTC),
+	} {
+		for name, back := range map[string]time.Time{
+			"JulianDay":         FromJulianDay(JulianDay(tm)),
+			"ModifiedJulianDay": FromModifiedJulianDay(ModifiedJulianDay(tm)),
+			"DaysSinceJ2000":    FromDaysSinceJ2000(DaysSinceJ2000(tm)),
+			"JulianCenturies":   FromJulianCenturies(JulianCenturies(tm)),
+		} {
+			if d := back.Sub(tm); d < -50*time.Microsecond || d > 50*time.Microsecond {
+				t.Errorf("%s round trip of %v = %v (off by %v)", name, tm, back, d)
+			}
+			if back.Location() != time.UTC {
+				t.Errorf("%s round trip returned %v, want UTC", name, back.Location())
+			}
+		}
+	}
+}
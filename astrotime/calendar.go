@@ -0,0 +1,95 @@
+package astrotime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Calendar selects how a year, month and day are read. Years are
+// astronomical: year 0 is 1 BC and year -1 is 2 BC.
+type Calendar int
+
+const (
+	// Gregorian is the proleptic Gregorian calendar of time.Time, applied
+	// to every date, even before it was introduced.
+	Gregorian Calendar = iota
+
+	// Julian is the Julian calendar, applied to every date.
+	Julian
+
+	// Historical is the calendar of most historical records and almanacs,
+	// and of Meeus: Julian up to 1582-10-04, Gregorian from the next day,
+	// 1582-10-15. Dates in between do not exist and are read as Julian.
+	Historical
+)
+
+// gregorianReformJDN is the Julian Day Number of 1582-10-15, the first day
+// of the Gregorian calendar.
+const gregorianReformJDN = 2299161
+
+func (c Calendar) String() string {
+	switch c {
+	case Gregorian:
+		return "Gregorian"
+	case Julian:
+		return "Julian"
+	case Historical:
+		return "Historical"
+	default:
+		return fmt.Sprintf("Calendar(%d)", int(c))
+	}
+}
+
+// isJulian reports whether c reads (year, month, day) as a Julian date.
+func (c Calendar) isJulian(year int, month time.Month, day int) bool {
+	switch c {
+	case Julian:
+		return true
+	case Historical:
+		return year < 1582 || year == 1582 && (month < time.October || month == time.October && day < 15)
+	default:
+		return false
+	}
+}
+
+// Date returns the UTC time of the given date and clock time in calendar
+// c, so that Date(Historical, 1066, time.October, 14, ...) is the Battle of
+// Hastings, which time.Date would place six days too early. Out-of-range
+// values are normalized as by time.Date.
+func Date(c Calendar, year int, month time.Month, day, hour, minute, sec, nsec int) time.Time {
+	if !c.isJulian(year, month, day) {
+		return time.Date(year, month, day, hour, minute, sec, nsec, time.UTC)
+	}
+	clock := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(nsec)
+	return timeutil.TimeFromDayNumber(timeutil.JulianCalendarDayNumber(year, month, day), clock)
+}
+
+// CalendarDate returns the UTC date of t in calendar c.
+func CalendarDate(t time.Time, c Calendar) (year int, month time.Month, day int) {
+	jdn := timeutil.DayNumber(t)
+	if c == Julian || c == Historical && jdn < gregorianReformJDN {
+		return timeutil.JulianCalendarDate(jdn)
+	}
+	return t.UTC().Date()
+}
+
+// CalendarJulianDay returns the Julian Day of a date in calendar c, with
+// the time of day as a fraction of day, as in Meeus' tables: 333 January
+// 27.5 in the Historical calendar is CalendarJulianDay(Historical, 333,
+// time.January, 27.5) = 1842713.0.
+func CalendarJulianDay(c Calendar, year int, month time.Month, day float64) float64 {
+	d := int(day)
+	if day < 0 && float64(d) != day {
+		d--
+	}
+	var jdn int64
+	if c.isJulian(year, month, d) {
+		jdn = timeutil.JulianCalendarDayNumber(year, month, d)
+	} else {
+		jdn = timeutil.DayNumber(time.Date(year, month, d, 0, 0, 0, 0, time.UTC))
+	}
+	return float64(jdn) - 0.5 + (day - float64(d))
+}
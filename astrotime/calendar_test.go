@@ -0,0 +1,179 @@
+package astrotime
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCalendarJulianDay(t *testing.T) {
+	// Meeus, Astronomical Algorithms, table 7.a; dates before 1582-10-15
+	// are Julian.
+	cases := []struct {
+		year  int
+		month time.Month
+		day   float64
+		want  float64
+	}{
+		{2000, time.January, 1.5, 2451545.0},
+		{1999, time.January, 1.0, 2451179.5},
+		{1987, time.January, 27.0, 2446822.5},
+		{1987, time.June, 19.5, 2446966.0},
+		{1988, time.January, 27.0, 2447187.5},
+		{1988, time.June, 19.5, 2447332.0},
+		{1900, time.January, 1.0, 2415020.5},
+		{1600, time.January, 1.0, 2305447.5},
+		{1600, time.December, 31.0, 2305812.5},
+		{837, time.April, 10.3, 2026871.8},
+		{-123, time.December, 31.0, 1676496.5},
+		{-122, time.January, 1.0, 1676497.5},
+		{-1000, time.July, 12.5, 1356001.0},
+		{-1000, time.February, 29.0, 1355866.5},
+		{-1001, time.August, 17.9, 1355671.4},
+		{-4712, time.January, 1.5, 0.0},
+		// Example 7.b.
+		{333, time.January, 27.5, 1842713.0},
+	}
+	for _, c := range cases {
+		got := CalendarJulianDay(Historical, c.year, c.month, c.day)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("CalendarJulianDay(%d-%02d-%v) = %.4f, want %.4f", c.year, c.month, c.day, got, c.want)
+		}
+
+		// Date and JulianDay agree with it.
+		d := int(math.Floor(c.day))
+		ns := int(math.Round((c.day - float64(d)) * 86400e9))
+		if got := JulianDay(Date(Historical, c.year, c.month, d, 0, 0, 0, ns)); math.Abs(got-c.want) > 1e-6 {
+			t.Errorf("JulianDay(Date(%d-%02d-%v)) = %.6f, want %.4f", c.year, c.month, c.day, got, c.want)
+		}
+	}
+}
+
+func TestCalendarReform(t *testing.T) {
+	// Thursday 4 October 1582 (Julian) was followed by Friday 15 October
+	// 1582 (Gregorian).
+	last := Date(Historical, 1582, time.October, 4, 0, 0, 0, 0)
+	first := Date(Historical, 1582, time.October, 15, 0, 0, 0, 0)
+	if d := first.Sub(last); d != 24*time.Hour {
+		t.Errorf("reform skipped %v, want one day", d)
+	}
+	if last.Weekday() != time.Thursday || first.Weekday() != time.Friday {
+		t.Errorf("weekdays %v and %v, want Thursday and Friday", last.Weekday(), first.Weekday())
+	}
+	if y, m, d := CalendarDate(last, Historical); y != 1582 || m != time.October || d != 4 {
+		t.Errorf("CalendarDate(last Julian day) = %d-%02d-%02d", y, m, d)
+	}
+	if y, m, d := CalendarDate(first, Historical); y != 1582 || m != time.October || d != 15 {
+		t.Errorf("CalendarDate(first Gregorian day) = %d-%02d-%02d", y, m, d)
+	}
+
+	// The Battle of Hastings, 14 October 1066 (Julian), was a Saturday.
+	hastings := Date(Historical, 1066, time.October, 14, 9, 0, 0, 0)
+	if hastings.Weekday() != time.Saturday {
+		t.Errorf("Hastings fell on %v, want Saturday", hastings.Weekday())
+	}
+	if got := hastings.Format("2006-01-02"); got != "1066-10-20" {
+		t.Errorf("Hastings is proleptic Gregorian %s, want 1066-10-20", got)
+	}
+
+	// After the reform Historical is Gregorian, and Julian runs behind it.
+	if y, m, d := CalendarDate(J2000Epoch, Julian); y != 1999 || m != time.December || d != 19 {
+		t.Errorf("J2000 in the Julian calendar = %d-%02d-%02d, want 1999-12-19", y, m, d)
+	}
+	if !Date(Historical, 2000, time.January, 1, 12, 0, 0, 0).Equal(J2000Epoch) {
+		t.Error("Historical 2000-01-01 12:00 is not J2000")
+	}
+}
+
+// TestCalendarRoundTrip checks that every day from 4713 BC to AD 3000
+// reads back as the date it was built from, in each calendar, and that the
+// Julian Days of consecutive days are one apart.
+func TestCalendarRoundTrip(t *testing.T) {
+	for _, c := range []Calendar{Gregorian, Julian, Historical} {
+		tm := Date(c, -4712, time.January, 1, 12, 0, 0, 0)
+		prevJD := JulianDay(tm) - 1
+		for ; tm.Year() < 3000; tm = tm.Add(24 * time.Hour) {
+			y, m, d := CalendarDate(tm, c)
+			if back := Date(c, y, m, d, 12, 0, 0, 0); !back.Equal(tm) {
+				t.Fatalf("%v: %v reads as %d-%02d-%02d, which is %v", c, tm, y, m, d, back)
+			}
+			jd := JulianDay(tm)
+			if jd != prevJD+1 {
+				t.Fatalf("%v: JulianDay(%v) = %v after %v", c, tm, jd, prevJD)
+			}
+			prevJD = jd
+		}
+	}
+}
+
+// TestJulianDayRoundTrip_Property round-trips random instants from 4713 BC
+// to AD 9999 through JulianDay and FromJulianDay, and checks JulianDay
+// against the day count of time.Time itself.
+func TestJulianDayRoundTrip_Property(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	lo := time.Date(-4712, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	hi := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC).Unix()
+
+	for i := 0; i < 100000; i++ {
+		tm := time.Unix(lo+rng.Int63n(hi-lo), rng.Int63n(1e9)).UTC()
+		jd := JulianDay(tm)
+
+		// Day boundaries fall at JD x.5 whatever the year's sign.
+		days := float64(tm.Unix()-tm.Unix()%86400) / 86400
+		if tm.Unix() < 0 && tm.Unix()%86400 != 0 {
+			days--
+		}
+		if want := days + 2440587.5; jd < want || jd >= want+1 {
+			t.Fatalf("JulianDay(%v) = %.6f, outside day starting at %.1f", tm, jd, want)
+		}
+
+		// About 40 µs now, 100 µs near JD 0.
+		if d := FromJulianDay(jd).Sub(tm); d < -100*time.Microsecond || d > 100*time.Microsecond {
+			t.Fatalf("round trip of %v = %v (off by %v)", tm, FromJulianDay(jd), d)
+		}
+	}
+}
+
+func TestJulianDay_NegativeYears(t *testing.T) {
+	// Proleptic Gregorian dates, as time.Time uses: -4713-11-24 12:00 is
+	// Julian Day 0, the same instant as -4712-01-01 12:00 Julian.
+	if got := JulianDay(time.Date(-4713, time.November, 24, 12, 0, 0, 0, time.UTC)); got != 0 {
+		t.Errorf("JulianDay(-4713-11-24 12:00) = %v, want 0", got)
+	}
+	if got := JulianDay(time.Date(0, time.March, 1, 0, 0, 0, 0, time.UTC)) - JulianDay(time.Date(0, time.February, 28, 0, 0, 0, 0, time.UTC)); got != 2 {
+		t.Errorf("year 0 (1 BC) is a leap year, but 28 February to 1 March is %v days", got)
+	}
+	if got := JulianDay(time.Date(-100, time.March, 1, 0, 0, 0, 0, time.UTC)) - JulianDay(time.Date(-100, time.February, 28, 0, 0, 0, 0, time.UTC)); got != 1 {
+		t.Errorf("Gregorian year -100 is not a leap year, but 28 February to 1 March is %v days", got)
+	}
+	if got := CalendarJulianDay(Julian, -100, time.March, 1) - CalendarJulianDay(Julian, -100, time.February, 28); got != 2 {
+		t.Errorf("Julian year -100 is a leap year, but 28 February to 1 March is %v days", got)
+	}
+}
+
+func TestJulianDay_LeapSecond(t *testing.T) {
+	// 2016-12-31 ended with 23:59:60 UTC. time.Time has no such second, so
+	// the JD steps from 23:59:59 straight to midnight, one second on.
+	before := time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC)
+	after := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got := (JulianDay(after) - JulianDay(before)) * 86400; math.Abs(got-1) > 1e-4 {
+		t.Errorf("JD step over the leap second = %.5f s, want 1", got)
+	}
+	if got := JulianDay(after); got != 2457754.5 {
+		t.Errorf("JulianDay(2017-01-01) = %v, want 2457754.5", got)
+	}
+
+	// In TT, which has no leap seconds, the two are two seconds apart.
+	ttBefore, err := Convert(before, UTC, TT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttAfter, err := Convert(after, UTC, TT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (JulianDay(ttAfter) - JulianDay(ttBefore)) * 86400; math.Abs(got-2) > 1e-4 {
+		t.Errorf("TT JD step over the leap second = %.5f s, want 2", got)
+	}
+}
@@ -0,0 +1,104 @@
+package astrotime
+
+import (
+	"math"
+	"time"
+)
+
+// DeltaTEstimate returns the long-term estimate of ΔT = TT - UT1 at t from
+// the polynomial fits of Espenak and Meeus (Five Millennium Canon of Solar
+// Eclipses, 2006) to the historical record of Morrison and Stephenson:
+// about 2.9 hours in AD 1, 4.8 hours in 500 BC. Before 500 BC and after
+// AD 2150 it follows the parabola -20 + 32u² seconds, u being centuries
+// from 1820, of the tidal slowing of the Earth's rotation.
+//
+// The fits cover 1999 BC to AD 3000. Within the leap-second era DeltaT is
+// better; before it DeltaT uses this estimate.
+func DeltaTEstimate(t time.Time) time.Duration {
+	return time.Duration(deltaTSeconds(decimalYear(t)) * float64(time.Second))
+}
+
+// DeltaTUncertainty returns the standard error of DeltaT at t. Between
+// 1972 and the last leap second, which keep UT1 within 0.9 s of UTC, it is
+// a second. Otherwise it is about 0.8u² seconds, u being centuries from
+// 1820 (Morrison and Stephenson 2004): some 4 minutes in AD 1 and 7
+// minutes in 500 BC; for the future this understates how unpredictable the
+// Earth's rotation is. A ΔT error turns the Earth under an eclipse path by
+// the same amount of rotation, but moves rise and set times only by the
+// Sun's or Moon's own motion in that time.
+func DeltaTUncertainty(t time.Time) time.Duration {
+	if starts, _ := LeapSeconds(); !t.Before(starts[0]) && !t.After(starts[len(starts)-1]) {
+		return time.Second
+	}
+	u := (decimalYear(t) - 1820) / 100
+	return time.Duration(math.Max(0.8*u*u, 1) * float64(time.Second))
+}
+
+// decimalYear returns t as a year with a fraction, e.g. 2000.5 at the
+// middle of 2000.
+func decimalYear(t time.Time) float64 {
+	return 2000 + DaysSinceJ2000(t)/365.25
+}
+
+// deltaTSeconds evaluates the Espenak-Meeus fits at decimal year y.
+func deltaTSeconds(y float64) float64 {
+	longTerm := func(y float64) float64 {
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	}
+
+	switch {
+	case y < -500:
+		return longTerm(y)
+	case y < 500:
+		u := y / 100
+		return poly(u, 10583.6, -1014.41, 33.78311, -5.952053, -0.1798452, 0.022174192, 0.0090316521)
+	case y < 1600:
+		u := (y - 1000) / 100
+		return poly(u, 1574.2, -556.01, 71.23472, 0.319781, -0.8503463, -0.005050998, 0.0083572073)
+	case y < 1700:
+		t := y - 1600
+		return poly(t, 120, -0.9808, -0.01532, 1.0/7129)
+	case y < 1800:
+		t := y - 1700
+		return poly(t, 8.83, 0.1603, -0.0059285, 0.00013336, -1.0/1174000)
+	case y < 1860:
+		t := y - 1800
+		return poly(t, 13.72, -0.332447, 0.0068612, 0.0041116, -0.00037436, 0.0000121272, -0.0000001699, 0.000000000875)
+	case y < 1900:
+		t := y - 1860
+		return poly(t, 7.62, 0.5737, -0.251754, 0.01680668, -0.0004473624, 1.0/233174)
+	case y < 1920:
+		t := y - 1900
+		return poly(t, -2.79, 1.494119, -0.0598939, 0.0061966, -0.000197)
+	case y < 1941:
+		t := y - 1920
+		return poly(t, 21.20, 0.84493, -0.076100, 0.0020936)
+	case y < 1961:
+		t := y - 1950
+		return poly(t, 29.07, 0.407, -1.0/233, 1.0/2547)
+	case y < 1986:
+		t := y - 1975
+		return poly(t, 45.45, 1.067, -1.0/260, -1.0/718)
+	case y < 2005:
+		t := y - 2000
+		return poly(t, 63.86, 0.3345, -0.060374, 0.0017275, 0.000651814, 0.00002373599)
+	case y < 2050:
+		t := y - 2000
+		return poly(t, 62.92, 0.32217, 0.005589)
+	case y < 2150:
+		return longTerm(y) - 0.5628*(2150-y)
+	default:
+		return longTerm(y)
+	}
+}
+
+// poly evaluates the polynomial with coefficients c (constant term first)
+// at x.
+func poly(x float64, c ...float64) float64 {
+	var v float64
+	for i := len(c) - 1; i >= 0; i-- {
+		v = v*x + c[i]
+	}
+	return v
+}
@@ -0,0 +1,330 @@
+package astrotime
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scale is an astronomical time scale.
+//
+// A time.Time always counts SI seconds of UTC. To carry a reading of another
+// scale, this package uses the time.Time whose UTC clock shows that reading:
+// the TT of 2017-01-01 00:00:00 UTC is returned as 2017-01-01 00:01:09.184
+// UTC. Keep such values in UTC and convert back with Convert before using
+// them as ordinary instants.
+type Scale int
+
+const (
+	UTC Scale = iota // Coordinated Universal Time, with leap seconds
+	UT1              // Earth rotation time; UTC + DUT1
+	TAI              // International Atomic Time; UTC + leap seconds
+	TT               // Terrestrial Time; TAI + 32.184 s
+	GPS              // GPS time; TAI - 19 s, equal to UTC in January 1980
+)
+
+// String returns the usual abbreviation of the scale.
+func (s Scale) String() string {
+	switch s {
+	case UTC:
+		return "UTC"
+	case UT1:
+		return "UT1"
+	case TAI:
+		return "TAI"
+	case TT:
+		return "TT"
+	case GPS:
+		return "GPS"
+	default:
+		return fmt.Sprintf("Scale(%d)", int(s))
+	}
+}
+
+// Fixed offsets from TAI.
+const (
+	TTMinusTAI  = 32184 * time.Millisecond
+	TAIMinusGPS = 19 * time.Second
+)
+
+// ErrBeforeLeapSeconds is returned for conversions involving TAI, TT or GPS
+// before 1972, when UTC was not yet an integer number of seconds from TAI.
+var ErrBeforeLeapSeconds = errors.New("astrotime: TAI-UTC is not tabulated before 1972")
+
+// Convert converts a reading t of scale from into scale to (see Scale for
+// how readings are represented).
+//
+// An instant inside an inserted leap second (23:59:60 UTC) has no time.Time
+// of its own and converts to UTC as the start of the following second.
+// UT1 uses the DUT1 table (see LoadDUT1), or UT1 = UTC where it has no
+// entries.
+func Convert(t time.Time, from, to Scale) (time.Time, error) {
+	if from < UTC || from > GPS || to < UTC || to > GPS {
+		return time.Time{}, fmt.Errorf("astrotime: unknown time scale %v or %v", from, to)
+	}
+	t = t.UTC()
+	if from == to {
+		return t, nil
+	}
+
+	// Go through UTC.
+	utc := t
+	switch from {
+	case UT1:
+		utc = t.Add(-DUT1(t))
+		// DUT1 changes by milliseconds a day, so one correction suffices.
+		utc = t.Add(-DUT1(utc))
+	case TAI, TT, GPS:
+		tai := t
+		if from == TT {
+			tai = t.Add(-TTMinusTAI)
+		} else if from == GPS {
+			tai = t.Add(TAIMinusGPS)
+		}
+		var err error
+		if utc, err = taiToUTC(tai); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	switch to {
+	case UTC:
+		return utc, nil
+	case UT1:
+		return utc.Add(DUT1(utc)), nil
+	}
+	d, err := TAIMinusUTC(utc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tai := utc.Add(d)
+	switch to {
+	case TT:
+		return tai.Add(TTMinusTAI), nil
+	case GPS:
+		return tai.Add(-TAIMinusGPS), nil
+	default:
+		return tai, nil
+	}
+}
+
+// TAIMinusUTC returns the number of leap seconds plus the initial 10 s
+// offset in effect at UTC instant t: 37 s since 2017.
+func TAIMinusUTC(t time.Time) (time.Duration, error) {
+	leapMu.RLock()
+	defer leapMu.RUnlock()
+
+	i := sort.Search(len(leapTable), func(i int) bool { return leapTable[i].start.After(t) }) - 1
+	if i < 0 {
+		return 0, ErrBeforeLeapSeconds
+	}
+	return leapTable[i].offset, nil
+}
+
+// DeltaT returns TT - UT1 at UTC instant t, the correction between the
+// dynamical time of ephemerides and the Earth's rotation. Before 1972,
+// when UTC had no leap seconds, it returns DeltaTEstimate; see
+// DeltaTUncertainty for how far that can be trusted.
+func DeltaT(t time.Time) (time.Duration, error) {
+	d, err := TAIMinusUTC(t)
+	if errors.Is(err, ErrBeforeLeapSeconds) {
+		return DeltaTEstimate(t), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return d + TTMinusTAI - DUT1(t), nil
+}
+
+// taiToUTC inverts TAIMinusUTC.
+func taiToUTC(tai time.Time) (time.Time, error) {
+	leapMu.RLock()
+	defer leapMu.RUnlock()
+
+	for i := len(leapTable) - 1; i >= 0; i-- {
+		e := leapTable[i]
+		if utc := tai.Add(-e.offset); !utc.Before(e.start) {
+			return utc, nil
+		}
+		if i > 0 && !tai.Add(-leapTable[i-1].offset).Before(e.start) {
+			// Inside the leap second before e.start.
+			return e.start, nil
+		}
+	}
+	return time.Time{}, ErrBeforeLeapSeconds
+}
+
+// -----------------------------
+// Leap seconds
+// -----------------------------
+
+//go:embed leap-seconds.list
+var leapSecondsList string
+
+// ntpEpoch is the epoch of the leap-seconds.list timestamps.
+var ntpEpoch = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type leapEntry struct {
+	start  time.Time     // UTC instant from which offset applies
+	offset time.Duration // TAI - UTC
+}
+
+var (
+	leapMu    sync.RWMutex
+	leapTable = mustParseLeapSeconds(leapSecondsList)
+)
+
+// LoadLeapSeconds replaces the built-in leap second table with one read
+// from r in the format of the IERS leap-seconds.list file: lines of an NTP
+// timestamp (seconds since 1900) and TAI-UTC in seconds, with # comments.
+// Use it when a leap second is announced after this package was built.
+func LoadLeapSeconds(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	table, err := parseLeapSeconds(string(b))
+	if err != nil {
+		return err
+	}
+	leapMu.Lock()
+	leapTable = table
+	leapMu.Unlock()
+	return nil
+}
+
+// LeapSeconds returns the UTC instants at which TAI-UTC changes, with the
+// new value of each.
+func LeapSeconds() (starts []time.Time, offsets []time.Duration) {
+	leapMu.RLock()
+	defer leapMu.RUnlock()
+	for _, e := range leapTable {
+		starts = append(starts, e.start)
+		offsets = append(offsets, e.offset)
+	}
+	return starts, offsets
+}
+
+func parseLeapSeconds(s string) ([]leapEntry, error) {
+	var table []leapEntry
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for n := 1; sc.Scan(); n++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("astrotime: leap seconds line %d: want timestamp and offset", n)
+		}
+		ntp, err1 := strconv.ParseInt(fields[0], 10, 64)
+		off, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("astrotime: leap seconds line %d: invalid number", n)
+		}
+		e := leapEntry{start: ntpEpoch.Add(time.Duration(ntp) * time.Second), offset: time.Duration(off) * time.Second}
+		if len(table) > 0 && !e.start.After(table[len(table)-1].start) {
+			return nil, fmt.Errorf("astrotime: leap seconds line %d: out of order", n)
+		}
+		table = append(table, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(table) == 0 {
+		return nil, errors.New("astrotime: empty leap seconds table")
+	}
+	return table, nil
+}
+
+func mustParseLeapSeconds(s string) []leapEntry {
+	table, err := parseLeapSeconds(s)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// -----------------------------
+// DUT1
+// -----------------------------
+
+type dut1Entry struct {
+	mjd  float64
+	dut1 float64 // UT1 - UTC, seconds
+}
+
+var (
+	dut1Mu    sync.RWMutex
+	dut1Table []dut1Entry
+)
+
+// DUT1 returns UT1 - UTC at t, interpolated linearly in the table loaded
+// with LoadDUT1. Outside the table, or with none loaded, it is zero: UTC is
+// kept within 0.9 s of UT1, so that is the worst-case error.
+func DUT1(t time.Time) time.Duration {
+	dut1Mu.RLock()
+	defer dut1Mu.RUnlock()
+
+	n := len(dut1Table)
+	if n == 0 {
+		return 0
+	}
+	mjd := ModifiedJulianDay(t)
+	if mjd < dut1Table[0].mjd || mjd > dut1Table[n-1].mjd {
+		return 0
+	}
+	i := sort.Search(n, func(i int) bool { return dut1Table[i].mjd >= mjd })
+	v := dut1Table[i].dut1
+	if i > 0 && dut1Table[i].mjd > mjd {
+		a, b := dut1Table[i-1], dut1Table[i]
+		v = a.dut1 + (b.dut1-a.dut1)*(mjd-a.mjd)/(b.mjd-a.mjd)
+	}
+	return time.Duration(math.Round(v * 1e9))
+}
+
+// LoadDUT1 replaces the DUT1 table with one read from r: lines of a
+// Modified Julian Day and UT1-UTC in seconds, in increasing date order,
+// with # comments. The values are published daily by the IERS (Bulletin A
+// and the finals2000A files); a row per day is plenty.
+func LoadDUT1(r io.Reader) error {
+	var table []dut1Entry
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("astrotime: DUT1 line %d: want MJD and UT1-UTC", n)
+		}
+		mjd, err1 := strconv.ParseFloat(fields[0], 64)
+		v, err2 := strconv.ParseFloat(fields[1], 64)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("astrotime: DUT1 line %d: invalid number", n)
+		}
+		if math.Abs(v) > 1 {
+			return fmt.Errorf("astrotime: DUT1 line %d: UT1-UTC %v s out of range", n, v)
+		}
+		if len(table) > 0 && mjd <= table[len(table)-1].mjd {
+			return fmt.Errorf("astrotime: DUT1 line %d: out of order", n)
+		}
+		table = append(table, dut1Entry{mjd: mjd, dut1: v})
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	dut1Mu.Lock()
+	dut1Table = table
+	dut1Mu.Unlock()
+	return nil
+}
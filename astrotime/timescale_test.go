@@ -0,0 +1,175 @@
+package astrotime
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTAIMinusUTC(t *testing.T) {
+	cases := []struct {
+		t    time.Time
+		want time.Duration
+	}{
+		{time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), 10 * time.Second},
+		{time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC), 19 * time.Second},
+		{time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC), 36 * time.Second},
+		{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 37 * time.Second},
+		{time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC), 37 * time.Second},
+	}
+	for _, c := range cases {
+		if got, err := TAIMinusUTC(c.t); err != nil || got != c.want {
+			t.Errorf("TAIMinusUTC(%v) = %v, %v; want %v", c.t, got, err, c.want)
+		}
+	}
+	if _, err := TAIMinusUTC(time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrBeforeLeapSeconds) {
+		t.Errorf("1960: err = %v, want ErrBeforeLeapSeconds", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	utc := time.Date(2025, time.June, 21, 12, 0, 0, 0, time.UTC)
+	want := map[Scale]time.Time{
+		UTC: utc,
+		UT1: utc,
+		TAI: utc.Add(37 * time.Second),
+		TT:  utc.Add(37*time.Second + 32184*time.Millisecond),
+		GPS: utc.Add(18 * time.Second),
+	}
+	for to, w := range want {
+		got, err := Convert(utc, UTC, to)
+		if err != nil || !got.Equal(w) {
+			t.Errorf("Convert(UTC -> %v) = %v, %v; want %v", to, got, err, w)
+			continue
+		}
+		for from := UTC; from <= GPS; from++ {
+			back, err := Convert(got, to, from)
+			if err != nil || !back.Equal(want[from]) {
+				t.Errorf("Convert(%v -> %v) = %v, %v; want %v", to, from, back, err, want[from])
+			}
+		}
+	}
+
+	// GPS time started equal to UTC.
+	gpsEpoch := time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	if got, _ := Convert(gpsEpoch, UTC, GPS); !got.Equal(gpsEpoch) {
+		t.Errorf("GPS at its epoch = %v, want %v", got, gpsEpoch)
+	}
+
+	// The TAI second of the 2016-12-31 leap second maps to 2017-01-01 UTC.
+	leap := time.Date(2017, time.January, 1, 0, 0, 36, 500e6, time.UTC)
+	if got, _ := Convert(leap, TAI, UTC); !got.Equal(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("TAI in leap second -> UTC = %v, want 2017-01-01T00:00:00Z", got)
+	}
+
+	if _, err := Convert(utc, UTC, Scale(9)); err == nil {
+		t.Error("expected error for an unknown scale")
+	}
+}
+
+func TestLoadLeapSeconds(t *testing.T) {
+	defer LoadLeapSeconds(strings.NewReader(leapSecondsList))
+
+	// A hypothetical leap second at the end of 2030.
+	extra := leapSecondsList + "4133980800\t38\t# 1 Jan 2031\n"
+	if err := LoadLeapSeconds(strings.NewReader(extra)); err != nil {
+		t.Fatalf("LoadLeapSeconds error: %v", err)
+	}
+	if got, _ := TAIMinusUTC(time.Date(2031, time.June, 1, 0, 0, 0, 0, time.UTC)); got != 38*time.Second {
+		t.Errorf("TAI-UTC in 2031 = %v, want 38s", got)
+	}
+
+	for _, bad := range []string{"", "# only comments\n", "3692217600\n", "3692217600 37\n3644697600 36\n"} {
+		if err := LoadLeapSeconds(strings.NewReader(bad)); err == nil {
+			t.Errorf("LoadLeapSeconds(%q) accepted", bad)
+		}
+	}
+}
+
+func TestDUT1(t *testing.T) {
+	defer LoadDUT1(strings.NewReader(""))
+
+	if err := LoadDUT1(strings.NewReader("# MJD  UT1-UTC\n60800 0.02\n60802 0.04\n")); err != nil {
+		t.Fatalf("LoadDUT1 error: %v", err)
+	}
+	mid := FromModifiedJulianDay(60801)
+	if got := DUT1(mid); got != 30*time.Millisecond {
+		t.Errorf("DUT1 between rows = %v, want 30ms", got)
+	}
+	if got := DUT1(FromModifiedJulianDay(61000)); got != 0 {
+		t.Errorf("DUT1 outside the table = %v, want 0", got)
+	}
+
+	ut1, err := Convert(mid, UTC, UT1)
+	if err != nil || !ut1.Equal(mid.Add(30*time.Millisecond)) {
+		t.Errorf("UT1 = %v, %v; want UTC + 30ms", ut1, err)
+	}
+	if back, _ := Convert(ut1, UT1, UTC); back.Sub(mid).Abs() > time.Microsecond {
+		t.Errorf("UT1 -> UTC = %v, want %v", back, mid)
+	}
+	if dt, _ := DeltaT(mid); dt != 69184*time.Millisecond-30*time.Millisecond {
+		t.Errorf("DeltaT = %v, want 69.154s", dt)
+	}
+
+	if err := LoadDUT1(strings.NewReader("60800 1.5\n")); err == nil {
+		t.Error("LoadDUT1 accepted |DUT1| > 1 s")
+	}
+}
+
+func TestDeltaTEstimate(t *testing.T) {
+	// Espenak and Meeus' table of ΔT at the start of each year, seconds.
+	for _, c := range []struct {
+		year int
+		want float64
+	}{
+		{-1000, 25400}, {-500, 17190}, {0, 10580}, {500, 5710}, {1000, 1570},
+		{1500, 200}, {1600, 120}, {1700, 9}, {1800, 14}, {1900, -3},
+		{1950, 29}, {1980, 51}, {2000, 64},
+	} {
+		tm := time.Date(c.year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		got := DeltaTEstimate(tm).Seconds()
+		if math.Abs(got-c.want) > math.Max(2, 0.005*math.Abs(c.want)) {
+			t.Errorf("DeltaTEstimate(%d) = %.0fs, want %.0fs", c.year, got, c.want)
+		}
+	}
+
+	// The fits join up without jumps.
+	for _, y := range []float64{-500, 500, 1600, 1700, 1800, 1860, 1900, 1920, 1941, 1961, 1986, 2005, 2050, 2150} {
+		if jump := deltaTSeconds(y) - deltaTSeconds(y-1e-9); math.Abs(jump) > 1.5 {
+			t.Errorf("ΔT jumps %.2fs at %v", jump, y)
+		}
+	}
+
+	// DeltaT falls back to the estimate before 1972, and uses the leap
+	// seconds after it.
+	old := time.Date(1066, time.October, 14, 0, 0, 0, 0, time.UTC)
+	if dt, err := DeltaT(old); err != nil || dt != DeltaTEstimate(old) {
+		t.Errorf("DeltaT(1066) = %v, %v; want the estimate %v", dt, err, DeltaTEstimate(old))
+	}
+	recent := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if dt, _ := DeltaT(recent); (dt - 69184*time.Millisecond).Abs() > time.Second {
+		t.Errorf("DeltaT(2020) = %v, want about 69.2s", dt)
+	}
+	if d := (DeltaTEstimate(recent) - 69184*time.Millisecond).Abs(); d > 3*time.Second {
+		t.Errorf("DeltaTEstimate(2020) is %v off the leap-second value", d)
+	}
+}
+
+func TestDeltaTUncertainty(t *testing.T) {
+	for _, c := range []struct {
+		year   int
+		lo, hi time.Duration
+	}{
+		{-500, 6 * time.Minute, 8 * time.Minute},
+		{0, 4 * time.Minute, 5 * time.Minute},
+		{1000, 50 * time.Second, 60 * time.Second},
+		{2000, time.Second, time.Second},
+	} {
+		got := DeltaTUncertainty(time.Date(c.year, time.January, 1, 0, 0, 0, 0, time.UTC))
+		if got < c.lo || got > c.hi {
+			t.Errorf("DeltaTUncertainty(%d) = %v, want %v to %v", c.year, got, c.lo, c.hi)
+		}
+	}
+}
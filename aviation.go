@@ -0,0 +1,75 @@
+package astroglide
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// AviationTwilight holds the twilight times used in flight planning and
+// military operations orders for one local calendar date.
+//
+// Civil.Rise is BMCT (begin morning civil twilight) and Civil.Set is EECT
+// (end evening civil twilight); the FAA defines night (14 CFR 1.1) as the
+// time between EECT and the next BMCT. Nautical gives BMNT and EENT, and
+// Sun the almanac sunrise and sunset (upper limb). SunCenter gives the
+// moments the Sun's center crosses the geometric horizon, which some
+// tables list instead.
+type AviationTwilight struct {
+	Nautical  RiseSet // BMNT (Rise) and EENT (Set), Sun at -12°
+	Civil     RiseSet // BMCT (Rise) and EECT (Set), Sun at -6°
+	Sun       RiseSet // sunrise and sunset, upper limb at the horizon
+	SunCenter RiseSet // Sun's center at 0°, no refraction
+}
+
+// BMNT returns begin morning nautical twilight, if it occurs.
+func (a AviationTwilight) BMNT() (time.Time, bool) { return a.Nautical.Rise, a.Nautical.HasRise }
+
+// BMCT returns begin morning civil twilight, if it occurs.
+func (a AviationTwilight) BMCT() (time.Time, bool) { return a.Civil.Rise, a.Civil.HasRise }
+
+// EECT returns end evening civil twilight, if it occurs.
+func (a AviationTwilight) EECT() (time.Time, bool) { return a.Civil.Set, a.Civil.HasSet }
+
+// EENT returns end evening nautical twilight, if it occurs.
+func (a AviationTwilight) EENT() (time.Time, bool) { return a.Nautical.Set, a.Nautical.HasSet }
+
+// AviationTwilightFor computes the aviation/military twilight presets for a
+// location and local calendar date. Individual events that do not occur
+// (e.g. nautical twilight on a high-latitude summer night) are flagged
+// through the HasRise/HasSet fields of each RiseSet.
+//
+// If none of the events occurs, a *NoEventError matching ErrNoRiseNoSet is
+// returned.
+func AviationTwilightFor(loc Coordinates, date time.Time, opts ...Option) (AviationTwilight, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return AviationTwilight{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return AviationTwilight{}, err
+	}
+
+	var a AviationTwilight
+	var found bool
+
+	presets := []struct {
+		rs  *RiseSet
+		alt float64
+	}{
+		{&a.Nautical, -12.0},
+		{&a.Civil, -6.0},
+		{&a.Sun, 90 - sun.StandardZenith},
+		{&a.SunCenter, 0.0},
+	}
+	for _, p := range presets {
+		rs, okUp, okDown := sunAltitudeCrossings(loc, date, p.alt, opts...)
+		*p.rs = rs
+		found = found || okUp || okDown
+	}
+
+	if !found {
+		return AviationTwilight{}, noEvent(Sun, "aviation twilight", loc, date)
+	}
+	return a, nil
+}
@@ -0,0 +1,76 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAviationTwilightFor(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 3, 20, 0, 0, 0, 0, loc)
+
+	a, err := AviationTwilightFor(phx, date)
+	if err != nil {
+		t.Fatalf("AviationTwilightFor error: %v", err)
+	}
+
+	civil, err := TwilightFor(phx, date, TwilightCivil)
+	if err != nil {
+		t.Fatalf("TwilightFor error: %v", err)
+	}
+	if bmct, ok := a.BMCT(); !ok || !bmct.Equal(civil.Rise) {
+		t.Errorf("BMCT = %v (%v), want civil dawn %v", bmct, ok, civil.Rise)
+	}
+	if eect, ok := a.EECT(); !ok || !eect.Equal(civil.Set) {
+		t.Errorf("EECT = %v (%v), want civil dusk %v", eect, ok, civil.Set)
+	}
+
+	// Morning order: BMNT < BMCT < sunrise < sun-center rise, and the
+	// reverse in the evening.
+	bmnt, _ := a.BMNT()
+	eent, _ := a.EENT()
+	morning := []time.Time{bmnt, a.Civil.Rise, a.Sun.Rise, a.SunCenter.Rise}
+	evening := []time.Time{a.SunCenter.Set, a.Sun.Set, a.Civil.Set, eent}
+	for i := 1; i < len(morning); i++ {
+		if !morning[i-1].Before(morning[i]) {
+			t.Errorf("morning event %d (%v) not before %d (%v)", i-1, morning[i-1], i, morning[i])
+		}
+		if !evening[i-1].Before(evening[i]) {
+			t.Errorf("evening event %d (%v) not before %d (%v)", i-1, evening[i-1], i, evening[i])
+		}
+	}
+
+	// The Sun's center clears the geometric horizon a few minutes after
+	// the upper limb appears.
+	if d := a.SunCenter.Rise.Sub(a.Sun.Rise); d < 2*time.Minute || d > 6*time.Minute {
+		t.Errorf("sun-center rise is %v after sunrise, want ~4 minutes", d)
+	}
+}
+
+func TestAviationTwilightFor_PartialAndNone(t *testing.T) {
+	// Oslo at midsummer: civil twilight and sunrise exist, but the Sun never
+	// gets 12° below the horizon.
+	oslo := Coordinates{Lat: 59.9139, Lon: 10.7522}
+	a, err := AviationTwilightFor(oslo, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("AviationTwilightFor error: %v", err)
+	}
+	if _, ok := a.BMNT(); ok {
+		t.Error("BMNT found at Oslo midsummer, want none")
+	}
+	if !a.Sun.HasRise || !a.Sun.HasSet {
+		t.Error("sunrise/sunset missing at Oslo midsummer")
+	}
+
+	// North Pole at midsummer: the Sun stays far above every preset.
+	pole := Coordinates{Lat: 89.9, Lon: 0}
+	_, err = AviationTwilightFor(pole, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrNoRiseNoSet) {
+		t.Errorf("pole error = %v, want ErrNoRiseNoSet", err)
+	}
+}
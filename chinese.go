@@ -0,0 +1,221 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// chinaStandardTime is UTC+8, the reference meridian of the modern Chinese
+// calendar: new moons and solar terms are assigned to dates in this zone.
+var chinaStandardTime = time.FixedZone("CST", 8*60*60)
+
+// SolarTerm is one of the 24 jieqi, the instants when the Sun's apparent
+// ecliptic longitude reaches a multiple of 15°.
+type SolarTerm struct {
+	Name      string    // pinyin name, e.g. "Qingming"
+	English   string    // English name, e.g. "Pure Brightness"
+	Longitude float64   // solar longitude in degrees (0 = March equinox)
+	Time      time.Time // instant of the term, UTC
+
+	// Principal is true for the 12 zhongqi (multiples of 30°), which
+	// determine month numbering and leap months.
+	Principal bool
+}
+
+// ChineseDate is a date in the Chinese lunisolar calendar.
+type ChineseDate struct {
+	// Year is the Gregorian year in which this Chinese year began (its
+	// Spring Festival), e.g. 2025 for the Year of the Snake 2025-26.
+	Year int
+
+	Month     int  // 1..12
+	LeapMonth bool // true for an intercalary month (runyue)
+	Day       int  // 1..30
+
+	Stem   string // heavenly stem of the year, e.g. "Yi"
+	Branch string // earthly branch of the year, e.g. "Si"
+	Animal string // zodiac animal of the year, e.g. "Snake"
+}
+
+var solarTermNames = [24][2]string{
+	{"Chunfen", "Spring Equinox"},
+	{"Qingming", "Pure Brightness"},
+	{"Guyu", "Grain Rain"},
+	{"Lixia", "Start of Summer"},
+	{"Xiaoman", "Grain Buds"},
+	{"Mangzhong", "Grain in Ear"},
+	{"Xiazhi", "Summer Solstice"},
+	{"Xiaoshu", "Minor Heat"},
+	{"Dashu", "Major Heat"},
+	{"Liqiu", "Start of Autumn"},
+	{"Chushu", "End of Heat"},
+	{"Bailu", "White Dew"},
+	{"Qiufen", "Autumn Equinox"},
+	{"Hanlu", "Cold Dew"},
+	{"Shuangjiang", "Frost's Descent"},
+	{"Lidong", "Start of Winter"},
+	{"Xiaoxue", "Minor Snow"},
+	{"Daxue", "Major Snow"},
+	{"Dongzhi", "Winter Solstice"},
+	{"Xiaohan", "Minor Cold"},
+	{"Dahan", "Major Cold"},
+	{"Lichun", "Start of Spring"},
+	{"Yushui", "Rain Water"},
+	{"Jingzhe", "Awakening of Insects"},
+}
+
+var (
+	heavenlyStems   = [10]string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"}
+	earthlyBranches = [12]string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"}
+	zodiacAnimals   = [12]string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+)
+
+// SolarTermsFor returns the 24 solar terms falling in the given Gregorian
+// year (UTC), in chronological order from Xiaohan (early January) to
+// Dongzhi (the December solstice).
+func SolarTermsFor(year int) ([]SolarTerm, error) {
+	if err := checkRange(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		return nil, err
+	}
+
+	terms := make([]SolarTerm, 0, 24)
+
+	// Xiaohan (285°) opens the Gregorian year; step 15° from there.
+	for i := 0; i < 24; i++ {
+		lon := math.Mod(285+15*float64(i), 360)
+		terms = append(terms, solarTerm(lon, approxSolarLongitudeDate(lon, year)))
+	}
+
+	return terms, nil
+}
+
+// ChineseDateOf converts the calendar date of `date` (its year, month and day
+// fields; the time of day and zone are ignored) to the Chinese lunisolar
+// calendar.
+//
+// Months begin on the day of the New Moon in China Standard Time, the month
+// containing the December solstice is month 11, and in a 13-month solstice
+// year the first month without a principal solar term is the leap month.
+// Because the underlying Sun and Moon models are approximate, dates whose
+// New Moon or principal term falls within about an hour of midnight (CST)
+// may occasionally differ from official almanacs.
+func ChineseDateOf(date time.Time) (ChineseDate, error) {
+	if err := checkRange(date); err != nil {
+		return ChineseDate{}, err
+	}
+
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, chinaStandardTime)
+
+	// Find the solstice year (sui) containing the date: from the start of
+	// month 11 (the lunation holding the December solstice) to the next one.
+	solsticeIn := func(year int) time.Time {
+		return cstDay(timeOfSolarLongitude(270, time.Date(year, time.December, 21, 0, 0, 0, 0, time.UTC)))
+	}
+
+	suiYear := y
+	m11 := newMoonDayOnOrBefore(solsticeIn(suiYear))
+	if m11.After(day) {
+		suiYear--
+		m11 = newMoonDayOnOrBefore(solsticeIn(suiYear))
+	}
+	nextM11 := newMoonDayOnOrBefore(solsticeIn(suiYear + 1))
+
+	// Month start days of the sui.
+	starts := []time.Time{m11}
+	for {
+		next := cstDay(nextLunarPhase(starts[len(starts)-1].Add(24*time.Hour), 0))
+		if !next.Before(nextM11) {
+			break
+		}
+		starts = append(starts, next)
+	}
+	starts = append(starts, nextM11)
+
+	hasLeap := len(starts)-1 == 13
+	leapTaken := false
+
+	month := 11
+	leap := false
+	gregYear := suiYear // months 11 and 12 belong to the year that began this spring
+
+	for i := 0; i+1 < len(starts); i++ {
+		if i > 0 {
+			if hasLeap && !leapTaken && !containsPrincipalTerm(starts[i], starts[i+1]) {
+				leap, leapTaken = true, true
+			} else {
+				leap = false
+				month = month%12 + 1
+				if month == 1 {
+					gregYear = suiYear + 1
+				}
+			}
+		}
+
+		if !day.Before(starts[i]) && day.Before(starts[i+1]) {
+			cd := ChineseDate{
+				Year:      gregYear,
+				Month:     month,
+				LeapMonth: leap,
+				Day:       int(math.Round(day.Sub(starts[i]).Hours()/24)) + 1,
+			}
+			cycle := ((gregYear-4)%60 + 60) % 60
+			cd.Stem = heavenlyStems[cycle%10]
+			cd.Branch = earthlyBranches[cycle%12]
+			cd.Animal = zodiacAnimals[cycle%12]
+			return cd, nil
+		}
+	}
+
+	// The sui covers the date by construction; reaching here means the
+	// lunation search went wrong.
+	return ChineseDate{}, fmt.Errorf("could not place %s in the Chinese calendar", day.Format("2006-01-02"))
+}
+
+// solarTerm builds the SolarTerm for a longitude from its instant.
+func solarTerm(lon float64, t time.Time) SolarTerm {
+	names := solarTermNames[int(math.Round(lon/15))%24]
+	return SolarTerm{
+		Name:      names[0],
+		English:   names[1],
+		Longitude: lon,
+		Time:      t,
+		Principal: math.Mod(lon, 30) == 0,
+	}
+}
+
+// approxSolarLongitudeDate returns the instant in the given Gregorian year
+// when the Sun reaches longitude lon.
+func approxSolarLongitudeDate(lon float64, year int) time.Time {
+	// The March equinox is close to March 20; the Sun moves ~1°/day.
+	guess := time.Date(year, time.March, 20, 0, 0, 0, 0, time.UTC).
+		Add(daysToDuration(lon / meanSolarLongitudeRate))
+	if guess.Year() > year {
+		guess = guess.AddDate(-1, 0, 0)
+	}
+	return timeOfSolarLongitude(lon, guess)
+}
+
+// cstDay returns midnight (China Standard Time) of the CST date of t.
+func cstDay(t time.Time) time.Time {
+	y, m, d := t.In(chinaStandardTime).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, chinaStandardTime)
+}
+
+// newMoonDayOnOrBefore returns the CST day of the last New Moon whose CST
+// date is on or before day.
+func newMoonDayOnOrBefore(day time.Time) time.Time {
+	endOfDay := day.Add(24*time.Hour - time.Nanosecond)
+	return cstDay(prevLunarPhase(endOfDay, 0))
+}
+
+// containsPrincipalTerm reports whether a principal solar term (a multiple
+// of 30° of solar longitude) has its CST date in [start, end).
+func containsPrincipalTerm(start, end time.Time) bool {
+	a := math.Floor(sun.EclipticLongitude(start.UTC()) / 30)
+	b := math.Floor(sun.EclipticLongitude(end.UTC()) / 30)
+	return a != b
+}
@@ -0,0 +1,87 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChineseDateOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		date   time.Time
+		year   int
+		month  int
+		leap   bool
+		day    int
+		animal string
+	}{
+		{"Spring Festival 2025", time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC), 2025, 1, false, 1, "Snake"},
+		{"eve of Spring Festival 2025", time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC), 2024, 12, false, 29, "Dragon"},
+		{"Spring Festival 2024", time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), 2024, 1, false, 1, "Dragon"},
+		{"leap 6th month 2025", time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC), 2025, 6, true, 1, "Snake"},
+		{"7th month after leap 2025", time.Date(2025, 8, 23, 0, 0, 0, 0, time.UTC), 2025, 7, false, 1, "Snake"},
+		{"Mid-Autumn 2025", time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC), 2025, 8, false, 15, "Snake"},
+		// The month-11 New Moon (2020-12-15 CST) precedes the solstice.
+		{"month 11 before solstice", time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC), 2020, 11, false, 1, "Rat"},
+		{"leap 2nd month 2023", time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), 2023, 2, true, 11, "Rabbit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChineseDateOf(tt.date)
+			if err != nil {
+				t.Fatalf("ChineseDateOf error: %v", err)
+			}
+			if got.Year != tt.year || got.Month != tt.month || got.LeapMonth != tt.leap || got.Day != tt.day || got.Animal != tt.animal {
+				t.Errorf("ChineseDateOf(%s) = %+v, want year %d month %d (leap %v) day %d, %s",
+					tt.date.Format("2006-01-02"), got, tt.year, tt.month, tt.leap, tt.day, tt.animal)
+			}
+		})
+	}
+}
+
+func TestChineseDateOf_SexagenaryYear(t *testing.T) {
+	got, err := ChineseDateOf(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ChineseDateOf error: %v", err)
+	}
+	if got.Stem != "Yi" || got.Branch != "Si" {
+		t.Errorf("2025 is a Yi-Si year, got %s-%s", got.Stem, got.Branch)
+	}
+}
+
+func TestSolarTermsFor(t *testing.T) {
+	terms, err := SolarTermsFor(2025)
+	if err != nil {
+		t.Fatalf("SolarTermsFor error: %v", err)
+	}
+	if len(terms) != 24 {
+		t.Fatalf("got %d terms, want 24", len(terms))
+	}
+	for i := 1; i < len(terms); i++ {
+		if !terms[i-1].Time.Before(terms[i].Time) {
+			t.Errorf("term %s not before %s", terms[i-1].Name, terms[i].Name)
+		}
+	}
+	if terms[0].Name != "Xiaohan" || terms[23].Name != "Dongzhi" {
+		t.Errorf("year should run Xiaohan..Dongzhi, got %s..%s", terms[0].Name, terms[23].Name)
+	}
+
+	// Reference instants (UTC) for 2025.
+	want := map[string]time.Time{
+		"Chunfen":  time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC),
+		"Xiazhi":   time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC),
+		"Qiufen":   time.Date(2025, 9, 22, 18, 19, 0, 0, time.UTC),
+		"Dongzhi":  time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC),
+		"Qingming": time.Date(2025, 4, 4, 12, 48, 0, 0, time.UTC),
+	}
+	for _, term := range terms {
+		ref, ok := want[term.Name]
+		if !ok {
+			continue
+		}
+		if d := diffMinutes(term.Time, ref); d > 20 {
+			t.Errorf("%s at %v, want ~%v (off by %.1f min)", term.Name, term.Time, ref, d)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * astroglide.h - C interface to the astroglide engine.
+ *
+ * Build the shared library with
+ *
+ *     go build -buildmode=c-shared -o libastroglide.so ./cmd/astroglide-cshared
+ *
+ * (libastroglide.dylib on macOS, astroglide.dll on Windows). Go also writes
+ * a libastroglide.h next to it; this header is the stable, hand-maintained
+ * subset meant for consumers.
+ *
+ * Times are Unix seconds. Functions that take a date use unix_ts and
+ * utc_offset (seconds east of UTC) only to pick the local calendar day;
+ * events are returned as instants.
+ */
+#ifndef ASTROGLIDE_H
+#define ASTROGLIDE_H
+
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Return codes. */
+#define ASTROGLIDE_OK            0
+#define ASTROGLIDE_NO_EVENT      1 /* neither event occurs on the date */
+#define ASTROGLIDE_OUT_OF_RANGE  2 /* date outside 1800-2199 */
+#define ASTROGLIDE_INVALID_ARG   3
+#define ASTROGLIDE_ERROR         4
+
+/* Bodies for astroglide_riseset. */
+#define ASTROGLIDE_SUN  0
+#define ASTROGLIDE_MOON 1
+
+/* Twilight kinds for astroglide_twilight. */
+#define ASTROGLIDE_TWILIGHT_CIVIL        0
+#define ASTROGLIDE_TWILIGHT_NAUTICAL     1
+#define ASTROGLIDE_TWILIGHT_ASTRONOMICAL 2
+
+typedef struct {
+	int64_t rise;     /* valid when has_rise != 0 */
+	int64_t set;      /* valid when has_set != 0 */
+	int32_t has_rise;
+	int32_t has_set;
+} astroglide_riseset_t;
+
+typedef struct {
+	double  fraction;   /* illuminated fraction, 0..1 */
+	double  elongation; /* Sun-Moon separation, degrees 0..180 */
+	int32_t waxing;
+	char    name[32];   /* e.g. "Waxing Crescent", NUL-terminated */
+} astroglide_moon_phase_t;
+
+int astroglide_riseset(double lat, double lon, int64_t unix_ts, int32_t utc_offset,
+                       int32_t body, astroglide_riseset_t *out);
+
+int astroglide_twilight(double lat, double lon, int64_t unix_ts, int32_t utc_offset,
+                        int32_t kind, astroglide_riseset_t *out);
+
+int astroglide_moon_phase(int64_t unix_ts, astroglide_moon_phase_t *out);
+
+/* Static description of a return code. */
+const char *astroglide_strerror(int code);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* ASTROGLIDE_H */
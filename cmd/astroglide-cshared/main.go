@@ -0,0 +1,141 @@
+//go:build cgo
+
+// Command astroglide-cshared builds astroglide as a C shared library so
+// Python, Rust, Swift and other FFI users can call the engine:
+//
+//	go build -buildmode=c-shared -o libastroglide.so ./cmd/astroglide-cshared
+//
+// The C API is declared in astroglide.h in this directory. Built normally
+// (without -buildmode=c-shared) the command does nothing.
+package main
+
+/*
+#include <string.h>
+#include "astroglide.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/thurmanmarka/astroglide"
+)
+
+func main() {}
+
+//export astroglide_riseset
+func astroglide_riseset(lat, lon C.double, unixTS C.int64_t, utcOffset C.int32_t, body C.int32_t, out *C.astroglide_riseset_t) C.int {
+	if out == nil {
+		return C.ASTROGLIDE_INVALID_ARG
+	}
+
+	var b astroglide.Body
+	switch body {
+	case C.ASTROGLIDE_SUN:
+		b = astroglide.Sun
+	case C.ASTROGLIDE_MOON:
+		b = astroglide.Moon
+	default:
+		return C.ASTROGLIDE_INVALID_ARG
+	}
+
+	rs, err := astroglide.RiseSetFor(b, coords(lat, lon), localDate(unixTS, utcOffset), astroglide.WithTrueInstants())
+	if err != nil {
+		return errorCode(err)
+	}
+	fillRiseSet(out, rs)
+	return C.ASTROGLIDE_OK
+}
+
+//export astroglide_twilight
+func astroglide_twilight(lat, lon C.double, unixTS C.int64_t, utcOffset C.int32_t, kind C.int32_t, out *C.astroglide_riseset_t) C.int {
+	if out == nil {
+		return C.ASTROGLIDE_INVALID_ARG
+	}
+
+	var k astroglide.TwilightKind
+	switch kind {
+	case C.ASTROGLIDE_TWILIGHT_CIVIL:
+		k = astroglide.TwilightCivil
+	case C.ASTROGLIDE_TWILIGHT_NAUTICAL:
+		k = astroglide.TwilightNautical
+	case C.ASTROGLIDE_TWILIGHT_ASTRONOMICAL:
+		k = astroglide.TwilightAstronomical
+	default:
+		return C.ASTROGLIDE_INVALID_ARG
+	}
+
+	rs, err := astroglide.TwilightFor(coords(lat, lon), localDate(unixTS, utcOffset), k, astroglide.WithTrueInstants())
+	if err != nil {
+		return errorCode(err)
+	}
+	fillRiseSet(out, rs)
+	return C.ASTROGLIDE_OK
+}
+
+//export astroglide_moon_phase
+func astroglide_moon_phase(unixTS C.int64_t, out *C.astroglide_moon_phase_t) C.int {
+	if out == nil {
+		return C.ASTROGLIDE_INVALID_ARG
+	}
+
+	p, err := astroglide.MoonPhaseAt(time.Unix(int64(unixTS), 0).UTC())
+	if err != nil {
+		return errorCode(err)
+	}
+
+	out.fraction = C.double(p.Fraction)
+	out.elongation = C.double(p.Elongation)
+	out.waxing = cBool(p.Waxing)
+
+	// Copy the name into the fixed buffer, always NUL-terminated.
+	name := p.Name
+	if limit := len(out.name) - 1; len(name) > limit {
+		name = name[:limit]
+	}
+	C.memset(unsafe.Pointer(&out.name[0]), 0, C.size_t(len(out.name)))
+	if len(name) > 0 {
+		C.memcpy(unsafe.Pointer(&out.name[0]), unsafe.Pointer(unsafe.StringData(name)), C.size_t(len(name)))
+	}
+	return C.ASTROGLIDE_OK
+}
+
+func coords(lat, lon C.double) astroglide.Coordinates {
+	return astroglide.Coordinates{Lat: float64(lat), Lon: float64(lon)}
+}
+
+// localDate returns the instant in a fixed zone utcOffset seconds east of
+// UTC, whose calendar date selects the day to compute.
+func localDate(unixTS C.int64_t, utcOffset C.int32_t) time.Time {
+	return time.Unix(int64(unixTS), 0).In(time.FixedZone("", int(utcOffset)))
+}
+
+func fillRiseSet(out *C.astroglide_riseset_t, rs astroglide.RiseSet) {
+	*out = C.astroglide_riseset_t{has_rise: cBool(rs.HasRise), has_set: cBool(rs.HasSet)}
+	if rs.HasRise {
+		out.rise = C.int64_t(rs.Rise.Unix())
+	}
+	if rs.HasSet {
+		out.set = C.int64_t(rs.Set.Unix())
+	}
+}
+
+func errorCode(err error) C.int {
+	switch {
+	case errors.Is(err, astroglide.ErrNoRiseNoSet):
+		return C.ASTROGLIDE_NO_EVENT
+	case errors.Is(err, astroglide.ErrOutOfRange):
+		return C.ASTROGLIDE_OUT_OF_RANGE
+	default:
+		return C.ASTROGLIDE_ERROR
+	}
+}
+
+func cBool(b bool) C.int32_t {
+	if b {
+		return 1
+	}
+	return 0
+}
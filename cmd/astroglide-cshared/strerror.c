@@ -0,0 +1,16 @@
+#include "astroglide.h"
+
+const char *astroglide_strerror(int code) {
+	switch (code) {
+	case ASTROGLIDE_OK:
+		return "ok";
+	case ASTROGLIDE_NO_EVENT:
+		return "event does not occur on this date";
+	case ASTROGLIDE_OUT_OF_RANGE:
+		return "time outside supported range";
+	case ASTROGLIDE_INVALID_ARG:
+		return "invalid argument";
+	default:
+		return "internal error";
+	}
+}
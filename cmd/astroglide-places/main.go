@@ -0,0 +1,404 @@
+// Command astroglide-places builds the place database embedded by
+// astroglide.LookupPlace (data/places.tsv.gz).
+//
+// The full database comes from GeoNames (https://download.geonames.org/export/dump/):
+//
+//	astroglide-places -geonames cities15000.txt -admin1 admin1CodesASCII.txt \
+//	    -countries countryInfo.txt -out data/places.tsv.gz
+//
+// which gives the roughly 30,000 places with more than 15,000 inhabitants.
+// Without GeoNames files it falls back to the tz database: one place per
+// zone from zone.tab (the zone's principal city) with country names from
+// iso3166.tab:
+//
+//	astroglide-places -zonetab /usr/share/zoneinfo/zone.tab \
+//	    -countries /usr/share/zoneinfo/iso3166.tab \
+//	    -extra cmd/astroglide-places/cities.tsv -out data/places.tsv.gz
+//
+// -extra adds places in the output format below (cities.tsv lists some 470
+// major cities), replacing any place of the same country and ASCII name.
+// The shipped database is this zone.tab build with cities.tsv.
+//
+// The output is a gzipped TSV. Lines starting with "C\t" name a country
+// (code, name); every other line is a place:
+//
+//	name  asciiname  region-code  region-name  country  lat  lon  tz  population
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type place struct {
+	name, ascii            string
+	regionCode, regionName string
+	country                string
+	lat, lon               float64
+	tz                     string
+	population             int
+}
+
+func main() {
+	log.SetFlags(0)
+
+	geonames := flag.String("geonames", "", "GeoNames cities file (e.g. cities15000.txt)")
+	admin1 := flag.String("admin1", "", "GeoNames admin1CodesASCII.txt, for region names")
+	zonetab := flag.String("zonetab", "", "tz database zone.tab, used when -geonames is not given")
+	countries := flag.String("countries", "", "country names: GeoNames countryInfo.txt or tz iso3166.tab")
+	extra := flag.String("extra", "", "more places in the output format, e.g. cities.tsv")
+	out := flag.String("out", "data/places.tsv.gz", "output file")
+	minPop := flag.Int("min-pop", 0, "skip GeoNames places with fewer inhabitants")
+	flag.Parse()
+
+	var (
+		places []place
+		err    error
+	)
+	switch {
+	case *geonames != "":
+		var regions map[string]string
+		if *admin1 != "" {
+			if regions, err = readAdmin1(*admin1); err != nil {
+				log.Fatal(err)
+			}
+		}
+		places, err = readGeoNames(*geonames, regions, *minPop)
+	case *zonetab != "":
+		places, err = readZoneTab(*zonetab)
+	default:
+		log.Fatal("need -geonames or -zonetab")
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *extra != "" {
+		more, err := readPlaces(*extra)
+		if err != nil {
+			log.Fatal(err)
+		}
+		places = merge(places, more)
+	}
+
+	var names map[string]string
+	if *countries != "" {
+		if names, err = readCountries(*countries); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := write(*out, places, names); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d places and %d countries to %s", len(places), len(names), *out)
+}
+
+// readGeoNames reads the GeoNames "geoname" table format.
+func readGeoNames(path string, regions map[string]string, minPop int) ([]place, error) {
+	var places []place
+	err := eachLine(path, func(fields []string) error {
+		if len(fields) < 19 {
+			return fmt.Errorf("want 19 fields, got %d", len(fields))
+		}
+		lat, err1 := strconv.ParseFloat(fields[4], 64)
+		lon, err2 := strconv.ParseFloat(fields[5], 64)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("bad coordinates %q, %q", fields[4], fields[5])
+		}
+		pop, _ := strconv.Atoi(fields[14])
+		if pop < minPop || fields[17] == "" {
+			return nil
+		}
+		p := place{
+			name:       fields[1],
+			ascii:      fields[2],
+			regionCode: fields[10],
+			country:    fields[8],
+			lat:        lat,
+			lon:        lon,
+			tz:         fields[17],
+			population: pop,
+		}
+		p.regionName = regions[p.country+"."+p.regionCode]
+		places = append(places, p)
+		return nil
+	})
+	return places, err
+}
+
+// readPlaces reads places in the output format.
+func readPlaces(path string) ([]place, error) {
+	var places []place
+	err := eachLine(path, func(fields []string) error {
+		if len(fields) != 9 {
+			return fmt.Errorf("want 9 fields, got %d", len(fields))
+		}
+		lat, err1 := strconv.ParseFloat(fields[5], 64)
+		lon, err2 := strconv.ParseFloat(fields[6], 64)
+		pop, err3 := strconv.Atoi(fields[8])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return fmt.Errorf("bad number in %q", strings.Join(fields, "\t"))
+		}
+		places = append(places, place{
+			name:       fields[0],
+			ascii:      fields[1],
+			regionCode: fields[2],
+			regionName: fields[3],
+			country:    fields[4],
+			lat:        lat,
+			lon:        lon,
+			tz:         fields[7],
+			population: pop,
+		})
+		return nil
+	})
+	return places, err
+}
+
+// merge adds more to places, dropping places that more replaces: those of
+// the same country and ASCII name, such as the zone.tab entry for a city.
+func merge(places, more []place) []place {
+	replaced := map[string]bool{}
+	for _, p := range more {
+		replaced[p.country+"\t"+strings.ToLower(p.ascii)] = true
+	}
+	var out []place
+	for _, p := range places {
+		if !replaced[p.country+"\t"+strings.ToLower(p.ascii)] {
+			out = append(out, p)
+		}
+	}
+	return append(out, more...)
+}
+
+// readAdmin1 reads admin1CodesASCII.txt into "CC.code" -> name.
+func readAdmin1(path string) (map[string]string, error) {
+	regions := map[string]string{}
+	err := eachLine(path, func(fields []string) error {
+		if len(fields) >= 2 {
+			regions[fields[0]] = fields[1]
+		}
+		return nil
+	})
+	return regions, err
+}
+
+// zoneRegions gives the state or province of zone.tab cities in countries
+// where people usually write one ("Phoenix, AZ").
+var zoneRegions = map[string][2]string{
+	"America/New_York":               {"NY", "New York"},
+	"America/Detroit":                {"MI", "Michigan"},
+	"America/Kentucky/Louisville":    {"KY", "Kentucky"},
+	"America/Kentucky/Monticello":    {"KY", "Kentucky"},
+	"America/Indiana/Indianapolis":   {"IN", "Indiana"},
+	"America/Indiana/Vincennes":      {"IN", "Indiana"},
+	"America/Indiana/Winamac":        {"IN", "Indiana"},
+	"America/Indiana/Marengo":        {"IN", "Indiana"},
+	"America/Indiana/Petersburg":     {"IN", "Indiana"},
+	"America/Indiana/Vevay":          {"IN", "Indiana"},
+	"America/Indiana/Tell_City":      {"IN", "Indiana"},
+	"America/Indiana/Knox":           {"IN", "Indiana"},
+	"America/Chicago":                {"IL", "Illinois"},
+	"America/Menominee":              {"MI", "Michigan"},
+	"America/North_Dakota/Center":    {"ND", "North Dakota"},
+	"America/North_Dakota/New_Salem": {"ND", "North Dakota"},
+	"America/North_Dakota/Beulah":    {"ND", "North Dakota"},
+	"America/Denver":                 {"CO", "Colorado"},
+	"America/Boise":                  {"ID", "Idaho"},
+	"America/Phoenix":                {"AZ", "Arizona"},
+	"America/Los_Angeles":            {"CA", "California"},
+	"America/Anchorage":              {"AK", "Alaska"},
+	"America/Juneau":                 {"AK", "Alaska"},
+	"America/Sitka":                  {"AK", "Alaska"},
+	"America/Metlakatla":             {"AK", "Alaska"},
+	"America/Yakutat":                {"AK", "Alaska"},
+	"America/Nome":                   {"AK", "Alaska"},
+	"America/Adak":                   {"AK", "Alaska"},
+	"Pacific/Honolulu":               {"HI", "Hawaii"},
+	"America/St_Johns":               {"NL", "Newfoundland and Labrador"},
+	"America/Halifax":                {"NS", "Nova Scotia"},
+	"America/Moncton":                {"NB", "New Brunswick"},
+	"America/Toronto":                {"ON", "Ontario"},
+	"America/Winnipeg":               {"MB", "Manitoba"},
+	"America/Regina":                 {"SK", "Saskatchewan"},
+	"America/Edmonton":               {"AB", "Alberta"},
+	"America/Vancouver":              {"BC", "British Columbia"},
+	"America/Whitehorse":             {"YT", "Yukon"},
+	"America/Iqaluit":                {"NU", "Nunavut"},
+	"America/Inuvik":                 {"NT", "Northwest Territories"},
+	"Australia/Sydney":               {"NSW", "New South Wales"},
+	"Australia/Broken_Hill":          {"NSW", "New South Wales"},
+	"Australia/Melbourne":            {"VIC", "Victoria"},
+	"Australia/Brisbane":             {"QLD", "Queensland"},
+	"Australia/Adelaide":             {"SA", "South Australia"},
+	"Australia/Perth":                {"WA", "Western Australia"},
+	"Australia/Darwin":               {"NT", "Northern Territory"},
+	"Australia/Hobart":               {"TAS", "Tasmania"},
+}
+
+// readZoneTab reads zone.tab: country, ±DDMM[SS]±DDDMM[SS], zone, comment.
+func readZoneTab(path string) ([]place, error) {
+	var places []place
+	err := eachLine(path, func(fields []string) error {
+		if len(fields) < 3 {
+			return fmt.Errorf("want at least 3 fields, got %d", len(fields))
+		}
+		lat, lon, err := parseISO6709(fields[1])
+		if err != nil {
+			return err
+		}
+		zone := fields[2]
+		name := strings.ReplaceAll(zone[strings.LastIndex(zone, "/")+1:], "_", " ")
+		p := place{
+			name:    name,
+			ascii:   name,
+			country: fields[0],
+			lat:     lat,
+			lon:     lon,
+			tz:      zone,
+		}
+		if r, ok := zoneRegions[zone]; ok {
+			p.regionCode, p.regionName = r[0], r[1]
+		}
+		places = append(places, p)
+		return nil
+	})
+	return places, err
+}
+
+// parseISO6709 parses zone.tab coordinates such as "+3326-11204" or
+// "+404251-0740023".
+func parseISO6709(s string) (lat, lon float64, err error) {
+	i := strings.IndexAny(s[1:], "+-") + 1
+	if i <= 0 {
+		return 0, 0, fmt.Errorf("bad coordinates %q", s)
+	}
+	if lat, err = parseDMS(s[:i], 2); err != nil {
+		return 0, 0, err
+	}
+	if lon, err = parseDMS(s[i:], 3); err != nil {
+		return 0, 0, err
+	}
+	return lat, lon, nil
+}
+
+// parseDMS parses ±D..DMM[SS] with degDigits degree digits.
+func parseDMS(s string, degDigits int) (float64, error) {
+	sign := 1.0
+	if s[0] == '-' {
+		sign = -1
+	}
+	digits := s[1:]
+	if len(digits) != degDigits+2 && len(digits) != degDigits+4 {
+		return 0, fmt.Errorf("bad coordinate %q", s)
+	}
+	var parts [3]float64
+	for i, start := 0, 0; start < len(digits); i++ {
+		n := 2
+		if i == 0 {
+			n = degDigits
+		}
+		v, err := strconv.Atoi(digits[start : start+n])
+		if err != nil {
+			return 0, fmt.Errorf("bad coordinate %q", s)
+		}
+		parts[i] = float64(v)
+		start += n
+	}
+	return sign * (parts[0] + parts[1]/60 + parts[2]/3600), nil
+}
+
+// readCountries reads GeoNames countryInfo.txt (ISO code in column 0, name
+// in column 4) or tz iso3166.tab (code, name).
+func readCountries(path string) (map[string]string, error) {
+	names := map[string]string{}
+	err := eachLine(path, func(fields []string) error {
+		switch {
+		case len(fields) >= 5:
+			names[fields[0]] = fields[4]
+		case len(fields) == 2:
+			names[fields[0]] = fields[1]
+		}
+		return nil
+	})
+	return names, err
+}
+
+// eachLine calls fn with the tab-separated fields of every non-comment line.
+func eachLine(path string, fn func(fields []string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if err := fn(strings.Split(line, "\t")); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+	}
+	return sc.Err()
+}
+
+func write(path string, places []place, countries map[string]string) error {
+	// Most populous first, so lookups can stop at the first match.
+	sort.SliceStable(places, func(i, j int) bool { return places[i].population > places[j].population })
+
+	codes := make([]string, 0, len(countries))
+	for code := range countries {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	w := bufio.NewWriter(zw)
+	for _, code := range codes {
+		fmt.Fprintf(w, "C\t%s\t%s\n", code, clean(countries[code]))
+	}
+	for _, p := range places {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			clean(p.name), clean(p.ascii), clean(p.regionCode), clean(p.regionName), p.country,
+			strconv.FormatFloat(p.lat, 'f', 4, 64), strconv.FormatFloat(p.lon, 'f', 4, 64),
+			p.tz, p.population)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeFile(path, &buf)
+}
+
+func writeFile(path string, r io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// clean keeps tabs and newlines out of a field.
+func clean(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ").Replace(s)
+}
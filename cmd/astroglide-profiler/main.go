@@ -115,7 +115,7 @@ func main() {
 		year     = flag.Int("year", 0, "year of the ephemeris data (optional, used for sanity checks)")
 		refCSV   = flag.String("refcsv", "", "path to reference ephemeris CSV file (date,rise,set)")
 		verbose  = flag.Bool("verbose", false, "log per-day errors instead of only summary")
-		twilight = flag.String("twilight", "", "twilight kind: civil, nautical, astronomical (Sun only)")
+		twilight = flag.String("twilight", "", "twilight kind: civil, nautical, astronomical, center (Sun only)")
 		outCSV   = flag.String("outcsv", "", "optional path to write per-row error CSV")
 	)
 
@@ -157,8 +157,10 @@ func main() {
 			twilightKind = astroglide.TwilightNautical
 		case "astronomical":
 			twilightKind = astroglide.TwilightAstronomical
+		case "center", "sun-center":
+			twilightKind = astroglide.TwilightSunCenter
 		default:
-			log.Fatalf("unknown twilight kind %q (use civil, nautical, or astronomical)", *twilight)
+			log.Fatalf("unknown twilight kind %q (use civil, nautical, astronomical, or center)", *twilight)
 		}
 	}
 
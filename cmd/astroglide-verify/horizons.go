@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+)
+
+// horizonsURL is the JPL Horizons API endpoint.
+const horizonsURL = "https://ssd.jpl.nasa.gov/api/horizons.api"
+
+// horizonsTimeLayout is the UT timestamp format of Horizons CSV rows.
+const horizonsTimeLayout = "2006-Jan-02 15:04"
+
+// sample is one Horizons ephemeris row: airless apparent topocentric
+// azimuth and elevation (degrees) at a UT instant.
+type sample struct {
+	Time      time.Time
+	Azimuth   float64
+	Elevation float64
+}
+
+// horizonsCommand returns the Horizons target code of a body.
+func horizonsCommand(body astroglide.Body) (string, error) {
+	switch body {
+	case astroglide.Sun:
+		return "10", nil
+	case astroglide.Moon:
+		return "301", nil
+	default:
+		return "", fmt.Errorf("no Horizons code for %v", body)
+	}
+}
+
+// horizonsQuery builds the observer-table query for body at site.
+func horizonsQuery(body astroglide.Body, s site, start, stop time.Time, step time.Duration) (url.Values, error) {
+	cmd, err := horizonsCommand(body)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := func(v string) string { return "'" + v + "'" }
+	q := url.Values{}
+	q.Set("format", "json")
+	q.Set("COMMAND", quote(cmd))
+	q.Set("OBJ_DATA", "NO")
+	q.Set("MAKE_EPHEM", "YES")
+	q.Set("EPHEM_TYPE", "OBSERVER")
+	q.Set("CENTER", quote("coord@399"))
+	q.Set("COORD_TYPE", "GEODETIC")
+	q.Set("SITE_COORD", quote(fmt.Sprintf("%.6f,%.6f,%.4f", s.Lon, s.Lat, s.Elevation/1000)))
+	q.Set("START_TIME", quote(start.UTC().Format("2006-01-02 15:04")))
+	q.Set("STOP_TIME", quote(stop.UTC().Format("2006-01-02 15:04")))
+	q.Set("STEP_SIZE", quote(fmt.Sprintf("%d m", int(step.Minutes()))))
+	q.Set("QUANTITIES", quote("4")) // apparent azimuth and elevation
+	q.Set("APPARENT", "AIRLESS")
+	q.Set("ANG_FORMAT", "DEG")
+	q.Set("TIME_TYPE", "UT")
+	q.Set("CSV_FORMAT", "YES")
+	return q, nil
+}
+
+// fetchHorizons returns the Horizons samples for body at site. When cacheDir
+// is set, raw responses are read from and written to it, so a comparison
+// can be rerun offline.
+func fetchHorizons(ctx context.Context, client *http.Client, api, cacheDir string, body astroglide.Body, s site, start, stop time.Time, step time.Duration) ([]sample, error) {
+	q, err := horizonsQuery(body, s, start, stop, step)
+	if err != nil {
+		return nil, err
+	}
+
+	var cachePath string
+	if cacheDir != "" {
+		name := fmt.Sprintf("%s_%s_%s_%s_%dm.json", strings.ToLower(body.String()), s.Name,
+			start.UTC().Format("20060102T1504"), stop.UTC().Format("20060102T1504"), int(step.Minutes()))
+		cachePath = filepath.Join(cacheDir, name)
+		if raw, err := os.ReadFile(cachePath); err == nil {
+			return parseHorizons(raw)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("horizons: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+
+	samples, err := parseHorizons(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(cachePath, raw, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return samples, nil
+}
+
+// parseHorizons extracts the rows between $$SOE and $$EOE of a Horizons
+// JSON response.
+func parseHorizons(raw []byte) ([]sample, error) {
+	var resp struct {
+		Result string `json:"result"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("horizons: decoding response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("horizons: %s", resp.Error)
+	}
+
+	start := strings.Index(resp.Result, "$$SOE")
+	end := strings.Index(resp.Result, "$$EOE")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("horizons: no ephemeris in response")
+	}
+
+	var out []sample
+	for _, line := range strings.Split(resp.Result[start+len("$$SOE"):end], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		s, err := parseHorizonsRow(line)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// parseHorizonsRow parses one CSV row such as
+//
+//	2025-Jan-01 00:00, , ,  105.123456, -34.567890,
+//
+// The columns between the date and the angles are presence flags and may
+// be blank.
+func parseHorizonsRow(line string) (sample, error) {
+	fields := strings.Split(line, ",")
+	t, err := time.Parse(horizonsTimeLayout, strings.TrimSpace(fields[0]))
+	if err != nil {
+		return sample{}, fmt.Errorf("horizons: row %q: %w", line, err)
+	}
+
+	var nums []float64
+	for _, f := range fields[1:] {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(f), 64); err == nil {
+			nums = append(nums, v)
+		}
+	}
+	if len(nums) < 2 {
+		return sample{}, fmt.Errorf("horizons: row %q: want azimuth and elevation", line)
+	}
+
+	return sample{
+		Time:      t,
+		Azimuth:   nums[len(nums)-2],
+		Elevation: nums[len(nums)-1],
+	}, nil
+}
@@ -0,0 +1,247 @@
+// Command astroglide-verify compares the package's Sun and Moon positions
+// with JPL Horizons over a grid of times and locations and writes an
+// accuracy report.
+//
+// Horizons is queried for airless apparent topocentric azimuth and
+// elevation, which is what PositionAt returns in the Topocentric frame, so
+// the angular difference between the two measures the models directly.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+)
+
+// site is a named observer location.
+type site struct {
+	Name string
+	astroglide.Coordinates
+}
+
+// defaultSites spans both hemispheres, the tropics and high latitudes.
+var defaultSites = []site{
+	{"phoenix", astroglide.Coordinates{Lat: 33.4484, Lon: -112.0740, Elevation: 331}},
+	{"newyork", astroglide.Coordinates{Lat: 40.7128, Lon: -74.0060, Elevation: 10}},
+	{"reykjavik", astroglide.Coordinates{Lat: 64.1466, Lon: -21.9426, Elevation: 20}},
+	{"tromso", astroglide.Coordinates{Lat: 69.6492, Lon: 18.9553, Elevation: 10}},
+	{"singapore", astroglide.Coordinates{Lat: 1.3521, Lon: 103.8198, Elevation: 15}},
+	{"quito", astroglide.Coordinates{Lat: -0.1807, Lon: -78.4678, Elevation: 2850}},
+	{"sydney", astroglide.Coordinates{Lat: -33.8688, Lon: 151.2093, Elevation: 20}},
+	{"ushuaia", astroglide.Coordinates{Lat: -54.8019, Lon: -68.3030, Elevation: 20}},
+}
+
+// result is the accuracy of one body at one site.
+type result struct {
+	Body         string  `json:"body"`
+	Site         string  `json:"site"`
+	Lat          float64 `json:"lat"`
+	Lon          float64 `json:"lon"`
+	ElevationM   float64 `json:"elevation_m"`
+	Samples      int     `json:"samples"`
+	RMSArcmin    float64 `json:"rms_arcmin"`     // great-circle error
+	MaxArcmin    float64 `json:"max_arcmin"`     // worst great-circle error
+	AltRMSArcmin float64 `json:"alt_rms_arcmin"` // elevation error alone
+}
+
+// report is the machine-readable output.
+type report struct {
+	Generated time.Time `json:"generated"`
+	Source    string    `json:"source"`
+	Start     time.Time `json:"start"`
+	Stop      time.Time `json:"stop"`
+	Step      string    `json:"step"`
+	Results   []result  `json:"results"`
+}
+
+func main() {
+	log.SetFlags(0)
+
+	var (
+		startS   = flag.String("start", "2025-01-01", "first UT date of the grid (YYYY-MM-DD)")
+		days     = flag.Int("days", 30, "number of days to sample")
+		step     = flag.Duration("step", 6*time.Hour, "sampling interval (whole minutes)")
+		bodiesS  = flag.String("bodies", "sun,moon", "comma-separated bodies: sun, moon")
+		sitesS   = flag.String("sites", "", "comma-separated site names (default: all of "+siteNames()+")")
+		out      = flag.String("out", "", "write the JSON report to this file (default stdout)")
+		cacheDir = flag.String("cache", "", "directory for raw Horizons responses; reused on later runs")
+		api      = flag.String("api", horizonsURL, "Horizons API endpoint")
+		timeout  = flag.Duration("timeout", 60*time.Second, "timeout per Horizons request")
+		failRMS  = flag.Float64("fail-rms", 0, "exit with status 1 if any RMS error exceeds this many arcminutes (0 = never)")
+	)
+	flag.Parse()
+
+	start, err := time.Parse("2006-01-02", *startS)
+	if err != nil {
+		log.Fatalf("invalid -start %q: %v", *startS, err)
+	}
+	if *days < 1 || *step < time.Minute {
+		log.Fatalf("need -days >= 1 and -step >= 1m")
+	}
+	stop := start.AddDate(0, 0, *days)
+
+	bodies, err := parseBodies(*bodiesS)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sites, err := selectSites(*sitesS)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rep := report{
+		Generated: time.Now().UTC(),
+		Source:    *api,
+		Start:     start,
+		Stop:      stop,
+		Step:      step.String(),
+	}
+
+	client := &http.Client{}
+	failed := false
+
+	for _, body := range bodies {
+		for _, s := range sites {
+			ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+			samples, err := fetchHorizons(ctx, client, *api, *cacheDir, body, s, start, stop, *step)
+			cancel()
+			if err != nil {
+				log.Fatalf("%v at %s: %v", body, s.Name, err)
+			}
+
+			r, err := compare(body, s, samples)
+			if err != nil {
+				log.Fatalf("%v at %s: %v", body, s.Name, err)
+			}
+			rep.Results = append(rep.Results, r)
+
+			log.Printf("%-5s %-10s n=%-4d rms=%6.2f' max=%6.2f' alt-rms=%6.2f'",
+				r.Body, r.Site, r.Samples, r.RMSArcmin, r.MaxArcmin, r.AltRMSArcmin)
+			if *failRMS > 0 && r.RMSArcmin > *failRMS {
+				failed = true
+			}
+		}
+	}
+
+	if err := writeReport(*out, rep); err != nil {
+		log.Fatalf("writing report: %v", err)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// compare evaluates the package at each Horizons sample and accumulates the
+// errors.
+func compare(body astroglide.Body, s site, samples []sample) (result, error) {
+	r := result{
+		Body:       strings.ToLower(body.String()),
+		Site:       s.Name,
+		Lat:        s.Lat,
+		Lon:        s.Lon,
+		ElevationM: s.Elevation,
+	}
+
+	var sumSq, sumAltSq float64
+	for _, ref := range samples {
+		pos, err := astroglide.PositionAt(body, s.Coordinates, ref.Time, astroglide.Topocentric)
+		if err != nil {
+			return result{}, err
+		}
+
+		// The separation formula works on any pair of spherical angles, so
+		// azimuth/elevation can stand in for RA/Dec.
+		sep := astroglide.AngularSeparationEquatorial(
+			astroglide.Equatorial{RA: pos.Azimuth, Dec: pos.Altitude},
+			astroglide.Equatorial{RA: ref.Azimuth, Dec: ref.Elevation},
+		) * 60
+		dAlt := (pos.Altitude - ref.Elevation) * 60
+
+		sumSq += sep * sep
+		sumAltSq += dAlt * dAlt
+		r.MaxArcmin = math.Max(r.MaxArcmin, sep)
+		r.Samples++
+	}
+
+	if r.Samples == 0 {
+		return result{}, fmt.Errorf("no samples")
+	}
+	r.RMSArcmin = math.Sqrt(sumSq / float64(r.Samples))
+	r.AltRMSArcmin = math.Sqrt(sumAltSq / float64(r.Samples))
+	return r, nil
+}
+
+func writeReport(path string, rep report) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
+
+func parseBodies(list string) ([]astroglide.Body, error) {
+	var out []astroglide.Body
+	for _, name := range strings.Split(list, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "sun":
+			out = append(out, astroglide.Sun)
+		case "moon":
+			out = append(out, astroglide.Moon)
+		case "":
+		default:
+			return nil, fmt.Errorf("unsupported body %q (use sun or moon)", name)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no bodies selected")
+	}
+	return out, nil
+}
+
+func selectSites(list string) ([]site, error) {
+	if strings.TrimSpace(list) == "" {
+		return defaultSites, nil
+	}
+
+	var out []site
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, s := range defaultSites {
+			if s.Name == name {
+				out = append(out, s)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown site %q (known: %s)", name, siteNames())
+		}
+	}
+	return out, nil
+}
+
+func siteNames() string {
+	names := make([]string, len(defaultSites))
+	for i, s := range defaultSites {
+		names[i] = s.Name
+	}
+	return strings.Join(names, ", ")
+}
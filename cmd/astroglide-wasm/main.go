@@ -0,0 +1,152 @@
+//go:build js && wasm
+
+// Command astroglide-wasm exposes a few astroglide functions to JavaScript
+// when compiled to WebAssembly:
+//
+//	GOOS=js GOARCH=wasm go build -o astroglide.wasm ./cmd/astroglide-wasm
+//	tinygo build -o astroglide.wasm -target wasm ./cmd/astroglide-wasm
+//
+// Load it with the wasm_exec.js that matches the compiler, then call
+//
+//	astroglide.riseSet("sun", 33.4484, -112.074, "2025-06-21", -420)
+//	astroglide.twilight("civil", 33.4484, -112.074, "2025-06-21", -420)
+//	astroglide.moonPhase(Date.now())
+//
+// Time zones are given as a UTC offset in minutes (east positive; note that
+// JavaScript's Date.getTimezoneOffset has the opposite sign), since browsers
+// ship no tz database to Go. Times come back as RFC 3339 strings, or null
+// when the event does not occur.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+)
+
+func main() {
+	js.Global().Set("astroglide", js.ValueOf(map[string]interface{}{
+		"riseSet":   js.FuncOf(riseSet),
+		"twilight":  js.FuncOf(twilight),
+		"moonPhase": js.FuncOf(moonPhase),
+	}))
+
+	// Keep the exported functions alive.
+	select {}
+}
+
+// riseSet(body, lat, lon, date, offsetMinutes) -> {rise, set} | {error}
+func riseSet(this js.Value, args []js.Value) interface{} {
+	if len(args) != 5 {
+		return jsError(fmt.Errorf("riseSet: want 5 arguments, got %d", len(args)))
+	}
+
+	var body astroglide.Body
+	switch strings.ToLower(args[0].String()) {
+	case "sun":
+		body = astroglide.Sun
+	case "moon":
+		body = astroglide.Moon
+	default:
+		return jsError(fmt.Errorf("riseSet: unsupported body %q", args[0].String()))
+	}
+
+	loc, date, err := locationAndDate(args[1:])
+	if err != nil {
+		return jsError(err)
+	}
+
+	rs, err := astroglide.RiseSetFor(body, loc, date)
+	if err != nil {
+		return jsError(err)
+	}
+	return riseSetValue(rs)
+}
+
+// twilight(kind, lat, lon, date, offsetMinutes) -> {rise, set} | {error}
+func twilight(this js.Value, args []js.Value) interface{} {
+	if len(args) != 5 {
+		return jsError(fmt.Errorf("twilight: want 5 arguments, got %d", len(args)))
+	}
+
+	var kind astroglide.TwilightKind
+	switch strings.ToLower(args[0].String()) {
+	case "civil":
+		kind = astroglide.TwilightCivil
+	case "nautical":
+		kind = astroglide.TwilightNautical
+	case "astronomical":
+		kind = astroglide.TwilightAstronomical
+	default:
+		return jsError(fmt.Errorf("twilight: unsupported kind %q", args[0].String()))
+	}
+
+	loc, date, err := locationAndDate(args[1:])
+	if err != nil {
+		return jsError(err)
+	}
+
+	rs, err := astroglide.TwilightFor(loc, date, kind)
+	if err != nil {
+		return jsError(err)
+	}
+	return riseSetValue(rs)
+}
+
+// moonPhase(unixMillis) -> {name, fraction, elongation, waxing} | {error}
+func moonPhase(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return jsError(fmt.Errorf("moonPhase: want 1 argument, got %d", len(args)))
+	}
+
+	t := time.UnixMilli(int64(args[0].Float())).UTC()
+	p, err := astroglide.MoonPhaseAt(t)
+	if err != nil {
+		return jsError(err)
+	}
+	return map[string]interface{}{
+		"name":       p.Name,
+		"fraction":   p.Fraction,
+		"elongation": p.Elongation,
+		"waxing":     p.Waxing,
+	}
+}
+
+// locationAndDate parses (lat, lon, "YYYY-MM-DD", offsetMinutes).
+func locationAndDate(args []js.Value) (astroglide.Coordinates, time.Time, error) {
+	loc := astroglide.Coordinates{Lat: args[0].Float(), Lon: args[1].Float()}
+
+	offset := args[3].Int()
+	tz := time.FixedZone(fmt.Sprintf("UTC%+03d:%02d", offset/60, abs(offset%60)), offset*60)
+
+	date, err := time.ParseInLocation("2006-01-02", args[2].String(), tz)
+	if err != nil {
+		return loc, time.Time{}, fmt.Errorf("invalid date %q: %w", args[2].String(), err)
+	}
+	return loc, date, nil
+}
+
+func riseSetValue(rs astroglide.RiseSet) map[string]interface{} {
+	out := map[string]interface{}{"rise": nil, "set": nil}
+	if rs.HasRise {
+		out["rise"] = rs.Rise.Format(time.RFC3339)
+	}
+	if rs.HasSet {
+		out["set"] = rs.Set.Format(time.RFC3339)
+	}
+	return out
+}
+
+func jsError(err error) map[string]interface{} {
+	return map[string]interface{}{"error": err.Error()}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// ---------------------
+// Almanac subcommand
+// ---------------------
+
+func runAlmanac(args []string) {
+	fs := flag.NewFlagSet("almanac", flag.ExitOnError)
+
+	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
+	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)`)
+	tzName := fs.String("tz", "Local", "IANA time zone for the dates (e.g. America/Phoenix)")
+	monthS := fs.String("month", "", "month in YYYY-MM (defaults to the current month)")
+	weekS := fs.String("week", "", "first day of a 7-day almanac in YYYY-MM-DD, instead of -month")
+	roundD := fs.Duration("round", 0, "round event times to the nearest multiple of this, e.g. 1m (the table always shows the nearest minute)")
+	formatS := fs.String("format", "human", output.Usage)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide almanac [flags]
+
+Prints one line per day of a month (or of a week with -week): sunrise,
+sunset, day length, civil dawn and dusk, moonrise, moonset and the Moon's
+phase at local noon.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	format, err := output.Parse(*formatS)
+	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
+	}
+	if *monthS != "" && *weekS != "" {
+		log.Fatal("use either -month or -week, not both")
+	}
+
+	coords := astroglide.Coordinates{Lat: *lat, Lon: *lon}
+	var tz *time.Location
+	if *placeS != "" {
+		coords, tz = lookupPlace(*placeS)
+	} else if tz, err = loadLocation(*tzName); err != nil {
+		log.Fatalf("invalid time zone %q: %v", *tzName, err)
+	}
+
+	var start, end time.Time
+	switch {
+	case *weekS != "":
+		if start, err = time.ParseInLocation("2006-01-02", *weekS, tz); err != nil {
+			log.Fatalf("invalid -week %q: %v", *weekS, err)
+		}
+		end = start.AddDate(0, 0, 7)
+	case *monthS != "":
+		if start, err = time.ParseInLocation("2006-01", *monthS, tz); err != nil {
+			log.Fatalf("invalid -month %q: %v", *monthS, err)
+		}
+		end = start.AddDate(0, 1, 0)
+	default:
+		now := time.Now().In(tz)
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, tz)
+		end = start.AddDate(0, 1, 0)
+	}
+
+	var days almanacDays
+	for day := start; day.Before(end); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, tz) {
+		d, err := computeAlmanacDay(coords, day, astroglide.WithRounding(*roundD))
+		if err != nil {
+			log.Fatalf("%s: %v", day.Format("2006-01-02"), err)
+		}
+		days = append(days, d)
+	}
+
+	if format != output.Human {
+		if err := output.Write(os.Stdout, format, days); err != nil {
+			log.Fatalf("failed to write output: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Almanac for lat=%.4f lon=%.4f (%s)\n\n", coords.Lat, coords.Lon, tz)
+	if err := printAlmanac(os.Stdout, days); err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+}
+
+// almanacDay is one line of the almanac. Events that do not occur on the
+// date are nil.
+type almanacDay struct {
+	Date        string     `json:"date"` // YYYY-MM-DD
+	Sunrise     *time.Time `json:"sunrise"`
+	Sunset      *time.Time `json:"sunset"`
+	DayLength   string     `json:"day_length"` // e.g. "10h 12m"
+	CivilDawn   *time.Time `json:"civil_dawn"`
+	CivilDusk   *time.Time `json:"civil_dusk"`
+	Moonrise    *time.Time `json:"moonrise"`
+	Moonset     *time.Time `json:"moonset"`
+	Phase       string     `json:"phase"`
+	Illuminated float64    `json:"illuminated"` // fraction at local noon
+}
+
+func computeAlmanacDay(coords astroglide.Coordinates, day time.Time, opts ...astroglide.Option) (almanacDay, error) {
+	d := almanacDay{Date: day.Format("2006-01-02")}
+
+	sunRS, err := astroglide.RiseSetFor(astroglide.Sun, coords, day, opts...)
+	if err != nil && !errors.Is(err, astroglide.ErrNoRiseNoSet) {
+		return d, err
+	}
+	d.Sunrise, d.Sunset = eventPtrs(sunRS)
+
+	civil, err := astroglide.TwilightFor(coords, day, astroglide.TwilightCivil, opts...)
+	if err != nil && !errors.Is(err, astroglide.ErrNoRiseNoSet) {
+		return d, err
+	}
+	d.CivilDawn, d.CivilDusk = eventPtrs(civil)
+
+	moonRS, err := astroglide.RiseSetFor(astroglide.Moon, coords, day, opts...)
+	if err != nil && !errors.Is(err, astroglide.ErrNoRiseNoSet) {
+		return d, err
+	}
+	d.Moonrise, d.Moonset = eventPtrs(moonRS)
+
+	length, err := astroglide.Photoperiod(coords, day, astroglide.PhotoperiodSunrise)
+	if err != nil {
+		return d, err
+	}
+	d.DayLength = formatHM(length)
+
+	phase, err := astroglide.MoonPhaseAt(time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, day.Location()))
+	if err != nil {
+		return d, err
+	}
+	d.Phase, d.Illuminated = phase.Name, phase.Fraction
+	return d, nil
+}
+
+// eventPtrs returns rs's rise and set, or nil for those that do not occur.
+func eventPtrs(rs astroglide.RiseSet) (rise, set *time.Time) {
+	if rs.HasRise {
+		rise = &rs.Rise
+	}
+	if rs.HasSet {
+		set = &rs.Set
+	}
+	return rise, set
+}
+
+func printAlmanac(w io.Writer, days almanacDays) error {
+	clock := func(t *time.Time) string {
+		if t == nil {
+			return "--:--"
+		}
+		return timeutil.RoundWallClock(*t, time.Minute).Format("15:04")
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tSUNRISE\tSUNSET\tDAY\tCIVIL DAWN\tCIVIL DUSK\tMOONRISE\tMOONSET\tPHASE")
+	for _, d := range days {
+		date, _ := time.Parse("2006-01-02", d.Date)
+		fmt.Fprintln(tw, strings.Join([]string{
+			date.Format("Mon Jan 02"),
+			clock(d.Sunrise),
+			clock(d.Sunset),
+			d.DayLength,
+			clock(d.CivilDawn),
+			clock(d.CivilDusk),
+			clock(d.Moonrise),
+			clock(d.Moonset),
+			fmt.Sprintf("%s (%.0f%%)", d.Phase, d.Illuminated*100),
+		}, "\t"))
+	}
+	return tw.Flush()
+}
+
+type almanacDays []almanacDay
+
+func (a almanacDays) Header() []string {
+	return []string{"date", "sunrise", "sunset", "day_length", "civil_dawn", "civil_dusk", "moonrise", "moonset", "phase", "illuminated"}
+}
+
+func (a almanacDays) Rows() [][]string {
+	rows := make([][]string, len(a))
+	for i, d := range a {
+		rows[i] = []string{
+			d.Date,
+			formatTimePtr(d.Sunrise),
+			formatTimePtr(d.Sunset),
+			d.DayLength,
+			formatTimePtr(d.CivilDawn),
+			formatTimePtr(d.CivilDusk),
+			formatTimePtr(d.Moonrise),
+			formatTimePtr(d.Moonset),
+			d.Phase,
+			strconv.FormatFloat(d.Illuminated, 'f', 3, 64),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// ---------------------
+// Batch subcommand
+// ---------------------
+
+func runBatch(args []string) {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+
+	geojsonS := fs.String("geojson", "", `GeoJSON file of points, lines or polygons ("-" for stdin)`)
+	gpxS := fs.String("gpx", "", `GPX file of waypoints, routes and tracks ("-" for stdin)`)
+	dateS := fs.String("date", "", "date in YYYY-MM-DD (default: each GPX point's own date, else today)")
+	tzName := fs.String("tz", "Local", "IANA time zone for dates and results (e.g. America/Phoenix)")
+	eventsS := fs.String("events", "sunrise,sunset,civil_dawn,civil_dusk", "comma-separated events with optional offsets, e.g. sunset-30m")
+	every := fs.Int("every", 1, "use only every Nth vertex of lines, routes and tracks (the last is always kept)")
+	formatS := fs.String("format", "human", output.Usage+", or geojson to get the points back as GeoJSON")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide batch (-geojson FILE | -gpx FILE) [flags]
+
+Computes the events for every point of a GeoJSON or GPX file. Lines,
+polygons, routes and tracks contribute each of their vertices.
+
+Events: %s.
+
+Flags:
+`, strings.Join(astroglide.EventExprNames(), ", "))
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	geoOut := strings.EqualFold(strings.TrimSpace(*formatS), "geojson")
+	var format output.Format
+	if !geoOut {
+		var err error
+		if format, err = output.Parse(*formatS); err != nil {
+			log.Fatalf("invalid -format: %v", err)
+		}
+	}
+
+	if (*geojsonS == "") == (*gpxS == "") {
+		log.Fatalf("need exactly one of -geojson or -gpx")
+	}
+	if *every < 1 {
+		log.Fatalf("invalid -every %d: must be at least 1", *every)
+	}
+
+	events, err := parseEventExprs(*eventsS)
+	if err != nil {
+		log.Fatalf("invalid -events: %v", err)
+	}
+	if len(events) == 0 {
+		log.Fatalf("no events to compute")
+	}
+
+	tz, err := loadLocation(*tzName)
+	if err != nil {
+		log.Fatalf("invalid time zone %q: %v", *tzName, err)
+	}
+
+	var date time.Time
+	if *dateS != "" {
+		if date, err = time.ParseInLocation("2006-01-02", *dateS, tz); err != nil {
+			log.Fatalf("invalid -date %q: %v", *dateS, err)
+		}
+	}
+
+	var points []batchPoint
+	if *geojsonS != "" {
+		points, err = readInput(*geojsonS, func(r io.Reader) ([]batchPoint, error) { return readGeoJSON(r, *every) })
+	} else {
+		points, err = readInput(*gpxS, func(r io.Reader) ([]batchPoint, error) { return readGPX(r, *every) })
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(points) == 0 {
+		log.Fatalf("no points in input")
+	}
+
+	results := make(batchResults, len(points))
+	for i, p := range points {
+		results[i] = computeBatch(p, events, date, tz)
+	}
+
+	switch {
+	case geoOut:
+		err = writeGeoJSON(os.Stdout, points, results)
+	case format == output.Human:
+		err = printBatch(os.Stdout, results, tz)
+	default:
+		err = output.Write(os.Stdout, format, results)
+	}
+	if err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+}
+
+// batchPoint is one input location.
+type batchPoint struct {
+	Name   string
+	Coords astroglide.Coordinates
+	Time   time.Time              // GPX <time>, zero if absent
+	Props  map[string]interface{} // GeoJSON feature properties
+}
+
+// computeBatch resolves events at p on date, or on the date of p's own
+// timestamp (or today) when date is zero.
+func computeBatch(p batchPoint, events []astroglide.EventExpr, date time.Time, tz *time.Location) batchResult {
+	if date.IsZero() {
+		t := p.Time
+		if t.IsZero() {
+			t = time.Now()
+		}
+		y, m, d := t.In(tz).Date()
+		date = time.Date(y, m, d, 0, 0, 0, 0, tz)
+	}
+
+	r := batchResult{
+		Name:      p.Name,
+		Latitude:  p.Coords.Lat,
+		Longitude: p.Coords.Lon,
+		Elevation: p.Coords.Elevation,
+		Date:      date.Format("2006-01-02"),
+		Events:    make([]resolved, 0, len(events)),
+	}
+	for _, e := range events {
+		res := resolved{Expr: e.String()}
+		if t, err := e.Resolve(p.Coords, date); err != nil {
+			res.Error, res.err = err.Error(), err
+		} else {
+			res.Time = &t
+		}
+		r.Events = append(r.Events, res)
+	}
+	return r
+}
+
+type batchResult struct {
+	Name      string     `json:"name"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Elevation float64    `json:"elevation,omitempty"`
+	Date      string     `json:"date"`
+	Events    []resolved `json:"events"`
+}
+
+type batchResults []batchResult
+
+// Header has one column per event; every result lists the same events.
+func (r batchResults) Header() []string {
+	h := []string{"name", "latitude", "longitude", "elevation", "date"}
+	if len(r) > 0 {
+		for _, e := range r[0].Events {
+			h = append(h, e.Expr)
+		}
+	}
+	return h
+}
+
+func (r batchResults) Rows() [][]string {
+	rows := make([][]string, len(r))
+	for i, res := range r {
+		row := []string{
+			res.Name,
+			strconv.FormatFloat(res.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(res.Longitude, 'f', -1, 64),
+			strconv.FormatFloat(res.Elevation, 'f', -1, 64),
+			res.Date,
+		}
+		for _, e := range res.Events {
+			row = append(row, formatTimePtr(e.Time))
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// printBatch writes a table with local clock times; events that do not
+// occur show as "none" and failed points as "error".
+func printBatch(w io.Writer, results batchResults, tz *time.Location) error {
+	fmt.Fprintf(w, "Events for %d points (%s)\n\n", len(results), tz)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(append([]string{"NAME", "LAT", "LON", "DATE"}, results.Header()[5:]...), "\t"))
+	var errs []string
+	for _, r := range results {
+		cols := []string{r.Name, fmt.Sprintf("%.5f", r.Latitude), fmt.Sprintf("%.5f", r.Longitude), r.Date}
+		for _, e := range r.Events {
+			switch {
+			case e.Time != nil:
+				cols = append(cols, timeutil.RoundWallClock(*e.Time, time.Second).Format("15:04:05"))
+			case errors.Is(e.err, astroglide.ErrNoRiseNoSet):
+				cols = append(cols, "none")
+			default:
+				cols = append(cols, "error")
+				errs = append(errs, fmt.Sprintf("%s %s: %s", r.Name, e.Expr, e.Error))
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		fmt.Fprintln(w)
+		for _, e := range errs {
+			fmt.Fprintln(w, e)
+		}
+	}
+	return nil
+}
+
+// readInput opens path ("-" for stdin) and parses it with parse.
+func readInput(path string, parse func(io.Reader) ([]batchPoint, error)) ([]batchPoint, error) {
+	if path == "-" {
+		return parse(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	points, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return points, nil
+}
+
+// ---------------------
+// GeoJSON
+// ---------------------
+
+// geoJSON is any GeoJSON object: a FeatureCollection, a Feature or a
+// geometry.
+type geoJSON struct {
+	Type        string                 `json:"type"`
+	Features    []geoJSON              `json:"features"`
+	Geometry    *geoJSON               `json:"geometry"`
+	Geometries  []geoJSON              `json:"geometries"`
+	Properties  map[string]interface{} `json:"properties"`
+	Coordinates json.RawMessage        `json:"coordinates"`
+}
+
+// readGeoJSON returns the positions of every geometry in a GeoJSON
+// document (RFC 7946). Features are named by their "name" property, or by
+// their index; vertices of lines and polygons get a [i] suffix.
+func readGeoJSON(r io.Reader, every int) ([]batchPoint, error) {
+	var doc geoJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	var points []batchPoint
+	var walk func(g geoJSON, name string, props map[string]interface{}) error
+	walk = func(g geoJSON, name string, props map[string]interface{}) error {
+		switch g.Type {
+		case "FeatureCollection":
+			for i, f := range g.Features {
+				if err := walk(f, fmt.Sprintf("feature %d", i+1), nil); err != nil {
+					return err
+				}
+			}
+		case "Feature":
+			if n, ok := g.Properties["name"].(string); ok && n != "" {
+				name = n
+			}
+			if g.Geometry == nil {
+				return nil
+			}
+			return walk(*g.Geometry, name, g.Properties)
+		case "GeometryCollection":
+			for _, sub := range g.Geometries {
+				if err := walk(sub, name, props); err != nil {
+					return err
+				}
+			}
+		case "Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon":
+			var raw interface{}
+			if err := json.Unmarshal(g.Coordinates, &raw); err != nil {
+				return fmt.Errorf("%s: invalid coordinates: %w", name, err)
+			}
+			pos, err := positions(raw, nil)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			for i, c := range pos {
+				if !keep(i, len(pos), every) {
+					continue
+				}
+				p := batchPoint{Name: name, Coords: c, Props: props}
+				if g.Type != "Point" {
+					p.Name = fmt.Sprintf("%s[%d]", name, i)
+				}
+				points = append(points, p)
+			}
+		default:
+			return fmt.Errorf("%s: unsupported GeoJSON type %q", name, g.Type)
+		}
+		return nil
+	}
+
+	if err := walk(doc, "point", nil); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// positions flattens nested GeoJSON coordinate arrays into positions
+// ([lon, lat] or [lon, lat, elevation]).
+func positions(v interface{}, out []astroglide.Coordinates) ([]astroglide.Coordinates, error) {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) == 0 {
+		return out, fmt.Errorf("invalid coordinates %v", v)
+	}
+	if _, nested := arr[0].([]interface{}); nested {
+		for _, sub := range arr {
+			var err error
+			if out, err = positions(sub, out); err != nil {
+				return out, err
+			}
+		}
+		return out, nil
+	}
+
+	var nums [3]float64
+	for i := 0; i < len(arr) && i < 3; i++ {
+		f, ok := arr[i].(float64)
+		if !ok {
+			return out, fmt.Errorf("invalid position %v", arr)
+		}
+		nums[i] = f
+	}
+	if len(arr) < 2 {
+		return out, fmt.Errorf("invalid position %v", arr)
+	}
+	return append(out, astroglide.Coordinates{Lat: nums[1], Lon: nums[0], Elevation: nums[2]}), nil
+}
+
+// writeGeoJSON writes the points as a FeatureCollection of Points whose
+// properties are the input properties plus the date and one property per
+// event (RFC 3339, or null when it does not occur).
+func writeGeoJSON(w io.Writer, points []batchPoint, results batchResults) error {
+	type feature struct {
+		Type       string                 `json:"type"`
+		Geometry   map[string]interface{} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	fc := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: make([]feature, len(points))}
+
+	for i, p := range points {
+		coords := []float64{p.Coords.Lon, p.Coords.Lat}
+		if p.Coords.Elevation != 0 {
+			coords = append(coords, p.Coords.Elevation)
+		}
+		props := map[string]interface{}{}
+		for k, v := range p.Props {
+			props[k] = v
+		}
+		props["name"] = p.Name
+		props["date"] = results[i].Date
+		for _, e := range results[i].Events {
+			if e.Time != nil {
+				props[e.Expr] = e.Time.Format(time.RFC3339)
+			} else {
+				props[e.Expr] = nil
+			}
+		}
+		fc.Features[i] = feature{
+			Type:       "Feature",
+			Geometry:   map[string]interface{}{"type": "Point", "coordinates": coords},
+			Properties: props,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fc)
+}
+
+// ---------------------
+// GPX
+// ---------------------
+
+type gpxFile struct {
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []struct {
+		Name   string     `xml:"name"`
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+	Name string  `xml:"name"`
+}
+
+// readGPX returns the waypoints, route points and track points of a GPX
+// 1.0 or 1.1 file. Points keep their <time>, so a recorded track gets the
+// events of the day each point was reached.
+func readGPX(r io.Reader, every int) ([]batchPoint, error) {
+	var doc gpxFile
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid GPX: %w", err)
+	}
+
+	var points []batchPoint
+	add := func(list []gpxPoint, name string, indexed bool) error {
+		for i, gp := range list {
+			if !keep(i, len(list), every) {
+				continue
+			}
+			p := batchPoint{
+				Name:   name,
+				Coords: astroglide.Coordinates{Lat: gp.Lat, Lon: gp.Lon, Elevation: gp.Ele},
+			}
+			if gp.Name != "" {
+				p.Name = gp.Name
+			} else if indexed {
+				p.Name = fmt.Sprintf("%s[%d]", name, i)
+			}
+			if gp.Time != "" {
+				t, err := time.Parse(time.RFC3339, strings.TrimSpace(gp.Time))
+				if err != nil {
+					return fmt.Errorf("%s: invalid time %q", p.Name, gp.Time)
+				}
+				p.Time = t
+			}
+			points = append(points, p)
+		}
+		return nil
+	}
+
+	for i, wpt := range doc.Waypoints {
+		if err := add([]gpxPoint{wpt}, fmt.Sprintf("waypoint %d", i+1), false); err != nil {
+			return nil, err
+		}
+	}
+	for i, rte := range doc.Routes {
+		name := rte.Name
+		if name == "" {
+			name = fmt.Sprintf("route %d", i+1)
+		}
+		if err := add(rte.Points, name, true); err != nil {
+			return nil, err
+		}
+	}
+	for i, trk := range doc.Tracks {
+		name := trk.Name
+		if name == "" {
+			name = fmt.Sprintf("track %d", i+1)
+		}
+		var all []gpxPoint
+		for _, seg := range trk.Segments {
+			all = append(all, seg.Points...)
+		}
+		if err := add(all, name, true); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// keep reports whether vertex i of n is kept with -every: every nth one,
+// plus the last.
+func keep(i, n, every int) bool {
+	return i%every == 0 || i == n-1
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// describePoints renders points as "name lat,lon[,ele][ time]" strings.
+func describePoints(points []batchPoint) []string {
+	out := make([]string, len(points))
+	for i, p := range points {
+		s := fmt.Sprintf("%s %g,%g", p.Name, p.Coords.Lat, p.Coords.Lon)
+		if p.Coords.Elevation != 0 {
+			s += fmt.Sprintf(",%g", p.Coords.Elevation)
+		}
+		if !p.Time.IsZero() {
+			s += " " + p.Time.Format(time.RFC3339)
+		}
+		out[i] = s
+	}
+	return out
+}
+
+func TestReadGeoJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		every int
+		want  []string
+	}{
+		{
+			name: "bare point",
+			doc:  `{"type": "Point", "coordinates": [-112.07, 33.45, 331]}`,
+			want: []string{"point 33.45,-112.07,331"},
+		},
+		{
+			name: "features with and without a name",
+			doc: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"name": "Phoenix"}, "geometry": {"type": "Point", "coordinates": [-112.07, 33.45]}},
+				{"type": "Feature", "properties": {"elev": 1}, "geometry": {"type": "Point", "coordinates": [-71.3, 44.27]}},
+				{"type": "Feature", "properties": {"name": ""}, "geometry": {"type": "Point", "coordinates": [2.35, 48.86]}},
+				{"type": "Feature", "properties": {"name": "nowhere"}, "geometry": null}
+			]}`,
+			want: []string{"Phoenix 33.45,-112.07", "feature 2 44.27,-71.3", "feature 3 48.86,2.35"},
+		},
+		{
+			name: "multipoint",
+			doc:  `{"type": "Feature", "properties": {"name": "stops"}, "geometry": {"type": "MultiPoint", "coordinates": [[0, 1], [2, 3]]}}`,
+			want: []string{"stops[0] 1,0", "stops[1] 3,2"},
+		},
+		{
+			name: "nested geometry collection",
+			doc: `{"type": "Feature", "properties": {"name": "trip"}, "geometry": {"type": "GeometryCollection", "geometries": [
+				{"type": "Point", "coordinates": [10, 50]},
+				{"type": "GeometryCollection", "geometries": [
+					{"type": "MultiPoint", "coordinates": [[11, 51], [12, 52]]}
+				]},
+				{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}
+			]}}`,
+			want: []string{
+				"trip 50,10",
+				"trip[0] 51,11", "trip[1] 52,12",
+				"trip[0] 0,0", "trip[1] 0,1", "trip[2] 1,1", "trip[3] 0,0",
+			},
+		},
+		{
+			name:  "every third vertex and the last",
+			doc:   `{"type": "LineString", "coordinates": [[0, 0], [0, 1], [0, 2], [0, 3], [0, 4]]}`,
+			every: 3,
+			want:  []string{"point[0] 0,0", "point[3] 3,0", "point[4] 4,0"},
+		},
+	}
+	for _, tt := range tests {
+		every := tt.every
+		if every == 0 {
+			every = 1
+		}
+		points, err := readGeoJSON(strings.NewReader(tt.doc), every)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := describePoints(points); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Properties of a feature carry through to its points.
+	points, err := readGeoJSON(strings.NewReader(`{"type": "Feature", "properties": {"id": 7}, "geometry": {"type": "Point", "coordinates": [0, 0]}}`), 1)
+	if err != nil || len(points) != 1 || points[0].Props["id"] != 7.0 {
+		t.Errorf("properties: %+v, %v", points, err)
+	}
+
+	for _, doc := range []string{
+		`not json`,
+		`{"type": "Circle", "coordinates": [0, 0]}`,
+		`{"type": "Point", "coordinates": [0]}`,
+		`{"type": "Point", "coordinates": ["a", "b"]}`,
+		`{"type": "LineString", "coordinates": []}`,
+	} {
+		if _, err := readGeoJSON(strings.NewReader(doc), 1); err == nil {
+			t.Errorf("readGeoJSON(%s) accepted", doc)
+		}
+	}
+}
+
+func TestReadGPX(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		every int
+		want  []string
+	}{
+		{
+			name: "waypoints",
+			doc: `<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+				<wpt lat="33.45" lon="-112.07"><ele>331</ele><name>Phoenix</name></wpt>
+				<wpt lat="44.27" lon="-71.3"></wpt>
+			</gpx>`,
+			want: []string{"Phoenix 33.45,-112.07,331", "waypoint 2 44.27,-71.3"},
+		},
+		{
+			name: "route points",
+			doc: `<gpx version="1.0">
+				<rte><name>loop</name>
+					<rtept lat="1" lon="2"/>
+					<rtept lat="3" lon="4"><name>summit</name></rtept>
+				</rte>
+				<rte><rtept lat="5" lon="6"/></rte>
+			</gpx>`,
+			want: []string{"loop[0] 1,2", "summit 3,4", "route 2[0] 5,6"},
+		},
+		{
+			name: "track points across segments keep their times",
+			doc: `<gpx version="1.1">
+				<trk><name>hike</name>
+					<trkseg>
+						<trkpt lat="10" lon="20"><time>2025-06-21T05:00:00Z</time></trkpt>
+						<trkpt lat="11" lon="21"><time> 2025-06-21T06:00:00-07:00 </time></trkpt>
+					</trkseg>
+					<trkseg>
+						<trkpt lat="12" lon="22"/>
+					</trkseg>
+				</trk>
+			</gpx>`,
+			want: []string{
+				"hike[0] 10,20 2025-06-21T05:00:00Z",
+				"hike[1] 11,21 2025-06-21T06:00:00-07:00",
+				"hike[2] 12,22",
+			},
+		},
+		{
+			name: "every other track point and the last",
+			doc: `<gpx><trk><trkseg>
+				<trkpt lat="0" lon="0"/><trkpt lat="1" lon="0"/><trkpt lat="2" lon="0"/><trkpt lat="3" lon="0"/>
+			</trkseg></trk></gpx>`,
+			every: 2,
+			want:  []string{"track 1[0] 0,0", "track 1[2] 2,0", "track 1[3] 3,0"},
+		},
+	}
+	for _, tt := range tests {
+		every := tt.every
+		if every == 0 {
+			every = 1
+		}
+		points, err := readGPX(strings.NewReader(tt.doc), every)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := describePoints(points); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+
+	for _, doc := range []string{
+		`<gpx><wpt lat="1" lon="2">`,
+		`<gpx><wpt lat="1" lon="2"><time>yesterday</time></wpt></gpx>`,
+	} {
+		if _, err := readGPX(strings.NewReader(doc), 1); err == nil {
+			t.Errorf("readGPX(%s) accepted", doc)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/config"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Config file and subcommand
+// ---------------------
+
+// userConfig is the loaded config file; its named places are searched
+// before the built-in gazetteer.
+var userConfig = &config.Config{}
+
+// parseFlags parses args into fs like fs.Parse, after adding a -config
+// flag and taking the defaults of -lat, -lon, -place and -format from the
+// config file. A -lat or -lon on the command line overrides a configured
+// default place.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	path := configPathArg(args)
+	fs.String("config", path, "config file with default location, time zone, format and named places")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	userConfig = cfg
+	if cfg.TZ != "" {
+		tz, err := loadLocation(cfg.TZ)
+		if err != nil {
+			log.Fatalf("config: time zone %q: %v", cfg.TZ, err)
+		}
+		time.Local = tz
+	}
+	for name, value := range cfg.Defaults {
+		if f := fs.Lookup(name); f != nil {
+			set := f.Value.Set
+			if d, ok := f.Value.(interface{ SetDefault(string) error }); ok {
+				set = d.SetDefault
+			}
+			if err := set(value); err != nil {
+				log.Fatalf("config: %s = %q: %v", name, value, err)
+			}
+			f.DefValue = value
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	if (explicit["lat"] || explicit["lon"]) && !explicit["place"] {
+		if f := fs.Lookup("place"); f != nil {
+			f.Value.Set("")
+		}
+	}
+	return nil
+}
+
+// configPathArg returns the -config flag's value in args, or the default
+// path. It runs before flag parsing, which needs the config's defaults.
+func configPathArg(args []string) string {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		if !strings.HasPrefix(a, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	if p := os.Getenv("ASTROGLIDE_CONFIG"); p != "" {
+		return p
+	}
+	return config.DefaultPath()
+}
+
+// configPlace looks name up among the config file's places.
+func configPlace(name string) (astroglide.Place, bool) {
+	for key, p := range userConfig.Places {
+		if strings.EqualFold(key, name) {
+			return astroglide.Place{
+				Name:        key,
+				Coordinates: astroglide.Coordinates{Lat: p.Lat, Lon: p.Lon},
+				TimeZone:    p.TZ,
+			}, true
+		}
+	}
+	return astroglide.Place{}, false
+}
+
+func runConfig(args []string) {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide config [flags] COMMAND
+
+Commands:
+  set NAME LAT LON [TZ]   save a named place, usable as -place NAME
+  set KEY VALUE           set a default: place, lat, lon, tz or format
+  unset KEY|NAME          remove a default or a named place
+  show                    print the config file
+  path                    print the config file's location
+
+The config file is %s
+unless -config or $ASTROGLIDE_CONFIG names another. Every command
+accepts -config.
+
+Flags:
+`, config.DefaultPath())
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	path := fs.Lookup("config").Value.String()
+	cfg := userConfig
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	switch cmd, rest := rest[0], rest[1:]; {
+	case cmd == "path" && len(rest) == 0:
+		fmt.Println(path)
+
+	case cmd == "show" && len(rest) == 0:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("config: %v", err)
+		}
+		os.Stdout.Write(b)
+
+	case cmd == "set" && len(rest) == 2:
+		key, value := rest[0], rest[1]
+		switch key {
+		case "lat", "lon":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				log.Fatalf("invalid %s %q: %v", key, value, err)
+			}
+			cfg.Defaults[key] = value
+			delete(cfg.Defaults, "place")
+		case "place":
+			if _, ok := configPlace(value); !ok {
+				if _, err := astroglide.LookupPlace(value); err != nil {
+					log.Fatalf("unknown place %q: save it with: astroglide config set %s LAT LON", value, value)
+				}
+			}
+			cfg.Defaults[key] = value
+			delete(cfg.Defaults, "lat")
+			delete(cfg.Defaults, "lon")
+		case "format":
+			if _, err := output.Parse(value); err != nil {
+				log.Fatalf("invalid format: %v", err)
+			}
+			cfg.Defaults[key] = value
+		case "tz":
+			if _, err := loadLocation(value); err != nil {
+				log.Fatalf("invalid time zone %q: %v", value, err)
+			}
+			cfg.TZ = value
+		default:
+			log.Fatalf("unknown key %q (use place, lat, lon, tz or format, or NAME LAT LON to save a place)", key)
+		}
+		saveConfig(cfg, path)
+
+	case cmd == "set" && (len(rest) == 3 || len(rest) == 4):
+		name := rest[0]
+		lat, err1 := strconv.ParseFloat(rest[1], 64)
+		lon, err2 := strconv.ParseFloat(rest[2], 64)
+		if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			log.Fatalf("invalid coordinates %s %s", rest[1], rest[2])
+		}
+		p := config.Place{Lat: lat, Lon: lon}
+		if len(rest) == 4 {
+			if _, err := loadLocation(rest[3]); err != nil {
+				log.Fatalf("invalid time zone %q: %v", rest[3], err)
+			}
+			p.TZ = rest[3]
+		}
+		cfg.Places[name] = p
+		if cfg.Defaults["place"] == "" && cfg.Defaults["lat"] == "" && cfg.Defaults["lon"] == "" {
+			cfg.Defaults["place"] = name
+			fmt.Fprintf(os.Stderr, "%s is now the default location\n", name)
+		}
+		saveConfig(cfg, path)
+
+	case cmd == "unset" && len(rest) == 1:
+		key := rest[0]
+		switch key {
+		case "place", "lat", "lon", "format":
+			delete(cfg.Defaults, key)
+		case "tz":
+			cfg.TZ = ""
+		default:
+			if _, ok := cfg.Places[key]; !ok {
+				log.Fatalf("no default or place named %q", key)
+			}
+			delete(cfg.Places, key)
+			if cfg.Defaults["place"] == key {
+				delete(cfg.Defaults, "place")
+			}
+		}
+		saveConfig(cfg, path)
+
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+}
+
+func saveConfig(cfg *config.Config, path string) {
+	if err := cfg.Save(path); err != nil {
+		log.Fatalf("config: %v", err)
+	}
+}
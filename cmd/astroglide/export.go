@@ -0,0 +1,245 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Export subcommand
+// ---------------------
+
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	var lats, lons floatList
+	fs.Var(&lats, "lat", "latitude in degrees (north positive); repeat with -lon for several locations")
+	fs.Var(&lons, "lon", "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)`)
+	locationsS := fs.String("locations", "", `comma-separated place names, e.g. home,cabin (use ";" between names that contain commas)`)
+	tzName := fs.String("tz", "Local", "IANA time zone for dates and results (e.g. America/Phoenix)")
+	year := fs.Int("year", 0, "export this whole year instead of -start/-end")
+	startS := fs.String("start", "", "first date, YYYY-MM-DD")
+	endS := fs.String("end", "", "last date, YYYY-MM-DD (default: -start)")
+	eventsS := fs.String("events", "sunrise,sunset,civil_dawn,civil_dusk", "comma-separated events with optional offsets, e.g. sunset-30m")
+	outS := fs.String("o", "", "output file; .csv, .json, .yaml, .parquet, .sql or .db picks the format (default: stdout)")
+	formatS := fs.String("format", "", "output format: csv, json, yaml, parquet, sql or sqlite (default: from -o, else csv)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide export (-year YEAR | -start DATE [-end DATE]) [flags]
+
+Writes the events for every date in a range as a long-format table: one
+row per location, date and event, with the local and UTC time and Unix
+seconds, or status "none" when the event does not occur that day. Suited
+to loading into pandas, R or a database.
+
+-format parquet writes a Parquet file with the same columns, date as a
+DATE and utc as a UTC timestamp.
+
+-format sql writes SQL that creates and fills an SQLite database (see
+the schema in the README); -o FILE.db runs it through the sqlite3 tool.
+
+Events: %s.
+
+Flags:
+`, strings.Join(astroglide.EventExprNames(), ", "))
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	format := exportFormat(*formatS, *outS)
+
+	events, err := parseEventExprs(*eventsS)
+	if err != nil {
+		log.Fatalf("invalid -events: %v", err)
+	}
+	if len(events) == 0 {
+		log.Fatalf("no events to export")
+	}
+
+	tz, err := loadLocation(*tzName)
+	if err != nil {
+		log.Fatalf("invalid time zone %q: %v", *tzName, err)
+	}
+	locs := resolveLocations(lats, lons, *placeS, *locationsS, tz)
+
+	firstDay, lastDay := *startS, *endS
+	if *year != 0 {
+		if firstDay != "" || lastDay != "" {
+			log.Fatalf("give -year or -start/-end, not both")
+		}
+		firstDay, lastDay = fmt.Sprintf("%04d-01-01", *year), fmt.Sprintf("%04d-12-31", *year)
+	}
+	if firstDay == "" {
+		log.Fatalf("-year or -start is required")
+	}
+	if lastDay == "" {
+		lastDay = firstDay
+	}
+
+	var rows exportRows
+	for _, l := range locs {
+		if l.name == "" {
+			l.name = fmt.Sprintf("%g,%g", l.coords.Lat, l.coords.Lon)
+		}
+		start, err := time.ParseInLocation("2006-01-02", firstDay, l.tz)
+		if err != nil {
+			log.Fatalf("invalid start date %q: %v", firstDay, err)
+		}
+		end, err := time.ParseInLocation("2006-01-02", lastDay, l.tz)
+		if err != nil {
+			log.Fatalf("invalid end date %q: %v", lastDay, err)
+		}
+		if end.Before(start) {
+			log.Fatalf("end date %s is before start date %s", lastDay, firstDay)
+		}
+
+		for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			for _, e := range events {
+				row := exportRow{
+					Location:  l.name,
+					Latitude:  l.coords.Lat,
+					Longitude: l.coords.Lon,
+					TimeZone:  l.tz.String(),
+					Date:      date.Format("2006-01-02"),
+					Event:     e.String(),
+				}
+				if t, err := e.Resolve(l.coords, date); err != nil {
+					row.Status = "none"
+				} else {
+					t = t.In(l.tz)
+					utc := t.UTC()
+					unix := t.Unix()
+					row.Time, row.UTC, row.Unix = &t, &utc, &unix
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	if format == formatSQLite {
+		if err := writeSQLiteDB(*outS, rows); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var w io.Writer = os.Stdout
+	if *outS != "" {
+		f, err := os.Create(*outS)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+		w = f
+	}
+	switch format {
+	case formatSQL:
+		err = writeSQL(w, rows)
+	case formatParquet:
+		err = writeParquet(w, rows)
+	default:
+		err = output.Write(w, format, rows)
+	}
+	if err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+}
+
+// Export formats beyond those of the output package.
+const (
+	formatSQL     output.Format = "sql"
+	formatSQLite  output.Format = "sqlite"
+	formatParquet output.Format = "parquet"
+)
+
+// exportFormat picks the output format from -format or the extension of
+// -o, defaulting to CSV.
+func exportFormat(formatS, out string) output.Format {
+	name := strings.ToLower(formatS)
+	if name == "" || name == string(output.Human) {
+		// A table has no human layout; "human" may be the config default.
+		name = strings.TrimPrefix(strings.ToLower(filepath.Ext(out)), ".")
+	}
+	switch name {
+	case "":
+		return output.CSV
+	case "yml":
+		return output.YAML
+	case "sql":
+		return formatSQL
+	case "sqlite", "sqlite3", "db":
+		if out == "" {
+			log.Fatalf("-format sqlite needs -o FILE")
+		}
+		return formatSQLite
+	case "parquet":
+		return formatParquet
+	}
+	format, err := output.Parse(name)
+	if err != nil {
+		log.Fatalf("invalid export format %q (use csv, json, yaml, parquet, sql or sqlite)", name)
+	}
+	return format
+}
+
+// exportRow is one event on one date at one location. Time, UTC and Unix
+// are nil, and Status set, when the event does not occur.
+type exportRow struct {
+	Location  string     `json:"location"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	TimeZone  string     `json:"timezone"`
+	Date      string     `json:"date"`
+	Event     string     `json:"event"`
+	Time      *time.Time `json:"time"`
+	UTC       *time.Time `json:"utc"`
+	Unix      *int64     `json:"unix"`
+	Status    string     `json:"status,omitempty"`
+}
+
+type exportRows []exportRow
+
+func (r exportRows) Header() []string {
+	return []string{"location", "latitude", "longitude", "timezone", "date", "event", "time", "utc", "unix", "status"}
+}
+
+func (r exportRows) Rows() [][]string {
+	rows := make([][]string, len(r))
+	for i, e := range r {
+		unix := ""
+		if e.Unix != nil {
+			unix = strconv.FormatInt(*e.Unix, 10)
+		}
+		rows[i] = []string{
+			e.Location,
+			strconv.FormatFloat(e.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(e.Longitude, 'f', -1, 64),
+			e.TimeZone,
+			e.Date,
+			e.Event,
+			formatTimePtr(e.Time),
+			formatTimePtr(e.UTC),
+			unix,
+			e.Status,
+		}
+	}
+	return rows
+}
@@ -1,15 +1,17 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
 )
 
 func main() {
@@ -26,6 +28,24 @@ func main() {
 	switch os.Args[1] {
 	case "phase":
 		runPhase(os.Args[2:])
+	case "watch":
+		runWatch(os.Args[2:])
+	case "batch":
+		runBatch(os.Args[2:])
+	case "sunpath":
+		runSunPath(os.Args[2:])
+	case "sky":
+		runSky(os.Args[2:])
+	case "report":
+		runReport(os.Args[2:])
+	case "almanac":
+		runAlmanac(os.Args[2:])
+	case "status":
+		runStatus(os.Args[2:])
+	case "config":
+		runConfig(os.Args[2:])
+	case "export":
+		runExport(os.Args[2:])
 	default:
 		fmt.Fprintf(os.Stderr, "unknown subcommand %q\n\n", os.Args[1])
 		usage()
@@ -39,23 +59,55 @@ func usage() {
 Usage:
   astroglide [flags]           # Sun/Moon rise/set (legacy/default mode)
   astroglide phase [flags]     # Moon phase / illumination
+  astroglide watch [flags]     # run a command at each sunrise/sunset/etc.
+  astroglide batch [flags]     # events for every point of a GeoJSON or GPX file
+  astroglide sunpath [flags]   # sun-path diagram as SVG
+  astroglide sky [flags]       # terminal chart of the Sun and Moon
+  astroglide report [flags]    # annual daylight summary
+  astroglide almanac [flags]   # daily Sun/Moon table for a month or week
+  astroglide status [flags]    # keep a file of current Sun/Moon positions
+  astroglide config COMMAND    # default location, time zone, format and named places
+  astroglide export [flags]    # events over a date range as a long-format table
 
 Default mode flags (rise/set):
+  -place string
+        place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)
   -lat float
-        latitude in degrees (north positive)
+        latitude in degrees (north positive); repeat -lat/-lon for several locations
   -lon float
         longitude in degrees (east positive, west negative)
+  -locations string
+        comma-separated place names, e.g. home,cabin (named places from the config file work too)
   -date string
         date in YYYY-MM-DD (optional, defaults to today in local time)
   -body string
         celestial body: sun or moon (default "sun")
   -event string
         event: rise, set, or both (default "both")
+  -format string
+        output format: human, json, csv or yaml (default "human")
   -json
-        output result as JSON
+        output result as JSON (same as -format json)
+  -expr string
+        event expressions to resolve, e.g. "sunset-45m,civil_dawn+10m"
+  -round duration
+        round event times to the nearest multiple, e.g. 1m (default: exact)
 
-For phase mode:
+Every mode accepts -format. For the other modes:
   astroglide phase -h
+  astroglide watch -h
+  astroglide batch -h
+  astroglide sunpath -h
+  astroglide sky -h
+  astroglide report -h
+  astroglide almanac -h
+  astroglide status -h
+  astroglide config -h
+  astroglide export -h
+
+Defaults for -lat, -lon, -place and -format, a time zone to use instead of
+the local one, and named places for -place can be kept in a config file;
+see astroglide config -h.
 `)
 }
 
@@ -66,12 +118,18 @@ For phase mode:
 func runRiseSet(args []string) {
 	fs := flag.NewFlagSet("astroglide", flag.ExitOnError)
 
-	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
-	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	var lats, lons floatList
+	fs.Var(&lats, "lat", "latitude in degrees (north positive); repeat with -lon for several locations")
+	fs.Var(&lons, "lon", "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)`)
+	locationsS := fs.String("locations", "", `comma-separated place names, e.g. home,cabin (use ";" between names that contain commas)`)
 	dateS := fs.String("date", "", "date in YYYY-MM-DD (optional, defaults to today in local time)")
 	bodyS := fs.String("body", "sun", "celestial body: sun or moon")
 	event := fs.String("event", "both", "event: rise, set, or both")
-	jsonOut := fs.Bool("json", false, "output result as JSON")
+	formatS := fs.String("format", "human", output.Usage)
+	jsonOut := fs.Bool("json", false, "output result as JSON (same as -format json)")
+	exprS := fs.String("expr", "", "comma-separated event expressions to resolve instead, e.g. sunset-45m,solar_noon")
+	roundD := fs.Duration("round", 0, "round event times to the nearest multiple of this, e.g. 1m or 1s (0 leaves them exact)")
 
 	fs.Usage = func() {
 		fmt.Fprintf(os.Stderr, `Usage: astroglide [flags]
@@ -81,27 +139,20 @@ Flags:
 		fs.PrintDefaults()
 	}
 
-	if err := fs.Parse(args); err != nil {
+	if err := parseFlags(fs, args); err != nil {
 		log.Fatalf("failed to parse flags: %v", err)
 	}
 
-	if *lat == 0 && *lon == 0 {
-		log.Println("warning: lat=0 lon=0 (Gulf of Guinea). Use -lat and -lon to set a real location.")
+	format, err := output.Parse(*formatS)
+	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
 	}
-
-	// Default date: today in local time.
-	var date time.Time
-	if *dateS == "" {
-		now := time.Now()
-		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-	} else {
-		var err error
-		date, err = time.ParseInLocation("2006-01-02", *dateS, time.Local)
-		if err != nil {
-			log.Fatalf("invalid -date %q: %v", *dateS, err)
-		}
+	if *jsonOut {
+		format = output.JSON
 	}
 
+	locs := resolveLocations(lats, lons, *placeS, *locationsS, time.Local)
+
 	// Parse body
 	var body astroglide.Body
 	switch strings.ToLower(*bodyS) {
@@ -113,24 +164,217 @@ Flags:
 		log.Fatalf("unsupported body %q (use sun or moon)", *bodyS)
 	}
 
-	coords := astroglide.Coordinates{
-		Lat: *lat,
-		Lon: *lon,
-		// Elevation reserved for future use
+	if *exprS != "" {
+		if len(locs) > 1 {
+			log.Fatalf("-expr takes a single location")
+		}
+		runExprs(locs[0].coords, localDate(*dateS, locs[0].tz), *exprS, *roundD, format)
+		return
+	}
+
+	results := make(riseSetResults, 0, len(locs))
+	for i, l := range locs {
+		date := localDate(*dateS, l.tz)
+		rs, err := astroglide.RiseSetFor(body, l.coords, date, astroglide.WithRounding(*roundD))
+		if err != nil {
+			log.Fatalf("error computing rise/set: %v", err)
+		}
+
+		if format == output.Human {
+			if len(locs) > 1 {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("== %s ==\n", l.name)
+			}
+			printHuman(body, l.coords, date, *event, rs)
+			continue
+		}
+		r := riseSetResult(body, l.coords, date, *event, rs)
+		if len(locs) > 1 {
+			r.Location = l.name
+		}
+		results = append(results, r)
+	}
+	if format == output.Human {
+		return
+	}
+
+	var v interface{} = results
+	if len(results) == 1 {
+		v = results[0]
+	}
+	if err := output.Write(os.Stdout, format, v); err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+}
+
+// namedLocation is one location of a query over several.
+type namedLocation struct {
+	name   string // place name, or "" for bare coordinates
+	coords astroglide.Coordinates
+	tz     *time.Location
+}
+
+// resolveLocations resolves -locations, -place or the -lat/-lon pairs;
+// bare coordinates use the time zone tz.
+func resolveLocations(lats, lons floatList, place, locations string, tz *time.Location) []namedLocation {
+	if locations != "" {
+		sep := ","
+		if strings.Contains(locations, ";") {
+			sep = ";"
+		}
+		var locs []namedLocation
+		for _, name := range strings.Split(locations, sep) {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			coords, tz := lookupPlace(name)
+			locs = append(locs, namedLocation{name: name, coords: coords, tz: tz})
+		}
+		if len(locs) == 0 {
+			log.Fatalf("invalid -locations: no place names")
+		}
+		return locs
+	}
+
+	if place != "" {
+		coords, tz := lookupPlace(place)
+		return []namedLocation{{name: place, coords: coords, tz: tz}}
+	}
+
+	if len(lats.vals) != len(lons.vals) && len(lats.vals)+len(lons.vals) > 1 {
+		log.Fatalf("got %d -lat and %d -lon values; give them in pairs", len(lats.vals), len(lons.vals))
 	}
+	if len(lats.vals) <= 1 && len(lons.vals) <= 1 {
+		coords := astroglide.Coordinates{Lat: lats.first(), Lon: lons.first()}
+		if coords.Lat == 0 && coords.Lon == 0 {
+			log.Println("warning: lat=0 lon=0 (Gulf of Guinea). Use -lat and -lon or -place to set a real location.")
+		}
+		return []namedLocation{{coords: coords, tz: tz}}
+	}
+	locs := make([]namedLocation, len(lats.vals))
+	for i := range lats.vals {
+		locs[i] = namedLocation{
+			name:   fmt.Sprintf("%g,%g", lats.vals[i], lons.vals[i]),
+			coords: astroglide.Coordinates{Lat: lats.vals[i], Lon: lons.vals[i]},
+			tz:     tz,
+		}
+	}
+	return locs
+}
 
-	rs, err := astroglide.RiseSetFor(body, coords, date)
+// localDate parses a -date flag in tz; empty means today there.
+func localDate(s string, tz *time.Location) time.Time {
+	if s == "" {
+		now := time.Now().In(tz)
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tz)
+	}
+	date, err := time.ParseInLocation("2006-01-02", s, tz)
 	if err != nil {
-		log.Fatalf("error computing rise/set: %v", err)
+		log.Fatalf("invalid -date %q: %v", s, err)
 	}
+	return date
+}
 
-	if *jsonOut {
-		printJSON(body, coords, date, *event, rs)
-	} else {
-		printHuman(body, coords, date, *event, rs)
+// floatList is a float flag that may be repeated. A default set from the
+// config file is replaced by the first value on the command line.
+type floatList struct {
+	vals     []float64
+	fromConf bool
+}
+
+func (f *floatList) String() string {
+	if f == nil || len(f.vals) == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(f.vals[0], 'f', -1, 64)
+}
+
+func (f *floatList) Set(s string) error {
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	if f.fromConf {
+		f.vals, f.fromConf = nil, false
+	}
+	f.vals = append(f.vals, x)
+	return nil
+}
+
+// SetDefault sets the value taken from the config file.
+func (f *floatList) SetDefault(s string) error {
+	if err := f.Set(s); err != nil {
+		return err
+	}
+	f.fromConf = true
+	return nil
+}
+
+func (f *floatList) first() float64 {
+	if len(f.vals) == 0 {
+		return 0
+	}
+	return f.vals[0]
+}
+
+// runExprs resolves event expressions such as "sunset-45m" for the date.
+func runExprs(coords astroglide.Coordinates, date time.Time, list string, round time.Duration, format output.Format) {
+	exprs, err := parseEventExprs(list)
+	if err != nil {
+		log.Fatalf("invalid -expr: %v", err)
+	}
+
+	results := make(exprResults, 0, len(exprs))
+	for _, e := range exprs {
+		r := resolved{Expr: e.String()}
+		if t, err := e.Resolve(coords, date); err != nil {
+			r.Error = err.Error()
+		} else {
+			t = timeutil.RoundWallClock(t, round)
+			r.Time = &t
+		}
+		results = append(results, r)
+	}
+
+	if format != output.Human {
+		if err := output.Write(os.Stdout, format, results); err != nil {
+			log.Fatalf("failed to write output: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Events for lat=%.6f lon=%.6f on %s (%s)\n\n", coords.Lat, coords.Lon, date.Format("2006-01-02"), date.Location())
+	for _, r := range results {
+		if r.Time == nil {
+			fmt.Printf("%-20s none (%s)\n", r.Expr, r.Error)
+			continue
+		}
+		fmt.Printf("%-20s %s\n", r.Expr, r.Time.Format(time.RFC3339))
 	}
 }
 
+type resolved struct {
+	Expr  string     `json:"expr"`
+	Time  *time.Time `json:"time"`
+	Error string     `json:"error,omitempty"`
+
+	err error
+}
+
+type exprResults []resolved
+
+func (r exprResults) Header() []string { return []string{"expr", "time", "error"} }
+
+func (r exprResults) Rows() [][]string {
+	rows := make([][]string, len(r))
+	for i, res := range r {
+		rows[i] = []string{res.Expr, formatTimePtr(res.Time), res.Error}
+	}
+	return rows
+}
+
 // ---------------------
 // Phase subcommand
 // ---------------------
@@ -139,7 +383,9 @@ func runPhase(args []string) {
 	fs := flag.NewFlagSet("phase", flag.ExitOnError)
 
 	tzName := fs.String("tz", "UTC", "IANA time zone name (e.g. America/Phoenix)")
+	placeS := fs.String("place", "", `use the time zone of a place, e.g. "Phoenix, AZ"`)
 	timeStr := fs.String("time", "", "Time in RFC3339 or 'YYYY-MM-DDTHH:MM' (optional, defaults to now in tz)")
+	formatS := fs.String("format", "human", output.Usage)
 
 	fs.Usage = func() {
 		fmt.Fprintf(os.Stderr, `Usage: astroglide phase [flags]
@@ -149,37 +395,25 @@ Flags:
 		fs.PrintDefaults()
 	}
 
-	if err := fs.Parse(args); err != nil {
+	if err := parseFlags(fs, args); err != nil {
 		log.Fatalf("failed to parse flags: %v", err)
 	}
 
-	loc, err := time.LoadLocation(*tzName)
+	format, err := output.Parse(*formatS)
 	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
+	}
+
+	var loc *time.Location
+	if *placeS != "" {
+		_, loc = lookupPlace(*placeS)
+	} else if loc, err = loadLocation(*tzName); err != nil {
 		log.Fatalf("invalid time zone %q: %v", *tzName, err)
 	}
 
-	var tLocal time.Time
-	if *timeStr == "" {
-		// Now in the given time zone
-		tLocal = time.Now().In(loc)
-	} else {
-		// Try a couple of common formats
-		layouts := []string{
-			time.RFC3339,
-			"2006-01-02T15:04",
-			"2006-01-02 15:04",
-			"2006-01-02",
-		}
-		var parseErr error
-		for _, layout := range layouts {
-			tLocal, parseErr = time.ParseInLocation(layout, *timeStr, loc)
-			if parseErr == nil {
-				break
-			}
-		}
-		if parseErr != nil {
-			log.Fatalf("could not parse -time %q: %v", *timeStr, parseErr)
-		}
+	tLocal, err := parseTimeFlag(*timeStr, loc)
+	if err != nil {
+		log.Fatalf("could not parse -time %q: %v", *timeStr, err)
 	}
 
 	phase, err := astroglide.MoonPhaseAt(tLocal)
@@ -187,6 +421,21 @@ Flags:
 		log.Fatalf("MoonPhaseAt failed: %v", err)
 	}
 
+	if format != output.Human {
+		out := phaseOutput{
+			Time:       phase.Time,
+			Timezone:   loc.String(),
+			Name:       phase.Name,
+			Fraction:   phase.Fraction,
+			Elongation: phase.Elongation,
+			Waxing:     phase.Waxing,
+		}
+		if err := output.Write(os.Stdout, format, out); err != nil {
+			log.Fatalf("failed to write output: %v", err)
+		}
+		return
+	}
+
 	fmt.Printf("Moon phase at %s (%s)\n", phase.Time.Format(time.RFC3339), loc.String())
 	fmt.Printf("  Name       : %s\n", phase.Name)
 	fmt.Printf("  Fraction   : %.3f (%.1f%% illuminated)\n", phase.Fraction, phase.Fraction*100)
@@ -198,6 +447,30 @@ Flags:
 	}
 }
 
+type phaseOutput struct {
+	Time       time.Time `json:"time"`
+	Timezone   string    `json:"timezone"`
+	Name       string    `json:"name"`
+	Fraction   float64   `json:"fraction"`
+	Elongation float64   `json:"elongation"`
+	Waxing     bool      `json:"waxing"`
+}
+
+func (p phaseOutput) Header() []string {
+	return []string{"time", "timezone", "name", "fraction", "elongation", "waxing"}
+}
+
+func (p phaseOutput) Rows() [][]string {
+	return [][]string{{
+		p.Time.Format(time.RFC3339),
+		p.Timezone,
+		p.Name,
+		strconv.FormatFloat(p.Fraction, 'f', 4, 64),
+		strconv.FormatFloat(p.Elongation, 'f', 2, 64),
+		strconv.FormatBool(p.Waxing),
+	}}
+}
+
 // ---------------------
 // Shared helpers
 // ---------------------
@@ -211,23 +484,91 @@ func printHuman(body astroglide.Body, coords astroglide.Coordinates, date time.T
 	fmt.Printf("%s rise/set for lat=%.6f lon=%.6f\n", bodyName, coords.Lat, coords.Lon)
 	fmt.Printf("Date: %s (%s)\n\n", date.Format("2006-01-02"), date.Location())
 
+	rise := formatEvent(rs.Rise, rs.HasRise)
+	set := formatEvent(rs.Set, rs.HasSet)
+
 	event = strings.ToLower(event)
 	switch event {
 	case "rise":
-		fmt.Printf("Rise: %s\n", rs.Rise.Format(time.RFC3339))
+		fmt.Printf("Rise: %s\n", rise)
 	case "set":
-		fmt.Printf("Set:  %s\n", rs.Set.Format(time.RFC3339))
+		fmt.Printf("Set:  %s\n", set)
 	case "both":
-		fmt.Printf("Rise: %s\n", rs.Rise.Format(time.RFC3339))
-		fmt.Printf("Set:  %s\n", rs.Set.Format(time.RFC3339))
+		fmt.Printf("Rise: %s\n", rise)
+		fmt.Printf("Set:  %s\n", set)
 	default:
 		fmt.Fprintf(os.Stderr, "unknown event %q, showing both\n", event)
-		fmt.Printf("Rise: %s\n", rs.Rise.Format(time.RFC3339))
-		fmt.Printf("Set:  %s\n", rs.Set.Format(time.RFC3339))
+		fmt.Printf("Rise: %s\n", rise)
+		fmt.Printf("Set:  %s\n", set)
+	}
+}
+
+// lookupPlace resolves a -place flag to coordinates and its time zone.
+func lookupPlace(name string) (astroglide.Coordinates, *time.Location) {
+	p, ok := configPlace(name)
+	if !ok {
+		var err error
+		if p, err = astroglide.LookupPlace(name); err != nil {
+			log.Fatalf("invalid -place: %v", err)
+		}
+	}
+	if p.TimeZone == "" {
+		return p.Coordinates, time.Local
+	}
+	tz, err := loadLocation(p.TimeZone)
+	if err != nil {
+		log.Fatalf("time zone %q of %s: %v", p.TimeZone, p, err)
 	}
+	return p.Coordinates, tz
+}
+
+// loadLocation is time.LoadLocation, with a hint when the system has no
+// time zone database.
+func loadLocation(name string) (*time.Location, error) {
+	tz, err := time.LoadLocation(name)
+	if err != nil {
+		// A zone every database has: if it is missing too, the database is.
+		if _, probeErr := time.LoadLocation("Europe/London"); probeErr != nil {
+			err = fmt.Errorf("%w (no time zone database on this system; rebuild with -tags tzdata or install tzdata)", err)
+		}
+	}
+	return tz, err
+}
+
+// parseTimeFlag parses a -time flag in loc: RFC 3339, "YYYY-MM-DDTHH:MM",
+// "YYYY-MM-DD HH:MM" or "YYYY-MM-DD". An empty string means now.
+func parseTimeFlag(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Now().In(loc), nil
+	}
+
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// formatEvent renders an event time to the nearest second, or "none" if it
+// does not occur.
+func formatEvent(t time.Time, ok bool) string {
+	if !ok {
+		return "none"
+	}
+	return timeutil.RoundWallClock(t, time.Second).Format(time.RFC3339)
 }
 
 type jsonOutput struct {
+	Location  string             `json:"location,omitempty"`
 	Body      string             `json:"body"`
 	Latitude  float64            `json:"latitude"`
 	Longitude float64            `json:"longitude"`
@@ -238,7 +579,7 @@ type jsonOutput struct {
 	Raw       astroglide.RiseSet `json:"raw"`
 }
 
-func printJSON(body astroglide.Body, coords astroglide.Coordinates, date time.Time, event string, rs astroglide.RiseSet) {
+func riseSetResult(body astroglide.Body, coords astroglide.Coordinates, date time.Time, event string, rs astroglide.RiseSet) jsonOutput {
 	bodyName := map[astroglide.Body]string{
 		astroglide.Sun:  "sun",
 		astroglide.Moon: "moon",
@@ -254,22 +595,55 @@ func printJSON(body astroglide.Body, coords astroglide.Coordinates, date time.Ti
 	}
 
 	e := strings.ToLower(event)
-	switch e {
-	case "rise":
-		out.Rise = &rs.Rise
-	case "set":
-		out.Set = &rs.Set
-	case "both":
-		out.Rise = &rs.Rise
-		out.Set = &rs.Set
-	default:
+	wantRise := e != "set"
+	wantSet := e != "rise"
+
+	// Missing events are omitted rather than shown as the zero time.
+	if wantRise && rs.HasRise {
 		out.Rise = &rs.Rise
+	}
+	if wantSet && rs.HasSet {
 		out.Set = &rs.Set
 	}
+	return out
+}
+
+func (o jsonOutput) Header() []string {
+	return []string{"body", "latitude", "longitude", "date", "timezone", "rise", "set"}
+}
+
+func (o jsonOutput) Rows() [][]string {
+	return [][]string{{
+		o.Body,
+		strconv.FormatFloat(o.Latitude, 'f', -1, 64),
+		strconv.FormatFloat(o.Longitude, 'f', -1, 64),
+		o.Date,
+		o.Timezone,
+		formatTimePtr(o.Rise),
+		formatTimePtr(o.Set),
+	}}
+}
+
+// riseSetResults are the results for several locations; their CSV has a
+// leading location column.
+type riseSetResults []jsonOutput
+
+func (r riseSetResults) Header() []string {
+	return append([]string{"location"}, jsonOutput{}.Header()...)
+}
+
+func (r riseSetResults) Rows() [][]string {
+	rows := make([][]string, len(r))
+	for i, o := range r {
+		rows[i] = append([]string{o.Location}, o.Rows()[0]...)
+	}
+	return rows
+}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(out); err != nil {
-		log.Fatalf("failed to encode JSON: %v", err)
+// formatTimePtr renders an optional time as RFC 3339, or "" when absent.
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
 	}
+	return t.Format(time.RFC3339)
 }
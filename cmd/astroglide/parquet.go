@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+// A minimal Parquet writer for the export table: one row group, one
+// uncompressed data page per column, PLAIN values and RLE definition
+// levels. That is all the format requires, and pandas, R (arrow), DuckDB
+// and Spark read it. See https://parquet.apache.org/docs/file-format/.
+
+// Parquet physical types, converted types, repetitions and encodings.
+const (
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetUTF8            = 0
+	parquetDate            = 6
+	parquetTimestampMicros = 10
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetPlain = 0
+	parquetRLE   = 3
+)
+
+// parquetColumn is one column of the table, filled row by row.
+type parquetColumn struct {
+	name      string
+	physical  int32
+	converted int32 // -1 when none
+	optional  bool
+	present   []bool       // one per row; false for a null
+	values    bytes.Buffer // PLAIN encoding of the present values
+}
+
+func (c *parquetColumn) null() { c.present = append(c.present, false) }
+
+func (c *parquetColumn) str(s string) {
+	c.present = append(c.present, true)
+	binary.Write(&c.values, binary.LittleEndian, uint32(len(s)))
+	c.values.WriteString(s)
+}
+
+func (c *parquetColumn) int32(v int32) {
+	c.present = append(c.present, true)
+	binary.Write(&c.values, binary.LittleEndian, v)
+}
+
+func (c *parquetColumn) int64(v int64) {
+	c.present = append(c.present, true)
+	binary.Write(&c.values, binary.LittleEndian, v)
+}
+
+func (c *parquetColumn) double(v float64) {
+	c.present = append(c.present, true)
+	binary.Write(&c.values, binary.LittleEndian, math.Float64bits(v))
+}
+
+// page returns the data page of the column: the definition levels of an
+// optional column, then its values.
+func (c *parquetColumn) page() []byte {
+	if !c.optional {
+		return c.values.Bytes()
+	}
+	// Runs of equal levels, each a ULEB128 (length << 1) and a byte.
+	var levels []byte
+	for i := 0; i < len(c.present); {
+		j := i
+		for j < len(c.present) && c.present[j] == c.present[i] {
+			j++
+		}
+		levels = binary.AppendUvarint(levels, uint64(j-i)<<1)
+		if c.present[i] {
+			levels = append(levels, 1)
+		} else {
+			levels = append(levels, 0)
+		}
+		i = j
+	}
+	page := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	return append(append(page, levels...), c.values.Bytes()...)
+}
+
+// writeParquet writes rows as a Parquet file with the columns of the CSV
+// export. date is a DATE, utc a UTC TIMESTAMP (microseconds) and time the
+// local RFC 3339 string, which keeps the UTC offset; time, utc, unix and
+// status are null where the CSV has empty fields.
+func writeParquet(w io.Writer, rows exportRows) error {
+	cols := []*parquetColumn{
+		{name: "location", physical: parquetByteArray, converted: parquetUTF8},
+		{name: "latitude", physical: parquetDouble, converted: -1},
+		{name: "longitude", physical: parquetDouble, converted: -1},
+		{name: "timezone", physical: parquetByteArray, converted: parquetUTF8},
+		{name: "date", physical: parquetInt32, converted: parquetDate},
+		{name: "event", physical: parquetByteArray, converted: parquetUTF8},
+		{name: "time", physical: parquetByteArray, converted: parquetUTF8, optional: true},
+		{name: "utc", physical: parquetInt64, converted: parquetTimestampMicros, optional: true},
+		{name: "unix", physical: parquetInt64, converted: -1, optional: true},
+		{name: "status", physical: parquetByteArray, converted: parquetUTF8, optional: true},
+	}
+	for _, r := range rows {
+		day, err := time.Parse("2006-01-02", r.Date)
+		if err != nil {
+			return err
+		}
+		cols[0].str(r.Location)
+		cols[1].double(r.Latitude)
+		cols[2].double(r.Longitude)
+		cols[3].str(r.TimeZone)
+		cols[4].int32(int32(day.Unix() / 86400))
+		cols[5].str(r.Event)
+		if r.Time != nil {
+			cols[6].str(formatTimePtr(r.Time))
+			cols[7].int64(r.UTC.UnixMicro())
+			cols[8].int64(*r.Unix)
+		} else {
+			cols[6].null()
+			cols[7].null()
+			cols[8].null()
+		}
+		if r.Status != "" {
+			cols[9].str(r.Status)
+		} else {
+			cols[9].null()
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString("PAR1")
+
+	var chunks compactWriter
+	var total int64
+	for _, c := range cols {
+		page := c.page()
+		var header compactWriter
+		header.begin()
+		header.i32(1, 0) // DATA_PAGE
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.beginField(5) // DataPageHeader
+		header.i32(1, int32(len(c.present)))
+		header.i32(2, parquetPlain)
+		header.i32(3, parquetRLE)
+		header.i32(4, parquetRLE)
+		header.end()
+		header.end()
+
+		offset := int64(file.Len())
+		size := int64(header.Len() + len(page))
+		file.Write(header.Bytes())
+		file.Write(page)
+		total += size
+
+		chunks.begin() // ColumnChunk
+		chunks.i64(2, offset)
+		chunks.beginField(3) // ColumnMetaData
+		chunks.i32(1, c.physical)
+		chunks.list(2, compactI32, 2)
+		chunks.varint(zigzag(parquetPlain))
+		chunks.varint(zigzag(parquetRLE))
+		chunks.list(3, compactBinary, 1)
+		chunks.bytes(c.name)
+		chunks.i32(4, 0) // UNCOMPRESSED
+		chunks.i64(5, int64(len(c.present)))
+		chunks.i64(6, size)
+		chunks.i64(7, size)
+		chunks.i64(9, offset)
+		chunks.end()
+		chunks.end()
+	}
+
+	var meta compactWriter
+	meta.begin() // FileMetaData
+	meta.i32(1, 1)
+	meta.list(2, compactStruct, len(cols)+1)
+	meta.begin()
+	meta.str(4, "schema")
+	meta.i32(5, int32(len(cols)))
+	meta.end()
+	for _, c := range cols {
+		meta.begin()
+		meta.i32(1, c.physical)
+		if c.optional {
+			meta.i32(3, parquetOptional)
+		} else {
+			meta.i32(3, parquetRequired)
+		}
+		meta.str(4, c.name)
+		if c.converted >= 0 {
+			meta.i32(6, c.converted)
+		}
+		meta.end()
+	}
+	meta.i64(3, int64(len(rows)))
+	meta.list(4, compactStruct, 1)
+	meta.begin() // RowGroup
+	meta.list(1, compactStruct, len(cols))
+	meta.Write(chunks.Bytes())
+	meta.i64(2, total)
+	meta.i64(3, int64(len(rows)))
+	meta.end()
+	meta.str(6, "astroglide export")
+	meta.end()
+
+	file.Write(meta.Bytes())
+	binary.Write(&file, binary.LittleEndian, uint32(meta.Len()))
+	file.WriteString("PAR1")
+	_, err := w.Write(file.Bytes())
+	return err
+}
+
+// Thrift compact protocol types.
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter writes the Thrift compact protocol that Parquet uses for
+// its page headers and footer. begin and end bracket a struct; fields are
+// written in increasing id order.
+type compactWriter struct {
+	bytes.Buffer
+	lastID []int16 // last field id of each open struct
+}
+
+func (w *compactWriter) begin() { w.lastID = append(w.lastID, 0) }
+
+func (w *compactWriter) end() {
+	w.WriteByte(0) // stop
+	w.lastID = w.lastID[:len(w.lastID)-1]
+}
+
+func (w *compactWriter) field(id int16, typ byte) {
+	last := &w.lastID[len(w.lastID)-1]
+	if d := id - *last; d > 0 && d <= 15 {
+		w.WriteByte(byte(d)<<4 | typ)
+	} else {
+		w.WriteByte(typ)
+		w.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+// beginField starts a struct-valued field.
+func (w *compactWriter) beginField(id int16) {
+	w.field(id, compactStruct)
+	w.begin()
+}
+
+func (w *compactWriter) i32(id int16, v int32) {
+	w.field(id, compactI32)
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *compactWriter) i64(id int16, v int64) {
+	w.field(id, compactI64)
+	w.varint(zigzag(v))
+}
+
+func (w *compactWriter) str(id int16, s string) {
+	w.field(id, compactBinary)
+	w.bytes(s)
+}
+
+// list starts a list field of n elements of type typ, which follow
+// without field headers.
+func (w *compactWriter) list(id int16, typ byte, n int) {
+	w.field(id, compactList)
+	if n < 15 {
+		w.WriteByte(byte(n)<<4 | typ)
+	} else {
+		w.WriteByte(0xf0 | typ)
+		w.varint(uint64(n))
+	}
+}
+
+func (w *compactWriter) bytes(s string) {
+	w.varint(uint64(len(s)))
+	w.WriteString(s)
+}
+
+func (w *compactWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func zigzag(v int64) uint64 { return uint64(v<<1) ^ uint64(v>>63) }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// thriftStruct is a decoded Thrift compact struct: field id to value, where
+// a value is an int64, a string, a []any or a thriftStruct.
+type thriftStruct map[int16]any
+
+// compactReader decodes the Thrift compact protocol, independently of
+// compactWriter.
+type compactReader struct {
+	t   *testing.T
+	buf []byte
+}
+
+func (r *compactReader) byte() byte {
+	if len(r.buf) == 0 {
+		r.t.Fatal("thrift: unexpected end of data")
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *compactReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.t.Fatal("thrift: bad varint")
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *compactReader) value(typ byte) any {
+	switch typ {
+	case 1, 2: // bool true, false
+		return typ == 1
+	case 5, 6: // i32, i64, zigzag varints
+		v := r.uvarint()
+		return int64(v>>1) ^ -int64(v&1)
+	case 8:
+		n := r.uvarint()
+		s := string(r.buf[:n])
+		r.buf = r.buf[n:]
+		return s
+	case 9:
+		head := r.byte()
+		n, elem := uint64(head>>4), head&0x0f
+		if n == 15 {
+			n = r.uvarint()
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case 12:
+		return r.structure()
+	}
+	r.t.Fatalf("thrift: unsupported type %d", typ)
+	return nil
+}
+
+func (r *compactReader) structure() thriftStruct {
+	s := thriftStruct{}
+	var id int16
+	for {
+		head := r.byte()
+		if head == 0 {
+			return s
+		}
+		if d := head >> 4; d != 0 {
+			id += int16(d)
+		} else {
+			v := r.uvarint()
+			id = int16(int64(v>>1) ^ -int64(v&1))
+		}
+		s[id] = r.value(head & 0x0f)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	rows := testExportRows(t)
+	var buf bytes.Buffer
+	if err := writeParquet(&buf, rows); err != nil {
+		t.Fatalf("writeParquet error: %v", err)
+	}
+	file := buf.Bytes()
+
+	// PAR1, pages, footer, footer length, PAR1.
+	if len(file) < 12 || string(file[:4]) != "PAR1" || string(file[len(file)-4:]) != "PAR1" {
+		t.Fatalf("file does not start and end with PAR1")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	metaStart := len(file) - 8 - metaLen
+	if metaStart < 4 {
+		t.Fatalf("footer length %d larger than the file", metaLen)
+	}
+	r := &compactReader{t: t, buf: file[metaStart : len(file)-8]}
+	meta := r.structure()
+	if len(r.buf) != 0 {
+		t.Errorf("%d bytes left after the footer", len(r.buf))
+	}
+
+	if meta[3] != int64(len(rows)) {
+		t.Errorf("num_rows = %v, want %d", meta[3], len(rows))
+	}
+
+	// Schema: the root, then one leaf per column.
+	wantCols := []struct {
+		name     string
+		physical int64
+		optional bool
+	}{
+		{"location", parquetByteArray, false},
+		{"latitude", parquetDouble, false},
+		{"longitude", parquetDouble, false},
+		{"timezone", parquetByteArray, false},
+		{"date", parquetInt32, false},
+		{"event", parquetByteArray, false},
+		{"time", parquetByteArray, true},
+		{"utc", parquetInt64, true},
+		{"unix", parquetInt64, true},
+		{"status", parquetByteArray, true},
+	}
+	schema := meta[2].([]any)
+	if len(schema) != len(wantCols)+1 {
+		t.Fatalf("%d schema elements, want %d", len(schema), len(wantCols)+1)
+	}
+	if root := schema[0].(thriftStruct); root[5] != int64(len(wantCols)) {
+		t.Errorf("root num_children = %v, want %d", root[5], len(wantCols))
+	}
+	for i, want := range wantCols {
+		el := schema[i+1].(thriftStruct)
+		repetition := int64(parquetRequired)
+		if want.optional {
+			repetition = parquetOptional
+		}
+		if el[4] != want.name || el[1] != want.physical || el[3] != repetition {
+			t.Errorf("schema element %d = %v, want %s type %d repetition %d", i+1, el, want.name, want.physical, repetition)
+		}
+	}
+
+	groups := meta[4].([]any)
+	if len(groups) != 1 {
+		t.Fatalf("%d row groups, want 1", len(groups))
+	}
+	group := groups[0].(thriftStruct)
+	if group[3] != int64(len(rows)) {
+		t.Errorf("row group num_rows = %v, want %d", group[3], len(rows))
+	}
+	chunks := group[1].([]any)
+	if len(chunks) != len(wantCols) {
+		t.Fatalf("%d column chunks, want %d", len(chunks), len(wantCols))
+	}
+
+	// The sunrise row has a time and no status, the moonrise row the
+	// reverse.
+	wantPresent := map[string][]bool{
+		"time":   {true, false},
+		"utc":    {true, false},
+		"unix":   {true, false},
+		"status": {false, true},
+	}
+	for i, c := range chunks {
+		cm := c.(thriftStruct)[3].(thriftStruct)
+		name := cm[3].([]any)[0]
+		if name != wantCols[i].name || cm[5] != int64(len(rows)) {
+			t.Errorf("chunk %d: path %v, num_values %v", i, name, cm[5])
+		}
+
+		offset := cm[9].(int64)
+		pr := &compactReader{t: t, buf: file[offset:]}
+		header := pr.structure()
+		page := pr.buf[:header[3].(int64)]
+		if dp := header[5].(thriftStruct); header[1] != int64(0) || dp[1] != int64(len(rows)) {
+			t.Errorf("chunk %d: page header %v", i, header)
+		}
+		if int64(len(page))+int64(len(file[offset:])-len(pr.buf)) != cm[6] {
+			t.Errorf("chunk %d: total_compressed_size %v does not match the page", i, cm[6])
+		}
+
+		want, ok := wantPresent[wantCols[i].name]
+		if !ok {
+			continue
+		}
+		// RLE runs of 1-bit definition levels, after their byte length.
+		levels := page[4 : 4+binary.LittleEndian.Uint32(page)]
+		var got []bool
+		for len(levels) > 0 {
+			run, n := binary.Uvarint(levels)
+			if run&1 != 0 {
+				t.Fatalf("chunk %d: bit-packed run, want RLE", i)
+			}
+			for j := uint64(0); j < run>>1; j++ {
+				got = append(got, levels[n] == 1)
+			}
+			levels = levels[n+1:]
+		}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("%s definition levels = %v, want %v", wantCols[i].name, got, want)
+		}
+	}
+}
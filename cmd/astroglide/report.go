@@ -0,0 +1,195 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Report subcommand
+// ---------------------
+
+func runReport(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+
+	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
+	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)`)
+	year := fs.Int("year", time.Now().Year(), "year to summarize")
+	tzName := fs.String("tz", "Local", "IANA time zone for the dates (e.g. America/Phoenix)")
+	thresholdsS := fs.String("thresholds", "12h", "comma-separated day lengths to report crossings of, e.g. 10h,12h,14h30m")
+	formatS := fs.String("format", "human", output.Usage+" (csv lists every day)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide report [flags]
+
+Summarizes a year of daylight: total and monthly hours, the shortest and
+longest days, polar night and midnight sun, and the dates on which the day
+length crosses each -thresholds value.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	format, err := output.Parse(*formatS)
+	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
+	}
+
+	var thresholds []time.Duration
+	for _, s := range strings.Split(*thresholdsS, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 || d > 24*time.Hour {
+			log.Fatalf("invalid -thresholds entry %q: want a duration between 0 and 24h", s)
+		}
+		thresholds = append(thresholds, d)
+	}
+
+	coords := astroglide.Coordinates{Lat: *lat, Lon: *lon}
+	var tz *time.Location
+	if *placeS != "" {
+		coords, tz = lookupPlace(*placeS)
+	} else if tz, err = loadLocation(*tzName); err != nil {
+		log.Fatalf("invalid time zone %q: %v", *tzName, err)
+	}
+
+	stats, err := astroglide.AnnualDaylightStats(coords, *year, tz, thresholds...)
+	if err != nil {
+		log.Fatalf("error computing daylight statistics: %v", err)
+	}
+
+	if format != output.Human {
+		if err := output.Write(os.Stdout, format, reportResult(coords, stats)); err != nil {
+			log.Fatalf("failed to write output: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Daylight %d for lat=%.4f lon=%.4f (%s)\n\n", stats.Year, coords.Lat, coords.Lon, tz)
+	fmt.Printf("  Total    : %.1f hours\n", stats.Total.Hours())
+	fmt.Printf("  Mean day : %s\n", formatHM(stats.Mean))
+	fmt.Printf("  Shortest : %s (%s)\n", stats.Shortest.Date.Format("Mon Jan 2"), formatHM(stats.Shortest.Length))
+	fmt.Printf("  Longest  : %s (%s)\n", stats.Longest.Date.Format("Mon Jan 2"), formatHM(stats.Longest.Length))
+	if stats.PolarNightDays > 0 || stats.MidnightSunDays > 0 {
+		fmt.Printf("  Polar    : %d days of polar night, %d of midnight sun\n", stats.PolarNightDays, stats.MidnightSunDays)
+	}
+
+	if len(stats.Crossings) > 0 {
+		fmt.Println()
+		for _, c := range stats.Crossings {
+			what := "first day under"
+			if c.Rising {
+				what = "first day of at least"
+			}
+			fmt.Printf("  %s  %s %s (%s)\n", c.Date.Format("Jan 02"), what, formatHM(c.Threshold), formatHM(c.Length))
+		}
+	}
+
+	fmt.Println()
+	for m, total := range stats.Monthly {
+		fmt.Printf("  %-9s %6.1f h\n", time.Month(m+1), total.Hours())
+	}
+}
+
+// formatHM formats a day length as "13h 05m".
+func formatHM(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// reportOutput is the structured form of a report, with durations in hours.
+type reportOutput struct {
+	Year            int              `json:"year"`
+	Latitude        float64          `json:"latitude"`
+	Longitude       float64          `json:"longitude"`
+	Timezone        string           `json:"timezone"`
+	TotalHours      float64          `json:"total_hours"`
+	MeanHours       float64          `json:"mean_hours"`
+	Shortest        reportDay        `json:"shortest"`
+	Longest         reportDay        `json:"longest"`
+	PolarNightDays  int              `json:"polar_night_days"`
+	MidnightSunDays int              `json:"midnight_sun_days"`
+	Crossings       []reportCrossing `json:"crossings"`
+	MonthlyHours    []float64        `json:"monthly_hours"`
+	Days            []reportDay      `json:"days"`
+}
+
+type reportDay struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
+type reportCrossing struct {
+	Date           string  `json:"date"`
+	ThresholdHours float64 `json:"threshold_hours"`
+	Hours          float64 `json:"hours"`
+	Rising         bool    `json:"rising"`
+}
+
+func reportResult(coords astroglide.Coordinates, s astroglide.AnnualDaylight) reportOutput {
+	day := func(d astroglide.DayLength) reportDay {
+		return reportDay{Date: d.Date.Format("2006-01-02"), Hours: roundHours(d.Length)}
+	}
+
+	out := reportOutput{
+		Year:            s.Year,
+		Latitude:        coords.Lat,
+		Longitude:       coords.Lon,
+		Timezone:        s.Shortest.Date.Location().String(),
+		TotalHours:      roundHours(s.Total),
+		MeanHours:       roundHours(s.Mean),
+		Shortest:        day(s.Shortest),
+		Longest:         day(s.Longest),
+		PolarNightDays:  s.PolarNightDays,
+		MidnightSunDays: s.MidnightSunDays,
+		Crossings:       []reportCrossing{},
+	}
+	for _, c := range s.Crossings {
+		out.Crossings = append(out.Crossings, reportCrossing{
+			Date:           c.Date.Format("2006-01-02"),
+			ThresholdHours: c.Threshold.Hours(),
+			Hours:          roundHours(c.Length),
+			Rising:         c.Rising,
+		})
+	}
+	for _, m := range s.Monthly {
+		out.MonthlyHours = append(out.MonthlyHours, roundHours(m))
+	}
+	for _, d := range s.Days {
+		out.Days = append(out.Days, day(d))
+	}
+	return out
+}
+
+// roundHours converts d to hours with four decimals (about a second).
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*1e4) / 1e4
+}
+
+// CSV lists the day lengths; the summary is in the other formats.
+func (r reportOutput) Header() []string { return []string{"date", "hours"} }
+
+func (r reportOutput) Rows() [][]string {
+	rows := make([][]string, len(r.Days))
+	for i, d := range r.Days {
+		rows[i] = []string{d.Date, strconv.FormatFloat(d.Hours, 'f', 4, 64)}
+	}
+	return rows
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Sky subcommand
+// ---------------------
+
+// skyBodies are the bodies shown by the sky chart, with their symbols.
+var skyBodies = []struct {
+	body   astroglide.Body
+	name   string
+	symbol byte
+}{
+	{astroglide.Sun, "Sun", '*'},
+	{astroglide.Moon, "Moon", 'M'},
+}
+
+func runSky(args []string) {
+	fs := flag.NewFlagSet("sky", flag.ExitOnError)
+
+	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
+	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)`)
+	tzName := fs.String("tz", "Local", "IANA time zone for -time (e.g. America/Phoenix)")
+	timeStr := fs.String("time", "", "Time in RFC3339 or 'YYYY-MM-DDTHH:MM' (optional, defaults to now)")
+	formatS := fs.String("format", "human", output.Usage)
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide sky [flags]
+
+Prints a panoramic chart of where the Sun and Moon are: azimuth across
+(N, E, S, W, N) and altitude up, 10° per row, with the horizon marked.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	format, err := output.Parse(*formatS)
+	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
+	}
+
+	coords := astroglide.Coordinates{Lat: *lat, Lon: *lon}
+	var tz *time.Location
+	if *placeS != "" {
+		coords, tz = lookupPlace(*placeS)
+	} else if tz, err = loadLocation(*tzName); err != nil {
+		log.Fatalf("invalid time zone %q: %v", *tzName, err)
+	}
+
+	t, err := parseTimeFlag(*timeStr, tz)
+	if err != nil {
+		log.Fatalf("could not parse -time %q: %v", *timeStr, err)
+	}
+
+	var bodies skyPositions
+	for _, b := range skyBodies {
+		pos, err := astroglide.PositionAt(b.body, coords, t, astroglide.Topocentric)
+		if err != nil {
+			log.Fatalf("%s position: %v", b.name, err)
+		}
+		bodies = append(bodies, skyPosition{
+			Body:     strings.ToLower(b.name),
+			Time:     t,
+			Altitude: pos.Altitude,
+			Azimuth:  pos.Azimuth,
+			symbol:   b.symbol,
+		})
+	}
+	phase, err := astroglide.MoonPhaseAt(t)
+	if err != nil {
+		log.Fatalf("MoonPhaseAt failed: %v", err)
+	}
+
+	if format != output.Human {
+		if err := output.Write(os.Stdout, format, bodies); err != nil {
+			log.Fatalf("failed to write output: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Sky at %s for lat=%.4f lon=%.4f\n\n", t.Format(time.RFC3339), coords.Lat, coords.Lon)
+	drawSky(os.Stdout, bodies)
+	fmt.Println()
+	for i, b := range bodies {
+		state := "up"
+		if b.Altitude < 0 {
+			state = "below horizon"
+		}
+		fmt.Printf("%c %-5s alt %6.2f°  az %6.2f° %-5s %s", b.symbol, skyBodies[i].name, b.Altitude, b.Azimuth, "("+compassPoint(b.Azimuth)+")", state)
+		if skyBodies[i].body == astroglide.Moon {
+			fmt.Printf(", %s, %.0f%% lit", phase.Name, phase.Fraction*100)
+		}
+		fmt.Println()
+	}
+}
+
+// The chart spans azimuth 0..360° at 5° per column and altitude 90..-20°
+// at 10° per row.
+const (
+	skyAzStep  = 5
+	skyAltStep = 10
+	skyAltMin  = -20
+)
+
+// drawSky writes the chart. Bodies more than 20° below the horizon are left
+// off; the text below the chart still lists them.
+func drawSky(w io.Writer, bodies skyPositions) {
+	cols := 360/skyAzStep + 1
+	rows := (90-skyAltMin)/skyAltStep + 1
+
+	grid := make([][]byte, rows)
+	for r := range grid {
+		grid[r] = []byte(strings.Repeat(" ", cols))
+		if 90-r*skyAltStep == 0 {
+			grid[r] = []byte(strings.Repeat("-", cols))
+		}
+	}
+	for _, b := range bodies {
+		r := int(math.Round((90 - b.Altitude) / skyAltStep))
+		if r < 0 || r >= rows {
+			continue
+		}
+		grid[r][int(math.Round(b.Azimuth/skyAzStep))] = b.symbol
+	}
+
+	for r, line := range grid {
+		fmt.Fprintf(w, "%4d° |%s|\n", 90-r*skyAltStep, line)
+	}
+
+	axis := []byte(strings.Repeat(" ", cols))
+	for az, label := range map[int]string{0: "N", 45: "NE", 90: "E", 135: "SE", 180: "S", 225: "SW", 270: "W", 315: "NW", 360: "N"} {
+		copy(axis[az/skyAzStep:], label)
+	}
+	fmt.Fprintf(w, "       %s\n", strings.TrimRight(string(axis), " "))
+}
+
+// compassPoint names the 16-wind compass point nearest az.
+func compassPoint(az float64) string {
+	points := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	return points[int(math.Round(az/22.5))%16]
+}
+
+type skyPosition struct {
+	Body     string    `json:"body"`
+	Time     time.Time `json:"time"`
+	Altitude float64   `json:"altitude"`
+	Azimuth  float64   `json:"azimuth"`
+
+	symbol byte
+}
+
+type skyPositions []skyPosition
+
+func (s skyPositions) Header() []string {
+	return []string{"body", "time", "altitude", "azimuth"}
+}
+
+func (s skyPositions) Rows() [][]string {
+	rows := make([][]string, len(s))
+	for i, p := range s {
+		rows[i] = []string{
+			p.Body,
+			p.Time.Format(time.RFC3339),
+			strconv.FormatFloat(p.Altitude, 'f', 3, 64),
+			strconv.FormatFloat(p.Azimuth, 'f', 3, 64),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlSchemaVersion is stored in the meta table; bump it when the schema
+// changes incompatibly.
+const sqlSchemaVersion = 2
+
+// sqlSchema is the SQLite schema of an exported almanac. It is documented
+// in the README; keep the two in step.
+const sqlSchema = `CREATE TABLE IF NOT EXISTS meta (
+  key   TEXT PRIMARY KEY,
+  value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS locations (
+  id        INTEGER PRIMARY KEY,
+  name      TEXT NOT NULL UNIQUE,
+  latitude  REAL NOT NULL,
+  longitude REAL NOT NULL,
+  timezone  TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+  location_id INTEGER NOT NULL REFERENCES locations(id),
+  date        TEXT NOT NULL,  -- local calendar date, YYYY-MM-DD
+  event       TEXT NOT NULL,  -- event expression, e.g. sunrise or sunset-30m
+  time        TEXT,           -- local time, RFC 3339; NULL if it does not occur
+  unix        INTEGER,        -- seconds since 1970-01-01 UTC; NULL likewise
+  status      TEXT,           -- 'none' if it does not occur, else NULL
+  PRIMARY KEY (location_id, date, event)
+);
+CREATE INDEX IF NOT EXISTS events_by_unix ON events(unix);
+`
+
+// writeSQL writes rows as an SQLite script that creates the schema and
+// inserts or replaces the rows, in one transaction.
+func writeSQL(w io.Writer, rows exportRows) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "BEGIN;")
+	bw.WriteString(sqlSchema)
+	fmt.Fprintf(bw, "INSERT OR REPLACE INTO meta VALUES ('schema_version', '%d');\n", sqlSchemaVersion)
+	fmt.Fprintf(bw, "INSERT OR REPLACE INTO meta VALUES ('generated', %s);\n", sqlString(time.Now().UTC().Format(time.RFC3339)))
+
+	seen := map[string]bool{}
+	for _, r := range rows {
+		if !seen[r.Location] {
+			seen[r.Location] = true
+			fmt.Fprintf(bw, "INSERT OR REPLACE INTO locations (id, name, latitude, longitude, timezone) VALUES ((SELECT id FROM locations WHERE name = %[1]s), %[1]s, %s, %s, %s);\n",
+				sqlString(r.Location),
+				strconv.FormatFloat(r.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(r.Longitude, 'f', -1, 64),
+				sqlString(r.TimeZone))
+		}
+
+		t, unix, status := "NULL", "NULL", "NULL"
+		if r.Time != nil {
+			t = sqlString(r.Time.Format(time.RFC3339))
+			unix = strconv.FormatInt(*r.Unix, 10)
+		}
+		if r.Status != "" {
+			status = sqlString(r.Status)
+		}
+		fmt.Fprintf(bw, "INSERT OR REPLACE INTO events (location_id, date, event, time, unix, status) VALUES ((SELECT id FROM locations WHERE name = %s), %s, %s, %s, %s, %s);\n",
+			sqlString(r.Location), sqlString(r.Date), sqlString(r.Event), t, unix, status)
+	}
+	fmt.Fprintln(bw, "COMMIT;")
+	return bw.Flush()
+}
+
+// sqlString quotes s as an SQL string literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// writeSQLiteDB adds rows to the SQLite database at path, creating it if
+// needed, by running the script through the sqlite3 command-line tool.
+func writeSQLiteDB(path string, rows exportRows) error {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return errors.New("writing a .db file needs the sqlite3 tool on PATH; use -format sql and load the script yourself")
+	}
+	var script bytes.Buffer
+	if err := writeSQL(&script, rows); err != nil {
+		return err
+	}
+	cmd := exec.Command(sqlite, "-bail", path)
+	cmd.Stdin = &script
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sqlite3 %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testExportRows returns two rows for one location: a sunrise that occurs
+// and a moonrise that does not.
+func testExportRows(t *testing.T) exportRows {
+	t.Helper()
+	tz, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	rise := time.Date(2025, 6, 21, 5, 19, 0, 0, tz)
+	utc := rise.UTC()
+	unix := rise.Unix()
+	base := exportRow{Location: "O'Hare's", Latitude: 33.4484, Longitude: -112.074, TimeZone: tz.String(), Date: "2025-06-21"}
+
+	sunrise, moonrise := base, base
+	sunrise.Event, sunrise.Time, sunrise.UTC, sunrise.Unix = "sunrise", &rise, &utc, &unix
+	moonrise.Event, moonrise.Status = "moonrise", "none"
+	return exportRows{sunrise, moonrise}
+}
+
+func TestWriteSQL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSQL(&buf, testExportRows(t)); err != nil {
+		t.Fatalf("writeSQL error: %v", err)
+	}
+	script := buf.String()
+
+	for _, want := range []string{
+		"BEGIN;\n",
+		"status      TEXT,",
+		"VALUES ('schema_version', '2');",
+		"'O''Hare''s'", // quotes doubled
+		"'sunrise', '2025-06-21T05:19:00-07:00', 1750508340, NULL);",
+		"'moonrise', NULL, NULL, 'none');",
+		"COMMIT;\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script lacks %q:\n%s", want, script)
+		}
+	}
+	if n := strings.Count(script, "INTO locations"); n != 1 {
+		t.Errorf("%d location inserts, want 1", n)
+	}
+
+	// Load it into a real database when the sqlite3 tool is available.
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("sqlite3 not on PATH")
+	}
+	db := filepath.Join(t.TempDir(), "almanac.db")
+	for i := 0; i < 2; i++ { // a second run replaces the rows
+		cmd := exec.Command(sqlite, "-bail", db)
+		cmd.Stdin = strings.NewReader(script)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("sqlite3 run %d: %v\n%s", i+1, err, out)
+		}
+	}
+	out, err := exec.Command(sqlite, db,
+		"SELECT l.name, e.event, coalesce(e.unix, ''), coalesce(e.status, '') FROM events e JOIN locations l ON l.id = e.location_id ORDER BY e.event").Output()
+	if err != nil {
+		t.Fatalf("sqlite3 query: %v", err)
+	}
+	if got, want := string(out), "O'Hare's|moonrise||none\nO'Hare's|sunrise|1750508340|\n"; got != want {
+		t.Errorf("table rows:\n%s\nwant:\n%s", got, want)
+	}
+}
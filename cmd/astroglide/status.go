@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Status subcommand
+// ---------------------
+
+func runStatus(args []string) {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+
+	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
+	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ"`)
+	outS := fs.String("out", "", "status file to write (default: print once to stdout)")
+	interval := fs.Duration("interval", time.Minute, "how often to rewrite the status file")
+	once := fs.Bool("once", false, "write the status file once and exit")
+	formatS := fs.String("format", "json", "output format: human (shell variables), json, csv or yaml")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide status [flags]
+
+Writes the current Sun and Moon altitude, azimuth and the Moon's phase to
+-out every -interval, for scripts and overlays that poll a file instead of
+linking the library. Each write replaces the file atomically, so readers
+never see a partial one. With -format human the file is a list of
+NAME=value lines that a shell can source.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	format, err := output.Parse(*formatS)
+	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
+	}
+	if *interval <= 0 {
+		log.Fatalf("invalid -interval %v: must be positive", *interval)
+	}
+
+	coords := astroglide.Coordinates{Lat: *lat, Lon: *lon}
+	tz := time.Local
+	if *placeS != "" {
+		coords, tz = lookupPlace(*placeS)
+	}
+
+	if *outS == "" {
+		st, err := currentStatus(coords, time.Now().In(tz))
+		if err != nil {
+			log.Fatalf("status: %v", err)
+		}
+		if err := writeStatus(os.Stdout, format, st); err != nil {
+			log.Fatalf("failed to write output: %v", err)
+		}
+		return
+	}
+
+	for {
+		st, err := currentStatus(coords, time.Now().In(tz))
+		if err != nil {
+			log.Fatalf("status: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := writeStatus(&buf, format, st); err != nil {
+			log.Fatalf("failed to format status: %v", err)
+		}
+		if err := replaceFile(*outS, buf.Bytes()); err != nil {
+			log.Fatalf("failed to write %s: %v", *outS, err)
+		}
+		if *once {
+			return
+		}
+		// Wake on the interval boundary so several instances stay in step.
+		now := time.Now()
+		time.Sleep(now.Truncate(*interval).Add(*interval).Sub(now))
+	}
+}
+
+// statusResult is the content of the status file.
+type statusResult struct {
+	Time         time.Time `json:"time"`
+	Lat          float64   `json:"lat"`
+	Lon          float64   `json:"lon"`
+	SunAltitude  float64   `json:"sun_altitude"`
+	SunAzimuth   float64   `json:"sun_azimuth"`
+	MoonAltitude float64   `json:"moon_altitude"`
+	MoonAzimuth  float64   `json:"moon_azimuth"`
+	MoonPhase    string    `json:"moon_phase"`
+	MoonFraction float64   `json:"moon_fraction"`
+	MoonWaxing   bool      `json:"moon_waxing"`
+}
+
+func currentStatus(coords astroglide.Coordinates, t time.Time) (statusResult, error) {
+	sun, err := astroglide.PositionAt(astroglide.Sun, coords, t, astroglide.Topocentric)
+	if err != nil {
+		return statusResult{}, err
+	}
+	moon, err := astroglide.PositionAt(astroglide.Moon, coords, t, astroglide.Topocentric)
+	if err != nil {
+		return statusResult{}, err
+	}
+	phase, err := astroglide.MoonPhaseAt(t)
+	if err != nil {
+		return statusResult{}, err
+	}
+	return statusResult{
+		Time:         t.Truncate(time.Second),
+		Lat:          coords.Lat,
+		Lon:          coords.Lon,
+		SunAltitude:  round2(sun.Altitude),
+		SunAzimuth:   round2(sun.Azimuth),
+		MoonAltitude: round2(moon.Altitude),
+		MoonAzimuth:  round2(moon.Azimuth),
+		MoonPhase:    phase.Name,
+		MoonFraction: round2(phase.Fraction),
+		MoonWaxing:   phase.Waxing,
+	}, nil
+}
+
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+func (s statusResult) Header() []string {
+	return []string{"time", "lat", "lon", "sun_altitude", "sun_azimuth", "moon_altitude", "moon_azimuth", "moon_phase", "moon_fraction", "moon_waxing"}
+}
+
+func (s statusResult) Rows() [][]string {
+	f := func(x float64) string { return strconv.FormatFloat(x, 'f', -1, 64) }
+	return [][]string{{
+		s.Time.Format(time.RFC3339), f(s.Lat), f(s.Lon),
+		f(s.SunAltitude), f(s.SunAzimuth), f(s.MoonAltitude), f(s.MoonAzimuth),
+		s.MoonPhase, f(s.MoonFraction), strconv.FormatBool(s.MoonWaxing),
+	}}
+}
+
+// writeStatus writes st in format; Human is NAME=value lines for sh.
+func writeStatus(w io.Writer, format output.Format, st statusResult) error {
+	if format != output.Human {
+		return output.Write(w, format, st)
+	}
+	header, row := st.Header(), st.Rows()[0]
+	for i, name := range header {
+		if _, err := fmt.Fprintf(w, "ASTROGLIDE_%s=%q\n", strings.ToUpper(name), row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceFile writes data to a temporary file next to name and renames it
+// over name, so that readers see either the old or the new content.
+func replaceFile(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Sun path subcommand
+// ---------------------
+
+func runSunPath(args []string) {
+	fs := flag.NewFlagSet("sunpath", flag.ExitOnError)
+
+	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
+	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ" (also sets the time zone)`)
+	year := fs.Int("year", time.Now().Year(), "year of the diagram")
+	tzName := fs.String("tz", "Local", "IANA time zone for the dates (e.g. America/Phoenix)")
+	outS := fs.String("o", "sunpath.svg", `output file ("-" for stdout)`)
+	formatS := fs.String("format", "svg", "svg, or json, csv or yaml for the underlying data")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide sunpath [flags]
+
+Draws a stereographic sun-path diagram: the Sun's arc on the 21st of each
+month (the equinox and solstice days in March, June, September and
+December) and the hour lines of local apparent time, seen from above with
+north up.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	svg := strings.EqualFold(strings.TrimSpace(*formatS), "svg")
+	var format output.Format
+	if !svg {
+		var err error
+		if format, err = output.Parse(*formatS); err != nil || format == output.Human {
+			log.Fatalf("invalid -format %q (use svg, json, csv or yaml)", *formatS)
+		}
+	}
+
+	coords := astroglide.Coordinates{Lat: *lat, Lon: *lon}
+	var tz *time.Location
+	if *placeS != "" {
+		coords, tz = lookupPlace(*placeS)
+	} else {
+		var err error
+		if tz, err = loadLocation(*tzName); err != nil {
+			log.Fatalf("invalid time zone %q: %v", *tzName, err)
+		}
+	}
+
+	sp, err := astroglide.SunPathData(coords, *year, tz)
+	if err != nil {
+		log.Fatalf("error computing sun path: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *outS != "-" {
+		f, err := os.Create(*outS)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+		w = f
+	}
+
+	if svg {
+		err = writeSunPathSVG(w, sp, *placeS)
+	} else {
+		err = output.Write(w, format, sunPathResult(sp))
+	}
+	if err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+}
+
+// Layout of the SVG diagram, in user units.
+const (
+	svgSize   = 800
+	svgRadius = 340
+)
+
+// project maps altitude/azimuth to diagram coordinates with a stereographic
+// projection centred on the zenith: north up, east right.
+func project(alt, az float64) (x, y float64) {
+	r := svgRadius * math.Tan((90-alt)*math.Pi/360)
+	a := az * math.Pi / 180
+	return svgSize/2 + r*math.Sin(a), svgSize/2 - r*math.Cos(a)
+}
+
+func writeSunPathSVG(w io.Writer, sp astroglide.SunPath, place string) error {
+	bw := bufio.NewWriter(w)
+	c := svgSize / 2
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", svgSize, svgSize+40)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+
+	title := fmt.Sprintf("Sun path %d, %.4f°, %.4f°", sp.Year, sp.Coordinates.Lat, sp.Coordinates.Lon)
+	if place != "" {
+		title = fmt.Sprintf("Sun path %d, %s", sp.Year, place)
+	}
+	fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" font-size="16">%s</text>`+"\n", c, svgSize+25, xmlEscape(title))
+
+	// Altitude circles every 10° and azimuth spokes every 30°.
+	fmt.Fprintln(bw, `<g fill="none" stroke="#ccc">`)
+	for alt := 10; alt < 90; alt += 10 {
+		_, y := project(float64(alt), 0)
+		fmt.Fprintf(bw, `<circle cx="%d" cy="%d" r="%.1f"/>`+"\n", c, c, float64(c)-y)
+	}
+	for az := 0; az < 360; az += 30 {
+		x, y := project(0, float64(az))
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%.1f" y2="%.1f"/>`+"\n", c, c, x, y)
+	}
+	fmt.Fprintln(bw, `</g>`)
+	fmt.Fprintf(bw, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="black" stroke-width="1.5"/>`+"\n", c, c, svgRadius)
+
+	fmt.Fprintln(bw, `<g fill="#888" font-size="10">`)
+	for alt := 10; alt < 90; alt += 10 {
+		x, y := project(float64(alt), 0)
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" dx="3" dy="-2">%d°</text>`+"\n", x, y, alt)
+	}
+	fmt.Fprintln(bw, `</g>`)
+	for az := 0; az < 360; az += 30 {
+		x, y := project(-6, float64(az))
+		label := map[int]string{0: "N", 90: "E", 180: "S", 270: "W"}[az]
+		if label == "" {
+			label = strconv.Itoa(az) + "°"
+		}
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n", x, y, label)
+	}
+
+	// Hour lines under the date arcs, labelled at their June end.
+	fmt.Fprintln(bw, `<g fill="none" stroke="#e08a00" stroke-width="1">`)
+	for _, line := range sp.HourLines {
+		fmt.Fprintf(bw, `<polyline points="%s"/>`+"\n", svgPoints(line.Points))
+	}
+	fmt.Fprintln(bw, `</g>`)
+	fmt.Fprintln(bw, `<g fill="#b06000" font-size="10" text-anchor="middle">`)
+	for _, line := range sp.HourLines {
+		p := line.Points[0]
+		x, y := project(p.Altitude, p.Azimuth)
+		fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" dy="-4">%s</text>`+"\n", x, y, line.Label)
+	}
+	fmt.Fprintln(bw, `</g>`)
+
+	// Date arcs; the equinoxes and solstices are drawn heavier.
+	for _, arc := range sp.DateArcs {
+		color, width := "#3060c0", "1"
+		if m := arc.Points[0].Time.Month(); m%3 == 0 {
+			color, width = "#c03030", "2"
+		}
+		fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%s"/>`+"\n", svgPoints(arc.Points), color, width)
+		if m := arc.Points[0].Time.Month(); m >= time.June && m <= time.December {
+			// Label one of each pair of months with similar arcs.
+			top := arc.Points[0]
+			for _, p := range arc.Points {
+				if p.Altitude > top.Altitude {
+					top = p
+				}
+			}
+			x, y := project(top.Altitude, top.Azimuth)
+			fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" dy="-4" text-anchor="middle" fill="%s">%s</text>`+"\n", x, y, color, arc.Label)
+		}
+	}
+
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+func svgPoints(points []astroglide.SunPathPoint) string {
+	var b strings.Builder
+	for i, p := range points {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		x, y := project(p.Altitude, p.Azimuth)
+		fmt.Fprintf(&b, "%.1f,%.1f", x, y)
+	}
+	return b.String()
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
+// sunPathRow is one sampled position in structured output.
+type sunPathRow struct {
+	Curve    string    `json:"curve"` // "date" or "hour"
+	Label    string    `json:"label"`
+	Time     time.Time `json:"time"`
+	Altitude float64   `json:"altitude"`
+	Azimuth  float64   `json:"azimuth"`
+}
+
+type sunPathRows []sunPathRow
+
+func sunPathResult(sp astroglide.SunPath) sunPathRows {
+	var rows sunPathRows
+	for _, curves := range []struct {
+		kind string
+		arcs []astroglide.SunPathArc
+	}{{"date", sp.DateArcs}, {"hour", sp.HourLines}} {
+		for _, arc := range curves.arcs {
+			for _, p := range arc.Points {
+				rows = append(rows, sunPathRow{Curve: curves.kind, Label: arc.Label, Time: p.Time, Altitude: p.Altitude, Azimuth: p.Azimuth})
+			}
+		}
+	}
+	return rows
+}
+
+func (r sunPathRows) Header() []string {
+	return []string{"curve", "label", "time", "altitude", "azimuth"}
+}
+
+func (r sunPathRows) Rows() [][]string {
+	rows := make([][]string, len(r))
+	for i, p := range r {
+		rows[i] = []string{
+			p.Curve,
+			p.Label,
+			p.Time.Format(time.RFC3339),
+			strconv.FormatFloat(p.Altitude, 'f', 3, 64),
+			strconv.FormatFloat(p.Azimuth, 'f', 3, 64),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,8 @@
+//go:build tzdata
+
+package main
+
+// Building with -tags tzdata embeds the IANA time zone database (about
+// 450 KB), so -tz and -place work in scratch containers and on Windows
+// machines without one. Go's own -tags timetzdata does the same.
+import _ "time/tzdata"
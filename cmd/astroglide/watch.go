@@ -0,0 +1,218 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+	"github.com/thurmanmarka/astroglide/cmd/internal/output"
+)
+
+// ---------------------
+// Watch subcommand
+// ---------------------
+
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+
+	lat := fs.Float64("lat", 0, "latitude in degrees (north positive)")
+	lon := fs.Float64("lon", 0, "longitude in degrees (east positive, west negative)")
+	placeS := fs.String("place", "", `place name instead of -lat/-lon, e.g. "Phoenix, AZ"`)
+	eventsS := fs.String("events", "sunrise,sunset", "comma-separated events with optional offsets, e.g. sunset-30m,civil_dusk")
+	cronS := fs.String("cron", "", `semicolon-separated cron expressions, e.g. "@sunset-30m * * 1-5; 0 7 * * *" (replaces -events)`)
+	list := fs.Int("list", 0, "print the next N firing times and exit")
+	execS := fs.String("exec", "", "shell command to run at each event (default: print a line)")
+	count := fs.Int("count", 0, "exit after this many events (0 = run forever)")
+	formatS := fs.String("format", "human", output.Usage+" (for the printed lines)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: astroglide watch [flags]
+
+Sleeps until each configured event and runs -exec (via sh -c) or prints a
+line. The command sees ASTROGLIDE_EVENT and ASTROGLIDE_TIME (RFC3339).
+With -format json, each printed line is a JSON object.
+
+Events: %s.
+Append an offset such as -30m or +1h15m.
+
+-cron takes cron expressions whose minute and hour may be replaced by an
+event token: "@sunset-30m * * 1-5" fires half an hour before sunset on
+weekdays, "30 6 * * *" at 06:30 every day.
+
+Flags:
+`, strings.Join(astroglide.EventExprNames(), ", "))
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	format, err := output.Parse(*formatS)
+	if err != nil {
+		log.Fatalf("invalid -format: %v", err)
+	}
+	var stream *output.Stream
+	if format != output.Human {
+		stream = output.NewStream(os.Stdout, format)
+	}
+
+	var schedules []schedule
+	if *cronS != "" {
+		for _, expr := range strings.Split(*cronS, ";") {
+			if strings.TrimSpace(expr) == "" {
+				continue
+			}
+			c, err := astroglide.ParseCron(expr)
+			if err != nil {
+				log.Fatalf("invalid -cron: %v", err)
+			}
+			schedules = append(schedules, c)
+		}
+	} else {
+		events, err := parseEventExprs(*eventsS)
+		if err != nil {
+			log.Fatalf("invalid -events: %v", err)
+		}
+		for _, ev := range events {
+			schedules = append(schedules, ev)
+		}
+	}
+	if len(schedules) == 0 {
+		log.Fatalf("no events to watch")
+	}
+
+	coords := astroglide.Coordinates{Lat: *lat, Lon: *lon}
+	tz := time.Local
+	if *placeS != "" {
+		coords, tz = lookupPlace(*placeS)
+	}
+
+	if *list > 0 {
+		now := time.Now().In(tz)
+		for listed := 0; listed < *list; {
+			due, at := nextFiring(schedules, coords, now)
+			for _, sched := range due {
+				if listed == *list {
+					break
+				}
+				fire(sched, at, "", stream)
+				listed++
+			}
+			now = at
+		}
+		return
+	}
+
+	after := time.Now().In(tz)
+	for fired := 0; *count == 0 || fired < *count; {
+		due, at := nextFiring(schedules, coords, after)
+		time.Sleep(time.Until(at))
+		for _, sched := range due {
+			if *count != 0 && fired == *count {
+				break
+			}
+			fire(sched, at, *execS, stream)
+			fired++
+		}
+		// Search on from the firing time, so a schedule due at the same
+		// instant is not skipped, unless the hooks ran past later events.
+		after = at
+		if now := time.Now().In(tz); now.After(after) {
+			after = now
+		}
+	}
+}
+
+// schedule is an event expression or a cron expression.
+type schedule interface {
+	Next(loc astroglide.Coordinates, after time.Time) (time.Time, error)
+	String() string
+}
+
+// nextFiring returns the schedules that fire first after now, in the
+// order given, and when. Several are returned when they fall due at the
+// same instant.
+func nextFiring(schedules []schedule, coords astroglide.Coordinates, now time.Time) ([]schedule, time.Time) {
+	var (
+		next   []schedule
+		nextAt time.Time
+	)
+	for _, s := range schedules {
+		at, err := s.Next(coords, now)
+		if err != nil {
+			log.Printf("%s: %v", s, err)
+			continue
+		}
+		switch {
+		case nextAt.IsZero() || at.Before(nextAt):
+			next, nextAt = []schedule{s}, at
+		case at.Equal(nextAt):
+			next = append(next, s)
+		}
+	}
+	if nextAt.IsZero() {
+		log.Fatalf("none of the configured events occur at this location")
+	}
+	return next, nextAt
+}
+
+// fire runs the hook for an event, or prints it when no hook is set. A
+// non-nil stream prints in that format instead of the plain line.
+func fire(ev schedule, at time.Time, command string, stream *output.Stream) {
+	stamp := at.Format(time.RFC3339)
+	if command == "" {
+		if stream == nil {
+			fmt.Printf("%s %s\n", stamp, ev)
+			return
+		}
+		if err := stream.Write(watchEvent{Event: ev.String(), Time: at}); err != nil {
+			log.Printf("%s: failed to write output: %v", ev, err)
+		}
+		return
+	}
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"ASTROGLIDE_EVENT="+ev.String(),
+		"ASTROGLIDE_TIME="+stamp,
+	)
+	if err := cmd.Run(); err != nil {
+		log.Printf("%s: command failed: %v", ev, err)
+	}
+}
+
+// watchEvent is one fired event in structured output.
+type watchEvent struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+}
+
+func (e watchEvent) Header() []string { return []string{"event", "time"} }
+
+func (e watchEvent) Rows() [][]string {
+	return [][]string{{e.Event, e.Time.Format(time.RFC3339)}}
+}
+
+// parseEventExprs parses a comma-separated list of event expressions.
+func parseEventExprs(list string) ([]astroglide.EventExpr, error) {
+	var exprs []astroglide.EventExpr
+	for _, s := range strings.Split(list, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		e, err := astroglide.ParseEventExpr(s)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	return exprs, nil
+}
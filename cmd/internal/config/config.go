@@ -0,0 +1,235 @@
+// Package config reads and writes the astroglide CLI's config file, which
+// holds defaults for the common flags and named places:
+//
+//	# ~/.config/astroglide/config.toml
+//	place = "home"          # default -place
+//	tz = "America/Phoenix"  # time zone used where a command defaults to local time
+//	format = "human"        # default -format
+//
+//	[places.home]
+//	lat = 33.45
+//	lon = -112.07
+//	tz = "America/Phoenix"
+//
+// Instead of place, lat and lon may be given at the top level. The file is
+// a small subset of TOML: top-level keys, [places.NAME] tables, and string
+// and number values. Save rewrites the file and drops comments.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Place is a named location.
+type Place struct {
+	Lat, Lon float64
+	TZ       string // IANA time zone; empty for the default
+}
+
+// Config is the content of a config file.
+type Config struct {
+	// Defaults maps flag names (lat, lon, place, format) to their default
+	// values as they would be typed on the command line.
+	Defaults map[string]string
+
+	// TZ is the IANA time zone that replaces the system's local time zone.
+	TZ string
+
+	Places map[string]Place
+}
+
+// DefaultPath returns the config file's standard location,
+// $XDG_CONFIG_HOME/astroglide/config.toml (~/.config on Unix, %AppData% on
+// Windows), or "" if there is no home directory.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "astroglide", "config.toml")
+}
+
+// Load reads the config file at path. A missing file gives an empty
+// Config and no error.
+func Load(path string) (*Config, error) {
+	c := &Config{Defaults: map[string]string{}, Places: map[string]Place{}}
+	if path == "" {
+		return c, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := c.parse(f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+func (c *Config) parse(r io.Reader) error {
+	var (
+		place   string // current [places.NAME] table, "" at the top level
+		p       Place
+		lineNum int
+	)
+	flush := func() {
+		if place != "" {
+			c.Places[place] = p
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lineNum++
+		line := stripComment(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			name, ok := strings.CutPrefix(strings.TrimSuffix(line, "]"), "[places.")
+			if !ok || !strings.HasSuffix(line, "]") || name == "" {
+				return fmt.Errorf("line %d: want [places.NAME], got %s", lineNum, line)
+			}
+			flush()
+			place, p = unquote(strings.TrimSpace(name)), Place{}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: want key = value", lineNum)
+		}
+		key, raw = unquote(strings.TrimSpace(key)), strings.TrimSpace(raw)
+		val := unquote(raw)
+
+		if place == "" {
+			switch key {
+			case "lat", "lon":
+				if _, err := strconv.ParseFloat(val, 64); err != nil {
+					return fmt.Errorf("line %d: %s: %v", lineNum, key, err)
+				}
+				c.Defaults[key] = val
+			case "place", "format":
+				c.Defaults[key] = val
+			case "tz":
+				c.TZ = val
+			default:
+				return fmt.Errorf("line %d: unknown key %q", lineNum, key)
+			}
+			continue
+		}
+
+		switch key {
+		case "lat", "lon":
+			x, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return fmt.Errorf("line %d: %s: %v", lineNum, key, err)
+			}
+			if key == "lat" {
+				p.Lat = x
+			} else {
+				p.Lon = x
+			}
+		case "tz":
+			p.TZ = val
+		default:
+			return fmt.Errorf("line %d: unknown key %q in [places.%s]", lineNum, key, place)
+		}
+	}
+	flush()
+	return sc.Err()
+}
+
+// stripComment removes a # comment outside quotes and surrounding space.
+func stripComment(line string) string {
+	inString, escaped := false, false
+	for i, r := range line {
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch r {
+		case '\\':
+			escaped = inString
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return strings.TrimSpace(line[:i])
+			}
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// unquote removes the double quotes around a TOML basic string.
+func unquote(s string) string {
+	if u, err := strconv.Unquote(s); err == nil && strings.HasPrefix(s, `"`) {
+		return u
+	}
+	return s
+}
+
+// Save writes c to path, creating its directory.
+func (c *Config) Save(path string) error {
+	if path == "" {
+		return errors.New("no config file path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, key := range []string{"place", "lat", "lon", "format"} {
+		v, ok := c.Defaults[key]
+		if !ok {
+			continue
+		}
+		if key == "lat" || key == "lon" {
+			fmt.Fprintf(&b, "%s = %s\n", key, v)
+		} else {
+			fmt.Fprintf(&b, "%s = %q\n", key, v)
+		}
+	}
+	if c.TZ != "" {
+		fmt.Fprintf(&b, "tz = %q\n", c.TZ)
+	}
+
+	names := make([]string, 0, len(c.Places))
+	for name := range c.Places {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := c.Places[name]
+		fmt.Fprintf(&b, "\n[places.%s]\nlat = %s\nlon = %s\n", tableKey(name),
+			strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Lon, 'f', -1, 64))
+		if p.TZ != "" {
+			fmt.Fprintf(&b, "tz = %q\n", p.TZ)
+		}
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// tableKey quotes name unless it is a bare TOML key.
+func tableKey(name string) string {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return strconv.Quote(name)
+		}
+	}
+	return name
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want Config
+	}{
+		{
+			name: "top-level defaults",
+			file: `place = "home"
+format = "json"
+tz = "America/Phoenix"
+lat = 33.45
+lon = -112.07`,
+			want: Config{
+				Defaults: map[string]string{"place": "home", "format": "json", "lat": "33.45", "lon": "-112.07"},
+				TZ:       "America/Phoenix",
+			},
+		},
+		{
+			name: "comments and blank lines",
+			file: `# a comment line
+
+format = "csv"   # trailing comment
+place = "#1 spot" # a # inside quotes stays
+place = "say \"#hi\"" # escaped quotes too
+`,
+			want: Config{Defaults: map[string]string{"format": "csv", "place": `say "#hi"`}},
+		},
+		{
+			name: "places tables",
+			file: `[places.home]
+lat = 33.45
+lon = -112.07
+tz = "America/Phoenix"
+
+[places.cabin]
+lat = 44.27
+lon = -71.3`,
+			want: Config{Places: map[string]Place{
+				"home":  {Lat: 33.45, Lon: -112.07, TZ: "America/Phoenix"},
+				"cabin": {Lat: 44.27, Lon: -71.3},
+			}},
+		},
+		{
+			name: "quoted keys",
+			file: `"format" = "yaml"
+[places."my cabin"]
+"lat" = 1.5
+lon = 2
+[places."Zürich, CH"]
+lat = 47.37
+lon = 8.54`,
+			want: Config{
+				Defaults: map[string]string{"format": "yaml"},
+				Places: map[string]Place{
+					"my cabin":   {Lat: 1.5, Lon: 2},
+					"Zürich, CH": {Lat: 47.37, Lon: 8.54},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := &Config{Defaults: map[string]string{}, Places: map[string]Place{}}
+		if err := got.parse(strings.NewReader(tt.file)); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		want := tt.want
+		if want.Defaults == nil {
+			want.Defaults = map[string]string{}
+		}
+		if want.Places == nil {
+			want.Places = map[string]Place{}
+		}
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("%s:\n got %+v\nwant %+v", tt.name, *got, want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		file, want string
+	}{
+		{"format", "line 1: want key = value"},
+		{"color = \"red\"", `line 1: unknown key "color"`},
+		{"lat = north", "line 1: lat"},
+		{"[places]", "line 1: want [places.NAME]"},
+		{"[other.home]", "line 1: want [places.NAME]"},
+		{"[places.home", "line 1: want [places.NAME]"},
+		{"[places.]", "line 1: want [places.NAME]"},
+		{"[places.home]\nformat = \"json\"", `line 2: unknown key "format" in [places.home]`},
+		{"\n\n[places.home]\nlon = west", "line 4: lon"},
+	}
+	for _, tt := range tests {
+		c := &Config{Defaults: map[string]string{}, Places: map[string]Place{}}
+		err := c.parse(strings.NewReader(tt.file))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parse(%q) = %v, want an error containing %q", tt.file, err, tt.want)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "astroglide", "config.toml")
+
+	// A missing file is an empty config.
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of a missing file: %v", err)
+	}
+	if len(c.Defaults) != 0 || len(c.Places) != 0 || c.TZ != "" {
+		t.Errorf("missing file gave %+v", c)
+	}
+
+	c.Defaults["place"] = "home"
+	c.Defaults["format"] = "json"
+	c.Defaults["lat"] = "33.45"
+	c.TZ = "America/Phoenix"
+	c.Places["home"] = Place{Lat: 33.45, Lon: -112.07, TZ: "America/Phoenix"}
+	c.Places["my cabin"] = Place{Lat: 44.27, Lon: -71.3}
+	c.Places[`say "#hi"`] = Place{Lat: -1, Lon: 1}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	back, err := Load(path)
+	if err != nil {
+		b, _ := os.ReadFile(path)
+		t.Fatalf("Load: %v\n%s", err, b)
+	}
+	if !reflect.DeepEqual(back, c) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", back, c)
+	}
+
+	if err := os.WriteFile(path, []byte("lat = 1\nbogus\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path+": line 2") {
+		t.Errorf("Load of a malformed file: err = %v, want the path and line", err)
+	}
+}
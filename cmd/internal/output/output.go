@@ -0,0 +1,292 @@
+// Package output writes command results as JSON, CSV or YAML, so every
+// astroglide command offers the same -format choices. Human-readable output
+// stays with each command, since its layout differs per command.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format name as given to -format.
+type Format string
+
+const (
+	Human Format = "human"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	YAML  Format = "yaml"
+)
+
+// Usage is the help text for a -format flag.
+const Usage = "output format: human, json, csv or yaml"
+
+// Parse returns the format named by s (case-insensitive).
+func Parse(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case Human, JSON, CSV, YAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (use human, json, csv or yaml)", s)
+	}
+}
+
+// Tabular is implemented by results that can be written as CSV: one header
+// row and any number of data rows of the same width.
+type Tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// Write writes v in format f. JSON and YAML use v's JSON encoding (field
+// names and order come from its json tags); CSV requires v to implement
+// Tabular. Human is the caller's job and returns an error here.
+func Write(w io.Writer, f Format, v interface{}) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case YAML:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return writeYAML(w, b)
+
+	case CSV:
+		t, ok := v.(Tabular)
+		if !ok {
+			return fmt.Errorf("%T cannot be written as CSV", v)
+		}
+		cw := csv.NewWriter(w)
+		cw.Write(t.Header())
+		cw.WriteAll(t.Rows())
+		return cw.Error()
+
+	default:
+		return fmt.Errorf("output.Write: unsupported format %q", f)
+	}
+}
+
+// Stream writes a sequence of results one at a time, for commands such as
+// watch that produce output as they go. JSON is written as one compact
+// object per line, YAML as list items and CSV with a single header.
+type Stream struct {
+	w           io.Writer
+	f           Format
+	wroteHeader bool
+}
+
+// NewStream returns a Stream writing format f to w.
+func NewStream(w io.Writer, f Format) *Stream {
+	return &Stream{w: w, f: f}
+}
+
+// Write writes one result.
+func (s *Stream) Write(v interface{}) error {
+	switch s.f {
+	case JSON:
+		return json.NewEncoder(s.w).Encode(v)
+
+	case YAML:
+		b, err := json.Marshal([]interface{}{v})
+		if err != nil {
+			return err
+		}
+		return writeYAML(s.w, b)
+
+	case CSV:
+		t, ok := v.(Tabular)
+		if !ok {
+			return fmt.Errorf("%T cannot be written as CSV", v)
+		}
+		cw := csv.NewWriter(s.w)
+		if !s.wroteHeader {
+			cw.Write(t.Header())
+			s.wroteHeader = true
+		}
+		cw.WriteAll(t.Rows())
+		return cw.Error()
+
+	default:
+		return fmt.Errorf("output.Stream: unsupported format %q", s.f)
+	}
+}
+
+// writeYAML converts a JSON document to block-style YAML, keeping the key
+// order of the JSON. Strings are written as double-quoted scalars, whose
+// escapes are a superset of JSON's.
+func writeYAML(w io.Writer, doc []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	v, err := readJSON(dec, tok)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	emitYAML(&buf, v, 0, false)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// object is a JSON object with its key order preserved.
+type object struct {
+	keys   []string
+	values []interface{}
+}
+
+// readJSON reads the value starting at tok.
+func readJSON(dec *json.Decoder, tok json.Token) (interface{}, error) {
+	switch tok {
+	case json.Delim('{'):
+		obj := &object{}
+		for dec.More() {
+			kt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			vt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := readJSON(dec, vt)
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, kt.(string))
+			obj.values = append(obj.values, v)
+		}
+		_, err := dec.Token() // '}'
+		return obj, err
+
+	case json.Delim('['):
+		var list []interface{}
+		for dec.More() {
+			vt, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := readJSON(dec, vt)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		_, err := dec.Token() // ']'
+		return list, err
+
+	default:
+		return tok, nil
+	}
+}
+
+// emitYAML writes v at the given indentation. inline is set when v follows
+// a "- " or "key: " on the same line.
+func emitYAML(buf *bytes.Buffer, v interface{}, indent int, inline bool) {
+	pad := strings.Repeat("  ", indent)
+
+	switch v := v.(type) {
+	case *object:
+		if len(v.keys) == 0 {
+			buf.WriteString("{}\n")
+			return
+		}
+		for i, k := range v.keys {
+			if i > 0 || !inline {
+				buf.WriteString(pad)
+			}
+			buf.WriteString(yamlKey(k) + ":")
+			emitNested(buf, v.values[i], indent+1)
+		}
+
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString("[]\n")
+			return
+		}
+		for i, item := range v {
+			if i > 0 || !inline {
+				buf.WriteString(pad)
+			}
+			buf.WriteString("- ")
+			if isCollection(item) {
+				emitYAML(buf, item, indent+1, true)
+			} else {
+				buf.WriteString(yamlScalar(item) + "\n")
+			}
+		}
+
+	default:
+		buf.WriteString(yamlScalar(v) + "\n")
+	}
+}
+
+// emitNested writes a mapping value after "key:".
+func emitNested(buf *bytes.Buffer, v interface{}, indent int) {
+	if !isCollection(v) || isEmpty(v) {
+		buf.WriteString(" ")
+		emitYAML(buf, v, indent, true)
+		return
+	}
+	buf.WriteString("\n")
+	emitYAML(buf, v, indent, false)
+}
+
+func isCollection(v interface{}) bool {
+	switch v.(type) {
+	case *object, []interface{}:
+		return true
+	}
+	return false
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case *object:
+		return len(v.keys) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// yamlKey quotes keys that are not plain identifiers.
+func yamlKey(k string) string {
+	for _, r := range k {
+		if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return strconv.Quote(k)
+		}
+	}
+	if k == "" {
+		return `""`
+	}
+	return k
+}
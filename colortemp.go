@@ -0,0 +1,60 @@
+package astroglide
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// daylightCCTTable is an empirical curve of the correlated colour
+// temperature (kelvin) of daylight against the Sun's altitude, for lighting
+// that follows the day: about 5700 K with the Sun high, falling as its
+// light crosses more air and Rayleigh scattering strips the blue, to
+// 2000 K at sunset and 1800 K by the end of civil twilight.
+var daylightCCTTable = []struct{ alt, kelvin float64 }{
+	{-6, 1800},
+	{-3, 1900},
+	{0, 2000},
+	{2, 2500},
+	{5, 3000},
+	{10, 3800},
+	{20, 4500},
+	{30, 5000},
+	{45, 5400},
+	{60, 5600},
+	{90, 5700},
+}
+
+// DaylightColorTemperature estimates the colour temperature in kelvin of
+// natural light at loc at time t, for circadian-lighting integrations that
+// warm and cool lamps with the day. It depends only on the Sun's altitude:
+// cool white with the Sun high, warm near sunrise and sunset, and 1800 K
+// from the end of civil twilight through the night.
+//
+// This is a documented empirical model of the warm direct-plus-sky light
+// that lighting schemes imitate, not a measurement: clouds, haze and the
+// bluish twilight sky itself (which can exceed 10000 K) are not modelled.
+func DaylightColorTemperature(loc Coordinates, t time.Time) (float64, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return 0, err
+	}
+	return daylightCCT(sun.AltitudeAt(loc.Lat, loc.Lon, t)), nil
+}
+
+// daylightCCT interpolates daylightCCTTable in mireds (1e6/K), the scale on
+// which equal steps look equally different.
+func daylightCCT(alt float64) float64 {
+	tbl := daylightCCTTable
+	if alt <= tbl[0].alt {
+		return tbl[0].kelvin
+	}
+	if alt >= tbl[len(tbl)-1].alt {
+		return tbl[len(tbl)-1].kelvin
+	}
+	i := sort.Search(len(tbl), func(i int) bool { return tbl[i].alt > alt })
+	lo, hi := tbl[i-1], tbl[i]
+	f := (alt - lo.alt) / (hi.alt - lo.alt)
+	mired := 1e6/lo.kelvin + f*(1e6/hi.kelvin-1e6/lo.kelvin)
+	return 1e6 / mired
+}
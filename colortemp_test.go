@@ -0,0 +1,51 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDaylightColorTemperature(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	noon, err := SolarNoon(phx, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, _ := DaylightColorTemperature(phx, noon); k < 5500 || k > 5700 {
+		t.Errorf("noon CCT = %.0f K, want about 5600", k)
+	}
+
+	rs, err := RiseSetFor(Sun, phx, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, _ := DaylightColorTemperature(phx, rs.Set); math.Abs(k-1950) > 100 {
+		t.Errorf("sunset CCT = %.0f K, want about 2000", k)
+	}
+	if k, _ := DaylightColorTemperature(phx, noon.Add(12*time.Hour)); k != 1800 {
+		t.Errorf("midnight CCT = %.0f K, want 1800", k)
+	}
+
+	if _, err := DaylightColorTemperature(Coordinates{Lat: 100}, noon); err == nil {
+		t.Error("invalid coordinates accepted")
+	}
+}
+
+func TestDaylightCCTMonotonic(t *testing.T) {
+	prev := 0.0
+	for alt := -10.0; alt <= 90; alt += 0.5 {
+		k := daylightCCT(alt)
+		if k < prev {
+			t.Fatalf("CCT falls from %.0f to %.0f K at %.1f°", prev, k, alt)
+		}
+		prev = k
+	}
+	for _, p := range daylightCCTTable {
+		if math.Abs(daylightCCT(p.alt)-p.kelvin) > 1e-6 {
+			t.Errorf("CCT at %v° = %v, want table value %v", p.alt, daylightCCT(p.alt), p.kelvin)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package astroglide
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Constellation is one of the 88 IAU constellations.
+type Constellation struct {
+	Abbrev string // IAU three-letter abbreviation, e.g. "Tau"
+	Name   string // Latin name, e.g. "Taurus"
+}
+
+// String returns the constellation's name.
+func (c Constellation) String() string { return c.Name }
+
+// constellationData is Roman's table of the IAU boundaries.
+//
+//go:embed data/constellations.txt
+var constellationData []byte
+
+// constellationRow is a band of the boundary table: positions at or north
+// of decLow with raLow <= RA < raHigh (hours, B1875.0) lie in abbrev,
+// unless an earlier row claimed them.
+type constellationRow struct {
+	raLow, raHigh, decLow float64
+	abbrev                string
+}
+
+var (
+	constellationOnce sync.Once
+	constellationRows []constellationRow
+	constellationErr  error
+)
+
+// b1875 is the epoch of the boundary table, B1875.0 (JD 2405889.2585).
+var b1875 = timeutil.TimeFromJulianDay(2405889.258550475)
+
+// ConstellationAt returns the constellation containing the J2000.0 (ICRS)
+// position ra, dec in degrees, using the official IAU boundaries as
+// tabulated by Roman (1987). For coordinates of date, such as those of
+// EquatorialOfDate, use ConstellationOfDate.
+func ConstellationAt(ra, dec float64) (Constellation, error) {
+	return ConstellationOfDate(Equatorial{RA: ra, Dec: dec}, J2000)
+}
+
+// ConstellationOfDate returns the constellation containing eq, whose
+// coordinates are referred to the equinox of t.
+func ConstellationOfDate(eq Equatorial, t time.Time) (Constellation, error) {
+	constellationOnce.Do(loadConstellations)
+	if constellationErr != nil {
+		return Constellation{}, constellationErr
+	}
+	if eq.Dec < -90 || eq.Dec > 90 {
+		return Constellation{}, fmt.Errorf("declination %.3f° out of range", eq.Dec)
+	}
+
+	// The boundaries are lines of constant RA and Dec for the equinox of
+	// B1875.0, so precess the position there.
+	ra, dec := coord.Precess(timeutil.Normalize360(eq.RA), eq.Dec, t, b1875)
+	raH := timeutil.Normalize360(ra) / 15
+	for _, r := range constellationRows {
+		if dec >= r.decLow && raH >= r.raLow && raH < r.raHigh {
+			return constellationByAbbrev(r.abbrev), nil
+		}
+	}
+	// The last row covers the whole south polar cap, so this is not reached.
+	return Constellation{}, fmt.Errorf("no constellation at RA %.3f°, Dec %.3f°", eq.RA, eq.Dec)
+}
+
+// MoonConstellation returns the constellation the Moon is in at t, as seen
+// from the Earth's centre; topocentric parallax can move it up to a degree,
+// which matters only near a boundary.
+func MoonConstellation(t time.Time) (Constellation, error) {
+	return bodyConstellation(Moon, t)
+}
+
+// SunConstellation returns the constellation the Sun is in at t. These are
+// the astronomical constellations, including Ophiuchus, not the signs of
+// the zodiac.
+func SunConstellation(t time.Time) (Constellation, error) {
+	return bodyConstellation(Sun, t)
+}
+
+func bodyConstellation(body Body, t time.Time) (Constellation, error) {
+	eq, err := EquatorialOfDate(body, t)
+	if err != nil {
+		return Constellation{}, err
+	}
+	return ConstellationOfDate(eq, t)
+}
+
+func loadConstellations() {
+	sc := bufio.NewScanner(bytes.NewReader(constellationData))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) != 4 {
+			constellationErr = fmt.Errorf("constellation boundaries line %d: %d fields, want 4", n, len(f))
+			return
+		}
+		raLow, err1 := strconv.ParseFloat(f[0], 64)
+		raHigh, err2 := strconv.ParseFloat(f[1], 64)
+		decLow, err3 := strconv.ParseFloat(f[2], 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			constellationErr = fmt.Errorf("constellation boundaries line %d: bad number", n)
+			return
+		}
+		if constellationByAbbrev(f[3]).Name == "" {
+			constellationErr = fmt.Errorf("constellation boundaries line %d: unknown constellation %q", n, f[3])
+			return
+		}
+		constellationRows = append(constellationRows, constellationRow{raLow, raHigh, decLow, f[3]})
+	}
+	if err := sc.Err(); err != nil {
+		constellationErr = fmt.Errorf("constellation boundaries: %w", err)
+	}
+}
+
+// constellationByAbbrev looks up an IAU abbreviation in any case.
+func constellationByAbbrev(abbrev string) Constellation {
+	for _, c := range constellations {
+		if strings.EqualFold(c.Abbrev, abbrev) {
+			return c
+		}
+	}
+	return Constellation{}
+}
+
+// constellations lists the 88 IAU constellations.
+var constellations = []Constellation{
+	{"And", "Andromeda"}, {"Ant", "Antlia"}, {"Aps", "Apus"}, {"Aqr", "Aquarius"},
+	{"Aql", "Aquila"}, {"Ara", "Ara"}, {"Ari", "Aries"}, {"Aur", "Auriga"},
+	{"Boo", "Boötes"}, {"Cae", "Caelum"}, {"Cam", "Camelopardalis"}, {"Cnc", "Cancer"},
+	{"CVn", "Canes Venatici"}, {"CMa", "Canis Major"}, {"CMi", "Canis Minor"}, {"Cap", "Capricornus"},
+	{"Car", "Carina"}, {"Cas", "Cassiopeia"}, {"Cen", "Centaurus"}, {"Cep", "Cepheus"},
+	{"Cet", "Cetus"}, {"Cha", "Chamaeleon"}, {"Cir", "Circinus"}, {"Col", "Columba"},
+	{"Com", "Coma Berenices"}, {"CrA", "Corona Australis"}, {"CrB", "Corona Borealis"}, {"Crv", "Corvus"},
+	{"Crt", "Crater"}, {"Cru", "Crux"}, {"Cyg", "Cygnus"}, {"Del", "Delphinus"},
+	{"Dor", "Dorado"}, {"Dra", "Draco"}, {"Equ", "Equuleus"}, {"Eri", "Eridanus"},
+	{"For", "Fornax"}, {"Gem", "Gemini"}, {"Gru", "Grus"}, {"Her", "Hercules"},
+	{"Hor", "Horologium"}, {"Hya", "Hydra"}, {"Hyi", "Hydrus"}, {"Ind", "Indus"},
+	{"Lac", "Lacerta"}, {"Leo", "Leo"}, {"LMi", "Leo Minor"}, {"Lep", "Lepus"},
+	{"Lib", "Libra"}, {"Lup", "Lupus"}, {"Lyn", "Lynx"}, {"Lyr", "Lyra"},
+	{"Men", "Mensa"}, {"Mic", "Microscopium"}, {"Mon", "Monoceros"}, {"Mus", "Musca"},
+	{"Nor", "Norma"}, {"Oct", "Octans"}, {"Oph", "Ophiuchus"}, {"Ori", "Orion"},
+	{"Pav", "Pavo"}, {"Peg", "Pegasus"}, {"Per", "Perseus"}, {"Phe", "Phoenix"},
+	{"Pic", "Pictor"}, {"Psc", "Pisces"}, {"PsA", "Piscis Austrinus"}, {"Pup", "Puppis"},
+	{"Pyx", "Pyxis"}, {"Ret", "Reticulum"}, {"Sge", "Sagitta"}, {"Sgr", "Sagittarius"},
+	{"Sco", "Scorpius"}, {"Scl", "Sculptor"}, {"Sct", "Scutum"}, {"Ser", "Serpens"},
+	{"Sex", "Sextans"}, {"Tau", "Taurus"}, {"Tel", "Telescopium"}, {"Tri", "Triangulum"},
+	{"TrA", "Triangulum Australe"}, {"Tuc", "Tucana"}, {"UMa", "Ursa Major"}, {"UMi", "Ursa Minor"},
+	{"Vel", "Vela"}, {"Vir", "Virgo"}, {"Vol", "Volans"}, {"Vul", "Vulpecula"},
+}
@@ -0,0 +1,59 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConstellationAt(t *testing.T) {
+	cases := []struct {
+		ra, dec float64
+		want    string
+	}{
+		{101.287, -16.716, "CMa"}, // Sirius
+		{37.955, 89.264, "UMi"},   // Polaris
+		{83.822, -5.391, "Ori"},   // Orion Nebula
+		{266.417, -29.008, "Sgr"}, // Galactic centre
+		{0, -89.9, "Oct"},
+	}
+	for _, tc := range cases {
+		c, err := ConstellationAt(tc.ra, tc.dec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Abbrev != tc.want {
+			t.Errorf("ConstellationAt(%v, %v) = %s, want %s", tc.ra, tc.dec, c.Abbrev, tc.want)
+		}
+	}
+	if _, err := ConstellationAt(0, 91); err == nil {
+		t.Error("ConstellationAt(0, 91) succeeded, want an error")
+	}
+}
+
+func TestSunConstellation(t *testing.T) {
+	for date, want := range map[string]string{
+		"2025-01-01": "Sagittarius",
+		"2025-03-20": "Pisces",
+		"2025-12-05": "Ophiuchus",
+	} {
+		d, _ := time.Parse("2006-01-02", date)
+		c, err := SunConstellation(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Name != want {
+			t.Errorf("%s: Sun in %s, want %s", date, c, want)
+		}
+	}
+}
+
+func TestMoonConstellation(t *testing.T) {
+	// The full Moon of 2025 January 13-14 occulted Mars in Gemini.
+	c, err := MoonConstellation(time.Date(2025, time.January, 14, 3, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Abbrev != "Gem" {
+		t.Errorf("Moon in %s, want Gemini", c)
+	}
+}
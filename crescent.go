@@ -0,0 +1,166 @@
+package astroglide
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// ErrNoCrescentSighting is returned by FirstCrescentAfter when the crescent
+// is not predicted to be visible within the search window after New Moon.
+var ErrNoCrescentSighting = errors.New("no crescent sighting predicted after this new moon")
+
+// CrescentClass is Yallop's visibility zone for the young crescent Moon,
+// from A (easily visible) to F (below the Danjon limit).
+type CrescentClass int
+
+const (
+	CrescentEasilyVisible       CrescentClass = iota // A: easily visible to the naked eye
+	CrescentVisiblePerfect                           // B: visible under perfect conditions
+	CrescentMayNeedOpticalAid                        // C: may need optical aid to find
+	CrescentOpticalAidOnly                           // D: visible with optical aid only
+	CrescentNotVisibleTelescope                      // E: not visible even with a telescope
+	CrescentNotVisible                               // F: below the Danjon limit
+)
+
+// String returns Yallop's letter for the class ("A".."F").
+func (c CrescentClass) String() string {
+	if c < CrescentEasilyVisible || c > CrescentNotVisible {
+		return "?"
+	}
+	return string(rune('A' + int(c)))
+}
+
+// NakedEye reports whether the class predicts a naked-eye sighting
+// (Yallop A or B).
+func (c CrescentClass) NakedEye() bool {
+	return c <= CrescentVisiblePerfect
+}
+
+// CrescentSighting describes the visibility of the young Moon on the evening
+// of one local calendar date, evaluated at Yallop's "best time".
+type CrescentSighting struct {
+	Sunset   time.Time // local sunset
+	Moonset  time.Time // first moonset after sunset (zero if the Moon is already down)
+	BestTime time.Time // sunset + 4/9 of the lag
+
+	Lag     time.Duration // moonset minus sunset (<= 0 if the Moon sets first)
+	MoonAge time.Duration // time since the preceding New Moon, at BestTime
+
+	ARCL  float64 // Sun–Moon elongation, degrees
+	ARCV  float64 // Moon minus Sun geocentric airless altitude, degrees
+	Width float64 // topocentric crescent width, arcminutes
+
+	Q     float64       // Yallop q-test value
+	V     float64       // Odeh V-test value
+	Class CrescentClass // Yallop visibility zone derived from Q
+}
+
+// CrescentVisibility evaluates how visible the crescent Moon is on the
+// evening of the given local calendar date, using Yallop's q-criterion
+// (NAO Technical Note 69) with Odeh's V reported alongside.
+//
+// The quantities are taken at the best time Tb = Ts + 4/9·lag, where Ts is
+// sunset and lag is the time from sunset to moonset. When the Moon sets
+// before the Sun, Tb is sunset and the class is CrescentNotVisible.
+//
+// If the Sun does not set on the date, ErrNoRiseNoSet is returned.
+func CrescentVisibility(loc Coordinates, date time.Time) (CrescentSighting, error) {
+	rs, err := SlideIntoSunset(loc, date)
+	if err != nil {
+		return CrescentSighting{}, err
+	}
+	if !rs.HasSet {
+		return CrescentSighting{}, noEvent(Sun, "sunset", loc, date)
+	}
+
+	s := CrescentSighting{Sunset: rs.Set, BestTime: rs.Set}
+
+	moonUp := func(t time.Time) float64 {
+		return moon.AltitudeAboveHorizon(loc.Lat, loc.Lon, t)
+	}
+	if moonUp(rs.Set) > 0 {
+		res := solver.FindAltitudeEvent(moonUp, rs.Set, rs.Set.Add(12*time.Hour),
+			0, solver.CrossingDown, 12*12+1, 10*time.Second)
+		if res.OK {
+			s.Moonset = res.Time.In(date.Location())
+			s.Lag = s.Moonset.Sub(s.Sunset)
+			s.BestTime = s.Sunset.Add(s.Lag * 4 / 9)
+		}
+	}
+
+	tb := s.BestTime.UTC()
+	s.MoonAge = tb.Sub(prevLunarPhase(tb, 0))
+
+	sEq := sun.GeocentricEquatorialApprox(tb)
+	mEq := moon.GeocentricEquatorialApprox(tb)
+	s.ARCL = coord.Separation(sEq.RA, sEq.Dec, mEq.RA, mEq.Dec)
+	s.ARCV = moon.GeocentricAltitudeAt(loc.Lat, loc.Lon, tb) - sun.AltitudeAt(loc.Lat, loc.Lon, tb)
+
+	// Topocentric semi-diameter grows with the Moon's altitude (it is
+	// nearer to the observer than to the Earth's centre).
+	hp := moon.HorizontalParallax(tb) * 60 // arcmin
+	semi := 0.27245 * hp
+	semiTopo := semi * (1 + timeutil.SinD(moon.AltitudeAt(loc.Lat, loc.Lon, tb))*timeutil.SinD(hp/60))
+	s.Width = semiTopo * (1 - timeutil.CosD(s.ARCL))
+
+	w := s.Width
+	poly := -0.1018*w*w*w + 0.7319*w*w - 6.3226*w
+	s.Q = (s.ARCV - (11.8371 + poly)) / 10
+	s.V = s.ARCV - (7.1651 + poly)
+
+	switch {
+	case s.Lag <= 0:
+		s.Class = CrescentNotVisible
+	case s.Q > 0.216:
+		s.Class = CrescentEasilyVisible
+	case s.Q > -0.014:
+		s.Class = CrescentVisiblePerfect
+	case s.Q > -0.160:
+		s.Class = CrescentMayNeedOpticalAid
+	case s.Q > -0.232:
+		s.Class = CrescentOpticalAidOnly
+	case s.Q > -0.293:
+		s.Class = CrescentNotVisibleTelescope
+	default:
+		s.Class = CrescentNotVisible
+	}
+
+	return s, nil
+}
+
+// FirstCrescentAfter finds the first New Moon after t and returns the
+// evening, from the local date of that New Moon onwards, on which the
+// crescent is first predicted to be visible to the naked eye (Yallop A or
+// B) from loc. In calendars based on sighting, such as the Hijri calendar,
+// the new month begins at that sunset.
+//
+// Dates are taken in t's time zone. If no evening within four days of the
+// New Moon qualifies, ErrNoCrescentSighting is returned.
+func FirstCrescentAfter(loc Coordinates, t time.Time) (CrescentSighting, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return CrescentSighting{}, err
+	}
+
+	tz := t.Location()
+	nm := nextLunarPhase(t, 0).In(tz)
+	year, month, day := nm.Date()
+
+	for i := 0; i <= 4; i++ {
+		date := time.Date(year, month, day+i, 12, 0, 0, 0, tz)
+		s, err := CrescentVisibility(loc, date)
+		if err != nil {
+			return CrescentSighting{}, err
+		}
+		if s.BestTime.After(nm) && s.Class.NakedEye() {
+			return s, nil
+		}
+	}
+
+	return CrescentSighting{}, &NoEventError{Body: Moon, Kind: "crescent sighting", Date: nm, Coords: loc, Err: ErrNoCrescentSighting}
+}
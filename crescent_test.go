@@ -0,0 +1,54 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCrescentVisibility_Makkah(t *testing.T) {
+	makkah := Coordinates{Lat: 21.4225, Lon: 39.8262}
+	ast := time.FixedZone("AST", 3*60*60)
+
+	// New Moon 2025-03-29 10:58 UTC. That evening the Moon is only ~5 hours
+	// old; the following evening (~29 hours) the crescent is easy.
+	tests := []struct {
+		day  int
+		want CrescentClass
+	}{
+		{29, CrescentNotVisible},
+		{30, CrescentEasilyVisible},
+	}
+
+	for _, tt := range tests {
+		got, err := CrescentVisibility(makkah, time.Date(2025, 3, tt.day, 0, 0, 0, 0, ast))
+		if err != nil {
+			t.Fatalf("CrescentVisibility error: %v", err)
+		}
+		if got.Class != tt.want {
+			t.Errorf("2025-03-%02d: class %v (q=%.3f, age %v), want %v",
+				tt.day, got.Class, got.Q, got.MoonAge.Round(time.Minute), tt.want)
+		}
+		if got.Lag > 0 && (got.BestTime.Before(got.Sunset) || got.BestTime.After(got.Moonset)) {
+			t.Errorf("2025-03-%02d: best time %v outside sunset %v .. moonset %v",
+				tt.day, got.BestTime, got.Sunset, got.Moonset)
+		}
+	}
+}
+
+func TestFirstCrescentAfter(t *testing.T) {
+	makkah := Coordinates{Lat: 21.4225, Lon: 39.8262}
+	ast := time.FixedZone("AST", 3*60*60)
+
+	got, err := FirstCrescentAfter(makkah, time.Date(2025, 3, 20, 0, 0, 0, 0, ast))
+	if err != nil {
+		t.Fatalf("FirstCrescentAfter error: %v", err)
+	}
+
+	y, m, d := got.Sunset.Date()
+	if y != 2025 || m != time.March || d != 30 {
+		t.Errorf("first sighting on %04d-%02d-%02d, want 2025-03-30", y, m, d)
+	}
+	if !got.Class.NakedEye() {
+		t.Errorf("class %v is not a naked-eye sighting", got.Class)
+	}
+}
@@ -0,0 +1,245 @@
+package astroglide
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchDays bounds how far ahead CronSchedule.Next looks for a firing
+// time, long enough to cross polar nights and reach a February 29.
+const cronSearchDays = 8 * 366
+
+// CronSchedule is a cron expression extended with astronomical events.
+// Parse one with ParseCron.
+//
+// The usual five fields "minute hour day-of-month month day-of-week" fire
+// at clock times, e.g. "30 6 * * 1-5". An event token in place of the
+// minute and hour fires at that event on each matching day:
+// "@sunset-30m * * 1-5" fires half an hour before sunset on weekdays, and
+// "@civil_dusk" alone fires every day. The token is an event expression
+// (see ParseEventExpr) after the @.
+type CronSchedule struct {
+	expr  string
+	event *EventExpr // nil for a clock schedule
+
+	minute, hour, dom, month, dow cronField
+}
+
+// cronField is the set of values a field matches, and whether it was "*"
+// (which matters for the day-of-month/day-of-week rule).
+type cronField struct {
+	set []bool
+	any bool
+}
+
+func (f cronField) match(v int) bool { return v < len(f.set) && f.set[v] }
+
+// ParseCron parses an extended cron expression (see CronSchedule). Fields
+// accept "*", numbers, ranges "1-5", lists "1,15" and steps "*/10" or
+// "0-30/5". Day of week is 0-7 with both 0 and 7 meaning Sunday. As in
+// cron, when both day of month and day of week are restricted, a day
+// matching either fires. Days of month that none of the months have, such
+// as "30 2" for February 30, are an error.
+func ParseCron(s string) (CronSchedule, error) {
+	fields := strings.Fields(s)
+	c := CronSchedule{expr: strings.Join(fields, " ")}
+	if len(fields) == 0 {
+		return CronSchedule{}, fmt.Errorf("empty cron expression")
+	}
+
+	if tok, ok := strings.CutPrefix(fields[0], "@"); ok {
+		e, err := ParseEventExpr(tok)
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("cron expression %q: %w", s, err)
+		}
+		c.event = &e
+		fields = fields[1:]
+		switch len(fields) {
+		case 0:
+			fields = []string{"*", "*", "*"}
+		case 3:
+		default:
+			return CronSchedule{}, fmt.Errorf("cron expression %q: want @event [day-of-month month day-of-week]", s)
+		}
+		fields = append([]string{"0", "0"}, fields...)
+	} else if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron expression %q: want 5 fields, got %d", s, len(fields))
+	}
+
+	specs := []struct {
+		f           *cronField
+		name        string
+		first, last int
+	}{
+		{&c.minute, "minute", 0, 59},
+		{&c.hour, "hour", 0, 23},
+		{&c.dom, "day of month", 1, 31},
+		{&c.month, "month", 1, 12},
+		{&c.dow, "day of week", 0, 7},
+	}
+	for i, sp := range specs {
+		f, err := parseCronField(fields[i], sp.first, sp.last)
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("cron expression %q: %s: %w", s, sp.name, err)
+		}
+		*sp.f = f
+	}
+	if c.dow.set[7] {
+		c.dow.set[0] = true
+	}
+	if c.dow.any && !c.domInMonth() {
+		return CronSchedule{}, fmt.Errorf("cron expression %q: no month has that day of month", s)
+	}
+	return c, nil
+}
+
+// cronMonthDays is the longest length of each month, February in a leap
+// year.
+var cronMonthDays = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// domInMonth reports whether some day of month in c falls in one of its
+// months, so that "0 0 30 2 *" can be rejected rather than searched for.
+func (c CronSchedule) domInMonth() bool {
+	for m := 1; m <= 12; m++ {
+		if !c.month.match(m) {
+			continue
+		}
+		for d := 1; d <= cronMonthDays[m]; d++ {
+			if c.dom.match(d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseCronField(s string, first, last int) (cronField, error) {
+	f := cronField{set: make([]bool, last+1), any: s == "*"}
+	for _, part := range strings.Split(s, ",") {
+		rng, stepS, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepS)
+			if err != nil || n < 1 {
+				return cronField{}, fmt.Errorf("invalid step %q", stepS)
+			}
+			step = n
+		}
+
+		lo, hi := first, last
+		if rng != "*" {
+			loS, hiS, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loS); err != nil {
+				return cronField{}, fmt.Errorf("invalid value %q", loS)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiS); err != nil {
+					return cronField{}, fmt.Errorf("invalid value %q", hiS)
+				}
+			} else if hasStep {
+				hi = last
+			}
+		}
+		if lo < first || hi > last || lo > hi {
+			return cronField{}, fmt.Errorf("%q out of range %d-%d", part, first, last)
+		}
+		for v := lo; v <= hi; v += step {
+			f.set[v] = true
+		}
+	}
+	return f, nil
+}
+
+// String returns the expression as parsed, with spacing normalized.
+func (c CronSchedule) String() string { return c.expr }
+
+// matchDay reports whether the calendar date of day matches the day
+// fields.
+func (c CronSchedule) matchDay(day time.Time) bool {
+	if !c.month.match(int(day.Month())) {
+		return false
+	}
+	dom, dow := c.dom.match(day.Day()), c.dow.match(int(day.Weekday()))
+	switch {
+	case c.dom.any:
+		return dow
+	case c.dow.any:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first firing time after `after`, in after's time zone,
+// which also gives the calendar dates and clock times of the fields.
+//
+// Event schedules fire on the days whose event matches the day fields (the
+// offset may carry the firing time onto a neighbouring date); days on
+// which the event does not occur are skipped. Clock times that do not
+// exist on a daylight saving change are skipped as well. An error is
+// returned if nothing fires within eight years.
+func (c CronSchedule) Next(loc Coordinates, after time.Time) (time.Time, error) {
+	if err := checkInputs(loc, after); err != nil {
+		return time.Time{}, err
+	}
+	if c.dow.set == nil {
+		return time.Time{}, fmt.Errorf("empty cron schedule")
+	}
+
+	tz := after.Location()
+	y, m, d := after.Date()
+	// Start a day early so that an event offset past midnight is found.
+	for i := -1; i < cronSearchDays; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, tz)
+		if !c.matchDay(day) {
+			continue
+		}
+		if checkRange(day) != nil {
+			break
+		}
+
+		if c.event != nil {
+			t, err := c.event.Resolve(loc, day)
+			if err == nil && t.After(after) {
+				return t, nil
+			}
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			if !c.hour.match(h) {
+				continue
+			}
+			for mi := 0; mi < 60; mi++ {
+				if !c.minute.match(mi) {
+					continue
+				}
+				t := time.Date(day.Year(), day.Month(), day.Day(), h, mi, 0, 0, tz)
+				if t.Hour() != h || t.Minute() != mi {
+					continue // skipped by a daylight saving change
+				}
+				if t.After(after) {
+					return t, nil
+				}
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("cron schedule %q does not fire within %d days", c.expr, cronSearchDays)
+}
+
+// NextN returns the next n firing times after `after` (see Next).
+func (c CronSchedule) NextN(loc Coordinates, after time.Time, n int) ([]time.Time, error) {
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		t, err := c.Next(loc, after)
+		if err != nil {
+			return times, err
+		}
+		times = append(times, t)
+		after = t
+	}
+	return times, nil
+}
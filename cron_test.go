@@ -0,0 +1,87 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	good := []string{"30 6 * * 1-5", "*/15 * * * *", "0 0 1,15 * *", "@sunset", "@sunset-30m * * 1-5", "@civil_dusk+10m 1 1 *", "0 12 * * 7", "0 0 29 2 *", "0 0 31 1-2 *", "0 0 30 2 1"}
+	for _, s := range good {
+		if _, err := ParseCron(s); err != nil {
+			t.Errorf("ParseCron(%q) error: %v", s, err)
+		}
+	}
+	bad := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "@noonish", "@sunset * *", "*/0 * * * *", "5-1 * * * *", "0 0 30 2 *", "0 0 31 4,6,9,11 *", "@sunset 31 2 *"}
+	for _, s := range bad {
+		if _, err := ParseCron(s); err == nil {
+			t.Errorf("ParseCron(%q) accepted", s)
+		}
+	}
+}
+
+func TestCronSchedule_Clock(t *testing.T) {
+	c, err := ParseCron("30 6 * * 1-5")
+	if err != nil {
+		t.Fatalf("ParseCron error: %v", err)
+	}
+	// Friday 2025-06-20 07:00 UTC: next weekday 06:30 is Monday.
+	got, err := c.NextN(Coordinates{}, time.Date(2025, 6, 20, 7, 0, 0, 0, time.UTC), 2)
+	if err != nil {
+		t.Fatalf("NextN error: %v", err)
+	}
+	want := []time.Time{
+		time.Date(2025, 6, 23, 6, 30, 0, 0, time.UTC),
+		time.Date(2025, 6, 24, 6, 30, 0, 0, time.UTC),
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("firing %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCronSchedule_Event(t *testing.T) {
+	loc := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*60*60)
+
+	c, err := ParseCron("@sunset-30m * * 1-5")
+	if err != nil {
+		t.Fatalf("ParseCron error: %v", err)
+	}
+	got, err := c.NextN(loc, time.Date(2025, 6, 20, 20, 0, 0, 0, mst), 3)
+	if err != nil {
+		t.Fatalf("NextN error: %v", err)
+	}
+	for i, day := range []int{23, 24, 25} {
+		rs, err := RiseSetFor(Sun, loc, time.Date(2025, 6, day, 0, 0, 0, 0, mst))
+		if err != nil {
+			t.Fatalf("RiseSetFor error: %v", err)
+		}
+		if want := rs.Set.Add(-30 * time.Minute); !got[i].Equal(want) {
+			t.Errorf("firing %d = %v, want %v", i, got[i], want)
+		}
+	}
+
+	// Before today's firing time it fires today.
+	daily, _ := ParseCron("@sunset")
+	next, err := daily.Next(loc, time.Date(2025, 6, 21, 12, 0, 0, 0, mst))
+	if err != nil || next.Day() != 21 {
+		t.Errorf("Next = %v, %v; want June 21", next, err)
+	}
+	if daily.String() != "@sunset" {
+		t.Errorf("String() = %q", daily.String())
+	}
+}
+
+func TestCronSchedule_PolarSkip(t *testing.T) {
+	// Tromsø: no sunset until late July.
+	c, _ := ParseCron("@sunset")
+	next, err := c.Next(Coordinates{Lat: 69.65, Lon: 18.96}, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Next error: %v", err)
+	}
+	if next.Month() != time.July {
+		t.Errorf("first sunset after midnight sun = %v, want late July", next)
+	}
+}
@@ -0,0 +1,201 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// AstronomicalDarknessAltitude is the solar altitude (degrees) below which
+// the sky is considered fully dark (end of astronomical twilight).
+const AstronomicalDarknessAltitude = -18.0
+
+// ErrNoDarkWindow is returned when no interval of the night reaches the
+// requested sky darkness score.
+var ErrNoDarkWindow = errors.New("no sufficiently dark window on this date")
+
+// DarkSkyWindow is a continuous interval whose sky darkness score stays at
+// or above a threshold, together with the best moment inside it.
+type DarkSkyWindow struct {
+	PhaseWindow
+	PeakTime  time.Time // instant with the highest score in the window
+	PeakScore float64   // score at PeakTime, in [0..1]
+}
+
+// DarknessCriteria controls which conditions define a "dark" interval for
+// DarknessWindowsFor. The zero value only requires the Sun to be below
+// AstronomicalDarknessAltitude.
+type DarknessCriteria struct {
+	// MoonMustBeDown additionally requires the Moon to be below the horizon.
+	MoonMustBeDown bool
+
+	// MaxMoonFraction relaxes MoonMustBeDown: the Moon may be up as long as
+	// its illuminated fraction is at most this value (e.g. 0.25 to tolerate
+	// a thin crescent). Ignored when MoonMustBeDown is false.
+	MaxMoonFraction float64
+}
+
+// DarknessWindowsFor returns the intervals of astronomical darkness during
+// the night that begins on the given local calendar date, i.e. between
+// local noon on date and local noon on the following day. Times are in the
+// date's time zone.
+//
+// The Sun must be below -18°; criteria optionally adds Moon conditions for
+// astrophotographers who want a moonless (or nearly moonless) sky.
+//
+// An empty result with a nil error means there is no darkness that night
+// (e.g. high-latitude summer, or the Moon is up all night).
+func DarknessWindowsFor(loc Coordinates, date time.Time, criteria DarknessCriteria) ([]PhaseWindow, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return nil, err
+	}
+
+	tz := date.Location()
+	year, month, day := date.Date()
+
+	start := time.Date(year, month, day, 12, 0, 0, 0, tz)
+	end := time.Date(year, month, day+1, 12, 0, 0, 0, tz)
+
+	dark := func(t time.Time) bool {
+		if sun.AltitudeAt(loc.Lat, loc.Lon, t) >= AstronomicalDarknessAltitude {
+			return false
+		}
+		if !criteria.MoonMustBeDown {
+			return true
+		}
+		if moon.AltitudeAboveHorizon(loc.Lat, loc.Lon, t) < 0 {
+			return true
+		}
+		phase, err := MoonPhaseAt(t)
+		return err == nil && phase.Fraction <= criteria.MaxMoonFraction
+	}
+
+	const (
+		steps = 24*12 + 1 // every 5 minutes
+		tol   = 30 * time.Second
+	)
+
+	intervals := solver.FindIntervals(dark, start, end, steps, tol)
+
+	windows := make([]PhaseWindow, 0, len(intervals))
+	for _, iv := range intervals {
+		windows = append(windows, PhaseWindow{
+			Start: iv.Start.In(tz),
+			End:   iv.End.In(tz),
+		})
+	}
+
+	return windows, nil
+}
+
+// SkyDarknessScore returns a 0–1 darkness metric for the sky above loc at
+// time t, where 0 is daylight (or a bright Moon overhead) and 1 is a fully
+// dark, moonless sky.
+//
+// The score is the product of a solar term and a lunar term:
+//
+//   - the solar term ramps linearly from 0 with the Sun at -6° (end of civil
+//     twilight) to 1 at -18° (astronomical darkness);
+//   - the lunar term is 1 - fraction*weight while the Moon is up, where the
+//     weight grows from 0.25 on the horizon to 1 at 30° altitude and above.
+//
+// This is a planning heuristic in the spirit of imaging planners, not a
+// photometric sky-brightness model.
+func SkyDarknessScore(loc Coordinates, t time.Time) (float64, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return 0, err
+	}
+
+	sunAlt := sun.AltitudeAt(loc.Lat, loc.Lon, t)
+	sunTerm := clamp01((-sunAlt - 6.0) / 12.0)
+	if sunTerm == 0 {
+		return 0, nil
+	}
+
+	if moon.AltitudeAboveHorizon(loc.Lat, loc.Lon, t) < 0 {
+		return sunTerm, nil
+	}
+
+	phase, err := MoonPhaseAt(t)
+	if err != nil {
+		return 0, err
+	}
+
+	moonAlt := math.Max(moon.AltitudeAt(loc.Lat, loc.Lon, t), 0)
+	weight := clamp01(0.25 + 0.75*timeutil.SinD(moonAlt)/timeutil.SinD(30))
+
+	return sunTerm * (1 - phase.Fraction*weight), nil
+}
+
+// BestDarkSkyWindowFor searches the night that begins on the given local
+// calendar date (local noon to local noon) for the longest interval whose
+// SkyDarknessScore stays at or above minScore.
+//
+// If no moment of the night reaches minScore, ErrNoDarkWindow is returned.
+func BestDarkSkyWindowFor(loc Coordinates, date time.Time, minScore float64) (DarkSkyWindow, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return DarkSkyWindow{}, err
+	}
+
+	tz := date.Location()
+	year, month, day := date.Date()
+
+	start := time.Date(year, month, day, 12, 0, 0, 0, tz)
+	end := time.Date(year, month, day+1, 12, 0, 0, 0, tz)
+
+	score := func(t time.Time) float64 {
+		s, _ := SkyDarknessScore(loc, t)
+		return s
+	}
+
+	const (
+		steps = 24*12 + 1 // every 5 minutes
+		tol   = 30 * time.Second
+	)
+
+	intervals := solver.FindIntervals(func(t time.Time) bool {
+		return score(t) >= minScore
+	}, start, end, steps, tol)
+
+	if len(intervals) == 0 {
+		return DarkSkyWindow{}, &NoEventError{Body: Sun, Kind: "dark-sky window", Date: date, Coords: loc, Err: ErrNoDarkWindow}
+	}
+
+	best := intervals[0]
+	for _, iv := range intervals[1:] {
+		if iv.End.Sub(iv.Start) > best.End.Sub(best.Start) {
+			best = iv
+		}
+	}
+
+	// Locate the darkest moment inside the chosen window.
+	const peakStep = 5 * time.Minute
+	w := DarkSkyWindow{
+		PhaseWindow: PhaseWindow{Start: best.Start.In(tz), End: best.End.In(tz)},
+		PeakTime:    best.Start.In(tz),
+		PeakScore:   score(best.Start),
+	}
+	for t := best.Start.Add(peakStep); !t.After(best.End); t = t.Add(peakStep) {
+		if s := score(t); s > w.PeakScore {
+			w.PeakTime, w.PeakScore = t.In(tz), s
+		}
+	}
+
+	return w, nil
+}
+
+// clamp01 limits v to the range [0, 1].
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,124 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDarknessWindowsFor_Phoenix(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load America/Phoenix: %v", err)
+	}
+
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.November, 28, 0, 0, 0, 0, loc)
+
+	windows, err := DarknessWindowsFor(coords, date, DarknessCriteria{})
+	if err != nil {
+		t.Fatalf("DarknessWindowsFor error: %v", err)
+	}
+	if len(windows) != 1 {
+		t.Fatalf("expected a single dark window, got %d: %+v", len(windows), windows)
+	}
+
+	// Astronomical dusk on 11-28 ≈ 18:48, astronomical dawn on 11-29 ≈ 05:45.
+	wantStart := time.Date(2025, time.November, 28, 18, 48, 0, 0, loc)
+	wantEnd := time.Date(2025, time.November, 29, 5, 45, 0, 0, loc)
+
+	if d := diffMinutes(windows[0].Start, wantStart); d > 5 {
+		t.Errorf("darkness starts at %v, want ~%v (off by %.1f min)", windows[0].Start, wantStart, d)
+	}
+	if d := diffMinutes(windows[0].End, wantEnd); d > 5 {
+		t.Errorf("darkness ends at %v, want ~%v (off by %.1f min)", windows[0].End, wantEnd, d)
+	}
+
+	// A first-quarter Moon sets around midnight, so requiring it to be down
+	// must shrink the window without extending it.
+	moonless, err := DarknessWindowsFor(coords, date, DarknessCriteria{MoonMustBeDown: true})
+	if err != nil {
+		t.Fatalf("DarknessWindowsFor(moon down) error: %v", err)
+	}
+	if len(moonless) == 0 {
+		t.Fatal("expected some moonless darkness after moonset")
+	}
+	for _, w := range moonless {
+		if w.Start.Before(windows[0].Start) || w.End.After(windows[0].End) {
+			t.Errorf("moonless window %+v escapes the dark window %+v", w, windows[0])
+		}
+	}
+	if !moonless[0].Start.After(windows[0].Start.Add(time.Hour)) {
+		t.Errorf("moonless darkness starts at %v, expected well after dusk", moonless[0].Start)
+	}
+}
+
+func TestDarknessWindowsFor_WhiteNight(t *testing.T) {
+	loc, err := time.LoadLocation("Atlantic/Reykjavik")
+	if err != nil {
+		t.Fatalf("failed to load Atlantic/Reykjavik: %v", err)
+	}
+
+	coords := Coordinates{Lat: 64.1466, Lon: -21.9426}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, loc)
+
+	windows, err := DarknessWindowsFor(coords, date, DarknessCriteria{})
+	if err != nil {
+		t.Fatalf("DarknessWindowsFor error: %v", err)
+	}
+	if len(windows) != 0 {
+		t.Errorf("expected no astronomical darkness in Reykjavik at midsummer, got %+v", windows)
+	}
+}
+
+func TestSkyDarknessScore(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load America/Phoenix: %v", err)
+	}
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	// Local noon: broad daylight.
+	noon := time.Date(2025, time.November, 28, 12, 0, 0, 0, loc)
+	if s, err := SkyDarknessScore(coords, noon); err != nil || s != 0 {
+		t.Errorf("SkyDarknessScore(noon) = %.3f, %v; want 0", s, err)
+	}
+
+	// 2025-11-20 is the new Moon: the small hours should be essentially ideal.
+	night := time.Date(2025, time.November, 20, 1, 0, 0, 0, loc)
+	if s, err := SkyDarknessScore(coords, night); err != nil || s < 0.95 {
+		t.Errorf("SkyDarknessScore(new Moon night) = %.3f, %v; want ~1", s, err)
+	}
+
+	// 2025-12-04 is just before full Moon, high in the sky around midnight.
+	bright := time.Date(2025, time.December, 4, 23, 30, 0, 0, loc)
+	if s, err := SkyDarknessScore(coords, bright); err != nil || s > 0.3 {
+		t.Errorf("SkyDarknessScore(full Moon) = %.3f, %v; want a low score", s, err)
+	}
+}
+
+func TestBestDarkSkyWindowFor(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load America/Phoenix: %v", err)
+	}
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	date := time.Date(2025, time.November, 20, 0, 0, 0, 0, loc)
+	w, err := BestDarkSkyWindowFor(coords, date, 0.9)
+	if err != nil {
+		t.Fatalf("BestDarkSkyWindowFor error: %v", err)
+	}
+	if w.End.Sub(w.Start) < 8*time.Hour {
+		t.Errorf("new Moon dark window lasts only %v", w.End.Sub(w.Start))
+	}
+	if w.PeakTime.Before(w.Start) || w.PeakTime.After(w.End) || w.PeakScore < 0.9 {
+		t.Errorf("peak %v (%.3f) inconsistent with window %+v", w.PeakTime, w.PeakScore, w.PhaseWindow)
+	}
+
+	// Around full Moon nothing reaches a near-perfect score.
+	full := time.Date(2025, time.December, 4, 0, 0, 0, 0, loc)
+	if _, err := BestDarkSkyWindowFor(coords, full, 0.99); !errors.Is(err, ErrNoDarkWindow) {
+		t.Errorf("expected ErrNoDarkWindow near full Moon, got %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package astroglide
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// DaylightTrend is the day length on a date and how fast it is changing,
+// the figure weather apps show as "2m 10s longer than yesterday".
+type DaylightTrend struct {
+	Date   time.Time     // local midnight starting the day
+	Length time.Duration // sunrise to sunset, as DaylightHours
+
+	// ChangeFromYesterday is Length minus the previous day's length, and
+	// ChangeToTomorrow the next day's length minus Length. Both are
+	// positive while the days are getting longer.
+	ChangeFromYesterday time.Duration
+	ChangeToTomorrow    time.Duration
+}
+
+// Gaining reports whether the days are getting longer.
+func (t DaylightTrend) Gaining() bool {
+	return t.ChangeFromYesterday > 0
+}
+
+// String formats the trend as e.g. "10h 12m 5s, gaining 2m 10s per day".
+func (t DaylightTrend) String() string {
+	change := t.ChangeFromYesterday.Round(time.Second)
+	switch {
+	case change > 0:
+		return fmt.Sprintf("%s, gaining %s per day", formatDayLength(t.Length), formatDayLength(change))
+	case change < 0:
+		return fmt.Sprintf("%s, losing %s per day", formatDayLength(t.Length), formatDayLength(-change))
+	default:
+		return formatDayLength(t.Length) + ", unchanged"
+	}
+}
+
+// formatDayLength formats d as "10h 12m 5s", "2m 10s" or "4s".
+func formatDayLength(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d/time.Hour), int(d/time.Minute)%60, int(d/time.Second)%60
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh %dm %ds", h, m, s)
+	case m > 0:
+		return fmt.Sprintf("%dm %ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
+
+// DaylightTrendFor returns the day length on the given local calendar date
+// and its change from the day before and to the day after.
+//
+// Unlike DaylightHours, days without a sunrise or sunset are not an error:
+// polar night counts as no daylight and midnight sun as the whole day.
+func DaylightTrendFor(loc Coordinates, date time.Time) (DaylightTrend, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return DaylightTrend{}, err
+	}
+
+	y, m, d := date.Date()
+	var lengths [3]time.Duration
+	for i := range lengths {
+		day := time.Date(y, m, d+i-1, 0, 0, 0, 0, date.Location())
+		l, err := dayLength(loc, day)
+		if err != nil {
+			return DaylightTrend{}, err
+		}
+		lengths[i] = l
+	}
+
+	return DaylightTrend{
+		Date:                timeutil.StartOfDay(date),
+		Length:              lengths[1],
+		ChangeFromYesterday: lengths[1] - lengths[0],
+		ChangeToTomorrow:    lengths[2] - lengths[1],
+	}, nil
+}
+
+// DayLength is a local calendar date and its daylight.
+type DayLength struct {
+	Date   time.Time // local midnight starting the day
+	Length time.Duration
+}
+
+// ShortestDay returns the date in year (in tz, UTC if nil) with the least
+// daylight. In polar night several days have none; the first is returned.
+func ShortestDay(loc Coordinates, year int, tz *time.Location) (DayLength, error) {
+	days, err := dayLengths(loc, year, tz)
+	if err != nil {
+		return DayLength{}, err
+	}
+	return extremeDay(days, func(a, b time.Duration) bool { return a < b }), nil
+}
+
+// LongestDay returns the date in year (in tz, UTC if nil) with the most
+// daylight. Under the midnight sun several days are entirely light; the
+// first is returned.
+func LongestDay(loc Coordinates, year int, tz *time.Location) (DayLength, error) {
+	days, err := dayLengths(loc, year, tz)
+	if err != nil {
+		return DayLength{}, err
+	}
+	return extremeDay(days, func(a, b time.Duration) bool { return a > b }), nil
+}
+
+// AnnualDaylight summarizes the daylight of one year at one place.
+type AnnualDaylight struct {
+	Year int
+
+	Total   time.Duration     // daylight summed over the year
+	Monthly [12]time.Duration // daylight summed over each month, January first
+	Mean    time.Duration     // Total divided by the number of days
+
+	Shortest DayLength
+	Longest  DayLength
+
+	// PolarNightDays and MidnightSunDays count the days the Sun stays
+	// below or above the horizon throughout.
+	PolarNightDays  int
+	MidnightSunDays int
+
+	// Crossings lists, in date order, the days on which the day length
+	// passes one of the requested thresholds.
+	Crossings []DaylightCrossing
+
+	Days []DayLength // every day of the year
+}
+
+// DaylightCrossing is the first day on the other side of a day-length
+// threshold, e.g. the first 12-hour day of spring.
+type DaylightCrossing struct {
+	Threshold time.Duration
+	Date      time.Time // local midnight starting the day
+	Length    time.Duration
+	Rising    bool // true when days become at least Threshold long
+}
+
+// AnnualDaylightStats computes the daylight statistics of year at loc, with
+// dates in tz (UTC if nil). Day lengths are as for DaylightTrendFor. Each
+// threshold (12 hours if none are given) is reported every time the day
+// length crosses it; the first day at or above it is a rising crossing and
+// the first day below it a falling one.
+func AnnualDaylightStats(loc Coordinates, year int, tz *time.Location, thresholds ...time.Duration) (AnnualDaylight, error) {
+	days, err := dayLengths(loc, year, tz)
+	if err != nil {
+		return AnnualDaylight{}, err
+	}
+	if len(thresholds) == 0 {
+		thresholds = []time.Duration{12 * time.Hour}
+	}
+
+	a := AnnualDaylight{
+		Year:     year,
+		Days:     days,
+		Shortest: extremeDay(days, func(a, b time.Duration) bool { return a < b }),
+		Longest:  extremeDay(days, func(a, b time.Duration) bool { return a > b }),
+	}
+	for i, d := range days {
+		a.Total += d.Length
+		a.Monthly[d.Date.Month()-1] += d.Length
+		switch {
+		case d.Length == 0:
+			a.PolarNightDays++
+		case d.Length >= d.Date.AddDate(0, 0, 1).Sub(d.Date):
+			a.MidnightSunDays++
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := days[i-1].Length
+		for _, th := range thresholds {
+			if (prev < th) != (d.Length < th) {
+				a.Crossings = append(a.Crossings, DaylightCrossing{
+					Threshold: th,
+					Date:      d.Date,
+					Length:    d.Length,
+					Rising:    d.Length >= th,
+				})
+			}
+		}
+	}
+	a.Mean = a.Total / time.Duration(len(days))
+	return a, nil
+}
+
+// dayLengths returns the day length of every day of year in tz (UTC if
+// nil).
+func dayLengths(loc Coordinates, year int, tz *time.Location) ([]DayLength, error) {
+	if tz == nil {
+		tz = time.UTC
+	}
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, tz)
+	if err := checkInputs(loc, jan1); err != nil {
+		return nil, err
+	}
+	if err := checkRange(jan1.AddDate(1, 0, 0).Add(-time.Nanosecond)); err != nil {
+		return nil, err
+	}
+
+	days := make([]DayLength, 0, 366)
+	for day := jan1; day.Year() == year; day = time.Date(year, day.Month(), day.Day()+1, 0, 0, 0, 0, tz) {
+		l, err := dayLength(loc, day)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, DayLength{Date: day, Length: l})
+	}
+	return days, nil
+}
+
+// extremeDay returns the first day whose length is better than all others.
+func extremeDay(days []DayLength, better func(a, b time.Duration) bool) DayLength {
+	best := days[0]
+	for _, d := range days[1:] {
+		if better(d.Length, best.Length) {
+			best = d
+		}
+	}
+	return best
+}
+
+// dayLength is daylightDuration, with polar night as zero and midnight sun
+// as the whole day.
+func dayLength(loc Coordinates, day time.Time) (time.Duration, error) {
+	return sunUpDuration(loc, day, sun.ApparentHorizonAltitudeSun)
+}
@@ -2,6 +2,7 @@ package astroglide_test
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -90,3 +91,169 @@ func TestDaylightHours_Equator(t *testing.T) {
 		t.Logf("Quito %s: %.2f hours", date.Format("2006-01-02"), hours)
 	}
 }
+
+func TestDaylightHours_ShortPolarDay(t *testing.T) {
+	// Just south of Tromsø on the last day before polar night the Sun is up
+	// for under half an hour, so rise and set fall inside one 30-minute
+	// sample window of the solver.
+	loc := astroglide.Coordinates{Lat: 69.6, Lon: 18.9553}
+	locOslo, _ := time.LoadLocation("Europe/Oslo")
+	date := time.Date(2025, time.November, 27, 0, 0, 0, 0, locOslo)
+
+	rs, err := astroglide.SlideIntoSunset(loc, date)
+	if err != nil {
+		t.Fatalf("SlideIntoSunset() error = %v", err)
+	}
+
+	day := rs.Set.Sub(rs.Rise)
+	if day < 15*time.Minute || day > 35*time.Minute {
+		t.Errorf("daylight = %v (%s to %s), want about 25 minutes",
+			day, rs.Rise.Format("15:04:05"), rs.Set.Format("15:04:05"))
+	}
+}
+
+func TestDaylightTrendFor(t *testing.T) {
+	phoenix := astroglide.Coordinates{Lat: 33.4484, Lon: -112.0740}
+	tz := time.FixedZone("MST", -7*3600)
+
+	// Early February: gaining about a minute and a half a day.
+	tr, err := astroglide.DaylightTrendFor(phoenix, time.Date(2025, time.February, 10, 0, 0, 0, 0, tz))
+	if err != nil {
+		t.Fatalf("DaylightTrendFor error: %v", err)
+	}
+	if !tr.Gaining() || tr.ChangeFromYesterday < 60*time.Second || tr.ChangeFromYesterday > 120*time.Second {
+		t.Errorf("February: change from yesterday %v, want +60s..+120s", tr.ChangeFromYesterday)
+	}
+	if d := tr.ChangeToTomorrow - tr.ChangeFromYesterday; d < -5*time.Second || d > 5*time.Second {
+		t.Errorf("February: changes %v and %v should be nearly equal", tr.ChangeFromYesterday, tr.ChangeToTomorrow)
+	}
+	hours, _ := astroglide.DaylightHours(phoenix, tr.Date)
+	if math.Abs(tr.Length.Hours()-hours) > 1e-9 {
+		t.Errorf("Length %v differs from DaylightHours %.4fh", tr.Length, hours)
+	}
+
+	// Early October: losing.
+	tr, err = astroglide.DaylightTrendFor(phoenix, time.Date(2025, time.October, 5, 0, 0, 0, 0, tz))
+	if err != nil {
+		t.Fatalf("DaylightTrendFor error: %v", err)
+	}
+	if tr.Gaining() || tr.ChangeFromYesterday > -60*time.Second {
+		t.Errorf("October: change from yesterday %v, want below -60s", tr.ChangeFromYesterday)
+	}
+	if s := tr.String(); !strings.Contains(s, "losing 2m") {
+		t.Errorf("String() = %q, want it to mention losing 2m...", s)
+	}
+
+	// Polar night and midnight sun are not errors.
+	tromso := astroglide.Coordinates{Lat: 69.6496, Lon: 18.9560}
+	tr, err = astroglide.DaylightTrendFor(tromso, time.Date(2025, time.December, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil || tr.Length != 0 || tr.ChangeFromYesterday != 0 {
+		t.Errorf("polar night: %+v, %v; want zero length and change", tr, err)
+	}
+	tr, err = astroglide.DaylightTrendFor(tromso, time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil || tr.Length != 24*time.Hour {
+		t.Errorf("midnight sun: %+v, %v; want 24h", tr, err)
+	}
+}
+
+func TestShortestLongestDay(t *testing.T) {
+	tests := []struct {
+		name              string
+		loc               astroglide.Coordinates
+		shortest, longest string
+	}{
+		{"Phoenix", astroglide.Coordinates{Lat: 33.4484, Lon: -112.0740}, "2025-12-21", "2025-06-20"},
+		{"Sydney", astroglide.Coordinates{Lat: -33.8688, Lon: 151.2093}, "2025-06-21", "2025-12-21"},
+		// First day of polar night and of midnight sun.
+		{"Tromsø", astroglide.Coordinates{Lat: 69.6496, Lon: 18.9560}, "2025-01-01", "2025-05-17"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			short, err := astroglide.ShortestDay(tt.loc, 2025, nil)
+			if err != nil {
+				t.Fatalf("ShortestDay error: %v", err)
+			}
+			long, err := astroglide.LongestDay(tt.loc, 2025, nil)
+			if err != nil {
+				t.Fatalf("LongestDay error: %v", err)
+			}
+			if got := short.Date.Format("2006-01-02"); !withinDays(got, tt.shortest, 1) {
+				t.Errorf("ShortestDay = %s (%v), want %s", got, short.Length, tt.shortest)
+			}
+			if got := long.Date.Format("2006-01-02"); !withinDays(got, tt.longest, 1) {
+				t.Errorf("LongestDay = %s (%v), want %s", got, long.Length, tt.longest)
+			}
+			if short.Length >= long.Length {
+				t.Errorf("shortest %v not below longest %v", short.Length, long.Length)
+			}
+		})
+	}
+}
+
+func withinDays(a, b string, n int) bool {
+	ta, _ := time.Parse("2006-01-02", a)
+	tb, _ := time.Parse("2006-01-02", b)
+	d := ta.Sub(tb)
+	return d >= -time.Duration(n)*24*time.Hour && d <= time.Duration(n)*24*time.Hour
+}
+
+func TestAnnualDaylightStats(t *testing.T) {
+	phoenix := astroglide.Coordinates{Lat: 33.4484, Lon: -112.0740}
+	tz := time.FixedZone("MST", -7*3600)
+
+	a, err := astroglide.AnnualDaylightStats(phoenix, 2025, tz, 12*time.Hour, 14*time.Hour)
+	if err != nil {
+		t.Fatalf("AnnualDaylightStats error: %v", err)
+	}
+	if len(a.Days) != 365 {
+		t.Fatalf("got %d days, want 365", len(a.Days))
+	}
+	// Refraction and the Sun's radius make the mean day a little over 12h.
+	if a.Mean < 12*time.Hour || a.Mean > 12*time.Hour+15*time.Minute {
+		t.Errorf("Mean = %v, want 12h..12h15m", a.Mean)
+	}
+	var monthly time.Duration
+	for _, m := range a.Monthly {
+		monthly += m
+	}
+	if monthly != a.Total {
+		t.Errorf("monthly totals add up to %v, Total is %v", monthly, a.Total)
+	}
+	if a.PolarNightDays != 0 || a.MidnightSunDays != 0 {
+		t.Errorf("polar days at Phoenix: %d night, %d sun", a.PolarNightDays, a.MidnightSunDays)
+	}
+
+	// 12h is crossed near the equiluxes (a few days before the March
+	// equinox, after the September one); 14h around May and July.
+	want := []struct {
+		threshold time.Duration
+		date      string
+		rising    bool
+	}{
+		{12 * time.Hour, "2025-03-16", true},
+		{14 * time.Hour, "2025-05-19", true},
+		{14 * time.Hour, "2025-07-25", false},
+		{12 * time.Hour, "2025-09-27", false},
+	}
+	if len(a.Crossings) != len(want) {
+		t.Fatalf("got %d crossings, want %d: %+v", len(a.Crossings), len(want), a.Crossings)
+	}
+	for i, w := range want {
+		c := a.Crossings[i]
+		if got := c.Date.Format("2006-01-02"); c.Threshold != w.threshold || c.Rising != w.rising || !withinDays(got, w.date, 3) {
+			t.Errorf("crossing %d = %v %s rising=%v, want %v %s rising=%v", i, c.Threshold, got, c.Rising, w.threshold, w.date, w.rising)
+		}
+	}
+
+	tromso := astroglide.Coordinates{Lat: 69.6496, Lon: 18.9560}
+	a, err = astroglide.AnnualDaylightStats(tromso, 2025, nil)
+	if err != nil {
+		t.Fatalf("AnnualDaylightStats error: %v", err)
+	}
+	if a.PolarNightDays < 40 || a.PolarNightDays > 60 {
+		t.Errorf("Tromsø: %d polar night days, want about 50", a.PolarNightDays)
+	}
+	if a.MidnightSunDays < 60 || a.MidnightSunDays > 75 {
+		t.Errorf("Tromsø: %d midnight sun days, want about 68", a.MidnightSunDays)
+	}
+}
@@ -0,0 +1,115 @@
+package astroglide
+
+import "time"
+
+// FlightRules defines the daylight window in which a jurisdiction allows
+// small unmanned aircraft to fly without night authorization: sunrise to
+// sunset, extended into twilight, where anti-collision lighting is
+// required.
+type FlightRules struct {
+	Name string
+
+	// BeforeSunrise and AfterSunset extend the window into twilight by
+	// fixed offsets.
+	BeforeSunrise, AfterSunset time.Duration
+
+	// CivilTwilight, when true, extends the window to civil dawn and dusk
+	// (Sun at -6°) instead of using the fixed offsets.
+	CivilTwilight bool
+}
+
+var (
+	// RulesPart107 is FAA 14 CFR 107.29 in the contiguous United States:
+	// civil twilight is the 30 minutes before official sunrise and after
+	// official sunset, and flying in it requires anti-collision lighting
+	// visible for 3 statute miles.
+	RulesPart107 = FlightRules{Name: "FAA Part 107", BeforeSunrise: 30 * time.Minute, AfterSunset: 30 * time.Minute}
+
+	// RulesPart107Alaska is Part 107 in Alaska, where civil twilight is
+	// taken from the Air Almanac: the Sun's center 6° below the horizon.
+	RulesPart107Alaska = FlightRules{Name: "FAA Part 107 (Alaska)", CivilTwilight: true}
+)
+
+// FlightWindow is the period of a local calendar date in which FlightRules
+// allow flying.
+type FlightWindow struct {
+	Date time.Time // local midnight starting the day
+
+	// Start and End bound the window. When the Sun does not set, or the
+	// twilight extends past midnight, the window runs from or to midnight.
+	Start, End time.Time
+
+	// MorningTwilight (Start to sunrise) and EveningTwilight (sunset to
+	// End) are the parts of the window in which anti-collision lighting is
+	// required. They are empty when the window has no twilight at that end.
+	MorningTwilight, EveningTwilight PhaseWindow
+
+	// LightingRequired is true when any part of the window is twilight.
+	LightingRequired bool
+}
+
+// Contains reports whether t falls within the window.
+func (w FlightWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// RequiresLighting reports whether t falls within one of the twilight
+// parts of the window, where anti-collision lighting is required.
+func (w FlightWindow) RequiresLighting(t time.Time) bool {
+	in := func(p PhaseWindow) bool { return !t.Before(p.Start) && t.Before(p.End) }
+	return in(w.MorningTwilight) || in(w.EveningTwilight)
+}
+
+// DroneFlightWindowFor returns the legal flight window of rules for the
+// local calendar date of date at loc, with sunrise and sunset as for
+// RiseSetFor. Flying outside the window is night operation, which Part
+// 107 allows only with additional training and lighting.
+//
+// If the Sun neither rises nor sets the error matches ErrNoRiseNoSet;
+// under midnight sun (ErrAlwaysUp) the window is instead the whole day.
+//
+// This is a planning aid, not legal advice; airspace, waivers and local
+// regulations still apply.
+func DroneFlightWindowFor(loc Coordinates, date time.Time, rules FlightRules, opts ...Option) (FlightWindow, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return FlightWindow{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return FlightWindow{}, err
+	}
+
+	rs, okRise, okSet := sunAltitudeCrossings(loc, date, 90-cfg.sunZenith(), opts...)
+	w := FlightWindow{Date: rs.Date, Start: rs.Date, End: rs.Date.AddDate(0, 0, 1)}
+	if !okRise && !okSet {
+		up := cfg.sunUpAllDay(loc, date, 90-cfg.sunZenith())
+		if !up {
+			return FlightWindow{}, noCrossing(Sun, "flight window", loc, date, up)
+		}
+		return w, nil
+	}
+
+	dawn, dusk := rs.Rise.Add(-rules.BeforeSunrise), rs.Set.Add(rules.AfterSunset)
+	okDawn, okDusk := okRise, okSet
+	if rules.CivilTwilight {
+		civil, okUp, okDown := sunAltitudeCrossings(loc, date, -6, opts...)
+		dawn, dusk = civil.Rise, civil.Set
+		okDawn, okDusk = okRise && okUp, okSet && okDown
+	}
+
+	if okRise {
+		if okDawn && dawn.After(w.Start) {
+			w.Start = dawn
+		}
+		w.MorningTwilight = PhaseWindow{Start: w.Start, End: rs.Rise}
+	}
+	if okSet {
+		if okDusk && dusk.Before(w.End) {
+			w.End = dusk
+		}
+		w.EveningTwilight = PhaseWindow{Start: rs.Set, End: w.End}
+	}
+	w.LightingRequired = w.MorningTwilight.End.After(w.MorningTwilight.Start) ||
+		w.EveningTwilight.End.After(w.EveningTwilight.Start)
+	return w, nil
+}
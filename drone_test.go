@@ -0,0 +1,69 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDroneFlightWindowFor_Part107(t *testing.T) {
+	loc := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*60*60)
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, mst)
+
+	w, err := DroneFlightWindowFor(loc, date, RulesPart107)
+	if err != nil {
+		t.Fatalf("DroneFlightWindowFor error: %v", err)
+	}
+	rs, err := RiseSetFor(Sun, loc, date)
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	if !w.Start.Equal(rs.Rise.Add(-30*time.Minute)) || !w.End.Equal(rs.Set.Add(30*time.Minute)) {
+		t.Errorf("window %v..%v, want 30 minutes around %v..%v", w.Start, w.End, rs.Rise, rs.Set)
+	}
+	if !w.LightingRequired {
+		t.Error("LightingRequired = false, want true")
+	}
+
+	noon := time.Date(2025, 6, 21, 12, 0, 0, 0, mst)
+	if !w.Contains(noon) || w.RequiresLighting(noon) {
+		t.Errorf("noon: Contains = %v, RequiresLighting = %v", w.Contains(noon), w.RequiresLighting(noon))
+	}
+	dusk := rs.Set.Add(10 * time.Minute)
+	if !w.Contains(dusk) || !w.RequiresLighting(dusk) {
+		t.Errorf("dusk: Contains = %v, RequiresLighting = %v", w.Contains(dusk), w.RequiresLighting(dusk))
+	}
+	if night := rs.Set.Add(time.Hour); w.Contains(night) {
+		t.Errorf("window contains %v", night)
+	}
+}
+
+func TestDroneFlightWindowFor_Alaska(t *testing.T) {
+	// Fairbanks at midsummer: the Sun sets but civil twilight lasts all
+	// night, so the whole day is flyable with lighting around midnight.
+	loc := Coordinates{Lat: 64.84, Lon: -147.72}
+	akdt := time.FixedZone("AKDT", -8*60*60)
+	w, err := DroneFlightWindowFor(loc, time.Date(2025, 6, 21, 0, 0, 0, 0, akdt), RulesPart107Alaska)
+	if err != nil {
+		t.Fatalf("DroneFlightWindowFor error: %v", err)
+	}
+	if w.End.Sub(w.Start) != 24*time.Hour || !w.LightingRequired {
+		t.Errorf("window %v..%v, LightingRequired = %v; want the whole day with lighting", w.Start, w.End, w.LightingRequired)
+	}
+
+	// Utqiagvik: midnight sun, no twilight at all.
+	w, err = DroneFlightWindowFor(Coordinates{Lat: 71.29, Lon: -156.79}, time.Date(2025, 6, 21, 0, 0, 0, 0, akdt), RulesPart107Alaska)
+	if err != nil {
+		t.Fatalf("DroneFlightWindowFor error: %v", err)
+	}
+	if w.End.Sub(w.Start) != 24*time.Hour || w.LightingRequired {
+		t.Errorf("midnight sun: window %v..%v, LightingRequired = %v", w.Start, w.End, w.LightingRequired)
+	}
+
+	// And polar night.
+	_, err = DroneFlightWindowFor(Coordinates{Lat: 71.29, Lon: -156.79}, time.Date(2025, 12, 21, 0, 0, 0, 0, akdt), RulesPart107Alaska)
+	if !errors.Is(err, ErrAlwaysDown) {
+		t.Errorf("polar night: err = %v, want ErrAlwaysDown", err)
+	}
+}
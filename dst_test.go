@@ -0,0 +1,158 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// dstZones are zones whose clocks change in awkward ways: by an hour at
+// 02:00, by half an hour (Lord Howe), at midnight (Santiago) and in a
+// half-hour zone (St. John's).
+var dstZones = []struct {
+	name string
+	loc  Coordinates
+	// dates spanning a transition, first day before it
+	from time.Time
+	tz   string
+}{
+	{"New York spring", Coordinates{Lat: 40.71, Lon: -74.01}, time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), "America/New_York"},
+	{"New York autumn", Coordinates{Lat: 40.71, Lon: -74.01}, time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC), "America/New_York"},
+	{"London autumn", Coordinates{Lat: 51.51, Lon: -0.13}, time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC), "Europe/London"},
+	{"Lord Howe spring", Coordinates{Lat: -31.55, Lon: 159.08}, time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC), "Australia/Lord_Howe"},
+	{"Lord Howe autumn", Coordinates{Lat: -31.55, Lon: 159.08}, time.Date(2025, 4, 4, 0, 0, 0, 0, time.UTC), "Australia/Lord_Howe"},
+	{"Santiago spring", Coordinates{Lat: -33.45, Lon: -70.67}, time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC), "America/Santiago"},
+	{"Santiago autumn", Coordinates{Lat: -33.45, Lon: -70.67}, time.Date(2025, 4, 4, 0, 0, 0, 0, time.UTC), "America/Santiago"},
+	{"St. John's spring", Coordinates{Lat: 47.56, Lon: -52.71}, time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), "America/St_Johns"},
+}
+
+// dstDays returns the six local calendar dates starting at z.from, as
+// values at local noon.
+func dstDays(t *testing.T, tzName string, from time.Time) []time.Time {
+	tz, err := time.LoadLocation(tzName)
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	var days []time.Time
+	for i := 0; i < 6; i++ {
+		d := from.AddDate(0, 0, i)
+		days = append(days, time.Date(d.Year(), d.Month(), d.Day(), 12, 0, 0, 0, tz))
+	}
+	return days
+}
+
+// TestDST_EventsOnTheirLocalDate checks the guarantee that an event
+// reported for a date lies within that local calendar date, however long
+// the date is, and that its Date is the first instant of it.
+func TestDST_EventsOnTheirLocalDate(t *testing.T) {
+	for _, z := range dstZones {
+		for _, day := range dstDays(t, z.tz, z.from) {
+			want := day.Format("2006-01-02")
+			for _, body := range []Body{Sun, Moon} {
+				rs, err := RiseSetFor(body, z.loc, day, WithTrueInstants())
+				if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+					t.Fatalf("%s %s %v: %v", z.name, want, body, err)
+				}
+				if got := rs.Date.Format("2006-01-02"); got != want {
+					t.Errorf("%s %s %v: Date is %s", z.name, want, body, rs.Date)
+				}
+				for _, ev := range []struct {
+					ok bool
+					t  time.Time
+				}{{rs.HasRise, rs.Rise}, {rs.HasSet, rs.Set}} {
+					if ev.ok && ev.t.In(day.Location()).Format("2006-01-02") != want {
+						t.Errorf("%s %s %v: event at %s is on another date", z.name, want, body, ev.t.In(day.Location()))
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestDST_NoMissedOrDuplicatedMoonEvents collects moonrises and moonsets
+// date by date across each transition and checks that consecutive ones
+// are a lunar day apart: a window of 24 elapsed hours loses the events in
+// the last hour of a 25-hour day and repeats those in the first hour after
+// a 23-hour one.
+func TestDST_NoMissedOrDuplicatedMoonEvents(t *testing.T) {
+	for _, z := range dstZones {
+		var rises, sets []time.Time
+		for _, day := range dstDays(t, z.tz, z.from) {
+			evs, err := RiseSetEventsFor(Moon, z.loc, day, WithTrueInstants())
+			if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+				t.Fatalf("%s %s: %v", z.name, day.Format("2006-01-02"), err)
+			}
+			for _, ev := range evs {
+				if ev.Kind == EventRise {
+					rises = append(rises, ev.Time)
+				} else {
+					sets = append(sets, ev.Time)
+				}
+			}
+		}
+		for name, evs := range map[string][]time.Time{"moonrise": rises, "moonset": sets} {
+			for i := 1; i < len(evs); i++ {
+				gap := evs[i].Sub(evs[i-1])
+				if gap < 23*time.Hour || gap > 26*time.Hour {
+					t.Errorf("%s: %ss at %s and %s are %v apart", z.name, name, evs[i-1].In(evs[i].Location()), evs[i].In(evs[i].Location()), gap)
+				}
+			}
+			if len(evs) < 4 {
+				t.Errorf("%s: only %d %ss in six days", z.name, len(evs), name)
+			}
+		}
+	}
+}
+
+func TestDST_LastHourOfLongDay(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	nyc := Coordinates{Lat: 40.7128, Lon: -74.0060}
+
+	// 2023-11-05 is 25 hours long; the moonrise at 23:35 EST falls in the
+	// hour a 24-hour search window would miss.
+	rs, err := RiseSetFor(Moon, nyc, time.Date(2023, 11, 5, 0, 0, 0, 0, ny))
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	if got := rs.Rise.Format("2006-01-02 15:04 MST"); !rs.HasRise || got != "2023-11-05 23:34 EST" {
+		t.Errorf("moonrise = %v (HasRise %v), want 2023-11-05 23:34 EST", rs.Rise, rs.HasRise)
+	}
+}
+
+func TestDST_DayLength(t *testing.T) {
+	for _, c := range []struct {
+		tz    string
+		y     int
+		m     time.Month
+		d     int
+		hours float64
+	}{
+		{"America/New_York", 2025, time.March, 9, 23},
+		{"America/New_York", 2025, time.November, 2, 25},
+		{"Australia/Lord_Howe", 2025, time.October, 5, 23.5},
+		{"Australia/Lord_Howe", 2025, time.April, 6, 24.5},
+		{"America/Santiago", 2025, time.September, 7, 23},
+		{"Asia/Kolkata", 2025, time.March, 9, 24},
+	} {
+		tz, err := time.LoadLocation(c.tz)
+		if err != nil {
+			t.Skipf("tzdata unavailable: %v", err)
+		}
+		date := time.Date(c.y, c.m, c.d, 12, 0, 0, 0, tz)
+		start, end := timeutil.LocalDay(date)
+		if got := end.Sub(start).Hours(); got != c.hours {
+			t.Errorf("%s %s: day is %vh, want %vh", c.tz, date.Format("2006-01-02"), got, c.hours)
+		}
+		if y, m, d := start.Date(); y != c.y || m != c.m || d != c.d {
+			t.Errorf("%s %s: day starts %v", c.tz, date.Format("2006-01-02"), start)
+		}
+		if y, m, d := end.Add(-time.Nanosecond).Date(); y != c.y || m != c.m || d != c.d {
+			t.Errorf("%s %s: day ends %v", c.tz, date.Format("2006-01-02"), end)
+		}
+	}
+}
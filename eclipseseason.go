@@ -0,0 +1,108 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// eclipseLimit is the largest distance (degrees) of the Sun from a lunar
+// node at which an eclipse of any kind can happen: the solar ecliptic
+// limit for a partial eclipse. The limit for a penumbral lunar eclipse is a
+// little smaller.
+const eclipseLimit = 18.5
+
+// sunNodeRate is how fast the Sun moves away from the regressing lunar
+// nodes, degrees per day.
+const sunNodeRate = meanSolarLongitudeRate - meanNodeRate
+
+// EclipseSeason is a window of about five weeks around the Sun's passage of
+// one of the Moon's nodes. Every eclipse happens in such a window, and each
+// season holds at least one solar eclipse; seasons recur about every 173
+// days.
+type EclipseSeason struct {
+	Ascending bool      // the Sun is at the Moon's ascending node, else the descending one
+	Center    time.Time // the Sun reaches the mean node, UTC
+	Start     time.Time // the Sun comes within the ecliptic limit of the node
+	End       time.Time // the Sun leaves it
+
+	// NewMoons and FullMoons list the New and Full Moons within
+	// [Start, End): the possible solar and lunar eclipses. Whether each
+	// actually happens, and how much, depends on how near the node it
+	// falls.
+	NewMoons  []time.Time
+	FullMoons []time.Time
+}
+
+// EclipseSeasons returns the eclipse seasons whose Center falls in the
+// given Gregorian year (UTC): two, or occasionally three. A month with no
+// New or Full Moon inside a season cannot have an eclipse. Centers are
+// good to about a day, since the true node wobbles around the mean one.
+//
+// With WithHistorical, years back to HistoricalMinTime are accepted and
+// the New and Full Moons are placed in UT, ΔT before the Terrestrial Time
+// the models run in. Other options have no effect.
+func EclipseSeasons(year int, opts ...Option) ([]EclipseSeason, error) {
+	cfg := newConfig(opts)
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := cfg.checkRange(from); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	// Search in TT and report in UT, which differ only in historical mode.
+	ut := func(t time.Time) time.Time { return t.Add(-cfg.deltaT(t)) }
+	from = from.Add(cfg.deltaT(from))
+	to := from.AddDate(1, 0, 0)
+
+	// Distance of the Sun past the ascending node; seasons are centered
+	// where it is 0 or 180.
+	elong := func(t time.Time) float64 {
+		return timeutil.Normalize360(sun.EclipticLongitude(t) - meanAscendingNode(t))
+	}
+	halfWidth := daysToDuration(eclipseLimit / sunNodeRate)
+
+	e := elong(from)
+	target := 180.0
+	if e >= 180 {
+		target = 0
+	}
+	t := from.Add(daysToDuration(timeutil.Normalize360(target-e) / sunNodeRate))
+
+	var out []EclipseSeason
+	for {
+		for i := 0; i < 10; i++ {
+			diff := wrap180(target - elong(t))
+			t = t.Add(daysToDuration(diff / sunNodeRate))
+			if math.Abs(diff) < 1e-6 {
+				break
+			}
+		}
+		if !t.Before(to) {
+			break
+		}
+
+		start, end := t.Add(-halfWidth), t.Add(halfWidth)
+		s := EclipseSeason{
+			Ascending: target == 0,
+			Center:    ut(t),
+			Start:     ut(start),
+			End:       ut(end),
+		}
+		for nm := nextLunarPhase(start, 0); nm.Before(end); nm = nextLunarPhase(nm.Add(24*time.Hour), 0) {
+			s.NewMoons = append(s.NewMoons, ut(nm))
+		}
+		for fm := nextLunarPhase(start, 180); fm.Before(end); fm = nextLunarPhase(fm.Add(24*time.Hour), 180) {
+			s.FullMoons = append(s.FullMoons, ut(fm))
+		}
+		out = append(out, s)
+
+		target = 180 - target
+		t = t.Add(daysToDuration(180 / sunNodeRate))
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEclipseSeasons(t *testing.T) {
+	// 2024: penumbral lunar eclipse 03-25 and total solar eclipse 04-08;
+	// partial lunar eclipse 09-18 and annular solar eclipse 10-02.
+	seasons, err := EclipseSeasons(2024)
+	if err != nil {
+		t.Fatalf("EclipseSeasons error: %v", err)
+	}
+	if len(seasons) != 2 {
+		t.Fatalf("got %d seasons, want 2: %+v", len(seasons), seasons)
+	}
+	eclipses := [][]time.Time{
+		{time.Date(2024, 3, 25, 7, 0, 0, 0, time.UTC), time.Date(2024, 4, 8, 18, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 9, 18, 3, 0, 0, 0, time.UTC), time.Date(2024, 10, 2, 19, 0, 0, 0, time.UTC)},
+	}
+	for i, s := range seasons {
+		for _, e := range eclipses[i] {
+			if e.Before(s.Start) || !e.Before(s.End) {
+				t.Errorf("season %d (%v..%v) misses the eclipse of %v", i, s.Start, s.End, e.Format("2006-01-02"))
+			}
+		}
+		if len(s.NewMoons) == 0 {
+			t.Errorf("season %d has no New Moon", i)
+		}
+		if len(s.FullMoons) == 0 {
+			t.Errorf("season %d has no Full Moon", i)
+		}
+		if s.Center.Year() != 2024 {
+			t.Errorf("season %d centered at %v", i, s.Center)
+		}
+	}
+	if seasons[0].Ascending == seasons[1].Ascending {
+		t.Error("consecutive seasons should alternate between the nodes")
+	}
+}
+
+func TestEclipseSeasons_NoEclipseOutside(t *testing.T) {
+	// No eclipse in 2024 between the seasons: the Full Moon of June 22
+	// is far from a node.
+	seasons, err := EclipseSeasons(2024)
+	if err != nil {
+		t.Fatalf("EclipseSeasons error: %v", err)
+	}
+	june := time.Date(2024, 6, 22, 1, 0, 0, 0, time.UTC)
+	for _, s := range seasons {
+		if !june.Before(s.Start) && june.Before(s.End) {
+			t.Errorf("June Full Moon inside season %v..%v", s.Start, s.End)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package astroglide
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// Ecliptic holds geocentric ecliptic coordinates in degrees, referred to
+// the equinox of date: longitude in [0, 360) and latitude in [-90, 90].
+type Ecliptic struct {
+	Lon float64 // ecliptic longitude, degrees
+	Lat float64 // ecliptic latitude, degrees
+}
+
+// EclipticOfDate returns the geocentric ecliptic longitude and latitude of
+// body at t from the same models as EquatorialOfDate. The Sun's latitude is
+// taken as zero; the Moon's position is good to a few tenths of a degree.
+func EclipticOfDate(body Body, t time.Time) (Ecliptic, error) {
+	if err := checkRange(t); err != nil {
+		return Ecliptic{}, err
+	}
+
+	switch body {
+	case Sun:
+		return Ecliptic{Lon: sun.EclipticLongitude(t.UTC())}, nil
+	case Moon:
+		lon, lat := moon.EclipticApprox(t.UTC())
+		return Ecliptic{Lon: lon, Lat: lat}, nil
+	default:
+		return Ecliptic{}, fmt.Errorf("unknown body %v", body)
+	}
+}
+
+// LunarArguments are the mean fundamental arguments of the lunar theory
+// (Meeus, Astronomical Algorithms, ch. 47), in degrees [0, 360). Periodic
+// terms for the Moon, eclipses and tides are sums of sines of their
+// combinations.
+type LunarArguments struct {
+	L      float64 // L', the Moon's mean longitude
+	D      float64 // mean elongation of the Moon from the Sun
+	M      float64 // the Sun's mean anomaly
+	MPrime float64 // M', the Moon's mean anomaly
+	F      float64 // the Moon's argument of latitude, from its ascending node
+}
+
+// LunarArgumentsAt returns the fundamental arguments at t used by the Moon
+// model. The mean ascending node of the Moon is at L - F.
+func LunarArgumentsAt(t time.Time) (LunarArguments, error) {
+	if err := checkRange(t); err != nil {
+		return LunarArguments{}, err
+	}
+
+	a := moon.FundamentalArguments(t.UTC())
+	return LunarArguments{L: a.L, D: a.D, M: a.M, MPrime: a.Mm, F: a.F}, nil
+}
@@ -0,0 +1,57 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEclipticOfDate_Moon(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 47.a: 1992-04-12 0h TD.
+	tm := time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC)
+
+	ecl, err := EclipticOfDate(Moon, tm)
+	if err != nil {
+		t.Fatalf("EclipticOfDate error: %v", err)
+	}
+	if math.Abs(ecl.Lon-133.163) > 0.3 || math.Abs(ecl.Lat-(-3.229)) > 0.2 {
+		t.Errorf("Moon = %+v, want λ 133.163, β -3.229", ecl)
+	}
+
+	args, err := LunarArgumentsAt(tm)
+	if err != nil {
+		t.Fatalf("LunarArgumentsAt error: %v", err)
+	}
+	want := LunarArguments{L: 134.290182, D: 113.842304, M: 97.643514, MPrime: 5.150833, F: 219.889721}
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"L", args.L, want.L}, {"D", args.D, want.D}, {"M", args.M, want.M},
+		{"MPrime", args.MPrime, want.MPrime}, {"F", args.F, want.F},
+	} {
+		if math.Abs(c.got-c.want) > 0.02 {
+			t.Errorf("%s = %.6f, want %.6f", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestEclipticOfDate_FullMoon(t *testing.T) {
+	// At Full Moon the Moon is opposite the Sun in ecliptic longitude.
+	tm := time.Date(2025, 5, 12, 16, 56, 0, 0, time.UTC)
+	sun, err := EclipticOfDate(Sun, tm)
+	if err != nil {
+		t.Fatalf("EclipticOfDate(Sun) error: %v", err)
+	}
+	moon, _ := EclipticOfDate(Moon, tm)
+	if d := math.Abs(wrap180(moon.Lon - sun.Lon - 180)); d > 0.5 {
+		t.Errorf("Moon - Sun = %.2f°, want ~180°", moon.Lon-sun.Lon)
+	}
+	if sun.Lat != 0 {
+		t.Errorf("Sun latitude = %v, want 0", sun.Lat)
+	}
+
+	if _, err := EclipticOfDate(Body(99), tm); err == nil {
+		t.Error("expected error for an unknown body")
+	}
+}
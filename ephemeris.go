@@ -0,0 +1,195 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// EphemerisProvider supplies the position of an object that astroglide has
+// no model for: a comet or asteroid from orbital elements, a planet from an
+// external ephemeris, or a table fetched from JPL Horizons.
+//
+// Equatorial returns the geocentric right ascension and declination of the
+// object at t in degrees, referred to the equinox of date like
+// EquatorialOfDate, and its distance from the Earth's centre in kilometres.
+// A distance of 0 or +Inf means "very far away": no parallax is applied.
+// Convert J2000 coordinates with PrecessToDate.
+type EphemerisProvider interface {
+	Equatorial(t time.Time) (ra, dec, distKm float64)
+}
+
+// EphemerisFunc adapts a function to an EphemerisProvider.
+type EphemerisFunc func(t time.Time) (ra, dec, distKm float64)
+
+// Equatorial calls f(t).
+func (f EphemerisFunc) Equatorial(t time.Time) (ra, dec, distKm float64) {
+	return f(t)
+}
+
+// FixedObject is an EphemerisProvider for an object that does not move
+// against the stars, such as a star or deep-sky object, given by its
+// J2000.0 catalog position. It is precessed to the date of each call.
+type FixedObject Equatorial
+
+// Equatorial returns the object's position precessed to t, with no
+// parallax.
+func (o FixedObject) Equatorial(t time.Time) (ra, dec, distKm float64) {
+	eq := PrecessToDate(Equatorial(o), t)
+	return eq.RA, eq.Dec, 0
+}
+
+// objectRiseAltitude is the altitude (degrees, geometric) of a point source
+// at rise and set: the standard refraction at the horizon, 34'.
+const objectRiseAltitude = -34.0 / 60
+
+// ObjectPositionAt is PositionAt for an object whose ephemeris p supplies.
+func ObjectPositionAt(p EphemerisProvider, loc Coordinates, t time.Time, frame PositionFrame) (Position, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return Position{}, err
+	}
+	return objectPosition(p, loc, t, frame), nil
+}
+
+// objectPosition computes ObjectPositionAt without checking its inputs.
+func objectPosition(p EphemerisProvider, loc Coordinates, t time.Time, frame PositionFrame) Position {
+	ra, dec, dist := p.Equatorial(t)
+	lst := coord.LocalSiderealTime(loc.Lon, t.UTC())
+	if frame == Topocentric && dist > 0 && !math.IsInf(dist, 1) {
+		ra, dec, dist = coord.Topocentric(ra, dec, dist, loc.Lat, loc.Elevation, lst)
+	}
+	alt, az := coord.Horizontal(ra, dec, loc.Lat, lst)
+	return Position{
+		Equatorial: Equatorial{RA: timeutil.Normalize360(ra), Dec: dec},
+		Altitude:   alt,
+		Azimuth:    az,
+		DistanceKm: dist,
+		Frame:      frame,
+	}
+}
+
+// ObjectRiseSetFor is RiseSetFor for an object whose ephemeris p supplies.
+// The object is treated as a point: it rises and sets when its topocentric
+// centre is 34' below the horizon, the standard refraction. WithZenith
+// sets another altitude; WithRounding and WithTrueInstants apply as usual,
+// WithLimb has no effect and WithHistorical is rejected with
+// ErrNotImplemented.
+//
+// Uncertainty covers only the solver; the accuracy of p is up to its
+// author. When the object neither rises nor sets on the date the error
+// matches ErrNoRiseNoSet and ErrAlwaysUp or ErrAlwaysDown, with Body set to
+// CustomBody.
+func ObjectRiseSetFor(p EphemerisProvider, loc Coordinates, date time.Time, opts ...Option) (RiseSet, error) {
+	cfg := newConfig(opts)
+	if cfg.historical {
+		return RiseSet{}, fmt.Errorf("%v in historical mode: %w", CustomBody, ErrNotImplemented)
+	}
+	if err := checkInputs(loc, date); err != nil {
+		return RiseSet{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return RiseSet{}, err
+	}
+	target := objectRiseAltitude
+	if cfg.hasZenith {
+		target = 90 - cfg.zenith
+	}
+
+	tz := date.Location()
+	year, month, day := date.Date()
+	start, end := timeutil.LocalDay(date)
+
+	alt := func(t time.Time) float64 {
+		return objectPosition(p, loc, t, Topocentric).Altitude
+	}
+
+	const (
+		steps = 48 // samples across the day (every 30 minutes)
+		tol   = time.Second
+	)
+
+	rs := RiseSet{Date: start}
+	var found []time.Time
+	for _, c := range solver.FindAllAltitudeEvents(alt, start, end, target, steps, tol) {
+		if !c.Time.Before(end) {
+			continue
+		}
+		switch {
+		case c.Type == solver.CrossingUp && !rs.HasRise:
+			rs.Rise, rs.HasRise = cfg.pin(c.Time.In(tz), year, month, day), true
+			found = append(found, c.Time)
+		case c.Type == solver.CrossingDown && !rs.HasSet:
+			rs.Set, rs.HasSet = cfg.pin(c.Time.In(tz), year, month, day), true
+			found = append(found, c.Time)
+		}
+	}
+	if len(found) == 0 {
+		noon := time.Date(year, month, day, 12, 0, 0, 0, tz)
+		return RiseSet{}, noCrossing(CustomBody, "rise/set", loc, date, alt(noon) > target)
+	}
+	rs.Uncertainty = (tol + cfg.round/2).Round(time.Second)
+	return rs, nil
+}
+
+// Transit holds an object's meridian transits on a local calendar date;
+// see LunarTransit, which it mirrors.
+type Transit struct {
+	Upper time.Time
+	Lower time.Time
+
+	HasUpper bool
+	HasLower bool
+
+	// Topocentric altitudes (degrees, geometric) at each transit.
+	UpperAltitude float64
+	LowerAltitude float64
+}
+
+// ObjectTransitFor returns the upper and lower meridian transits on the
+// given local calendar date of an object whose ephemeris p supplies, in
+// the date's time zone. An object that moves slowly against the stars
+// transits about 4 minutes earlier each day.
+//
+// If neither transit falls on the date, a *NoEventError matching
+// ErrNoRiseNoSet is returned.
+func ObjectTransitFor(p EphemerisProvider, loc Coordinates, date time.Time) (Transit, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return Transit{}, err
+	}
+
+	tz := date.Location()
+	start, end := timeutil.LocalDay(date)
+
+	sinHA := func(t time.Time) float64 {
+		pos := objectPosition(p, loc, t, Topocentric)
+		return timeutil.SinD(coord.LocalSiderealTime(loc.Lon, t.UTC()) - pos.RA)
+	}
+
+	const (
+		steps = 48
+		tol   = time.Second
+	)
+
+	var tr Transit
+	for _, c := range solver.FindAllAltitudeEvents(sinHA, start, end, 0, steps, tol) {
+		if !c.Time.Before(end) {
+			continue
+		}
+		pos := objectPosition(p, loc, c.Time, Topocentric)
+		switch {
+		case c.Type == solver.CrossingUp && !tr.HasUpper:
+			tr.Upper, tr.UpperAltitude, tr.HasUpper = c.Time.In(tz), pos.Altitude, true
+		case c.Type == solver.CrossingDown && !tr.HasLower:
+			tr.Lower, tr.LowerAltitude, tr.HasLower = c.Time.In(tz), pos.Altitude, true
+		}
+	}
+
+	if !tr.HasUpper && !tr.HasLower {
+		return Transit{}, noEvent(CustomBody, "transit", loc, date)
+	}
+	return tr, nil
+}
@@ -0,0 +1,107 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// sunEphemeris supplies the Sun through the EphemerisProvider interface.
+var sunEphemeris = EphemerisFunc(func(t time.Time) (ra, dec, distKm float64) {
+	eq, _ := EquatorialOfDate(Sun, t)
+	return eq.RA, eq.Dec, geocentricDistanceKm(Sun, t)
+})
+
+func TestObjectPositionAtMatchesPositionAt(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740, Elevation: 331}
+	at := time.Date(2025, time.June, 21, 17, 30, 0, 0, time.UTC)
+
+	for _, frame := range []PositionFrame{Geocentric, Topocentric} {
+		want, err := PositionAt(Sun, phx, at, frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ObjectPositionAt(sunEphemeris, phx, at, frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got.Altitude-want.Altitude) > 1e-6 || math.Abs(got.Azimuth-want.Azimuth) > 1e-6 {
+			t.Errorf("%v: alt/az = %.6f/%.6f, want %.6f/%.6f", frame, got.Altitude, got.Azimuth, want.Altitude, want.Azimuth)
+		}
+	}
+}
+
+func TestObjectRiseSetForSun(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+	date := time.Date(2025, time.March, 20, 0, 0, 0, 0, mst)
+
+	want, err := RiseSetFor(Sun, phx, date, WithTrueInstants(), WithZenith(90.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ObjectRiseSetFor(sunEphemeris, phx, date, WithTrueInstants(), WithZenith(90.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.HasRise || !got.HasSet {
+		t.Fatalf("got %+v, want a rise and a set", got)
+	}
+	if d := got.Rise.Sub(want.Rise); d.Abs() > 5*time.Second {
+		t.Errorf("rise = %v, want %v", got.Rise, want.Rise)
+	}
+	if d := got.Set.Sub(want.Set); d.Abs() > 5*time.Second {
+		t.Errorf("set = %v, want %v", got.Set, want.Set)
+	}
+}
+
+func TestObjectRiseSetForFixedObject(t *testing.T) {
+	// Polaris never sets from mid-northern latitudes.
+	polaris := FixedObject{RA: 37.9546, Dec: 89.2641}
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := ObjectRiseSetFor(polaris, Coordinates{Lat: 45, Lon: 0}, date)
+	if !errors.Is(err, ErrNoRiseNoSet) || !errors.Is(err, ErrAlwaysUp) {
+		t.Fatalf("err = %v, want ErrNoRiseNoSet and ErrAlwaysUp", err)
+	}
+	var nev *NoEventError
+	if errors.As(err, &nev) && nev.Body != CustomBody {
+		t.Errorf("Body = %v, want %v", nev.Body, CustomBody)
+	}
+
+	// Sirius rises and sets, about 4 minutes earlier each day.
+	sirius := FixedObject{RA: 101.2872, Dec: -16.7161}
+	a, err := ObjectRiseSetFor(sirius, Coordinates{Lat: 45, Lon: 0}, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ObjectRiseSetFor(sirius, Coordinates{Lat: 45, Lon: 0}, date.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := a.Rise.Add(24 * time.Hour).Sub(b.Rise); d < 3*time.Minute || d > 5*time.Minute {
+		t.Errorf("rises %v and %v differ by 24h less %v, want about 3m56s", a.Rise, b.Rise, d)
+	}
+}
+
+func TestObjectTransitFor(t *testing.T) {
+	loc := Coordinates{Lat: 45, Lon: 0}
+	sirius := FixedObject{RA: 101.2872, Dec: -16.7161}
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	tr, err := ObjectTransitFor(sirius, loc, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.HasUpper {
+		t.Fatalf("got %+v, want an upper transit", tr)
+	}
+	pos, _ := ObjectPositionAt(sirius, loc, tr.Upper, Topocentric)
+	if math.Abs(pos.Azimuth-180) > 0.01 {
+		t.Errorf("azimuth at upper transit = %.3f, want 180", pos.Azimuth)
+	}
+	if want := 90 - loc.Lat + pos.Dec; math.Abs(tr.UpperAltitude-want) > 0.01 {
+		t.Errorf("upper transit altitude = %.3f, want %.3f", tr.UpperAltitude, want)
+	}
+}
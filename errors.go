@@ -0,0 +1,81 @@
+package astroglide
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrAlwaysUp is matched, along with ErrNoRiseNoSet, when a body has no
+	// rise or set on a date because it stays above the horizon (or the
+	// twilight altitude) all day, e.g. the midnight sun.
+	ErrAlwaysUp = errors.New("body stays up all day")
+
+	// ErrAlwaysDown is matched, along with ErrNoRiseNoSet, when a body stays
+	// below the horizon (or the twilight altitude) all day, e.g. polar night.
+	ErrAlwaysDown = errors.New("body stays down all day")
+)
+
+// NoEventError reports that an event does not occur for a body on a date at
+// a location, e.g. the Sun never sets during polar summer. It carries
+// enough context for callers to log which request failed without parsing
+// the message, and matches its sentinel (ErrNoRiseNoSet unless stated
+// otherwise) with errors.Is:
+//
+//	var nev *astroglide.NoEventError
+//	if errors.As(err, &nev) {
+//		log.Printf("no %s for %v at %+v on %s", nev.Kind, nev.Body, nev.Coords, nev.Date)
+//	}
+type NoEventError struct {
+	Body   Body
+	Kind   string // event that was searched for, e.g. "rise/set", "civil twilight"
+	Date   time.Time
+	Coords Coordinates
+
+	// Err is the sentinel this error matches: ErrNoRiseNoSet,
+	// ErrNoDarkWindow or ErrNoCrescentSighting. Nil means ErrNoRiseNoSet.
+	Err error
+
+	// For rise/set and twilight with neither event on the date, AlwaysUp or
+	// AlwaysDown tells whether the body stayed above or below the altitude
+	// all day; the error then also matches ErrAlwaysUp or ErrAlwaysDown.
+	AlwaysUp   bool
+	AlwaysDown bool
+}
+
+func (e *NoEventError) Error() string {
+	state := ""
+	switch {
+	case e.AlwaysUp:
+		state = " (up all day)"
+	case e.AlwaysDown:
+		state = " (down all day)"
+	}
+	return fmt.Sprintf("%s: no %s for %v on %s at (%.4f, %.4f)%s", e.Unwrap(),
+		e.Kind, e.Body, e.Date.Format("2006-01-02"), e.Coords.Lat, e.Coords.Lon, state)
+}
+
+// Is matches ErrAlwaysUp and ErrAlwaysDown as recorded in e.
+func (e *NoEventError) Is(target error) bool {
+	return (target == ErrAlwaysUp && e.AlwaysUp) || (target == ErrAlwaysDown && e.AlwaysDown)
+}
+
+// Unwrap returns the sentinel the error stands for.
+func (e *NoEventError) Unwrap() error {
+	if e.Err == nil {
+		return ErrNoRiseNoSet
+	}
+	return e.Err
+}
+
+// noEvent returns a *NoEventError matching ErrNoRiseNoSet.
+func noEvent(body Body, kind string, loc Coordinates, date time.Time) error {
+	return &NoEventError{Body: body, Kind: kind, Date: date, Coords: loc}
+}
+
+// noCrossing returns a *NoEventError matching ErrNoRiseNoSet and, as up
+// says, ErrAlwaysUp or ErrAlwaysDown.
+func noCrossing(body Body, kind string, loc Coordinates, date time.Time, up bool) error {
+	return &NoEventError{Body: body, Kind: kind, Date: date, Coords: loc, AlwaysUp: up, AlwaysDown: !up}
+}
@@ -0,0 +1,97 @@
+package astroglide
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNoEventError_PolarNight(t *testing.T) {
+	tromso := Coordinates{Lat: 69.6496, Lon: 18.9560}
+	date := time.Date(2025, time.December, 21, 0, 0, 0, 0, time.UTC)
+
+	_, err := RiseSetFor(Sun, tromso, date)
+	if !errors.Is(err, ErrNoRiseNoSet) {
+		t.Fatalf("err = %v, want ErrNoRiseNoSet", err)
+	}
+
+	var nev *NoEventError
+	if !errors.As(err, &nev) {
+		t.Fatalf("err = %#v, want *NoEventError", err)
+	}
+	if nev.Body != Sun || nev.Kind != "rise/set" || nev.Coords != tromso || !nev.Date.Equal(date) {
+		t.Errorf("unexpected context: %+v", nev)
+	}
+}
+
+func TestNoEventError_Twilight(t *testing.T) {
+	// In London at the June solstice the Sun never gets below -18°.
+	london := Coordinates{Lat: 51.5074, Lon: -0.1278}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	_, err := TwilightFor(london, date, TwilightAstronomical)
+	var nev *NoEventError
+	if !errors.As(err, &nev) || !errors.Is(err, ErrNoRiseNoSet) {
+		t.Fatalf("err = %v, want *NoEventError matching ErrNoRiseNoSet", err)
+	}
+	if nev.Kind != "astronomical twilight" {
+		t.Errorf("Kind = %q, want %q", nev.Kind, "astronomical twilight")
+	}
+	if errors.Is(err, ErrNoDarkWindow) {
+		t.Error("twilight error unexpectedly matches ErrNoDarkWindow")
+	}
+}
+
+func TestNoEventError_AlwaysUpDown(t *testing.T) {
+	tromso := Coordinates{Lat: 69.6496, Lon: 18.9560}
+	june := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC)
+	december := time.Date(2025, time.December, 21, 0, 0, 0, 0, time.UTC)
+
+	_, err := RiseSetFor(Sun, tromso, june)
+	if !errors.Is(err, ErrNoRiseNoSet) || !errors.Is(err, ErrAlwaysUp) || errors.Is(err, ErrAlwaysDown) {
+		t.Errorf("midnight sun: err = %v, want ErrNoRiseNoSet and ErrAlwaysUp", err)
+	}
+	_, err = RiseSetFor(Sun, tromso, december)
+	if !errors.Is(err, ErrAlwaysDown) || errors.Is(err, ErrAlwaysUp) {
+		t.Errorf("polar night: err = %v, want ErrAlwaysDown", err)
+	}
+
+	// London's June nights stay brighter than astronomical twilight.
+	london := Coordinates{Lat: 51.5074, Lon: -0.1278}
+	if _, err := TwilightFor(london, june, TwilightAstronomical); !errors.Is(err, ErrAlwaysUp) {
+		t.Errorf("London astronomical twilight: err = %v, want ErrAlwaysUp", err)
+	}
+
+	// Far north the Moon stays up or down for days around the standstill
+	// extremes of its declination; both occur within a month.
+	var up, down int
+	for d := 0; d < 30; d++ {
+		date := time.Date(2025, time.January, 1+d, 0, 0, 0, 0, time.UTC)
+		_, err := RiseSetFor(Moon, Coordinates{Lat: 78.22, Lon: 15.65}, date)
+		switch {
+		case errors.Is(err, ErrAlwaysUp):
+			up++
+			if pos, _ := PositionAt(Moon, Coordinates{Lat: 78.22, Lon: 15.65}, date.Add(12*time.Hour), Topocentric); pos.Altitude < 0 {
+				t.Errorf("%s: ErrAlwaysUp with the Moon at %.1f°", date.Format("2006-01-02"), pos.Altitude)
+			}
+		case errors.Is(err, ErrAlwaysDown):
+			down++
+			if pos, _ := PositionAt(Moon, Coordinates{Lat: 78.22, Lon: 15.65}, date.Add(12*time.Hour), Topocentric); pos.Altitude > 0 {
+				t.Errorf("%s: ErrAlwaysDown with the Moon at %.1f°", date.Format("2006-01-02"), pos.Altitude)
+			}
+		case err != nil:
+			if errors.Is(err, ErrNoRiseNoSet) {
+				t.Errorf("%s: %v matches neither ErrAlwaysUp nor ErrAlwaysDown", date.Format("2006-01-02"), err)
+			}
+		}
+	}
+	if up == 0 || down == 0 {
+		t.Errorf("Svalbard January: %d days with the Moon always up and %d always down, want both", up, down)
+	}
+
+	var nev *NoEventError
+	if _, err := RiseSetFor(Sun, tromso, june); errors.As(err, &nev) && !strings.Contains(nev.Error(), "up all day") {
+		t.Errorf("Error() = %q, want it to say up all day", nev.Error())
+	}
+}
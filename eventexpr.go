@@ -0,0 +1,147 @@
+package astroglide
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// EventExpr is a named event with an optional offset, such as "sunset-45m"
+// or "civil_dawn+10m", as used by home-automation schedules. Parse one with
+// ParseEventExpr.
+type EventExpr struct {
+	Name   string // canonical event name, e.g. "sunset"
+	Body   Body
+	Kind   EventKind
+	Offset time.Duration // added to the event time (negative = before)
+}
+
+// eventExprNames maps expression names to a body and event kind.
+var eventExprNames = map[string]struct {
+	body Body
+	kind EventKind
+}{
+	"sunrise":           {Sun, EventRise},
+	"sunset":            {Sun, EventSet},
+	"solar_noon":        {Sun, EventTransit},
+	"civil_dawn":        {Sun, EventCivilDawn},
+	"civil_dusk":        {Sun, EventCivilDusk},
+	"nautical_dawn":     {Sun, EventNauticalDawn},
+	"nautical_dusk":     {Sun, EventNauticalDusk},
+	"astronomical_dawn": {Sun, EventAstronomicalDawn},
+	"astronomical_dusk": {Sun, EventAstronomicalDusk},
+	"moonrise":          {Moon, EventRise},
+	"moonset":           {Moon, EventSet},
+	"lunar_noon":        {Moon, EventTransit},
+	"new_moon":          {Moon, EventNewMoon},
+	"first_quarter":     {Moon, EventFirstQuarter},
+	"full_moon":         {Moon, EventFullMoon},
+	"last_quarter":      {Moon, EventLastQuarter},
+}
+
+// EventExprNames returns the event names ParseEventExpr accepts, sorted.
+func EventExprNames() []string {
+	names := make([]string, 0, len(eventExprNames))
+	for name := range eventExprNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseEventExpr parses an event expression: an event name (see
+// EventExprNames; case-insensitive) optionally followed by a signed Go
+// duration, e.g. "sunset-45m", "civil_dawn+10m", "solar_noon" or
+// "sunrise + 1h30m".
+func ParseEventExpr(s string) (EventExpr, error) {
+	compact := strings.ToLower(strings.Join(strings.Fields(s), ""))
+
+	name, offS := compact, ""
+	if i := strings.IndexAny(compact, "+-"); i >= 0 {
+		name, offS = compact[:i], compact[i:]
+	}
+
+	def, ok := eventExprNames[name]
+	if !ok {
+		return EventExpr{}, fmt.Errorf("unknown event %q in expression %q", name, s)
+	}
+
+	e := EventExpr{Name: name, Body: def.body, Kind: def.kind}
+	if offS != "" {
+		d, err := time.ParseDuration(offS)
+		if err != nil {
+			return EventExpr{}, fmt.Errorf("invalid offset in expression %q: %w", s, err)
+		}
+		e.Offset = d
+	}
+
+	return e, nil
+}
+
+// String formats the expression in the form ParseEventExpr accepts.
+func (e EventExpr) String() string {
+	switch {
+	case e.Offset > 0:
+		return e.Name + "+" + formatOffset(e.Offset)
+	case e.Offset < 0:
+		return e.Name + "-" + formatOffset(-e.Offset)
+	default:
+		return e.Name
+	}
+}
+
+// formatOffset renders d like time.Duration.String without the trailing
+// zero units, e.g. "45m" rather than "45m0s".
+func formatOffset(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// Resolve returns the time of the expression on the given local calendar
+// date: the event on that date plus the offset. Lunar phase events resolve
+// only on the date they fall on. If the event does not occur on the date, a
+// *NoEventError is returned.
+func (e EventExpr) Resolve(loc Coordinates, date time.Time) (time.Time, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return time.Time{}, err
+	}
+	if err := validateEventKinds(e.Body, []EventKind{e.Kind}); err != nil {
+		return time.Time{}, err
+	}
+
+	tz := date.Location()
+	midnight, next := timeutil.LocalDay(date)
+
+	if target, ok := e.Kind.lunarPhaseElongation(); ok {
+		t := nextLunarPhase(midnight.Add(-time.Nanosecond), target).In(tz)
+		if t.Before(next) {
+			return t.Add(e.Offset), nil
+		}
+		return time.Time{}, noEvent(e.Body, e.Kind.String(), loc, date)
+	}
+
+	t, ok := dailyEvent(e.Body, e.Kind, loc, midnight)
+	if !ok {
+		return time.Time{}, noEvent(e.Body, e.Kind.String(), loc, date)
+	}
+	return t.Add(e.Offset), nil
+}
+
+// Next returns the first time after `after` at which the expression occurs,
+// i.e. the next event whose offset-adjusted time is later than after.
+func (e EventExpr) Next(loc Coordinates, after time.Time) (time.Time, error) {
+	ev, err := NextEvent(e.Body, loc, after.Add(-e.Offset), e.Kind)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ev.Time.Add(e.Offset), nil
+}
@@ -0,0 +1,88 @@
+package astroglide
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEventExpr(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantName   string
+		wantBody   Body
+		wantKind   EventKind
+		wantOffset time.Duration
+	}{
+		{"sunset-45m", "sunset", Sun, EventSet, -45 * time.Minute},
+		{"civil_dawn+10m", "civil_dawn", Sun, EventCivilDawn, 10 * time.Minute},
+		{"solar_noon", "solar_noon", Sun, EventTransit, 0},
+		{" Sunrise + 1h30m ", "sunrise", Sun, EventRise, 90 * time.Minute},
+		{"moonrise-2h", "moonrise", Moon, EventRise, -2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEventExpr(tt.in)
+		if err != nil {
+			t.Errorf("ParseEventExpr(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got.Name != tt.wantName || got.Body != tt.wantBody || got.Kind != tt.wantKind || got.Offset != tt.wantOffset {
+			t.Errorf("ParseEventExpr(%q) = %+v", tt.in, got)
+		}
+		if got.String() == "" || strings.Contains(got.String(), "0s") {
+			t.Errorf("String() = %q, want compact offset", got.String())
+		}
+		if again, err := ParseEventExpr(got.String()); err != nil || again != got {
+			t.Errorf("round trip of %q via %q = %+v, %v", tt.in, got.String(), again, err)
+		}
+	}
+
+	for _, bad := range []string{"", "dusk", "sunset+", "sunset-45", "sunset*2"} {
+		if _, err := ParseEventExpr(bad); err == nil {
+			t.Errorf("ParseEventExpr(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestEventExpr_Resolve(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, loc)
+
+	rs, err := SlideIntoSunset(phx, date)
+	if err != nil {
+		t.Fatalf("SlideIntoSunset error: %v", err)
+	}
+
+	e, _ := ParseEventExpr("sunset-45m")
+	got, err := e.Resolve(phx, date)
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	if want := rs.Set.Add(-45 * time.Minute); !got.Equal(want) {
+		t.Errorf("Resolve(sunset-45m) = %v, want %v", got, want)
+	}
+
+	// Between 45 minutes before sunset and sunset, "sunset-45m" has already
+	// passed today, so Next moves to tomorrow.
+	next, err := e.Next(phx, rs.Set.Add(-10*time.Minute))
+	if err != nil {
+		t.Fatalf("Next error: %v", err)
+	}
+	if next.Day() != 22 {
+		t.Errorf("Next(sunset-45m) = %v, want 2025-06-22", next)
+	}
+
+	// A full Moon resolves only on its own date (2025-06-11 07:44 UTC).
+	full, _ := ParseEventExpr("full_moon")
+	if _, err := full.Resolve(phx, time.Date(2025, 6, 11, 0, 0, 0, 0, loc)); err != nil {
+		t.Errorf("full_moon on 2025-06-11: %v", err)
+	}
+	if _, err := full.Resolve(phx, date); err == nil {
+		t.Error("full_moon on 2025-06-21 resolved, want error")
+	}
+}
@@ -0,0 +1,319 @@
+package astroglide
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// EventKind identifies an astronomical event that NextEvent can search for.
+type EventKind int
+
+const (
+	EventRise EventKind = iota
+	EventSet
+	EventTransit // upper meridian transit (solar noon, lunar noon)
+
+	EventCivilDawn
+	EventCivilDusk
+	EventNauticalDawn
+	EventNauticalDusk
+	EventAstronomicalDawn
+	EventAstronomicalDusk
+
+	EventNewMoon
+	EventFirstQuarter
+	EventFullMoon
+	EventLastQuarter
+)
+
+var eventKindNames = map[EventKind]string{
+	EventRise:             "rise",
+	EventSet:              "set",
+	EventTransit:          "transit",
+	EventCivilDawn:        "civil dawn",
+	EventCivilDusk:        "civil dusk",
+	EventNauticalDawn:     "nautical dawn",
+	EventNauticalDusk:     "nautical dusk",
+	EventAstronomicalDawn: "astronomical dawn",
+	EventAstronomicalDusk: "astronomical dusk",
+	EventNewMoon:          "new moon",
+	EventFirstQuarter:     "first quarter",
+	EventFullMoon:         "full moon",
+	EventLastQuarter:      "last quarter",
+}
+
+// String returns the event name, e.g. "civil dusk".
+func (k EventKind) String() string {
+	if name, ok := eventKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// lunarPhaseElongation returns the elongation (degrees) of a phase event.
+func (k EventKind) lunarPhaseElongation() (float64, bool) {
+	switch k {
+	case EventNewMoon:
+		return 0, true
+	case EventFirstQuarter:
+		return 90, true
+	case EventFullMoon:
+		return 180, true
+	case EventLastQuarter:
+		return 270, true
+	default:
+		return 0, false
+	}
+}
+
+// twilight returns the twilight kind and whether k is its dawn (true) or
+// dusk (false) crossing.
+func (k EventKind) twilight() (kind TwilightKind, dawn, ok bool) {
+	switch k {
+	case EventCivilDawn:
+		return TwilightCivil, true, true
+	case EventCivilDusk:
+		return TwilightCivil, false, true
+	case EventNauticalDawn:
+		return TwilightNautical, true, true
+	case EventNauticalDusk:
+		return TwilightNautical, false, true
+	case EventAstronomicalDawn:
+		return TwilightAstronomical, true, true
+	case EventAstronomicalDusk:
+		return TwilightAstronomical, false, true
+	default:
+		return 0, false, false
+	}
+}
+
+// Event is one occurrence of an astronomical event.
+type Event struct {
+	Body Body
+	Kind EventKind
+	Time time.Time // exact instant, in the time zone of the search start
+}
+
+// maxEventSearchDays bounds the search of NextEvent and PreviousEvent. A
+// year covers the longest polar day or night.
+const maxEventSearchDays = 370
+
+// NextEvent returns the first event of the given kinds for body strictly
+// after `after`, searching forward across local day boundaries (in after's
+// time zone). With no kinds it looks for the next rise or set.
+//
+// Twilight kinds apply to the Sun and lunar phase kinds to the Moon.
+// Event times are true instants (see WithTrueInstants).
+//
+// If none of the kinds occurs within about a year, a *NoEventError matching
+// ErrNoRiseNoSet is returned.
+func NextEvent(body Body, loc Coordinates, after time.Time, kinds ...EventKind) (Event, error) {
+	return searchEvent(body, loc, after, true, kinds)
+}
+
+// PreviousEvent returns the last event of the given kinds for body strictly
+// before `before`, searching backward across local day boundaries. It finds
+// the events a date's RiseSet lacks because they fell on the date before,
+// such as the sunset just after midnight that ended yesterday's daylight
+// near the Arctic Circle, or a moonrise skipped by the Moon's daily delay.
+//
+// Kinds, time zone and errors are as for NextEvent.
+func PreviousEvent(body Body, loc Coordinates, before time.Time, kinds ...EventKind) (Event, error) {
+	return searchEvent(body, loc, before, false, kinds)
+}
+
+// searchEvent implements NextEvent (forward) and PreviousEvent.
+func searchEvent(body Body, loc Coordinates, from time.Time, forward bool, kinds []EventKind) (Event, error) {
+	if err := checkInputs(loc, from); err != nil {
+		return Event{}, err
+	}
+	if len(kinds) == 0 {
+		kinds = []EventKind{EventRise, EventSet}
+	}
+	if err := validateEventKinds(body, kinds); err != nil {
+		return Event{}, err
+	}
+
+	// closer reports whether a is nearer to from than b, in the search
+	// direction.
+	closer := func(a, b time.Time) bool {
+		if forward {
+			return a.Before(b)
+		}
+		return a.After(b)
+	}
+
+	var best Event
+	consider := func(k EventKind, t time.Time) {
+		if closer(from, t) && (best.Time.IsZero() || closer(t, best.Time)) {
+			best = Event{Body: body, Kind: k, Time: t}
+		}
+	}
+
+	// Lunar phases are global instants; solve for them directly.
+	var daily []EventKind
+	for _, k := range kinds {
+		if target, ok := k.lunarPhaseElongation(); ok {
+			if forward {
+				consider(k, nextLunarPhase(from, target).In(from.Location()))
+			} else {
+				consider(k, prevLunarPhase(from.Add(-time.Nanosecond), target).In(from.Location()))
+			}
+			continue
+		}
+		daily = append(daily, k)
+	}
+
+	tz := from.Location()
+	year, month, day := from.Date()
+	step := 1
+	if !forward {
+		step = -1
+	}
+
+	for i := 0; len(daily) > 0 && i < maxEventSearchDays; i++ {
+		// Local noon always exists, unlike midnight in some zones.
+		date, next := timeutil.LocalDay(time.Date(year, month, day+step*i, 12, 0, 0, 0, tz))
+		near, far := date, next
+		if !forward {
+			near, far = next, date
+		}
+		if !best.Time.IsZero() && closer(best.Time, near) {
+			break
+		}
+
+		for _, k := range daily {
+			if t, ok := dailyEvent(body, k, loc, date); ok {
+				consider(k, t)
+			}
+		}
+
+		if !best.Time.IsZero() && closer(best.Time, far) {
+			break
+		}
+	}
+
+	if best.Time.IsZero() {
+		names := make([]string, len(kinds))
+		for i, k := range kinds {
+			names[i] = k.String()
+		}
+		return Event{}, noEvent(body, strings.Join(names, "/"), loc, from)
+	}
+
+	return best, nil
+}
+
+// RiseSetEventsFor returns every rise and set of body on the local calendar
+// date, in time order. Most days have one of each and match RiseSetFor, but
+// near the poles the Moon (and, around the equinoxes, the Sun) can cross the
+// horizon three or more times in one day; RiseSetFor reports only the first
+// crossing in each direction.
+//
+// WithLimb, WithZenith and WithTrueInstants apply as for RiseSetFor. The
+// sampled solver is always used, whatever WithPrecision says.
+//
+// If the body neither rises nor sets, a *NoEventError matching
+// ErrNoRiseNoSet is returned.
+func RiseSetEventsFor(body Body, loc Coordinates, date time.Time, opts ...Option) ([]Event, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	var rises, sets []time.Time
+	switch body {
+	case Sun:
+		rises, sets = cfg.sunAllCrossingsUTC(loc, date, 90-cfg.sunZenith())
+	case Moon:
+		rises, sets = cfg.moonAllCrossingsUTC(loc, date)
+	default:
+		return nil, fmt.Errorf("unknown body %v", body)
+	}
+
+	if len(rises) == 0 && len(sets) == 0 {
+		if body == Sun {
+			return nil, noCrossing(Sun, "rise/set", loc, date, cfg.sunUpAllDay(loc, date, 90-cfg.sunZenith()))
+		}
+		return nil, noCrossing(Moon, "rise/set", loc, date, cfg.moonUpAllDay(loc, date))
+	}
+
+	tz := date.Location()
+	year, month, day := date.Date()
+
+	events := make([]Event, 0, len(rises)+len(sets))
+	for _, t := range rises {
+		events = append(events, Event{Body: body, Kind: EventRise, Time: cfg.pin(t.In(tz), year, month, day)})
+	}
+	for _, t := range sets {
+		events = append(events, Event{Body: body, Kind: EventSet, Time: cfg.pin(t.In(tz), year, month, day)})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+
+	return events, nil
+}
+
+// validateEventKinds rejects kinds that do not apply to body.
+func validateEventKinds(body Body, kinds []EventKind) error {
+	if body != Sun && body != Moon {
+		return fmt.Errorf("unknown body %v", body)
+	}
+	for _, k := range kinds {
+		if _, ok := eventKindNames[k]; !ok {
+			return fmt.Errorf("unknown event kind %v", k)
+		}
+		_, isPhase := k.lunarPhaseElongation()
+		_, _, isTwilight := k.twilight()
+		switch {
+		case isPhase && body != Moon:
+			return fmt.Errorf("%v is a lunar phase event, not a %v event", k, body)
+		case isTwilight && body != Sun:
+			return fmt.Errorf("%v is a solar event, not a %v event", k, body)
+		}
+	}
+	return nil
+}
+
+// dailyEvent returns the true instant of a rise/set/twilight/transit event
+// on the local calendar date, if it occurs.
+func dailyEvent(body Body, kind EventKind, loc Coordinates, date time.Time) (time.Time, bool) {
+	switch kind {
+	case EventRise, EventSet:
+		rs, err := RiseSetFor(body, loc, date, WithTrueInstants())
+		if err != nil {
+			return time.Time{}, false
+		}
+		if kind == EventRise {
+			return rs.Rise, rs.HasRise
+		}
+		return rs.Set, rs.HasSet
+
+	case EventTransit:
+		if body == Moon {
+			lt, err := LunarTransitFor(loc, date)
+			return lt.Upper, err == nil && lt.HasUpper
+		}
+		t, err := SolarNoon(loc, date)
+		return t, err == nil
+	}
+
+	if tk, dawn, ok := kind.twilight(); ok {
+		rs, err := TwilightFor(loc, date, tk, WithTrueInstants())
+		if err != nil {
+			return time.Time{}, false
+		}
+		if dawn {
+			return rs.Rise, rs.HasRise
+		}
+		return rs.Set, rs.HasSet
+	}
+
+	return time.Time{}, false
+}
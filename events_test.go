@@ -0,0 +1,140 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNextEvent_CrossesMidnight(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	// After tonight's sunset the next rise is tomorrow morning.
+	after := time.Date(2025, 6, 21, 21, 0, 0, 0, loc)
+	ev, err := NextEvent(Sun, phx, after)
+	if err != nil {
+		t.Fatalf("NextEvent error: %v", err)
+	}
+	if ev.Kind != EventRise || ev.Body != Sun {
+		t.Fatalf("got %v %v, want Sun rise", ev.Body, ev.Kind)
+	}
+	want, err := SlideIntoSunset(phx, time.Date(2025, 6, 22, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("SlideIntoSunset error: %v", err)
+	}
+	if !ev.Time.Equal(want.Rise) {
+		t.Errorf("next rise = %v, want %v", ev.Time, want.Rise)
+	}
+
+	// The earliest of several kinds wins.
+	ev, err = NextEvent(Sun, phx, time.Date(2025, 6, 21, 12, 0, 0, 0, loc),
+		EventSet, EventCivilDusk, EventTransit)
+	if err != nil {
+		t.Fatalf("NextEvent error: %v", err)
+	}
+	if ev.Kind != EventTransit {
+		t.Errorf("got %v at %v, want solar transit ~12:30", ev.Kind, ev.Time)
+	}
+}
+
+func TestNextEvent_LunarPhase(t *testing.T) {
+	ev, err := NextEvent(Moon, Coordinates{}, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		EventFullMoon, EventNewMoon)
+	if err != nil {
+		t.Fatalf("NextEvent error: %v", err)
+	}
+	// Full Moon 2025-05-12 16:56 UTC comes before the 2025-05-27 New Moon.
+	want := time.Date(2025, 5, 12, 16, 56, 0, 0, time.UTC)
+	if ev.Kind != EventFullMoon || ev.Time.Sub(want).Abs() > 30*time.Minute {
+		t.Errorf("got %v at %v, want full moon at %v", ev.Kind, ev.Time, want)
+	}
+}
+
+func TestNextEvent_PolarNight(t *testing.T) {
+	tromso := Coordinates{Lat: 69.6496, Lon: 18.9560}
+
+	// The Sun is below the horizon from late November until mid-January.
+	ev, err := NextEvent(Sun, tromso, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), EventRise)
+	if err != nil {
+		t.Fatalf("NextEvent error: %v", err)
+	}
+	if ev.Time.Year() != 2026 || ev.Time.Month() != time.January || ev.Time.Day() < 10 || ev.Time.Day() > 20 {
+		t.Errorf("first sunrise after polar night = %v, want mid-January 2026", ev.Time)
+	}
+}
+
+func TestNextEvent_InvalidKinds(t *testing.T) {
+	tm := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := NextEvent(Sun, Coordinates{}, tm, EventFullMoon); err == nil {
+		t.Error("expected error for a lunar phase on the Sun")
+	}
+	if _, err := NextEvent(Moon, Coordinates{}, tm, EventCivilDawn); err == nil {
+		t.Error("expected error for twilight on the Moon")
+	}
+	if _, err := NextEvent(Sun, Coordinates{}, tm, EventKind(99)); err == nil {
+		t.Error("expected error for an unknown event kind")
+	}
+}
+
+func TestRiseSetEventsFor_MultipleCrossings(t *testing.T) {
+	// Longyearbyen, Svalbard: on 2025-05-09 (UTC) the Moon sets just after
+	// midnight, rises in the afternoon and sets again before the day ends.
+	lyr := Coordinates{Lat: 78.22, Lon: 15.65}
+	date := time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC)
+
+	events, err := RiseSetEventsFor(Moon, lyr, date)
+	if err != nil {
+		t.Fatalf("RiseSetEventsFor error: %v", err)
+	}
+
+	want := []EventKind{EventSet, EventRise, EventSet}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events %v, want %v", len(events), events, want)
+	}
+	for i, ev := range events {
+		if ev.Kind != want[i] || ev.Body != Moon {
+			t.Errorf("event %d = %v %v, want Moon %v", i, ev.Body, ev.Kind, want[i])
+		}
+		if i > 0 && !ev.Time.After(events[i-1].Time) {
+			t.Errorf("event %d at %v not after %v", i, ev.Time, events[i-1].Time)
+		}
+	}
+
+	// RiseSetFor keeps the first crossing in each direction.
+	rs, err := RiseSetFor(Moon, lyr, date)
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	if d := rs.Set.Sub(events[0].Time); d < -time.Minute || d > time.Minute {
+		t.Errorf("RiseSetFor set = %v, want first set %v", rs.Set, events[0].Time)
+	}
+	if d := rs.Rise.Sub(events[1].Time); d < -time.Minute || d > time.Minute {
+		t.Errorf("RiseSetFor rise = %v, want %v", rs.Rise, events[1].Time)
+	}
+}
+
+func TestRiseSetEventsFor_OrdinaryDay(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	events, err := RiseSetEventsFor(Sun, phx, time.Date(2025, 6, 21, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("RiseSetEventsFor error: %v", err)
+	}
+	if len(events) != 2 || events[0].Kind != EventRise || events[1].Kind != EventSet {
+		t.Errorf("events = %v, want one rise then one set", events)
+	}
+
+	_, err = RiseSetEventsFor(Sun, Coordinates{Lat: 89.9}, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrNoRiseNoSet) {
+		t.Errorf("polar day error = %v, want ErrNoRiseNoSet", err)
+	}
+}
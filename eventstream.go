@@ -0,0 +1,118 @@
+package astroglide
+
+import (
+	"errors"
+	"time"
+)
+
+// EventSource selects which events of one body an EventIterator yields.
+// With no Kinds, rises and sets are yielded.
+type EventSource struct {
+	Body  Body
+	Kinds []EventKind
+}
+
+// EventIterator lazily yields astronomical events in chronological order,
+// indefinitely (up to the end of the supported date range). Create one with
+// EventsFrom and call Next repeatedly. It is not safe for concurrent use.
+type EventIterator struct {
+	loc     Coordinates
+	sources []EventSource
+	pending []*Event // next event per source; nil once a source is exhausted
+	err     error
+	started bool
+	start   time.Time
+}
+
+// EventsFrom returns an iterator over the events of the given sources that
+// occur strictly after start, as seen from loc. Without sources it yields
+// sunrises and sunsets. Event times are in start's time zone.
+//
+//	it := astroglide.EventsFrom(loc, time.Now(),
+//		astroglide.EventSource{Body: astroglide.Sun, Kinds: []astroglide.EventKind{astroglide.EventRise, astroglide.EventSet, astroglide.EventCivilDusk}},
+//		astroglide.EventSource{Body: astroglide.Moon, Kinds: []astroglide.EventKind{astroglide.EventFullMoon}},
+//	)
+//	for i := 0; i < 10; i++ {
+//		ev, err := it.Next()
+//		...
+//	}
+func EventsFrom(loc Coordinates, start time.Time, sources ...EventSource) *EventIterator {
+	if len(sources) == 0 {
+		sources = []EventSource{{Body: Sun}}
+	}
+	return &EventIterator{loc: loc, sources: sources, start: start}
+}
+
+// Next returns the next event in time order. Sources that have no further
+// events within a year (e.g. sunrise during polar night at the pole) are
+// retried from that point on; once every source is exhausted, or on any
+// other error, Next keeps returning that error.
+func (it *EventIterator) Next() (Event, error) {
+	if it.err != nil {
+		return Event{}, it.err
+	}
+
+	if !it.started {
+		it.started = true
+		it.pending = make([]*Event, len(it.sources))
+		for i := range it.sources {
+			if err := it.advance(i, it.start); err != nil {
+				it.err = err
+				return Event{}, err
+			}
+		}
+	}
+
+	idx := -1
+	for i, ev := range it.pending {
+		if ev != nil && (idx < 0 || ev.Time.Before(it.pending[idx].Time)) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		it.err = noEvent(it.sources[0].Body, "event", it.loc, it.start)
+		return Event{}, it.err
+	}
+
+	ev := *it.pending[idx]
+	if err := it.advance(idx, ev.Time); err != nil {
+		it.err = err
+		return Event{}, err
+	}
+
+	return ev, nil
+}
+
+// Take returns the next n events.
+func (it *EventIterator) Take(n int) ([]Event, error) {
+	events := make([]Event, 0, n)
+	for len(events) < n {
+		ev, err := it.Next()
+		if err != nil {
+			return events, err
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// advance finds the next event of source i after t.
+func (it *EventIterator) advance(i int, t time.Time) error {
+	src := it.sources[i]
+	for {
+		ev, err := NextEvent(src.Body, it.loc, t, src.Kinds...)
+		switch {
+		case err == nil:
+			it.pending[i] = &ev
+			return nil
+		case errors.Is(err, ErrOutOfRange):
+			it.pending[i] = nil
+			return nil
+		case errors.Is(err, ErrNoRiseNoSet):
+			// Nothing within the search horizon; keep looking further out.
+			t = t.AddDate(0, 0, maxEventSearchDays)
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventsFrom_Chronological(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, loc)
+
+	it := EventsFrom(phx, start,
+		EventSource{Body: Sun, Kinds: []EventKind{EventRise, EventSet, EventCivilDusk}},
+		EventSource{Body: Moon, Kinds: []EventKind{EventFullMoon, EventNewMoon}},
+	)
+
+	events, err := it.Take(3*31 + 2)
+	if err != nil {
+		t.Fatalf("Take error: %v", err)
+	}
+
+	counts := map[EventKind]int{}
+	prev := start
+	for _, ev := range events {
+		if !ev.Time.After(prev) {
+			t.Fatalf("event %v %v at %v not after %v", ev.Body, ev.Kind, ev.Time, prev)
+		}
+		prev = ev.Time
+		counts[ev.Kind]++
+	}
+
+	// A month of rise/set/dusk plus the Full Moon (05-12) and New Moon (05-27).
+	if counts[EventFullMoon] != 1 || counts[EventNewMoon] != 1 {
+		t.Errorf("phase counts: full %d, new %d, want 1 each", counts[EventFullMoon], counts[EventNewMoon])
+	}
+	for _, k := range []EventKind{EventRise, EventSet, EventCivilDusk} {
+		if n := counts[k]; n < 30 || n > 32 {
+			t.Errorf("%v count = %d, want ~31", k, n)
+		}
+	}
+}
+
+func TestEventsFrom_PolarSourceDoesNotStall(t *testing.T) {
+	// At 85°N the Sun does not set between April and September; the
+	// iterator must skip ahead instead of stopping.
+	it := EventsFrom(Coordinates{Lat: 85, Lon: 0}, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		EventSource{Body: Sun, Kinds: []EventKind{EventSet}})
+
+	ev, err := it.Next()
+	if err != nil {
+		t.Fatalf("Next error: %v", err)
+	}
+	if ev.Time.Month() < time.August || ev.Time.Month() > time.October {
+		t.Errorf("first sunset = %v, want late summer", ev.Time)
+	}
+}
@@ -0,0 +1,119 @@
+package astroglide
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Reflected-light meter calibration constant (ISO 2720) and the luminance,
+// in cd/m², of a surface of 0 mag/arcsec² in V.
+const (
+	meterCalibration = 12.5
+	zeroMagLuminance = 10.8e4
+)
+
+// ExposureStep is one sample of an exposure ramp.
+type ExposureStep struct {
+	Time time.Time
+
+	SunAltitude  float64 // degrees
+	MoonAltitude float64 // degrees
+	Sky          float64 // zenith sky brightness, mag/arcsec², as SkyBrightness.Zenith
+
+	// EV is the exposure value at ISO 100 that renders the zenith sky as a
+	// mid-tone: about 15 for a clear daytime sky and -9 for a dark night.
+	EV float64
+
+	// Offset is the change in EV since the first step of the ramp. It turns
+	// negative as the sky darkens: an Offset of -3 calls for three stops
+	// more exposure (longer shutter, wider aperture or higher ISO) than the
+	// first frame.
+	Offset float64
+}
+
+// ExposureRamp samples the sky above coords every step from start through
+// end and returns the exposure change a day-to-night (or night-to-day)
+// timelapse needs to keep the sky's brightness steady, from the
+// SkyBrightnessAt model for a natural dark sky.
+//
+// Real ramps are usually flattened, letting the night frames come out
+// darker than the daytime ones; scale the offsets to taste. Write the
+// result with WriteExposureRampCSV to load it into a ramping controller.
+func ExposureRamp(coords Coordinates, start, end time.Time, step time.Duration) ([]ExposureStep, error) {
+	if step <= 0 {
+		return nil, errors.New("exposure ramp step must be positive")
+	}
+	if end.Before(start) {
+		return nil, errors.New("exposure ramp ends before it starts")
+	}
+	if err := checkInputs(coords, start); err != nil {
+		return nil, err
+	}
+	if err := checkRange(end); err != nil {
+		return nil, err
+	}
+
+	var steps []ExposureStep
+	for t := start; !t.After(end); t = t.Add(step) {
+		sb, err := SkyBrightnessAt(coords, t, 0)
+		if err != nil {
+			return nil, err
+		}
+		ev := skyEV(sb.Zenith)
+		offset := 0.0
+		if len(steps) > 0 {
+			offset = ev - steps[0].EV
+		}
+		steps = append(steps, ExposureStep{
+			Time:         t,
+			SunAltitude:  sb.SunAltitude,
+			MoonAltitude: sb.MoonAltitude,
+			Sky:          sb.Zenith,
+			EV:           ev,
+			Offset:       offset,
+		})
+	}
+	return steps, nil
+}
+
+// skyEV returns the ISO 100 exposure value of a sky of the given surface
+// brightness (mag/arcsec²).
+func skyEV(mag float64) float64 {
+	luminance := zeroMagLuminance * math.Pow(10, -0.4*mag)
+	return math.Log2(luminance * 100 / meterCalibration)
+}
+
+// WriteExposureRampCSV writes steps as CSV with a header row: the RFC 3339
+// time, seconds since the first step, the Sun's and Moon's altitudes, the
+// sky brightness, EV and the EV offset. The elapsed seconds and EV offset
+// columns are the ramp as keyframes relative to the first frame.
+//
+// This is the only export format: there is no writer for qDslrDashboard's
+// own ramping files. Map the elapsed seconds and EV offset columns onto a
+// controller's keyframes by hand or with a small script.
+func WriteExposureRampCSV(w io.Writer, steps []ExposureStep) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "elapsed_s", "sun_alt", "moon_alt", "sky_mag_arcsec2", "ev", "ev_offset"}); err != nil {
+		return err
+	}
+	for _, s := range steps {
+		elapsed := s.Time.Sub(steps[0].Time)
+		if err := cw.Write([]string{
+			s.Time.Format(time.RFC3339),
+			strconv.FormatFloat(elapsed.Seconds(), 'f', 0, 64),
+			strconv.FormatFloat(s.SunAltitude, 'f', 2, 64),
+			strconv.FormatFloat(s.MoonAltitude, 'f', 2, 64),
+			strconv.FormatFloat(s.Sky, 'f', 2, 64),
+			strconv.FormatFloat(s.EV, 'f', 2, 64),
+			strconv.FormatFloat(s.Offset, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
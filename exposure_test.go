@@ -0,0 +1,56 @@
+package astroglide
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExposureRamp(t *testing.T) {
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	// Sunset 2025-11-20 is about 17:22 MST; the Moon is new.
+	start := time.Date(2025, time.November, 21, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	steps, err := ExposureRamp(coords, start, end, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("ExposureRamp error: %v", err)
+	}
+	if len(steps) != 19 {
+		t.Fatalf("got %d steps, want 19", len(steps))
+	}
+	if steps[0].Offset != 0 {
+		t.Errorf("first Offset = %v, want 0", steps[0].Offset)
+	}
+	if ev := steps[0].EV; ev < 11 || ev > 16 {
+		t.Errorf("EV before sunset = %.1f, want daylight", ev)
+	}
+	for i := 1; i < len(steps); i++ {
+		if steps[i].Offset > steps[i-1].Offset {
+			t.Errorf("step %d: offset rises from %.2f to %.2f after sunset", i, steps[i-1].Offset, steps[i].Offset)
+		}
+	}
+	// From daylight to a dark night is some 20–25 stops.
+	if last := steps[len(steps)-1].Offset; last > -20 || last < -26 {
+		t.Errorf("total ramp = %.1f EV, want about -22", last)
+	}
+
+	var b strings.Builder
+	if err := WriteExposureRampCSV(&b, steps[:2]); err != nil {
+		t.Fatalf("WriteExposureRampCSV error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || lines[0] != "time,elapsed_s,sun_alt,moon_alt,sky_mag_arcsec2,ev,ev_offset" {
+		t.Fatalf("CSV =\n%s", b.String())
+	}
+	if !strings.HasPrefix(lines[2], "2025-11-21T00:10:00Z,600,") {
+		t.Errorf("second row = %q", lines[2])
+	}
+
+	if _, err := ExposureRamp(coords, start, end, 0); err == nil {
+		t.Error("expected error for a zero step")
+	}
+	if _, err := ExposureRamp(coords, end, start, time.Minute); err == nil {
+		t.Error("expected error for end before start")
+	}
+}
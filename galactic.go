@@ -0,0 +1,61 @@
+package astroglide
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// GalacticCenter is the J2000.0 position of the galactic centre (Sgr A*),
+// RA 17h45m40s, Dec -29°00'28".
+var GalacticCenter = Equatorial{RA: 266.4168, Dec: -29.0078}
+
+// GalacticCoreWindows returns the intervals during the night that begins on
+// the given local calendar date (local noon to local noon) when the galactic
+// centre is at least minAltitude degrees above the horizon while the Sun is
+// below AstronomicalDarknessAltitude. Times are in the date's time zone.
+//
+// An empty result with a nil error means the core is not visible in a dark
+// sky that night (e.g. northern winter, or too far north).
+func GalacticCoreWindows(loc Coordinates, date time.Time, minAltitude float64) ([]PhaseWindow, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return nil, err
+	}
+
+	tz := date.Location()
+	year, month, day := date.Date()
+
+	start := time.Date(year, month, day, 12, 0, 0, 0, tz)
+	end := time.Date(year, month, day+1, 12, 0, 0, 0, tz)
+
+	// Precession over a night is negligible; use the equinox of the
+	// date's midnight throughout.
+	gc := PrecessToDate(GalacticCenter, start.Add(12*time.Hour))
+
+	visible := func(t time.Time) bool {
+		if sun.AltitudeAt(loc.Lat, loc.Lon, t) >= AstronomicalDarknessAltitude {
+			return false
+		}
+		alt, _ := coord.Horizontal(gc.RA, gc.Dec, loc.Lat, coord.LocalSiderealTime(loc.Lon, t))
+		return alt >= minAltitude
+	}
+
+	const (
+		steps = 24*12 + 1 // every 5 minutes
+		tol   = 30 * time.Second
+	)
+
+	intervals := solver.FindIntervals(visible, start, end, steps, tol)
+
+	windows := make([]PhaseWindow, 0, len(intervals))
+	for _, iv := range intervals {
+		windows = append(windows, PhaseWindow{
+			Start: iv.Start.In(tz),
+			End:   iv.End.In(tz),
+		})
+	}
+
+	return windows, nil
+}
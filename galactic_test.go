@@ -0,0 +1,44 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGalacticCoreWindows(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*60*60)
+
+	// Mid-June: the core transits around 01:00 local, well inside the
+	// roughly 22:00–03:30 astronomical night.
+	windows, err := GalacticCoreWindows(phx, time.Date(2025, 6, 15, 0, 0, 0, 0, mst), 10)
+	if err != nil {
+		t.Fatalf("GalacticCoreWindows error: %v", err)
+	}
+	if len(windows) != 1 {
+		t.Fatalf("got %d windows, want 1: %+v", len(windows), windows)
+	}
+	w := windows[0]
+	if d := w.End.Sub(w.Start); d < 4*time.Hour {
+		t.Errorf("window %v – %v lasts %v, want at least 4h", w.Start, w.End, d)
+	}
+	transit := time.Date(2025, 6, 16, 1, 0, 0, 0, mst)
+	if transit.Before(w.Start) || transit.After(w.End) {
+		t.Errorf("window %v – %v does not contain the ~01:00 transit", w.Start, w.End)
+	}
+
+	// Mid-December: the core is up only in daylight.
+	windows, err = GalacticCoreWindows(phx, time.Date(2025, 12, 15, 0, 0, 0, 0, mst), 10)
+	if err != nil {
+		t.Fatalf("GalacticCoreWindows error: %v", err)
+	}
+	if len(windows) != 0 {
+		t.Errorf("December: got %d windows, want none: %+v", len(windows), windows)
+	}
+
+	// From 70°N the core never climbs above 0°.
+	windows, _ = GalacticCoreWindows(Coordinates{Lat: 70, Lon: 20}, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 0)
+	if len(windows) != 0 {
+		t.Errorf("70°N: got %d windows, want none", len(windows))
+	}
+}
@@ -0,0 +1,60 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+)
+
+// Distance returns the great-circle distance in kilometres from c to o
+// over a spherical Earth of mean radius 6371 km, which is within 0.5% of
+// the ellipsoidal distance. Elevation is ignored.
+func (c Coordinates) Distance(o Coordinates) float64 {
+	return coord.Distance(c.Lat, c.Lon, o.Lat, o.Lon)
+}
+
+// Bearing returns the initial bearing from c to o in degrees from true
+// north through east, [0, 360). Along a great circle the bearing changes
+// as you go, noticeably only over hundreds of kilometres. Compare it with
+// an Azimuth from PositionAt to line a foreground up with the Sun or Moon.
+func (c Coordinates) Bearing(o Coordinates) float64 {
+	return coord.Bearing(c.Lat, c.Lon, o.Lat, o.Lon)
+}
+
+// Destination returns the point reached by going distKm kilometres from c
+// with initial bearing bearing (degrees from true north through east). The
+// result keeps c's Elevation, which the caller may want to replace.
+//
+//	// Walk 300 m directly away from the setting Sun to put it behind the tower.
+//	spot := tower.Destination(pos.Azimuth+180, 0.3)
+func (c Coordinates) Destination(bearing, distKm float64) Coordinates {
+	lat, lon := coord.Destination(c.Lat, c.Lon, bearing, distKm)
+	return Coordinates{Lat: lat, Lon: lon, Elevation: c.Elevation}
+}
+
+// RelativeBearing returns the direction of body seen from loc at t
+// relative to bearing (degrees from true north through east), in degrees
+// (-180, 180]: 0 means the body is straight ahead, positive values to the
+// right and ±180 behind. Use the bearing of a road, runway or camera
+// heading to ask "is the Sun in my eyes?". The body's altitude is not
+// considered.
+func RelativeBearing(body Body, loc Coordinates, t time.Time, bearing float64) (float64, error) {
+	pos, err := PositionAt(body, loc, t, Topocentric)
+	if err != nil {
+		return 0, err
+	}
+	return relativeAngle(pos.Azimuth, bearing), nil
+}
+
+// relativeAngle returns az - ref wrapped to (-180, 180].
+func relativeAngle(az, ref float64) float64 {
+	rel := math.Mod(az-ref, 360)
+	switch {
+	case rel > 180:
+		rel -= 360
+	case rel <= -180:
+		rel += 360
+	}
+	return rel
+}
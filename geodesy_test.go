@@ -0,0 +1,65 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCoordinatesGeodesy(t *testing.T) {
+	london := Coordinates{Lat: 51.5074, Lon: -0.1278}
+	paris := Coordinates{Lat: 48.8566, Lon: 2.3522}
+
+	if d := london.Distance(paris); math.Abs(d-343.5) > 1 {
+		t.Errorf("London-Paris = %.1f km, want about 343.5", d)
+	}
+	if b := london.Bearing(paris); math.Abs(b-148.1) > 0.5 {
+		t.Errorf("bearing London to Paris = %.1f°, want about 148.1°", b)
+	}
+
+	// Going the distance along the bearing arrives.
+	p := london.Destination(london.Bearing(paris), london.Distance(paris))
+	if d := p.Distance(paris); d > 1e-3 {
+		t.Errorf("Destination missed Paris by %.3f km", d)
+	}
+
+	equator := Coordinates{}
+	if b := equator.Bearing(Coordinates{Lon: 1}); math.Abs(b-90) > 1e-9 {
+		t.Errorf("bearing due east = %v, want 90", b)
+	}
+	if b := equator.Bearing(Coordinates{Lat: -1}); math.Abs(b-180) > 1e-9 {
+		t.Errorf("bearing due south = %v, want 180", b)
+	}
+	// One degree of latitude is about 111.2 km on the mean sphere.
+	if d := equator.Distance(Coordinates{Lat: 1}); math.Abs(d-111.19) > 0.01 {
+		t.Errorf("one degree = %.3f km, want 111.19", d)
+	}
+	// Short hops stay accurate.
+	if d := equator.Distance(equator.Destination(45, 0.3)); math.Abs(d-0.3) > 1e-9 {
+		t.Errorf("300 m walk = %.9f km", d)
+	}
+}
+
+func TestRelativeBearing(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	noon, err := SolarNoon(phx, time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SolarNoon error: %v", err)
+	}
+	for _, c := range []struct{ heading, want float64 }{
+		{180, 0},   // facing the Sun
+		{90, 90},   // Sun on the right
+		{270, -90}, // Sun on the left
+	} {
+		rel, err := RelativeBearing(Sun, phx, noon, c.heading)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(rel-c.want) > 0.5 {
+			t.Errorf("heading %v: relative bearing %.2f°, want %v", c.heading, rel, c.want)
+		}
+	}
+	if rel, _ := RelativeBearing(Sun, phx, noon, 0); math.Abs(math.Abs(rel)-180) > 0.5 {
+		t.Errorf("heading north: relative bearing %.2f°, want ±180", rel)
+	}
+}
@@ -0,0 +1,73 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// GlareWindows returns the intervals on the given local calendar date when
+// the Sun is in the eyes of someone travelling on headingDeg (degrees from
+// true north through east): above the horizon, no higher than maxAltDeg,
+// and within toleranceDeg of straight ahead in azimuth. Times are in the
+// date's time zone.
+//
+// A driver's sun visor cuts off at roughly 20-25°, and a tolerance of
+// 15-30° covers a windscreen; heading west on a road with bearing 270°,
+//
+//	GlareWindows(loc, date, 270, 25, 20)
+//
+// gives the evening glare. An empty result with a nil error means no glare
+// that day. Terrain and buildings, which often hide a low Sun, are not
+// considered.
+func GlareWindows(loc Coordinates, date time.Time, headingDeg, toleranceDeg, maxAltDeg float64) ([]PhaseWindow, error) {
+	if !(toleranceDeg > 0 && toleranceDeg <= 180) {
+		return nil, fmt.Errorf("invalid glare tolerance %.3f°: must be in (0, 180]", toleranceDeg)
+	}
+	if !(maxAltDeg > sun.ApparentHorizonAltitudeSun && maxAltDeg <= 90) {
+		return nil, fmt.Errorf("invalid glare altitude %.3f°: must be above the horizon and at most 90°", maxAltDeg)
+	}
+	if math.IsNaN(headingDeg) || math.IsInf(headingDeg, 0) {
+		return nil, fmt.Errorf("invalid heading %v", headingDeg)
+	}
+	if err := checkInputs(loc, date); err != nil {
+		return nil, err
+	}
+
+	tz := date.Location()
+	start, end := timeutil.LocalDay(date)
+
+	glare := func(t time.Time) bool {
+		eq := sun.GeocentricEquatorialApprox(t)
+		alt, az := coord.Horizontal(eq.RA, eq.Dec, loc.Lat, coord.LocalSiderealTime(loc.Lon, t))
+		if alt <= sun.ApparentHorizonAltitudeSun || alt > maxAltDeg {
+			return false
+		}
+		return math.Abs(relativeAngle(az, headingDeg)) <= toleranceDeg
+	}
+
+	// Near the horizon the Sun's azimuth moves about a quarter of a degree
+	// a minute, faster when it is high; sample every minute so narrow cones
+	// are not missed.
+	const (
+		steps = 24*60 + 1
+		tol   = 10 * time.Second
+	)
+
+	intervals := solver.FindIntervals(glare, start.UTC(), end.UTC(), steps, tol)
+
+	windows := make([]PhaseWindow, 0, len(intervals))
+	for _, iv := range intervals {
+		windows = append(windows, PhaseWindow{
+			Start: iv.Start.In(tz),
+			End:   iv.End.In(tz),
+		})
+	}
+
+	return windows, nil
+}
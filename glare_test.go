@@ -0,0 +1,67 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGlareWindows(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+	date := time.Date(2025, 3, 20, 0, 0, 0, 0, mst)
+
+	rs, err := RiseSetFor(Sun, phx, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Westbound on I-10 at the equinox: glare in the hour or so before
+	// sunset, ending as the Sun goes down.
+	west, err := GlareWindows(phx, date, 270, 20, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(west) != 1 {
+		t.Fatalf("westbound: %d windows, want 1: %v", len(west), west)
+	}
+	w := west[0]
+	if d := w.End.Sub(rs.Set); d < -2*time.Minute || d > 2*time.Minute {
+		t.Errorf("westbound glare ends %s, sunset %s", w.End.Format("15:04"), rs.Set.Format("15:04"))
+	}
+	if d := w.End.Sub(w.Start); d < 45*time.Minute || d > 2*time.Hour {
+		t.Errorf("westbound glare lasts %v, want about an hour", d)
+	}
+	if w.Start.Location() != mst {
+		t.Errorf("window not in the date's zone: %v", w.Start.Location())
+	}
+
+	// Eastbound gets the morning Sun instead.
+	east, err := GlareWindows(phx, date, 90, 20, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(east) != 1 || east[0].Start.Sub(rs.Rise).Abs() > 2*time.Minute {
+		t.Errorf("eastbound: %v, want one window from sunrise %s", east, rs.Rise.Format("15:04"))
+	}
+
+	// A northbound driver never faces the Sun here in March.
+	north, err := GlareWindows(phx, date, 0, 20, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(north) != 0 {
+		t.Errorf("northbound: %v, want none", north)
+	}
+}
+
+func TestGlareWindows_InvalidArgs(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct{ tolerance, maxAlt float64 }{
+		{0, 20}, {181, 20}, {20, -5}, {20, 91},
+	} {
+		if _, err := GlareWindows(phx, date, 270, c.tolerance, c.maxAlt); err == nil {
+			t.Errorf("tolerance %v, max altitude %v: no error", c.tolerance, c.maxAlt)
+		}
+	}
+}
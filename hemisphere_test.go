@@ -0,0 +1,216 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// Sign errors in latitude, declination or hour angle cancel out surprisingly
+// often for northern mid-latitude sites like Phoenix and New York. These
+// tests cover the southern hemisphere, the equator and the subarctic.
+
+var hemisphereSites = []struct {
+	name   string
+	coords Coordinates
+	tz     string
+}{
+	{"Sydney", Coordinates{Lat: -33.8688, Lon: 151.2093}, "Australia/Sydney"},
+	{"Quito", Coordinates{Lat: -0.1807, Lon: -78.4678}, "America/Guayaquil"},
+	{"Singapore", Coordinates{Lat: 1.3521, Lon: 103.8198}, "Asia/Singapore"},
+	{"Reykjavik", Coordinates{Lat: 64.1466, Lon: -21.9426}, "Atlantic/Reykjavik"},
+	{"Ushuaia", Coordinates{Lat: -54.8019, Lon: -68.3030}, "America/Argentina/Ushuaia"},
+}
+
+var hemisphereDates = []struct {
+	month time.Month
+	day   int
+}{
+	{time.March, 20}, {time.June, 21}, {time.September, 22}, {time.December, 21},
+}
+
+// hemisphereReference holds the 2025 Sun times at hemisphereSites, local
+// clock time rounded to the minute as almanacs print them: sunrise and
+// sunset, then civil and nautical dawn and dusk ("" when the event does not
+// occur that day). They are the times of NOAA's solar calculator, fixed
+// here so the test checks against a table rather than a second
+// implementation. Sydney and Ushuaia are in summer time in December and
+// March; Reykjavik's June "sunset" is the one just after midnight.
+var hemisphereReference = []struct {
+	site            string
+	month           time.Month
+	day             int
+	rise, set       string
+	civil, nautical [2]string
+}{
+	{"Sydney", time.March, 20, "06:58", "19:07", [2]string{"06:33", "19:32"}, [2]string{"06:04", "20:01"}},
+	{"Sydney", time.June, 21, "07:00", "16:54", [2]string{"06:32", "17:22"}, [2]string{"06:01", "17:53"}},
+	{"Sydney", time.September, 22, "05:45", "17:51", [2]string{"05:20", "18:16"}, [2]string{"04:51", "18:45"}},
+	{"Sydney", time.December, 21, "05:41", "20:06", [2]string{"05:12", "20:35"}, [2]string{"04:36", "21:11"}},
+	{"Quito", time.March, 20, "06:18", "18:24", [2]string{"05:57", "18:45"}, [2]string{"05:33", "19:09"}},
+	{"Quito", time.June, 21, "06:12", "18:19", [2]string{"05:50", "18:42"}, [2]string{"05:24", "19:08"}},
+	{"Quito", time.September, 22, "06:03", "18:10", [2]string{"05:43", "18:30"}, [2]string{"05:19", "18:54"}},
+	{"Quito", time.December, 21, "06:08", "18:16", [2]string{"05:46", "18:39"}, [2]string{"05:19", "19:05"}},
+	{"Singapore", time.March, 20, "07:09", "19:15", [2]string{"06:48", "19:36"}, [2]string{"06:24", "20:00"}},
+	{"Singapore", time.June, 21, "07:01", "19:13", [2]string{"06:38", "19:35"}, [2]string{"06:12", "20:01"}},
+	{"Singapore", time.September, 22, "06:54", "19:01", [2]string{"06:34", "19:21"}, [2]string{"06:10", "19:45"}},
+	{"Singapore", time.December, 21, "07:01", "19:04", [2]string{"06:39", "19:27"}, [2]string{"06:13", "19:53"}},
+	{"Reykjavik", time.March, 20, "07:28", "19:44", [2]string{"06:40", "20:32"}, [2]string{"05:42", "21:31"}},
+	{"Reykjavik", time.June, 21, "02:55", "00:04", [2]string{"", ""}, [2]string{"", ""}},
+	{"Reykjavik", time.September, 22, "07:11", "19:28", [2]string{"06:23", "20:15"}, [2]string{"05:25", "21:14"}},
+	{"Reykjavik", time.December, 21, "11:22", "15:30", [2]string{"10:03", "16:49"}, [2]string{"08:54", "17:58"}},
+	{"Ushuaia", time.March, 20, "07:35", "19:45", [2]string{"06:59", "20:21"}, [2]string{"06:16", "21:04"}},
+	{"Ushuaia", time.June, 21, "09:59", "17:11", [2]string{"09:14", "17:57"}, [2]string{"08:26", "18:44"}},
+	{"Ushuaia", time.September, 22, "07:21", "19:32", [2]string{"06:45", "20:08"}, [2]string{"06:02", "20:51"}},
+	{"Ushuaia", time.December, 21, "04:52", "22:11", [2]string{"03:54", "23:09"}, [2]string{"", ""}},
+}
+
+func TestHemisphere_SunAgainstReference(t *testing.T) {
+	// Half a minute of rounding in the table plus the library's accuracy.
+	const tolMinutes = 1.5
+
+	sites := make(map[string]Coordinates)
+	zones := make(map[string]string)
+	for _, s := range hemisphereSites {
+		sites[s.name], zones[s.name] = s.coords, s.tz
+	}
+
+	for _, ref := range hemisphereReference {
+		tz, err := time.LoadLocation(zones[ref.site])
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", zones[ref.site], err)
+		}
+		loc := sites[ref.site]
+		date := time.Date(2025, ref.month, ref.day, 0, 0, 0, 0, tz)
+
+		events := []struct {
+			name      string
+			rise, set string
+			get       func() (RiseSet, error)
+		}{
+			{"sunrise/sunset", ref.rise, ref.set, func() (RiseSet, error) {
+				return SlideIntoSunset(loc, date)
+			}},
+			{"civil", ref.civil[0], ref.civil[1], func() (RiseSet, error) {
+				return TwilightFor(loc, date, TwilightCivil)
+			}},
+			{"nautical", ref.nautical[0], ref.nautical[1], func() (RiseSet, error) {
+				return TwilightFor(loc, date, TwilightNautical)
+			}},
+		}
+		for _, ev := range events {
+			rs, err := ev.get()
+			if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+				t.Errorf("%s %s %s: %v", ref.site, date.Format("2006-01-02"), ev.name, err)
+				continue
+			}
+
+			check := func(what string, got time.Time, ok bool, want string) {
+				if ok != (want != "") {
+					t.Errorf("%s %s %s %s: occurs=%v, table says %q",
+						ref.site, date.Format("2006-01-02"), ev.name, what, ok, want)
+					return
+				}
+				if !ok {
+					return
+				}
+				w, err := time.ParseInLocation("2006-01-02 15:04", date.Format("2006-01-02 ")+want, tz)
+				if err != nil {
+					t.Fatalf("bad table time %q: %v", want, err)
+				}
+				if d := diffMinutes(got, w); d > tolMinutes {
+					t.Errorf("%s %s %s %s: got %s, table %s (off by %.1f min)",
+						ref.site, date.Format("2006-01-02"), ev.name, what,
+						got.Format("15:04:05"), want, d)
+				}
+			}
+			check("rise", rs.Rise, rs.HasRise, ev.rise)
+			check("set", rs.Set, rs.HasSet, ev.set)
+		}
+	}
+}
+
+func TestHemisphere_DayLengthBySeason(t *testing.T) {
+	dayLength := func(site int, month time.Month, day int) time.Duration {
+		s := hemisphereSites[site]
+		tz, err := time.LoadLocation(s.tz)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", s.tz, err)
+		}
+		hours, err := DaylightHours(s.coords, time.Date(2025, month, day, 0, 0, 0, 0, tz))
+		if err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		return time.Duration(hours * float64(time.Hour))
+	}
+
+	const (
+		sydney = iota
+		quito
+		singapore
+		reykjavik
+		ushuaia
+	)
+
+	// Southern sites have their long days in December.
+	for _, site := range []int{sydney, ushuaia} {
+		june, dec := dayLength(site, time.June, 21), dayLength(site, time.December, 21)
+		if dec <= june+2*time.Hour {
+			t.Errorf("%s: December day %v not clearly longer than June day %v",
+				hemisphereSites[site].name, dec, june)
+		}
+	}
+	if june, dec := dayLength(reykjavik, time.June, 21), dayLength(reykjavik, time.December, 21); june < 20*time.Hour || dec > 5*time.Hour {
+		t.Errorf("Reykjavik: June day %v, December day %v; want >20h and <5h", june, dec)
+	}
+
+	// On the equator the day is about 12h07m all year (refraction and the
+	// solar disc add a few minutes to the geometric 12 hours).
+	for _, site := range []int{quito, singapore} {
+		for _, dd := range hemisphereDates {
+			got := dayLength(site, dd.month, dd.day)
+			if got < 12*time.Hour || got > 12*time.Hour+15*time.Minute {
+				t.Errorf("%s %s %d: day length %v, want 12h00m–12h15m",
+					hemisphereSites[site].name, dd.month, dd.day, got)
+			}
+		}
+	}
+}
+
+func TestHemisphere_RiseSetAzimuths(t *testing.T) {
+	for _, site := range hemisphereSites {
+		tz, err := time.LoadLocation(site.tz)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", site.tz, err)
+		}
+		for _, body := range []Body{Sun, Moon} {
+			for _, dd := range hemisphereDates {
+				date := time.Date(2025, dd.month, dd.day, 0, 0, 0, 0, tz)
+				rs, err := RiseSetFor(body, site.coords, date, WithTrueInstants())
+				if err != nil {
+					continue // no crossing at all that day
+				}
+
+				// Bodies rise in the east and set in the west, close to the
+				// horizon, whichever hemisphere the observer is in.
+				check := func(what string, at time.Time, east bool) {
+					pos, err := PositionAt(body, site.coords, at, Topocentric)
+					if err != nil {
+						t.Fatalf("PositionAt: %v", err)
+					}
+					if (pos.Azimuth < 180) != east || math.Abs(pos.Altitude) > 1.5 {
+						t.Errorf("%s %v %s %s: az=%.1f° alt=%.2f°",
+							site.name, body, date.Format("2006-01-02"), what, pos.Azimuth, pos.Altitude)
+					}
+				}
+				if rs.HasRise {
+					check("rise", rs.Rise, true)
+				}
+				if rs.HasSet {
+					check("set", rs.Set, false)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package astroglide
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/astrotime"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// HistoricalMinTime is the earliest date accepted in historical mode, the
+// start of the ΔT fits of astrotime.DeltaTEstimate.
+var HistoricalMinTime = time.Date(-1999, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// siderealRate is how far the Earth turns relative to the stars, in
+// degrees per hour of UT.
+const siderealRate = 15.04106864
+
+// WithHistorical enables historical mode, for questions such as sunrise in
+// Rome on the Ides of March, 44 BC, or the eclipse seasons of that year. It
+// applies to every function that takes options: rise and set, twilight and
+// the almanac windows built on them, MoonPhaseAt and EclipseSeasons.
+// ObjectRiseSetFor, whose ephemeris comes from the caller, rejects it.
+//
+// Dates back to HistoricalMinTime are accepted, and the Sun and Moon
+// are placed at Terrestrial Time, ΔT (astrotime.DeltaT) ahead of the
+// Earth's rotation: some three hours in antiquity, in which the Moon moves
+// by three times its own width.
+//
+// RiseSet.Uncertainty grows accordingly. ΔT is known only from historical
+// eclipse records, to about 4 minutes in AD 1 (astrotime.DeltaTUncertainty),
+// though rise and set times move by only a small share of that, and the
+// truncated Sun and Moon series are taken to lose accuracy with distance
+// from the supported range. Build dates from old sources with
+// astrotime.Date: they are usually Julian, which time.Date would read as
+// Gregorian. Named zones give old dates in local mean time; a
+// time.FixedZone for the place's longitude says the same more plainly.
+//
+// Within the supported range historical mode moves times by a few seconds
+// at most, the effect of the modern ΔT of about a minute.
+func WithHistorical() Option {
+	return func(c *config) {
+		c.historical = true
+	}
+}
+
+// checkRange is checkRange with the wider date range of historical mode
+// when c enables it.
+func (c config) checkRange(t time.Time) error {
+	if !c.historical {
+		return checkRange(t)
+	}
+	if t.Before(HistoricalMinTime) || !t.Before(MaxSupportedTime) {
+		return &OutOfRangeError{Time: t, Min: HistoricalMinTime, Max: MaxSupportedTime}
+	}
+	return nil
+}
+
+// checkInputs is checkInputs with the wider date range of historical mode
+// when c enables it.
+func (c config) checkInputs(loc Coordinates, t time.Time) error {
+	if err := loc.Validate(); err != nil {
+		return err
+	}
+	return c.checkRange(t)
+}
+
+// deltaT returns ΔT at t, to the second, in historical mode and 0
+// otherwise: how far the instants the Sun and Moon models work in lead UT.
+func (c config) deltaT(t time.Time) time.Duration {
+	if !c.historical {
+		return 0
+	}
+	dt, err := astrotime.DeltaT(t)
+	if err != nil {
+		dt = astrotime.DeltaTEstimate(t)
+	}
+	return dt.Round(time.Second)
+}
+
+// ephemerisFrame returns the observer and date at which to run the Sun and
+// Moon models for loc and the local calendar date of date, and ΔT, by which
+// the instants the models work in lead UT. Outside historical mode it
+// returns loc, date and 0.
+//
+// The models read one instant for both the body's motion and the Earth's
+// rotation. Run at TT = UT + ΔT they put the body in the right place, and
+// moving the observer west by the angle the Earth turns in ΔT (the
+// "ephemeris meridian" of the old almanacs) puts the sky in the right place
+// too. The date moves to a zone ΔT behind its own, so that its local day
+// covers the same instants of UT; within a day the zone offset is taken as
+// fixed, which historical dates are.
+func (c config) ephemerisFrame(loc Coordinates, date time.Time) (Coordinates, time.Time, time.Duration) {
+	if !c.historical {
+		return loc, date, 0
+	}
+	start := timeutil.StartOfDay(date)
+	dt := c.deltaT(start)
+
+	_, offset := start.Zone()
+	tz := time.FixedZone("TT", offset-int(dt/time.Second))
+	y, m, d := date.Date()
+
+	eloc := loc
+	eloc.Lon = timeutil.Normalize360(loc.Lon-siderealRate*dt.Hours()+180) - 180
+	return eloc, time.Date(y, m, d, 12, 0, 0, 0, tz), dt
+}
+
+// fromEphemeris converts an instant found in the frame of ephemerisFrame
+// back to UT. Events that were not found stay zero.
+func fromEphemeris(t time.Time, ok bool, dt time.Duration) time.Time {
+	if !ok {
+		return t
+	}
+	return t.Add(-dt)
+}
+
+// fromEphemerisAll converts the instants of ts in place, like fromEphemeris.
+func fromEphemerisAll(ts []time.Time, dt time.Duration) {
+	for i := range ts {
+		ts[i] = ts[i].Add(-dt)
+	}
+}
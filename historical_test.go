@@ -0,0 +1,238 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/astrotime"
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+var rome = Coordinates{Lat: 41.8925, Lon: 12.4853}
+
+// romeMeanTime is local mean time in Rome, the clock of a sundial.
+var romeMeanTime = time.FixedZone("LMT", int(rome.Lon/15*3600))
+
+// julian44BC returns the given date of 44 BC (astronomical year -43) in
+// the Julian calendar, at noon in Rome.
+func julian44BC(month time.Month, day int) time.Time {
+	return astrotime.Date(astrotime.Julian, -43, month, day, 11, 10, 0, 0).In(romeMeanTime)
+}
+
+func TestHistorical_Range(t *testing.T) {
+	ides := julian44BC(time.March, 15)
+
+	if _, err := SlideIntoSunset(rome, ides); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("without WithHistorical: err = %v, want ErrOutOfRange", err)
+	}
+	if _, err := SlideIntoSunset(rome, ides, WithHistorical()); err != nil {
+		t.Errorf("with WithHistorical: %v", err)
+	}
+	if _, err := TwilightFor(rome, ides, TwilightCivil, WithHistorical()); err != nil {
+		t.Errorf("TwilightFor with WithHistorical: %v", err)
+	}
+
+	tooOld := HistoricalMinTime.Add(-time.Hour)
+	_, err := RiseSetFor(Moon, rome, tooOld, WithHistorical())
+	var oor *OutOfRangeError
+	if !errors.As(err, &oor) || !oor.Min.Equal(HistoricalMinTime) {
+		t.Errorf("before HistoricalMinTime: err = %v, want an *OutOfRangeError from HistoricalMinTime", err)
+	}
+}
+
+// TestHistorical_IdesOfMarch checks the Sun in Rome in March 44 BC against
+// the calendar: the days and nights were equal around the equinox, which
+// fell on 23 March (Julian) that year.
+func TestHistorical_IdesOfMarch(t *testing.T) {
+	rs, err := SlideIntoSunset(rome, julian44BC(time.March, 15), WithHistorical())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rs.Rise.Format("15:04"); got < "06:05" || got > "06:25" {
+		t.Errorf("sunrise on the Ides = %s LMT, want about 06:15", got)
+	}
+	if got := rs.Date.Format("2006-01-02"); got != "-0043-03-13" {
+		t.Errorf("Date = %s, want -0043-03-13 (proleptic Gregorian)", got)
+	}
+	if rs.Uncertainty <= time.Second || rs.Uncertainty > 2*time.Minute {
+		t.Errorf("Uncertainty = %v, want more than a second but within two minutes", rs.Uncertainty)
+	}
+
+	best, bestDiff := 0, math.Inf(1)
+	for day := 15; day <= 31; day++ {
+		rs, err := TwilightFor(rome, julian44BC(time.March, day), TwilightSunCenter, WithHistorical())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := math.Abs(rs.Set.Sub(rs.Rise).Hours() - 12); diff < bestDiff {
+			best, bestDiff = day, diff
+		}
+	}
+	if best < 22 || best > 24 {
+		t.Errorf("12-hour day on %d March 44 BC (Julian), want about the 23rd", best)
+	}
+
+	moon, err := RiseSetFor(Moon, rome, julian44BC(time.March, 15), WithHistorical())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moon.Uncertainty <= rs.Uncertainty {
+		t.Errorf("Moon uncertainty %v not above the Sun's %v", moon.Uncertainty, rs.Uncertainty)
+	}
+}
+
+// TestHistorical_EphemerisFrame checks that running the models at TT from
+// the shifted observer of ephemerisFrame gives the sky of UT at the real
+// observer, with the body at TT.
+func TestHistorical_EphemerisFrame(t *testing.T) {
+	cfg := newConfig([]Option{WithHistorical()})
+	date := julian44BC(time.March, 15)
+	eloc, edate, dt := cfg.ephemerisFrame(rome, date)
+
+	if dt < 2*time.Hour || dt > 4*time.Hour {
+		t.Fatalf("ΔT in 44 BC = %v, want about 3h", dt)
+	}
+	if edate.Format("2006-01-02") != date.Format("2006-01-02") {
+		t.Errorf("ephemeris date %v is not the date %v", edate, date)
+	}
+	if start, want := timeutil.StartOfDay(edate), timeutil.StartOfDay(date).Add(dt); !start.Equal(want) {
+		t.Errorf("ephemeris day starts %v, want %v", start, want)
+	}
+
+	for h := 0; h < 24; h += 5 {
+		ut := timeutil.StartOfDay(date).Add(time.Duration(h) * time.Hour)
+		eq := sun.GeocentricEquatorialApprox(ut.Add(dt))
+		want, _ := coord.Horizontal(eq.RA, eq.Dec, rome.Lat, coord.LocalSiderealTime(rome.Lon, ut.UTC()))
+		got, _ := coord.Horizontal(eq.RA, eq.Dec, eloc.Lat, coord.LocalSiderealTime(eloc.Lon, ut.Add(dt).UTC()))
+		if math.Abs(got-want) > 1e-3 {
+			t.Errorf("%s: altitude %.4f in the ephemeris frame, %.4f directly", ut, got, want)
+		}
+	}
+
+	if loc, d, dt := newConfig(nil).ephemerisFrame(rome, date); loc != rome || !d.Equal(date) || dt != 0 {
+		t.Errorf("outside historical mode ephemerisFrame = %v, %v, %v", loc, d, dt)
+	}
+}
+
+// TestHistorical_ModernDates checks that historical mode only nudges
+// results within the supported range.
+func TestHistorical_ModernDates(t *testing.T) {
+	phoenix := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.FixedZone("MST", -7*3600))
+	for _, body := range []Body{Sun, Moon} {
+		plain, err := RiseSetFor(body, phoenix, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hist, err := RiseSetFor(body, phoenix, date, WithHistorical())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := hist.Rise.Sub(plain.Rise).Abs(); d > 10*time.Second {
+			t.Errorf("%v rise moved %v", body, d)
+		}
+		if d := hist.Set.Sub(plain.Set).Abs(); d > 10*time.Second {
+			t.Errorf("%v set moved %v", body, d)
+		}
+		if hist.Uncertainty-plain.Uncertainty > 2*time.Second {
+			t.Errorf("%v uncertainty %v, was %v", body, hist.Uncertainty, plain.Uncertainty)
+		}
+	}
+}
+
+// TestHistorical_AlmanacFunctions checks that every function taking options
+// accepts historical dates with WithHistorical and rejects them without.
+func TestHistorical_AlmanacFunctions(t *testing.T) {
+	ides := julian44BC(time.March, 15)
+	calls := map[string]func(...Option) error{
+		"MoonPhaseAt": func(o ...Option) error {
+			_, err := MoonPhaseAt(ides, o...)
+			return err
+		},
+		"GoldenHourFor": func(o ...Option) error {
+			_, err := GoldenHourFor(rome, ides, o...)
+			return err
+		},
+		"SunAltitudeWindowFor": func(o ...Option) error {
+			_, err := SunAltitudeWindowFor(rome, ides, -8, -4, o...)
+			return err
+		},
+		"TwilightDuration": func(o ...Option) error {
+			_, err := TwilightDuration(rome, ides, TwilightCivil, o...)
+			return err
+		},
+		"RiseSetEventsFor": func(o ...Option) error {
+			_, err := RiseSetEventsFor(Sun, rome, ides, o...)
+			return err
+		},
+		"UsableLight": func(o ...Option) error {
+			_, err := UsableLight(rome, ides, o...)
+			return err
+		},
+		"AviationTwilightFor": func(o ...Option) error {
+			_, err := AviationTwilightFor(rome, ides, o...)
+			return err
+		},
+		"DroneFlightWindowFor": func(o ...Option) error {
+			_, err := DroneFlightWindowFor(rome, ides, RulesPart107, o...)
+			return err
+		},
+		"LightingSchedule": func(o ...Option) error {
+			_, err := LightingSchedule(rome, ides, ides, 0, 0, 0, o...)
+			return err
+		},
+		"EclipseSeasons": func(o ...Option) error {
+			_, err := EclipseSeasons(-43, o...)
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%s without WithHistorical: err = %v, want ErrOutOfRange", name, err)
+		}
+		if err := call(WithHistorical()); err != nil {
+			t.Errorf("%s with WithHistorical: %v", name, err)
+		}
+	}
+
+	// Historical mode moves the Sun by ΔT, so the functions must agree with
+	// RiseSetFor on the same date.
+	rs, err := SlideIntoSunset(rome, ides, WithHistorical(), WithTrueInstants())
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := RiseSetEventsFor(Sun, rome, ides, WithHistorical(), WithTrueInstants())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || diffMinutes(events[0].Time, rs.Rise) > 0.1 || diffMinutes(events[1].Time, rs.Set) > 0.1 {
+		t.Errorf("RiseSetEventsFor = %v, want the sunrise %v and sunset %v of SlideIntoSunset", events, rs.Rise, rs.Set)
+	}
+
+	star := FixedObject{RA: 101.287, Dec: -16.716}
+	if _, err := ObjectRiseSetFor(star, rome, ides, WithHistorical()); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("ObjectRiseSetFor with WithHistorical: err = %v, want ErrNotImplemented", err)
+	}
+}
+
+// TestHistorical_EclipseSeasons checks the season of the eclipse Thales is
+// said to have foretold, total over Asia Minor on 28 May 585 BC (Julian).
+func TestHistorical_EclipseSeasons(t *testing.T) {
+	eclipse := astrotime.Date(astrotime.Julian, -584, time.May, 28, 14, 0, 0, 0)
+	seasons, err := EclipseSeasons(-584, WithHistorical())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range seasons {
+		for _, nm := range s.NewMoons {
+			if diffMinutes(nm, eclipse) < 24*60 {
+				return
+			}
+		}
+	}
+	t.Errorf("no season of 585 BC has a New Moon within a day of %v: %+v", eclipse, seasons)
+}
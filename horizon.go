@@ -0,0 +1,152 @@
+package astroglide
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// Limb selects which point of a body's disk defines rise and set.
+type Limb int
+
+const (
+	// LimbUpper puts the top edge of the disk on the horizon. This is the
+	// conventional (almanac) definition and the default.
+	LimbUpper Limb = iota
+
+	// LimbCenter puts the center of the disk on the horizon.
+	LimbCenter
+
+	// LimbLower puts the bottom edge of the disk on the horizon, e.g. the
+	// moment the Sun has fully cleared it.
+	LimbLower
+)
+
+// String returns the limb name, e.g. "upper limb".
+func (l Limb) String() string {
+	switch l {
+	case LimbUpper:
+		return "upper limb"
+	case LimbCenter:
+		return "center"
+	case LimbLower:
+		return "lower limb"
+	default:
+		return fmt.Sprintf("Limb(%d)", int(l))
+	}
+}
+
+const (
+	// standardRefraction is the conventional refraction at the horizon (34').
+	standardRefraction = 34.0 / 60.0
+
+	// sunSemiDiameter is the conventional solar semi-diameter (16').
+	sunSemiDiameter = 16.0 / 60.0
+)
+
+// WithLimb computes rise and set for the given point of the disk instead of
+// the upper limb. Refraction at the horizon is still included. It applies to
+// RiseSetFor and SlideIntoSunset.
+func WithLimb(l Limb) Option {
+	return func(c *config) {
+		c.limb = l
+	}
+}
+
+// WithZenith computes rise and set as the moments the body's center reaches
+// the given zenith angle (degrees), overriding WithLimb. No refraction or
+// semi-diameter is added: the standard sunrise corresponds to 90.833, and
+// 96 to the end of civil twilight. It applies to RiseSetFor and
+// SlideIntoSunset.
+func WithZenith(deg float64) Option {
+	return func(c *config) {
+		c.zenith = deg
+		c.hasZenith = true
+	}
+}
+
+// validateHorizon rejects rise/set definitions that cannot be solved.
+func (c config) validateHorizon() error {
+	if c.hasZenith && !(c.zenith > 0 && c.zenith < 180) {
+		return fmt.Errorf("invalid zenith %.3f°: must be between 0° and 180°", c.zenith)
+	}
+	if c.limb < LimbUpper || c.limb > LimbLower {
+		return fmt.Errorf("unknown %v", c.limb)
+	}
+	return nil
+}
+
+// sunZenith returns the zenith angle (degrees) of the Sun's center at rise
+// and set under c.
+func (c config) sunZenith() float64 {
+	if c.hasZenith {
+		return c.zenith
+	}
+	switch c.limb {
+	case LimbCenter:
+		return 90 + standardRefraction
+	case LimbLower:
+		return 90 + standardRefraction - sunSemiDiameter
+	default:
+		return sun.StandardZenith
+	}
+}
+
+// moonRiseSetUTC solves for moonrise and moonset (UTC) under c.
+func (c config) moonRiseSetUTC(loc Coordinates, date time.Time) (rs moon.RiseSet, okRise, okSet bool) {
+	loc, date, dt := c.ephemerisFrame(loc, date)
+	if c.hasZenith {
+		rs, okRise, okSet = moon.RiseSetForDateAtAltitude(loc.Lat, loc.Lon, date, 90-c.zenith)
+	} else {
+		rs, okRise, okSet = moon.RiseSetForDateLimb(loc.Lat, loc.Lon, date, float64(c.limb))
+	}
+	rs.Rise, rs.Set = fromEphemeris(rs.Rise, okRise, dt), fromEphemeris(rs.Set, okSet, dt)
+	return rs, okRise, okSet
+}
+
+// sunUpAllDay reports whether the Sun's center stays above targetAlt on the
+// local calendar date, given that it never crosses it: the altitude at
+// local apparent noon decides.
+func (c config) sunUpAllDay(loc Coordinates, date time.Time, targetAlt float64) bool {
+	loc, date, _ = c.ephemerisFrame(loc, date)
+	noon := sun.TransitForDate(loc.Lon, date)
+	return sun.AltitudeAt(loc.Lat, loc.Lon, noon) > targetAlt
+}
+
+// moonUpAllDay reports whether the Moon stays above its rise/set altitude
+// under c on the local calendar date, given that it never crosses it.
+func (c config) moonUpAllDay(loc Coordinates, date time.Time) bool {
+	loc, date, _ = c.ephemerisFrame(loc, date)
+	y, m, d := date.Date()
+	t := time.Date(y, m, d, 12, 0, 0, 0, date.Location())
+	if c.hasZenith {
+		return moon.AltitudeAt(loc.Lat, loc.Lon, t) > 90-c.zenith
+	}
+	dist := moon.GeocentricEquatorialWithDistanceApprox(t).Distance
+	return moon.AltitudeAboveHorizon(loc.Lat, loc.Lon, t) > float64(c.limb)*moon.SemiDiameter(dist)
+}
+
+// moonAllCrossingsUTC finds every moonrise and moonset (UTC) under c.
+func (c config) moonAllCrossingsUTC(loc Coordinates, date time.Time) (rises, sets []time.Time) {
+	loc, date, dt := c.ephemerisFrame(loc, date)
+	if c.hasZenith {
+		rises, sets = moon.AllCrossingsForDateAtAltitude(loc.Lat, loc.Lon, date, 90-c.zenith)
+	} else {
+		rises, sets = moon.AllCrossingsForDateLimb(loc.Lat, loc.Lon, date, float64(c.limb))
+	}
+	fromEphemerisAll(rises, dt)
+	fromEphemerisAll(sets, dt)
+	return rises, sets
+}
+
+// sunAllCrossingsUTC finds every upward and downward crossing (UTC) of
+// targetAlt by the Sun's center under c.
+func (c config) sunAllCrossingsUTC(loc Coordinates, date time.Time, targetAlt float64) (up, down []time.Time) {
+	loc, date, dt := c.ephemerisFrame(loc, date)
+	up, down = c.sunCache.AllCrossingsForDate(loc.Lat, loc.Lon, date, targetAlt)
+	fromEphemerisAll(up, dt)
+	fromEphemerisAll(down, dt)
+	return up, down
+}
@@ -0,0 +1,120 @@
+package astroglide
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// starlightLux is the illuminance of a clear moonless night sky, from
+// starlight, the zodiacal light and airglow.
+const starlightLux = 0.002
+
+// sunIlluminanceTable gives representative clear-sky horizontal illuminance
+// from the Sun and the twilight sky, as log10(lux) against the Sun's
+// altitude: about 500 lux at sunset, 3 lux at the end of civil twilight and
+// 0.008 lux at the end of nautical twilight.
+var sunIlluminanceTable = []struct{ alt, logLux float64 }{
+	{-18, -4.0},
+	{-15, -3.2},
+	{-12, -2.1},
+	{-9, -0.8},
+	{-6, 0.53},
+	{-3, 1.6},
+	{-0.8, 2.6},
+	{0, 2.8},
+	{5, 3.6},
+	{10, 4.0},
+	{20, 4.5},
+	{30, 4.8},
+	{45, 5.0},
+	{60, 5.05},
+	{90, 5.1},
+}
+
+// NightLight is the horizontal illuminance at the ground, in lux, split by
+// source.
+type NightLight struct {
+	Time time.Time
+
+	Sun       float64 // sunlight and twilight; 0 once the Sun is 18° down
+	Moon      float64 // direct moonlight, as Moonlight
+	Starlight float64 // the moonless night sky
+	Total     float64
+}
+
+// Moonlight estimates the illuminance in lux that the Moon casts on level
+// ground at loc at time t, under a clear sky: from its phase (the
+// Allen/Krisciunas & Schaefer phase law), distance, altitude and the
+// extinction along the line of sight. A Full Moon overhead gives about
+// 0.25 lux; the result is 0 while the Moon is below the horizon.
+//
+// Clouds, haze and terrain are not modelled.
+func Moonlight(loc Coordinates, t time.Time) (float64, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return 0, err
+	}
+	alt := moon.AltitudeAt(loc.Lat, loc.Lon, t)
+	if alt <= 0 {
+		return 0, nil
+	}
+	phase, err := MoonPhaseAt(t)
+	if err != nil {
+		return 0, err
+	}
+	return moonIlluminance(180-phase.Elongation, alt, geocentricDistanceKm(Moon, t)), nil
+}
+
+// NightIlluminance estimates the total illuminance in lux on level ground
+// at loc at time t: direct moonlight (see Moonlight), sunlight and twilight
+// interpolated from clear-sky tables, and a fixed 0.002 lux from the
+// moonless night sky. Totals run from about 0.002 lux on a dark night to
+// 0.3 lux under a high Full Moon and 3 lux at the end of civil twilight.
+func NightIlluminance(loc Coordinates, t time.Time) (NightLight, error) {
+	m, err := Moonlight(loc, t)
+	if err != nil {
+		return NightLight{}, err
+	}
+	s := sunIlluminance(sun.AltitudeAt(loc.Lat, loc.Lon, t))
+	return NightLight{
+		Time:      t,
+		Sun:       s,
+		Moon:      m,
+		Starlight: starlightLux,
+		Total:     s + m + starlightLux,
+	}, nil
+}
+
+// moonIlluminance returns the illuminance in lux on level ground from the
+// Moon at phase angle alpha (0 at Full Moon), altitude alt and distKm from
+// the Earth.
+func moonIlluminance(alpha, alt, distKm float64) float64 {
+	// Apparent V magnitude of the Moon outside the atmosphere, and the
+	// illuminance normal to its direction: a source of magnitude m gives
+	// 10^(-0.4(m + 14.18)) lux.
+	mag := -12.73 + 0.026*math.Abs(alpha) + 4e-9*math.Pow(alpha, 4)
+	mag += 5 * math.Log10(distKm/meanMoonDistanceKm)
+	normal := math.Pow(10, -0.4*(mag+14.18))
+
+	extinction := math.Pow(10, -0.4*vExtinction*airmassKS(90-alt))
+	return normal * extinction * timeutil.SinD(alt)
+}
+
+// sunIlluminance interpolates sunIlluminanceTable in log10(lux).
+func sunIlluminance(alt float64) float64 {
+	tbl := sunIlluminanceTable
+	if alt < tbl[0].alt {
+		return 0
+	}
+	if alt >= tbl[len(tbl)-1].alt {
+		return math.Pow(10, tbl[len(tbl)-1].logLux)
+	}
+	i := sort.Search(len(tbl), func(i int) bool { return tbl[i].alt > alt })
+	lo, hi := tbl[i-1], tbl[i]
+	f := (alt - lo.alt) / (hi.alt - lo.alt)
+	return math.Pow(10, lo.logLux+f*(hi.logLux-lo.logLux))
+}
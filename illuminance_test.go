@@ -0,0 +1,69 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoonIlluminance(t *testing.T) {
+	full := moonIlluminance(0, 90, meanMoonDistanceKm)
+	if full < 0.2 || full > 0.3 {
+		t.Errorf("Full Moon overhead = %.3f lux, want ~0.23", full)
+	}
+	quarter := moonIlluminance(90, 90, meanMoonDistanceKm)
+	if r := full / quarter; r < 7 || r > 12 {
+		t.Errorf("Full/quarter ratio = %.1f, want ~9", r)
+	}
+	if low := moonIlluminance(0, 10, meanMoonDistanceKm); low > full*0.2 {
+		t.Errorf("Full Moon at 10° = %.3f lux, want much less than overhead %.3f", low, full)
+	}
+}
+
+func TestSunIlluminance(t *testing.T) {
+	cases := []struct{ alt, want float64 }{
+		{-6, 3.4},
+		{-12, 0.008},
+		{0, 630},
+	}
+	for _, c := range cases {
+		if got := sunIlluminance(c.alt); math.Abs(math.Log10(got/c.want)) > 0.05 {
+			t.Errorf("sunIlluminance(%v) = %.4g lux, want %.4g", c.alt, got, c.want)
+		}
+	}
+	if got := sunIlluminance(-20); got != 0 {
+		t.Errorf("sunIlluminance(-20) = %v, want 0", got)
+	}
+	if got := sunIlluminance(90); got < 1e5 {
+		t.Errorf("sunIlluminance(90) = %v, want full daylight", got)
+	}
+}
+
+func TestNightIlluminance(t *testing.T) {
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	// 2025-11-05: a Full Moon high over Phoenix around midnight.
+	full, err := NightIlluminance(coords, time.Date(2025, time.November, 5, 7, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NightIlluminance error: %v", err)
+	}
+	if full.Moon < 0.1 || full.Moon > 0.3 || full.Sun != 0 {
+		t.Errorf("Full Moon night = %+v, want ~0.2 lux of moonlight and no sun", full)
+	}
+	if math.Abs(full.Total-(full.Moon+full.Starlight)) > 1e-12 {
+		t.Errorf("Total = %v, want Moon+Starlight", full.Total)
+	}
+
+	// 2025-11-20: New Moon, at local midnight.
+	dark, err := NightIlluminance(coords, time.Date(2025, time.November, 21, 7, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NightIlluminance error: %v", err)
+	}
+	if dark.Moon != 0 || dark.Total != starlightLux {
+		t.Errorf("moonless night = %+v, want starlight only", dark)
+	}
+
+	if _, err := Moonlight(Coordinates{Lat: 91}, time.Now()); err == nil {
+		t.Error("expected error for latitude out of range")
+	}
+}
@@ -0,0 +1,75 @@
+// Package coord contains spherical-astronomy helpers shared by the Sun,
+// Moon and higher-level event code: angular separations and conversions
+// between coordinate systems.
+package coord
+
+import (
+	"math"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Separation returns the angular distance (degrees, 0..180) between two
+// points given by right ascension and declination in degrees.
+//
+//	cos ψ = sin δ1 sin δ2 + cos δ1 cos δ2 cos(α1 - α2)
+func Separation(ra1, dec1, ra2, dec2 float64) float64 {
+	d1 := timeutil.Deg2Rad(dec1)
+	d2 := timeutil.Deg2Rad(dec2)
+	dRA := timeutil.Deg2Rad(ra1 - ra2)
+
+	cosPsi := math.Sin(d1)*math.Sin(d2) + math.Cos(d1)*math.Cos(d2)*math.Cos(dRA)
+
+	// Clamp to handle numerical noise
+	if cosPsi > 1 {
+		cosPsi = 1
+	} else if cosPsi < -1 {
+		cosPsi = -1
+	}
+
+	return timeutil.Rad2Deg(math.Acos(cosPsi))
+}
+
+// MeanEarthRadiusKm is the mean radius of the Earth, for distances along
+// the surface.
+const MeanEarthRadiusKm = 6371.0
+
+// Destination returns the point (degrees) reached by travelling distKm
+// along a great circle from (lat, lon) with initial bearing bearing
+// (degrees from north through east), on a spherical Earth.
+func Destination(lat, lon, bearing, distKm float64) (float64, float64) {
+	phi1 := timeutil.Deg2Rad(lat)
+	theta := timeutil.Deg2Rad(bearing)
+	delta := distKm / MeanEarthRadiusKm
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	dLon := math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(phi1), math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2))
+
+	lon2 := math.Mod(lon+timeutil.Rad2Deg(dLon)+540, 360) - 180
+	return timeutil.Rad2Deg(phi2), lon2
+}
+
+// Distance returns the great-circle distance (km) between two points given
+// by latitude and longitude in degrees, on a spherical Earth. It uses the
+// haversine formula, which stays accurate for short distances.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := timeutil.Deg2Rad(lat1)
+	phi2 := timeutil.Deg2Rad(lat2)
+	dPhi := phi2 - phi1
+	dLambda := timeutil.Deg2Rad(lon2 - lon1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * MeanEarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Bearing returns the initial bearing (degrees from north through east,
+// [0, 360)) of the great circle from (lat1, lon1) to (lat2, lon2).
+func Bearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := timeutil.Deg2Rad(lat1)
+	phi2 := timeutil.Deg2Rad(lat2)
+	dLambda := timeutil.Deg2Rad(lon2 - lon1)
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	return timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(y, x)))
+}
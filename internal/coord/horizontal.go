@@ -0,0 +1,75 @@
+package coord
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// EarthRadiusKm is the equatorial radius of the Earth used for parallax.
+const EarthRadiusKm = 6378.14
+
+// LocalSiderealTime returns the local mean sidereal time (degrees, [0, 360))
+// at longitude lon (east positive) and time t, using the same linear GMST
+// expression as the Sun and Moon altitude models.
+func LocalSiderealTime(lon float64, t time.Time) float64 {
+	d := timeutil.DaysSinceJ2000(t)
+	return timeutil.Normalize360(280.46061837 + 360.98564736629*d + lon)
+}
+
+// Horizontal converts equatorial coordinates (degrees) to altitude and
+// azimuth (degrees; azimuth measured from north through east) for an
+// observer at latitude lat with local sidereal time lst.
+func Horizontal(ra, dec, lat, lst float64) (alt, az float64) {
+	H := lst - ra
+
+	sinAlt := timeutil.SinD(lat)*timeutil.SinD(dec) + timeutil.CosD(lat)*timeutil.CosD(dec)*timeutil.CosD(H)
+	alt = timeutil.Rad2Deg(math.Asin(math.Max(-1, math.Min(1, sinAlt))))
+
+	// Meeus 13.5 measures azimuth from the south; shift to north.
+	y := timeutil.SinD(H)
+	x := timeutil.CosD(H)*timeutil.SinD(lat) - timeutil.TanD(dec)*timeutil.CosD(lat)
+	az = timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(y, x)) + 180)
+
+	return alt, az
+}
+
+// ParallacticAngle returns the parallactic angle (degrees, (-180, 180],
+// Meeus 14.1) of an object at hour angle ha and declination dec seen from
+// latitude lat: the angle at the object between the directions to the
+// celestial pole and the zenith, positive west of the meridian. It is
+// written without Meeus's tan(lat) so that it stays finite at the poles,
+// where it is undefined and 0 or ±180 is returned.
+func ParallacticAngle(lat, ha, dec float64) float64 {
+	return timeutil.Rad2Deg(math.Atan2(
+		timeutil.SinD(ha)*timeutil.CosD(lat),
+		timeutil.SinD(lat)*timeutil.CosD(dec)-timeutil.CosD(lat)*timeutil.SinD(dec)*timeutil.CosD(ha),
+	))
+}
+
+// Topocentric shifts geocentric equatorial coordinates (degrees) of a body
+// at distanceKm to those seen by an observer at (lat, elevationM) with local
+// sidereal time lst. It returns the topocentric RA, Dec and distance.
+//
+// The observer's position uses the IAU 1976 ellipsoid (Meeus ch. 11).
+func Topocentric(ra, dec, distanceKm, lat, elevationM, lst float64) (raT, decT, distT float64) {
+	const flattening = 0.99664719 // b/a
+
+	u := math.Atan(flattening * timeutil.TanD(lat))
+	h := elevationM / (EarthRadiusKm * 1000)
+	rhoSin := flattening*math.Sin(u) + h*timeutil.SinD(lat)
+	rhoCos := math.Cos(u) + h*timeutil.CosD(lat)
+
+	// Body and observer as vectors in Earth radii, equatorial frame of date.
+	r := distanceKm / EarthRadiusKm
+	x := r*timeutil.CosD(dec)*timeutil.CosD(ra) - rhoCos*timeutil.CosD(lst)
+	y := r*timeutil.CosD(dec)*timeutil.SinD(ra) - rhoCos*timeutil.SinD(lst)
+	z := r*timeutil.SinD(dec) - rhoSin
+
+	rho := math.Sqrt(x*x + y*y + z*z)
+	raT = timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(y, x)))
+	decT = timeutil.Rad2Deg(math.Asin(z / rho))
+
+	return raT, decT, rho * EarthRadiusKm
+}
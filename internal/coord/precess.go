@@ -0,0 +1,39 @@
+package coord
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Precess converts mean equatorial coordinates (degrees) referred to the
+// equinox of `from` to the equinox of `to`, using the IAU 1976 precession
+// angles (Meeus, Astronomical Algorithms, ch. 21). Nutation and aberration
+// are not included. The returned RA is in [0, 360).
+func Precess(ra, dec float64, from, to time.Time) (float64, float64) {
+	T := timeutil.JulianCenturies(from)
+	t := (timeutil.JulianDay(to) - timeutil.JulianDay(from)) / 36525.0
+	if t == 0 {
+		return ra, dec
+	}
+
+	// Precession angles in arcseconds.
+	base := 2306.2181 + 1.39656*T - 0.000139*T*T
+	zeta := base*t + (0.30188-0.000344*T)*t*t + 0.017998*t*t*t
+	z := base*t + (1.09468+0.000066*T)*t*t + 0.018203*t*t*t
+	theta := (2004.3109-0.85330*T-0.000217*T*T)*t - (0.42665+0.000217*T)*t*t - 0.041833*t*t*t
+
+	zeta /= 3600
+	z /= 3600
+	theta /= 3600
+
+	A := timeutil.CosD(dec) * timeutil.SinD(ra+zeta)
+	B := timeutil.CosD(theta)*timeutil.CosD(dec)*timeutil.CosD(ra+zeta) - timeutil.SinD(theta)*timeutil.SinD(dec)
+	C := timeutil.SinD(theta)*timeutil.CosD(dec)*timeutil.CosD(ra+zeta) + timeutil.CosD(theta)*timeutil.SinD(dec)
+
+	raOut := timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(A, B)) + z)
+	decOut := timeutil.Rad2Deg(math.Asin(math.Max(-1, math.Min(1, C))))
+
+	return raOut, decOut
+}
@@ -83,28 +83,102 @@ type EquatorialDistance struct {
 // Returned Rise and Set are in UTC.
 // okRise/okSet indicate whether rise/set events were found in that local date.
 func RiseSetForDate(lat, lon float64, date time.Time) (rs RiseSet, okRise, okSet bool) {
-	loc := date.Location()
+	return RiseSetForDateLimb(lat, lon, date, 0)
+}
+
+// RiseSetForDateLimb is RiseSetForDate with the horizon raised by `limb`
+// lunar semi-diameters: 0 puts the upper limb on the horizon (the default),
+// 1 the center and 2 the lower limb.
+func RiseSetForDateLimb(lat, lon float64, date time.Time, limb float64) (rs RiseSet, okRise, okSet bool) {
+	// Rise horizon: distance-dependent, shifted by the requested limb.
+	riseHorizon := func(distanceKm float64) float64 {
+		return ApparentHorizonAltitudeMoon(distanceKm) + limb*SemiDiameter(distanceKm)
+	}
+
+	// Set horizon: same, but with a small extra drop in the horizon so that
+	// the Moon "sets" slightly earlier, compensating for the observed ~0.9
+	// minute late bias.
+	setHorizon := func(distanceKm float64) float64 {
+		return riseHorizon(distanceKm) + moonSetExtraDropDeg
+	}
+
+	return riseSetForDate(lat, lon, date, riseHorizon, setHorizon)
+}
+
+// RiseSetForDateAtAltitude finds the times on the local calendar date when
+// the Moon's center crosses targetAlt (degrees, topocentric, airless).
+func RiseSetForDateAtAltitude(lat, lon float64, date time.Time, targetAlt float64) (rs RiseSet, okRise, okSet bool) {
+	horizon := func(float64) float64 { return targetAlt }
+	return riseSetForDate(lat, lon, date, horizon, horizon)
+}
+
+// AllCrossingsForDateLimb returns every rise and set (UTC) during the local
+// calendar day of `date`, in time order, under the same horizon definition
+// as RiseSetForDateLimb. Near the poles the Moon can rise or set more than
+// once in a day.
+func AllCrossingsForDateLimb(lat, lon float64, date time.Time, limb float64) (risesUTC, setsUTC []time.Time) {
+	return allCrossingsForDate(lat, lon, date, func(distanceKm float64) float64 {
+		return ApparentHorizonAltitudeMoon(distanceKm) + limb*SemiDiameter(distanceKm)
+	}, moonSetExtraDropDeg)
+}
+
+// AllCrossingsForDateAtAltitude returns every crossing (UTC) of targetAlt
+// by the Moon's center during the local calendar day of `date`.
+func AllCrossingsForDateAtAltitude(lat, lon float64, date time.Time, targetAlt float64) (risesUTC, setsUTC []time.Time) {
+	return allCrossingsForDate(lat, lon, date, func(float64) float64 { return targetAlt }, 0)
+}
+
+// allCrossingsForDate finds the upward crossings of horizon and the
+// downward crossings of horizon+setDrop during the local calendar day.
+func allCrossingsForDate(lat, lon float64, date time.Time, horizon func(distanceKm float64) float64, setDrop float64) (risesUTC, setsUTC []time.Time) {
+	startLocal, endLocal := timeutil.LocalDay(date)
+
+	altFunc := func(t time.Time) float64 {
+		eq := GeocentricEquatorialWithDistanceApprox(t)
+		return apparentAltitude(lat, lon, t) - horizon(eq.Distance)
+	}
+
+	const (
+		steps = 48               // samples across the day
+		tol   = 30 * time.Second // root tolerance
+	)
 
-	// Define the search window as the local calendar day: [00:00, 24:00).
-	startLocal := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
-	endLocal := startLocal.Add(24 * time.Hour)
+	for _, c := range solver.FindAllAltitudeEvents(altFunc, startLocal, endLocal, 0, steps, tol) {
+		if c.Type == solver.CrossingUp {
+			risesUTC = append(risesUTC, c.Time.UTC())
+		}
+	}
+	for _, c := range solver.FindAllAltitudeEvents(altFunc, startLocal, endLocal, setDrop, steps, tol) {
+		if c.Type == solver.CrossingDown {
+			setsUTC = append(setsUTC, c.Time.UTC())
+		}
+	}
+	return risesUTC, setsUTC
+}
+
+// SemiDiameter returns the Moon's geocentric angular semi-diameter in
+// degrees at the given distance.
+func SemiDiameter(distanceKm float64) float64 {
+	const moonRadiusKm = 1737.4
+	return timeutil.Rad2Deg(math.Asin(moonRadiusKm / distanceKm))
+}
+
+// riseSetForDate solves for the upward crossing of riseHorizon and the
+// downward crossing of setHorizon (both functions of the Moon's distance)
+// during the local calendar day of date.
+func riseSetForDate(lat, lon float64, date time.Time, riseHorizon, setHorizon func(distanceKm float64) float64) (rs RiseSet, okRise, okSet bool) {
+	// Search the local calendar day, which is 23 or 25 hours long on the
+	// days daylight saving time starts and ends.
+	startLocal, endLocal := timeutil.LocalDay(date)
 
-	// Rise altitude function: apparent altitude minus distance-dependent horizon.
 	altFuncRise := func(t time.Time) float64 {
 		eq := GeocentricEquatorialWithDistanceApprox(t)
-		alt := apparentAltitude(lat, lon, t)
-		horizon := ApparentHorizonAltitudeMoon(eq.Distance)
-		return alt - horizon
+		return apparentAltitude(lat, lon, t) - riseHorizon(eq.Distance)
 	}
 
-	// Set altitude function: same, but with a small extra drop in the horizon
-	// so that the Moon "sets" slightly earlier, compensating for the observed
-	// ~0.9 minute late bias.
 	altFuncSet := func(t time.Time) float64 {
 		eq := GeocentricEquatorialWithDistanceApprox(t)
-		alt := apparentAltitude(lat, lon, t)
-		horizon := ApparentHorizonAltitudeMoon(eq.Distance) + moonSetExtraDropDeg
-		return alt - horizon
+		return apparentAltitude(lat, lon, t) - setHorizon(eq.Distance)
 	}
 
 	// We're solving for zero crossings of altFunc*(t).
@@ -112,7 +186,7 @@ func RiseSetForDate(lat, lon float64, date time.Time) (rs RiseSet, okRise, okSet
 
 	const (
 		steps = 48               // samples across the day
-		tol   = 30 * time.Second // bisection tolerance
+		tol   = 30 * time.Second // root tolerance
 	)
 
 	// Find rise (crossing upward).
@@ -261,3 +335,39 @@ func GeocentricEquatorialWithDistanceApprox(t time.Time) EquatorialDistance {
 		Distance: delta,
 	}
 }
+
+// AltitudeAt returns the Moon's topocentric altitude (in degrees) as seen
+// from (lat, lon) at time t.
+func AltitudeAt(lat, lon float64, t time.Time) float64 {
+	return apparentAltitude(lat, lon, t)
+}
+
+// AltitudeAboveHorizon returns how far (in degrees) the Moon's center is
+// above the distance-dependent rise/set horizon at time t. Positive values
+// mean the Moon is up under the same definition RiseSetForDate uses for rise.
+func AltitudeAboveHorizon(lat, lon float64, t time.Time) float64 {
+	eq := GeocentricEquatorialWithDistanceApprox(t)
+	return apparentAltitude(lat, lon, t) - ApparentHorizonAltitudeMoon(eq.Distance)
+}
+
+// GeocentricAltitudeAt returns the Moon's geocentric, airless altitude (in
+// degrees) at (lat, lon) and time t: no parallax or refraction is applied.
+// Crescent visibility criteria are defined in terms of this quantity.
+func GeocentricAltitudeAt(lat, lon float64, t time.Time) float64 {
+	eq := GeocentricEquatorialApprox(t)
+
+	d := timeutil.DaysSinceJ2000(t)
+	gmst := 280.46061837 + 360.98564736629*d
+	H := timeutil.Normalize360(gmst + lon - eq.RA)
+
+	sinAlt := timeutil.SinD(lat)*timeutil.SinD(eq.Dec) +
+		timeutil.CosD(lat)*timeutil.CosD(eq.Dec)*timeutil.CosD(H)
+	return timeutil.Rad2Deg(math.Asin(sinAlt))
+}
+
+// HorizontalParallax returns the Moon's equatorial horizontal parallax in
+// degrees at time t.
+func HorizontalParallax(t time.Time) float64 {
+	eq := GeocentricEquatorialWithDistanceApprox(t)
+	return timeutil.Rad2Deg(horizontalParallax(eq.Distance))
+}
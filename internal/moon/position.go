@@ -15,8 +15,8 @@ type Equatorial struct {
 	Dec float64 // declination, degrees
 }
 
-// GeocentricEquatorialApprox returns an approximate geocentric RA/Dec for the Moon
-// at the given time t.
+// EclipticApprox returns the Moon's approximate geocentric ecliptic
+// longitude and latitude (degrees) at time t. Longitude is in [0, 360).
 //
 // This is a medium-precision model using a small set of dominant periodic terms
 // in ecliptic longitude and latitude. It's significantly better than the
@@ -29,30 +29,15 @@ type Equatorial struct {
 //	Mm  = mean anomaly of the Moon
 //	D   = mean elongation of the Moon from the Sun
 //	F   = argument of latitude of the Moon
-func GeocentricEquatorialApprox(t time.Time) Equatorial {
-	d := timeutil.DaysSinceJ2000(t)
-
-	// Convert day count to degrees for the standard fundamental arguments.
-	// All linear coefficients here are in deg/day.
-	Lprime := 218.3164477 + 13.17639648*d // mean longitude of the Moon
-	M := 357.5291092 + 0.98560028*d       // mean anomaly of the Sun
-	Mm := 134.9633964 + 13.06499295*d     // mean anomaly of the Moon
-	D := 297.8501921 + 12.19074912*d      // mean elongation from the Sun
-	F := 93.2720950 + 13.22935024*d       // argument of latitude
-
-	// Normalize to [0, 360)
-	Lprime = timeutil.Normalize360(Lprime)
-	M = timeutil.Normalize360(M)
-	Mm = timeutil.Normalize360(Mm)
-	D = timeutil.Normalize360(D)
-	F = timeutil.Normalize360(F)
+func EclipticApprox(t time.Time) (lonDeg, latDeg float64) {
+	a := FundamentalArguments(t)
 
 	// Convert to radians for trig.
-	Lr := timeutil.Deg2Rad(Lprime)
-	Mr := timeutil.Deg2Rad(M)
-	Mmr := timeutil.Deg2Rad(Mm)
-	Dr := timeutil.Deg2Rad(D)
-	Fr := timeutil.Deg2Rad(F)
+	Lr := timeutil.Deg2Rad(a.L)
+	Mr := timeutil.Deg2Rad(a.M)
+	Mmr := timeutil.Deg2Rad(a.Mm)
+	Dr := timeutil.Deg2Rad(a.D)
+	Fr := timeutil.Deg2Rad(a.F)
 
 	// Ecliptic longitude λ (deg), using a handful of main terms.
 	// λ ≈ L' + 6.289 sin(Mm) + 1.274 sin(2D − Mm)
@@ -74,8 +59,45 @@ func GeocentricEquatorialApprox(t time.Time) Equatorial {
 		timeutil.Deg2Rad(0.277)*math.Sin(Mmr-Fr) +
 		timeutil.Deg2Rad(0.173)*math.Sin(2*Dr-Fr)
 
-	// Mean obliquity of the ecliptic ε (deg) – simple linear model.
-	eps := timeutil.Deg2Rad(23.439291 - 0.0000137*d)
+	return timeutil.Normalize360(timeutil.Rad2Deg(lon)), timeutil.Rad2Deg(lat)
+}
+
+// Arguments are the mean fundamental arguments of the lunar theory, in
+// degrees [0, 360).
+type Arguments struct {
+	L  float64 // L', mean longitude of the Moon
+	M  float64 // mean anomaly of the Sun
+	Mm float64 // M', mean anomaly of the Moon
+	D  float64 // mean elongation of the Moon from the Sun
+	F  float64 // argument of latitude of the Moon
+}
+
+// FundamentalArguments returns the mean arguments used by EclipticApprox
+// at time t.
+func FundamentalArguments(t time.Time) Arguments {
+	d := timeutil.DaysSinceJ2000(t)
+
+	// Convert day count to degrees for the standard fundamental arguments.
+	// All linear coefficients here are in deg/day.
+	return Arguments{
+		L:  timeutil.Normalize360(218.3164477 + 13.17639648*d),
+		M:  timeutil.Normalize360(357.5291092 + 0.98560028*d),
+		Mm: timeutil.Normalize360(134.9633964 + 13.06499295*d),
+		D:  timeutil.Normalize360(297.8501921 + 12.19074912*d),
+		F:  timeutil.Normalize360(93.2720950 + 13.22935024*d),
+	}
+}
+
+// GeocentricEquatorialApprox returns an approximate geocentric RA/Dec for the Moon
+// at the given time t, converting the EclipticApprox position to equatorial
+// coordinates.
+func GeocentricEquatorialApprox(t time.Time) Equatorial {
+	lonDeg, latDeg := EclipticApprox(t)
+	lon := timeutil.Deg2Rad(lonDeg)
+	lat := timeutil.Deg2Rad(latDeg)
+
+	// Mean obliquity of the ecliptic ε.
+	eps := timeutil.Deg2Rad(timeutil.MeanObliquity(t))
 
 	// Convert from ecliptic (lon, lat) to equatorial (RA, Dec).
 	x := math.Cos(lat) * math.Cos(lon)
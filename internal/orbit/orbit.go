@@ -0,0 +1,184 @@
+// Package orbit propagates heliocentric two-body (Keplerian) orbits of
+// comets and asteroids from osculating elements, and reduces them to
+// geocentric positions.
+//
+// Elliptic, parabolic and hyperbolic orbits are handled, following Meeus,
+// Astronomical Algorithms, ch. 30, 33 and 34. Planetary perturbations are
+// ignored, so positions drift from the truth by arcminutes a few months
+// from the elements' epoch; use fresh elements.
+package orbit
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+const (
+	// gaussK is the Gaussian gravitational constant, radians per day for a
+	// body of negligible mass at 1 AU.
+	gaussK = 0.01720209895
+
+	// kmPerAU is the astronomical unit in kilometres.
+	kmPerAU = 149597870.7
+
+	// lightDaysPerAU is the light time for 1 AU, in days.
+	lightDaysPerAU = 0.0057755183
+
+	// obliquityJ2000 is the obliquity of the ecliptic at J2000.0, degrees.
+	obliquityJ2000 = 23.4392911
+)
+
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// ErrElements is returned for elements that describe no orbit.
+var ErrElements = errors.New("orbit: invalid elements")
+
+// Elements are osculating heliocentric orbital elements referred to the
+// J2000.0 ecliptic and equinox, in the perihelion form used for comets.
+// Asteroid elements given as semimajor axis and mean anomaly at an epoch
+// convert with FromMeanAnomaly.
+type Elements struct {
+	Perihelion time.Time // time of perihelion passage, T (TT, used as UTC)
+	Q          float64   // perihelion distance, AU
+	E          float64   // eccentricity
+
+	ArgPerihelion float64 // ω, degrees
+	Node          float64 // Ω, longitude of the ascending node, degrees
+	Inclination   float64 // i, degrees
+}
+
+// FromMeanAnomaly returns the elements of an elliptic orbit given by its
+// semimajor axis a (AU), eccentricity e, and mean anomaly m (degrees) at
+// epoch.
+func FromMeanAnomaly(epoch time.Time, a, e, m, argPeri, node, incl float64) Elements {
+	n := timeutil.Rad2Deg(gaussK / (a * math.Sqrt(a))) // degrees per day
+	m = math.Remainder(m, 360)
+	days := m / n
+	return Elements{
+		Perihelion:    epoch.Add(-time.Duration(days * 24 * float64(time.Hour))),
+		Q:             a * (1 - e),
+		E:             e,
+		ArgPerihelion: argPeri,
+		Node:          node,
+		Inclination:   incl,
+	}
+}
+
+// Validate reports whether el describes an orbit.
+func (el Elements) Validate() error {
+	if !(el.Q > 0) || !(el.E >= 0) || math.IsInf(el.Q, 0) || math.IsInf(el.E, 0) || el.Perihelion.IsZero() {
+		return ErrElements
+	}
+	return nil
+}
+
+// Heliocentric returns the body's heliocentric rectangular coordinates in
+// AU at t, referred to the J2000.0 equator and equinox.
+func (el Elements) Heliocentric(t time.Time) (x, y, z float64) {
+	days := t.Sub(el.Perihelion).Hours() / 24
+	xv, yv := el.perifocal(days)
+
+	// Unit vectors towards perihelion (P) and 90° ahead of it (Q), in the
+	// ecliptic frame.
+	sw, cw := math.Sincos(timeutil.Deg2Rad(el.ArgPerihelion))
+	sn, cn := math.Sincos(timeutil.Deg2Rad(el.Node))
+	si, ci := math.Sincos(timeutil.Deg2Rad(el.Inclination))
+	px, py, pz := cw*cn-sw*sn*ci, cw*sn+sw*cn*ci, sw*si
+	qx, qy, qz := -sw*cn-cw*sn*ci, -sw*sn+cw*cn*ci, cw*si
+
+	xe := xv*px + yv*qx
+	ye := xv*py + yv*qy
+	ze := xv*pz + yv*qz
+
+	se, ce := math.Sincos(timeutil.Deg2Rad(obliquityJ2000))
+	return xe, ye*ce - ze*se, ye*se + ze*ce
+}
+
+// perifocal returns the position in the orbital plane, in AU, with x
+// towards perihelion, days after perihelion.
+func (el Elements) perifocal(days float64) (x, y float64) {
+	q, e := el.Q, el.E
+	switch {
+	case math.Abs(e-1) < 1e-8:
+		// Parabola: Barker's equation, solved in closed form.
+		w := 3 * gaussK / math.Sqrt(2*q*q*q) * days
+		yy := math.Cbrt(w/2 + math.Sqrt(w*w/4+1))
+		s := yy - 1/yy
+		return q * (1 - s*s), 2 * q * s
+
+	case e < 1:
+		a := q / (1 - e)
+		m := math.Remainder(gaussK/(a*math.Sqrt(a))*days, 2*math.Pi)
+		ea := solveKepler(e, m)
+		sE, cE := math.Sincos(ea)
+		return a * (cE - e), a * math.Sqrt(1-e*e) * sE
+
+	default:
+		a := q / (e - 1)
+		m := gaussK / (a * math.Sqrt(a)) * days
+		h := solveHyperbolic(e, m)
+		return a * (e - math.Cosh(h)), a * math.Sqrt(e*e-1) * math.Sinh(h)
+	}
+}
+
+// solveKepler solves Kepler's equation E - e sin E = M for the eccentric
+// anomaly E (radians), with M in [-π, π].
+func solveKepler(e, m float64) float64 {
+	ea := m
+	if e > 0.8 {
+		ea = math.Copysign(math.Pi, m)
+	}
+	for i := 0; i < 100; i++ {
+		d := (ea - e*math.Sin(ea) - m) / (1 - e*math.Cos(ea))
+		ea -= d
+		if math.Abs(d) < 1e-12 {
+			break
+		}
+	}
+	return ea
+}
+
+// solveHyperbolic solves e sinh H - H = M for the hyperbolic anomaly H.
+func solveHyperbolic(e, m float64) float64 {
+	h := math.Asinh(m / e)
+	for i := 0; i < 100; i++ {
+		d := (e*math.Sinh(h) - h - m) / (e*math.Cosh(h) - 1)
+		h -= d
+		if math.Abs(d) < 1e-12 {
+			break
+		}
+	}
+	return h
+}
+
+// Geocentric returns the body's astrometric geocentric right ascension
+// and declination (degrees) referred to the equinox of date, and its
+// distance in kilometres, at t. Light time is allowed for; aberration and
+// nutation are not.
+func (el Elements) Geocentric(t time.Time) (ra, dec, distKm float64) {
+	// The Sun's geocentric position of date, precessed back to J2000.
+	s := sun.GeocentricEquatorialApprox(t)
+	sra, sdec := coord.Precess(s.RA, s.Dec, t, j2000)
+	r := sun.DistanceAU(t)
+	sx := r * timeutil.CosD(sdec) * timeutil.CosD(sra)
+	sy := r * timeutil.CosD(sdec) * timeutil.SinD(sra)
+	sz := r * timeutil.SinD(sdec)
+
+	var x, y, z, delta float64
+	for i := 0; i < 3; i++ {
+		tau := time.Duration(delta * lightDaysPerAU * 24 * float64(time.Hour))
+		hx, hy, hz := el.Heliocentric(t.Add(-tau))
+		x, y, z = hx+sx, hy+sy, hz+sz
+		delta = math.Sqrt(x*x + y*y + z*z)
+	}
+
+	ra = timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(y, x)))
+	dec = timeutil.Rad2Deg(math.Asin(z / delta))
+	ra, dec = coord.Precess(ra, dec, j2000, t)
+	return ra, dec, delta * kmPerAU
+}
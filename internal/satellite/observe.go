@@ -0,0 +1,89 @@
+package satellite
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// WGS-84 ellipsoid used for the observer's position.
+const (
+	wgs84A  = 6378.137
+	wgs84F  = 1 / 298.257223563
+	wgs84E2 = wgs84F * (2 - wgs84F)
+)
+
+// LookAngles describes where a satellite appears to a ground observer.
+type LookAngles struct {
+	Azimuth   float64 // degrees from north, clockwise
+	Elevation float64 // degrees above the geometric horizon
+	RangeKm   float64 // slant range
+}
+
+// Look converts a TEME satellite position at time t into azimuth,
+// elevation and range for an observer at geodetic lat/lon (degrees) and
+// elevation (metres). Polar motion is ignored.
+func Look(pos Vector, t time.Time, lat, lon, elevationM float64) LookAngles {
+	g := gmst(t)
+	cg, sg := math.Cos(g), math.Sin(g)
+
+	// TEME -> Earth-fixed.
+	x := cg*pos.X + sg*pos.Y
+	y := -sg*pos.X + cg*pos.Y
+	z := pos.Z
+
+	obs := observerECEF(lat, lon, elevationM)
+	rx, ry, rz := x-obs.X, y-obs.Y, z-obs.Z
+
+	phi := timeutil.Deg2Rad(lat)
+	lam := timeutil.Deg2Rad(lon)
+	sphi, cphi := math.Sin(phi), math.Cos(phi)
+	slam, clam := math.Sin(lam), math.Cos(lam)
+
+	south := sphi*clam*rx + sphi*slam*ry - cphi*rz
+	east := -slam*rx + clam*ry
+	zenith := cphi*clam*rx + cphi*slam*ry + sphi*rz
+
+	rng := math.Sqrt(rx*rx + ry*ry + rz*rz)
+	az := timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(east, -south)))
+	elev := timeutil.Rad2Deg(math.Asin(zenith / rng))
+
+	return LookAngles{Azimuth: az, Elevation: elev, RangeKm: rng}
+}
+
+// Sunlit reports whether a satellite at TEME position pos is illuminated by
+// the Sun, whose geocentric direction is given by RA/Dec in degrees. A
+// cylindrical Earth shadow is assumed.
+func Sunlit(pos Vector, sunRA, sunDec float64) bool {
+	ra := timeutil.Deg2Rad(sunRA)
+	dec := timeutil.Deg2Rad(sunDec)
+	sx := math.Cos(dec) * math.Cos(ra)
+	sy := math.Cos(dec) * math.Sin(ra)
+	sz := math.Sin(dec)
+
+	along := pos.X*sx + pos.Y*sy + pos.Z*sz
+	if along > 0 {
+		return true
+	}
+
+	px := pos.X - along*sx
+	py := pos.Y - along*sy
+	pz := pos.Z - along*sz
+	return math.Sqrt(px*px+py*py+pz*pz) > earthRadiusKm
+}
+
+func observerECEF(lat, lon, elevationM float64) Vector {
+	phi := timeutil.Deg2Rad(lat)
+	lam := timeutil.Deg2Rad(lon)
+	h := elevationM / 1000
+
+	sphi := math.Sin(phi)
+	n := wgs84A / math.Sqrt(1-wgs84E2*sphi*sphi)
+
+	return Vector{
+		X: (n + h) * math.Cos(phi) * math.Cos(lam),
+		Y: (n + h) * math.Cos(phi) * math.Sin(lam),
+		Z: (n*(1-wgs84E2) + h) * sphi,
+	}
+}
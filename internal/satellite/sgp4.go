@@ -0,0 +1,361 @@
+// Package satellite implements the SGP4 orbit propagator for Earth
+// satellites described by NORAD two-line element sets, together with the
+// geometry needed to observe them from the ground.
+//
+// The implementation follows the near-Earth branch of Vallado et al.,
+// "Revisiting Spacetrack Report #3" (2006), using WGS-72 constants as the
+// element sets are fitted with them. Deep-space objects (orbital period of
+// 225 minutes or more, SDP4) are not supported yet.
+package satellite
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// WGS-72 constants used by SGP4.
+const (
+	earthRadiusKm = 6378.135
+	muKm3s2       = 398600.8
+	j2            = 0.001082616
+	j3            = -0.00000253881
+	j4            = -0.00000165597
+	j3oj2         = j3 / j2
+	twoPi         = 2 * math.Pi
+	minutesPerDay = 1440.0
+)
+
+// xke is sqrt(GM) in earth radii^1.5 per minute.
+var xke = 60.0 / math.Sqrt(earthRadiusKm*earthRadiusKm*earthRadiusKm/muKm3s2)
+
+var (
+	// ErrDeepSpace is returned for orbits that need the SDP4 deep-space
+	// extensions, which are not implemented.
+	ErrDeepSpace = errors.New("satellite: deep-space orbits (period >= 225 min) are not supported")
+
+	// ErrDecayed is returned when the propagated orbit is no longer physical
+	// (eccentricity out of range or the satellite is below the surface).
+	ErrDecayed = errors.New("satellite: orbit has decayed")
+)
+
+// Elements holds the mean orbital elements of a two-line element set in the
+// units SGP4 works with.
+type Elements struct {
+	Epoch        time.Time // element set epoch (UTC)
+	Inclination  float64   // radians
+	RAAN         float64   // right ascension of ascending node, radians
+	Eccentricity float64
+	ArgPerigee   float64 // radians
+	MeanAnomaly  float64 // radians
+	MeanMotion   float64 // Kozai mean motion, radians per minute
+	BStar        float64 // drag term, 1/earth radii
+}
+
+// Vector is a Cartesian vector in kilometres (or km/s for velocities).
+type Vector struct {
+	X, Y, Z float64
+}
+
+// Propagator holds the SGP4 state initialised from one element set.
+type Propagator struct {
+	el Elements
+
+	// Derived quantities computed once at initialisation.
+	noUnkozai, ao                  float64
+	isimp                          bool
+	con41, x1mth2, x7thm1          float64
+	cc1, cc4, cc5                  float64
+	d2, d3, d4                     float64
+	delmo, eta, sinmao             float64
+	argpdot, mdot, nodedot, nodecf float64
+	omgcof, xmcof, xlcof, aycof    float64
+	t2cof, t3cof, t4cof, t5cof     float64
+}
+
+// NewPropagator initialises SGP4 for the given elements.
+func NewPropagator(el Elements) (*Propagator, error) {
+	p := &Propagator{el: el}
+
+	ecco := el.Eccentricity
+	inclo := el.Inclination
+	argpo := el.ArgPerigee
+
+	// --- initl: recover the original (Brouwer) mean motion ---
+	eccsq := ecco * ecco
+	omeosq := 1 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(inclo)
+	cosio2 := cosio * cosio
+
+	ak := math.Pow(xke/el.MeanMotion, 2.0/3.0)
+	d1 := 0.75 * j2 * (3*cosio2 - 1) / (rteosq * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1 - del*del - del*(1.0/3.0+134*del*del/81))
+	del = d1 / (adel * adel)
+	p.noUnkozai = el.MeanMotion / (1 + del)
+
+	if twoPi/p.noUnkozai >= 225 {
+		return nil, ErrDeepSpace
+	}
+
+	p.ao = math.Pow(xke/p.noUnkozai, 2.0/3.0)
+	sinio := math.Sin(inclo)
+	po := p.ao * omeosq
+	con42 := 1 - 5*cosio2
+	p.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := p.ao * (1 - ecco)
+
+	// --- sgp4init ---
+	ss := 78.0/earthRadiusKm + 1
+	qzms2t := math.Pow((120.0-78.0)/earthRadiusKm, 4)
+
+	p.isimp = rp < 220.0/earthRadiusKm+1
+
+	sfour := ss
+	qzms24 := qzms2t
+	perige := (rp - 1) * earthRadiusKm
+	if perige < 156 {
+		sfour = perige - 78
+		if perige < 98 {
+			sfour = 20
+		}
+		qzms24 = math.Pow((120-sfour)/earthRadiusKm, 4)
+		sfour = sfour/earthRadiusKm + 1
+	}
+
+	pinvsq := 1 / posq
+	tsi := 1 / (p.ao - sfour)
+	p.eta = p.ao * ecco * tsi
+	etasq := p.eta * p.eta
+	eeta := ecco * p.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+
+	cc2 := coef1 * p.noUnkozai * (p.ao*(1+1.5*etasq+eeta*(4+etasq)) +
+		0.375*j2*tsi/psisq*p.con41*(8+3*etasq*(8+etasq)))
+	p.cc1 = el.BStar * cc2
+
+	cc3 := 0.0
+	if ecco > 1.0e-4 {
+		cc3 = -2 * coef * tsi * j3oj2 * p.noUnkozai * sinio / ecco
+	}
+
+	p.x1mth2 = 1 - cosio2
+	p.cc4 = 2 * p.noUnkozai * coef1 * p.ao * omeosq *
+		(p.eta*(2+0.5*etasq) + ecco*(0.5+2*etasq) -
+			j2*tsi/(p.ao*psisq)*
+				(-3*p.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+
+					0.75*p.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*argpo)))
+	p.cc5 = 2 * coef1 * p.ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * j2 * pinvsq * p.noUnkozai
+	temp2 := 0.5 * temp1 * j2 * pinvsq
+	temp3 := -0.46875 * j4 * pinvsq * pinvsq * p.noUnkozai
+
+	p.mdot = p.noUnkozai + 0.5*temp1*rteosq*p.con41 +
+		0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+	p.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) +
+		temp3*(3-36*cosio2+49*cosio4)
+	xhdot1 := -temp1 * cosio
+	p.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*cosio
+
+	p.omgcof = el.BStar * cc3 * math.Cos(argpo)
+	if ecco > 1.0e-4 {
+		p.xmcof = -2.0 / 3.0 * coef * el.BStar / eeta
+	}
+	p.nodecf = 3.5 * omeosq * xhdot1 * p.cc1
+	p.t2cof = 1.5 * p.cc1
+
+	if math.Abs(cosio+1) > 1.5e-12 {
+		p.xlcof = -0.25 * j3oj2 * sinio * (3 + 5*cosio) / (1 + cosio)
+	} else {
+		p.xlcof = -0.25 * j3oj2 * sinio * (3 + 5*cosio) / 1.5e-12
+	}
+	p.aycof = -0.5 * j3oj2 * sinio
+	p.delmo = math.Pow(1+p.eta*math.Cos(el.MeanAnomaly), 3)
+	p.sinmao = math.Sin(el.MeanAnomaly)
+	p.x7thm1 = 7*cosio2 - 1
+
+	if !p.isimp {
+		cc1sq := p.cc1 * p.cc1
+		p.d2 = 4 * p.ao * tsi * cc1sq
+		temp := p.d2 * tsi * p.cc1 / 3
+		p.d3 = (17*p.ao + sfour) * temp
+		p.d4 = 0.5 * temp * p.ao * tsi * (221*p.ao + 31*sfour) * p.cc1
+		p.t3cof = p.d2 + 2*cc1sq
+		p.t4cof = 0.25 * (3*p.d3 + p.cc1*(12*p.d2+10*cc1sq))
+		p.t5cof = 0.2 * (3*p.d4 + 12*p.cc1*p.d3 + 6*p.d2*p.d2 + 15*cc1sq*(2*p.d2+cc1sq))
+	}
+
+	return p, nil
+}
+
+// Epoch returns the epoch of the element set the propagator was built from.
+func (p *Propagator) Epoch() time.Time {
+	return p.el.Epoch
+}
+
+// Propagate returns the TEME position (km) and velocity (km/s) at time t.
+func (p *Propagator) Propagate(t time.Time) (pos, vel Vector, err error) {
+	return p.PropagateMinutes(t.Sub(p.el.Epoch).Minutes())
+}
+
+// PropagateMinutes returns the TEME position (km) and velocity (km/s)
+// tsince minutes after the element set epoch.
+func (p *Propagator) PropagateMinutes(tsince float64) (pos, vel Vector, err error) {
+	el := p.el
+
+	// Secular gravity and atmospheric drag.
+	xmdf := el.MeanAnomaly + p.mdot*tsince
+	argpdf := el.ArgPerigee + p.argpdot*tsince
+	nodedf := el.RAAN + p.nodedot*tsince
+	argpm := argpdf
+	mm := xmdf
+	t2 := tsince * tsince
+	nodem := nodedf + p.nodecf*t2
+	tempa := 1 - p.cc1*tsince
+	tempe := el.BStar * p.cc4 * tsince
+	templ := p.t2cof * t2
+
+	if !p.isimp {
+		delomg := p.omgcof * tsince
+		delmtemp := 1 + p.eta*math.Cos(xmdf)
+		delm := p.xmcof * (delmtemp*delmtemp*delmtemp - p.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * tsince
+		t4 := t3 * tsince
+		tempa = tempa - p.d2*t2 - p.d3*t3 - p.d4*t4
+		tempe = tempe + el.BStar*p.cc5*(math.Sin(mm)-p.sinmao)
+		templ = templ + p.t3cof*t3 + t4*(p.t4cof+tsince*p.t5cof)
+	}
+
+	nm := p.noUnkozai
+	em := el.Eccentricity
+	inclm := el.Inclination
+
+	am := math.Pow(xke/nm, 2.0/3.0) * tempa * tempa
+	nm = xke / math.Pow(am, 1.5)
+	em -= tempe
+
+	if em >= 1 || em < -0.001 {
+		return Vector{}, Vector{}, ErrDecayed
+	}
+	if em < 1.0e-6 {
+		em = 1.0e-6
+	}
+
+	mm += p.noUnkozai * templ
+	xlm := mm + argpm + nodem
+
+	nodem = math.Mod(nodem, twoPi)
+	argpm = math.Mod(argpm, twoPi)
+	xlm = math.Mod(xlm, twoPi)
+	mm = math.Mod(xlm-argpm-nodem, twoPi)
+
+	sinip := math.Sin(inclm)
+	cosip := math.Cos(inclm)
+
+	// Long-period periodics.
+	axnl := em * math.Cos(argpm)
+	temp := 1 / (am * (1 - em*em))
+	aynl := em*math.Sin(argpm) + temp*p.aycof
+	xl := mm + argpm + nodem + temp*p.xlcof*axnl
+
+	// Solve Kepler's equation.
+	u := math.Mod(xl-nodem, twoPi)
+	eo1 := u
+	tem5 := 9999.9
+	var sineo1, coseo1 float64
+	for ktr := 1; math.Abs(tem5) >= 1.0e-12 && ktr <= 10; ktr++ {
+		sineo1 = math.Sin(eo1)
+		coseo1 = math.Cos(eo1)
+		tem5 = 1 - coseo1*axnl - sineo1*aynl
+		tem5 = (u - aynl*coseo1 + axnl*sineo1 - eo1) / tem5
+		if math.Abs(tem5) >= 0.95 {
+			tem5 = math.Copysign(0.95, tem5)
+		}
+		eo1 += tem5
+	}
+
+	// Short-period periodics.
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+	if pl < 0 {
+		return Vector{}, Vector{}, ErrDecayed
+	}
+
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * j2 * temp
+	temp2 := temp1 * temp
+
+	mrt := rl*(1-1.5*temp2*betal*p.con41) + 0.5*temp1*p.x1mth2*cos2u
+	su -= 0.25 * temp2 * p.x7thm1 * sin2u
+	xnode := nodem + 1.5*temp2*cosip*sin2u
+	xinc := inclm + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*p.x1mth2*sin2u/xke
+	rvdot := rvdotl + nm*temp1*(p.x1mth2*cos2u+1.5*p.con41)/xke
+
+	// Orientation vectors.
+	sinsu, cossu := math.Sin(su), math.Cos(su)
+	snod, cnod := math.Sin(xnode), math.Cos(xnode)
+	sini, cosi := math.Sin(xinc), math.Cos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+	ux := xmx*sinsu + cnod*cossu
+	uy := xmy*sinsu + snod*cossu
+	uz := sini * sinsu
+	vx := xmx*cossu - cnod*sinsu
+	vy := xmy*cossu - snod*sinsu
+	vz := sini * cossu
+
+	if mrt < 1 {
+		return Vector{}, Vector{}, ErrDecayed
+	}
+
+	vkmpersec := earthRadiusKm * xke / 60
+	pos = Vector{
+		X: mrt * ux * earthRadiusKm,
+		Y: mrt * uy * earthRadiusKm,
+		Z: mrt * uz * earthRadiusKm,
+	}
+	vel = Vector{
+		X: (mvt*ux + rvdot*vx) * vkmpersec,
+		Y: (mvt*uy + rvdot*vy) * vkmpersec,
+		Z: (mvt*uz + rvdot*vz) * vkmpersec,
+	}
+
+	return pos, vel, nil
+}
+
+// gmst returns the Greenwich mean sidereal time (radians) at t using the
+// IAU-82 expression SGP4's TEME frame is defined against.
+func gmst(t time.Time) float64 {
+	tut1 := (timeutil.JulianDay(t) - 2451545.0) / 36525.0
+	sec := -6.2e-6*tut1*tut1*tut1 + 0.093104*tut1*tut1 +
+		(876600.0*3600+8640184.812866)*tut1 + 67310.54841
+	g := math.Mod(sec*math.Pi/180/240, twoPi)
+	if g < 0 {
+		g += twoPi
+	}
+	return g
+}
@@ -0,0 +1,161 @@
+package satellite
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// ParseElements extracts the SGP4 mean elements from the two data lines of
+// a NORAD two-line element set. Only the fixed-column fields SGP4 needs are
+// read; checksums are not verified here (see VerifyChecksum).
+func ParseElements(line1, line2 string) (Elements, error) {
+	line1 = strings.TrimRight(line1, " \r\n")
+	line2 = strings.TrimRight(line2, " \r\n")
+
+	if len(line1) < 61 || line1[0] != '1' {
+		return Elements{}, fmt.Errorf("satellite: malformed TLE line 1 %q", line1)
+	}
+	if len(line2) < 63 || line2[0] != '2' {
+		return Elements{}, fmt.Errorf("satellite: malformed TLE line 2 %q", line2)
+	}
+
+	var (
+		el  Elements
+		err error
+	)
+
+	field := func(line string, from, to int) string {
+		// Columns are 1-based and inclusive, as in the TLE specification.
+		if to > len(line) {
+			to = len(line)
+		}
+		return strings.TrimSpace(line[from-1 : to])
+	}
+	num := func(name, s string) float64 {
+		if err != nil {
+			return 0
+		}
+		var v float64
+		v, err = strconv.ParseFloat(s, 64)
+		if err != nil {
+			err = fmt.Errorf("satellite: invalid %s %q", name, s)
+		}
+		return v
+	}
+
+	yy := num("epoch year", field(line1, 19, 20))
+	doy := num("epoch day", field(line1, 21, 32))
+	bstar := field(line1, 54, 61)
+	incl := num("inclination", field(line2, 9, 16))
+	raan := num("RAAN", field(line2, 18, 25))
+	ecc := num("eccentricity", "0."+field(line2, 27, 33))
+	argp := num("argument of perigee", field(line2, 35, 42))
+	ma := num("mean anomaly", field(line2, 44, 51))
+	mm := num("mean motion", field(line2, 53, 63))
+	if err != nil {
+		return Elements{}, err
+	}
+
+	el.BStar, err = ParseImpliedDecimal(bstar)
+	if err != nil {
+		return Elements{}, fmt.Errorf("satellite: invalid BSTAR %q", bstar)
+	}
+
+	// Two-digit years: 57-99 are 1957-1999, 00-56 are 2000-2056.
+	year := int(yy) + 2000
+	if yy >= 57 {
+		year = int(yy) + 1900
+	}
+	el.Epoch = EpochTime(year, doy)
+
+	el.Inclination = timeutil.Deg2Rad(incl)
+	el.RAAN = timeutil.Deg2Rad(raan)
+	el.Eccentricity = ecc
+	el.ArgPerigee = timeutil.Deg2Rad(argp)
+	el.MeanAnomaly = timeutil.Deg2Rad(ma)
+	el.MeanMotion = mm * twoPi / minutesPerDay
+
+	return el, nil
+}
+
+// ParseImpliedDecimal parses TLE fields such as " 28098-4" or "-11606-4",
+// which mean ±0.28098e-4 with an implied leading decimal point.
+func ParseImpliedDecimal(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	sign := 1.0
+	switch s[0] {
+	case '-':
+		sign = -1
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	mantissa, exponent := s, "0"
+	if i := strings.LastIndexAny(s, "+-"); i > 0 {
+		mantissa, exponent = s[:i], s[i:]
+	}
+
+	m, err := strconv.ParseFloat("0."+strings.TrimSpace(mantissa), 64)
+	if err != nil {
+		return 0, err
+	}
+	e, err := strconv.Atoi(exponent)
+	if err != nil {
+		return 0, err
+	}
+
+	return sign * m * math.Pow(10, float64(e)), nil
+}
+
+// EpochTime converts a TLE epoch (year and fractional 1-based day of year)
+// to a UTC time.
+func EpochTime(year int, dayOfYear float64) time.Time {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration((dayOfYear - 1) * 24 * float64(time.Hour)))
+}
+
+// ErrChecksum is returned by VerifyChecksum for a line whose checksum
+// does not match its content.
+var ErrChecksum = errors.New("satellite: TLE checksum mismatch")
+
+// Checksum returns the modulo-10 checksum of a TLE data line: the sum of
+// its digits, counting each minus sign as 1, over the first 68 columns.
+func Checksum(line string) int {
+	if len(line) > 68 {
+		line = line[:68]
+	}
+	sum := 0
+	for _, c := range line {
+		switch {
+		case c >= '0' && c <= '9':
+			sum += int(c - '0')
+		case c == '-':
+			sum++
+		}
+	}
+	return sum % 10
+}
+
+// VerifyChecksum reports whether column 69 of a TLE data line holds its
+// checksum.
+func VerifyChecksum(line string) error {
+	line = strings.TrimRight(line, " \r\n")
+	if len(line) < 69 || line[68] < '0' || line[68] > '9' {
+		return fmt.Errorf("satellite: TLE line %q has no checksum", line)
+	}
+	if want, got := Checksum(line), int(line[68]-'0'); got != want {
+		return fmt.Errorf("%w: line %q has %d, want %d", ErrChecksum, line, got, want)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package solver
 
 import (
+	"math"
+	"sort"
 	"time"
 )
 
@@ -25,10 +27,19 @@ type Result struct {
 
 // FindAltitudeEvent searches for a time in [start, end] where the altitude function
 // crosses targetDeg in the direction specified by eventType.
-// It uses a simple bracket-then-bisect strategy.
+//
+// It samples the function at `steps` evenly spaced times. A sign change
+// between two samples brackets a crossing; where three samples stay on one
+// side of the target but bend towards it, a parabola through them locates
+// the turning point and one extra evaluation there tells whether the body
+// crossed and came back between samples (a rise and set inside one sample
+// window, common at high latitudes). Each bracket is then refined with
+// Brent's method down to tol.
+//
+// Only the first crossing in the requested direction is returned; use
+// FindAllAltitudeEvents for every crossing.
 //
 // This is generic and can be used for Sun, Moon, twilight, etc.
-// For Level 1 we don't use it yet; it's here as a building block for Level 2/3.
 func FindAltitudeEvent(f AltitudeFunc, start, end time.Time, targetDeg float64, eventType EventType, steps int, tol time.Duration) Result {
 	if !start.Before(end) {
 		return Result{OK: false}
@@ -37,24 +48,42 @@ func FindAltitudeEvent(f AltitudeFunc, start, end time.Time, targetDeg float64,
 		steps = 2
 	}
 
+	g := func(t time.Time) float64 { return f(t) - targetDeg }
+
 	// Step 1: sample across [start, end] to find a sign change
 	// in (altitude - target)
 	interval := end.Sub(start) / time.Duration(steps-1)
 
 	var (
-		prevT   = start
-		prevAlt = f(prevT) - targetDeg
+		prevT    = start
+		prevAlt  = g(prevT)
+		prev2T   time.Time
+		prev2Alt float64
 	)
 
 	for i := 1; i < steps; i++ {
 		t := start.Add(time.Duration(i) * interval)
-		alt := f(t) - targetDeg
+		alt := g(t)
+
+		// A crossing pair hidden between samples comes before any crossing
+		// further on, so look for one first.
+		if i >= 2 {
+			if h, ok := hiddenTurn(g, prev2T, prev2Alt, prevT, prevAlt, t, alt); ok {
+				switch {
+				case hasCrossing(h.gLo, h.gV, eventType):
+					return brent(g, h.lo, h.v, h.gLo, h.gV, tol)
+				case hasCrossing(h.gV, h.gHi, eventType):
+					return brent(g, h.v, h.hi, h.gV, h.gHi, tol)
+				}
+			}
+		}
 
 		if hasCrossing(prevAlt, alt, eventType) {
 			// We have a bracket [prevT, t]
-			return bisect(f, prevT, t, targetDeg, eventType, tol)
+			return brent(g, prevT, t, prevAlt, alt, tol)
 		}
 
+		prev2T, prev2Alt = prevT, prevAlt
 		prevT, prevAlt = t, alt
 	}
 
@@ -76,32 +105,269 @@ func hasCrossing(a1, a2 float64, eventType EventType) bool {
 	}
 }
 
-func bisect(f AltitudeFunc, a, b time.Time, targetDeg float64, eventType EventType, tol time.Duration) Result {
+// turn is a turning point at v inside the sample interval [lo, hi] that
+// takes g to the other side of zero, so g crosses zero once in [lo, v] and
+// once in [v, hi].
+type turn struct {
+	lo, v, hi    time.Time
+	gLo, gV, gHi float64
+}
+
+// hiddenTurn checks three consecutive samples that all lie on the same side
+// of zero for a turning point between them that reaches the other side. It
+// fits a parabola through the samples and, if its vertex lies inside and
+// beyond zero, evaluates g there to confirm.
+func hiddenTurn(g func(time.Time) float64, t0 time.Time, g0 float64, t1 time.Time, g1 float64, t2 time.Time, g2 float64) (turn, bool) {
+	if (g0 > 0) != (g1 > 0) || (g1 > 0) != (g2 > 0) {
+		return turn{}, false
+	}
+
+	// Parabola through (-h, g0), (0, g1), (h, g2) in units of h.
+	curv := g0 - 2*g1 + g2
+	if curv == 0 {
+		return turn{}, false
+	}
+	x := (g0 - g2) / (2 * curv) // vertex offset from t1, in steps
+	if x <= -1 || x >= 1 {
+		return turn{}, false
+	}
+	peak := g1 - (g0-g2)*x/4
+	if (peak > 0) == (g1 > 0) {
+		return turn{}, false
+	}
+
+	tv := t1.Add(time.Duration(x * float64(t2.Sub(t1))))
+	gv := g(tv)
+	if (gv > 0) == (g1 > 0) {
+		return turn{}, false
+	}
+
+	// The vertex splits its sample interval into two brackets.
+	if x > 0 {
+		return turn{lo: t1, v: tv, hi: t2, gLo: g1, gV: gv, gHi: g2}, true
+	}
+	return turn{lo: t0, v: tv, hi: t1, gLo: g0, gV: gv, gHi: g1}, true
+}
+
+// Crossing is one crossing of the target altitude.
+type Crossing struct {
+	Time time.Time
+	Type EventType // CrossingUp or CrossingDown
+}
+
+// FindAllAltitudeEvents returns every crossing of targetDeg by f in
+// [start, end], in time order. It samples and refines exactly like
+// FindAltitudeEvent but keeps going after the first crossing, so it also
+// reports days on which a body near the pole rises and sets several times.
+func FindAllAltitudeEvents(f AltitudeFunc, start, end time.Time, targetDeg float64, steps int, tol time.Duration) []Crossing {
+	if !start.Before(end) {
+		return nil
+	}
+	if steps < 2 {
+		steps = 2
+	}
+
+	g := func(t time.Time) float64 { return f(t) - targetDeg }
+	interval := end.Sub(start) / time.Duration(steps-1)
+
 	var (
-		altA = f(a) - targetDeg
-		altB = f(b) - targetDeg
+		out      []Crossing
+		prevT    = start
+		prevAlt  = g(prevT)
+		prev2T   time.Time
+		prev2Alt float64
+		covered  time.Time // end of the last interval searched for a turn
 	)
 
-	// Simple safety check
-	if !hasCrossing(altA, altB, eventType) {
+	add := func(a, b time.Time, ga, gb float64) {
+		typ := CrossingDown
+		if gb > ga {
+			typ = CrossingUp
+		}
+		if res := brent(g, a, b, ga, gb, tol); res.OK {
+			out = append(out, Crossing{Time: res.Time, Type: typ})
+		}
+	}
+
+	for i := 1; i < steps; i++ {
+		t := start.Add(time.Duration(i) * interval)
+		alt := g(t)
+
+		if i >= 2 {
+			// Consecutive triples share an interval; skip a turn that was
+			// already found there.
+			if h, ok := hiddenTurn(g, prev2T, prev2Alt, prevT, prevAlt, t, alt); ok && !h.lo.Before(covered) {
+				add(h.lo, h.v, h.gLo, h.gV)
+				add(h.v, h.hi, h.gV, h.gHi)
+				covered = h.hi
+			}
+		}
+
+		if hasCrossing(prevAlt, alt, CrossingUp) || hasCrossing(prevAlt, alt, CrossingDown) {
+			add(prevT, t, prevAlt, alt)
+		}
+
+		prev2T, prev2Alt = prevT, prevAlt
+		prevT, prevAlt = t, alt
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out
+}
+
+// brent refines a bracketed root of g in [a, b] (ga, gb of opposite sign,
+// or gb zero) with Brent's method until the bracket is narrower than tol.
+func brent(g func(time.Time) float64, a, b time.Time, ga, gb float64, tol time.Duration) Result {
+	if ga*gb > 0 {
 		return Result{OK: false}
 	}
 
-	for b.Sub(a) > tol {
-		mid := a.Add(b.Sub(a) / 2)
-		altM := f(mid) - targetDeg
+	// Work in seconds from a.
+	origin := a
+	at := func(x float64) time.Time {
+		return origin.Add(time.Duration(x * float64(time.Second)))
+	}
 
-		if hasCrossing(altA, altM, eventType) {
-			b = mid
-			altB = altM
+	const maxIter = 100
+	xtol := tol.Seconds() / 2
+
+	xa, xb := 0.0, b.Sub(a).Seconds()
+	fa, fb := ga, gb
+	xc, fc := xa, fa
+	d := xb - xa
+	e := d
+
+	for i := 0; i < maxIter; i++ {
+		if (fb > 0 && fc > 0) || (fb < 0 && fc < 0) {
+			xc, fc = xa, fa
+			d = xb - xa
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			xa, xb, xc = xb, xc, xb
+			fa, fb, fc = fb, fc, fb
+		}
+
+		m := (xc - xb) / 2
+		if math.Abs(m) <= xtol || fb == 0 {
+			break
+		}
+
+		if math.Abs(e) >= xtol && math.Abs(fa) > math.Abs(fb) {
+			// Try inverse quadratic interpolation (secant if only two
+			// distinct points).
+			var p, q float64
+			s := fb / fa
+			if xa == xc {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q0 := fa / fc
+				r := fb / fc
+				p = s * (2*m*q0*(q0-r) - (xb-xa)*(r-1))
+				q = (q0 - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(xtol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
 		} else {
-			a = mid
-			altA = altM
+			d = m
+			e = d
+		}
+
+		xa, fa = xb, fb
+		if math.Abs(d) > xtol {
+			xb += d
+		} else {
+			xb += math.Copysign(xtol, m)
 		}
+		fb = g(at(xb))
 	}
 
 	return Result{
-		Time: a.Add(b.Sub(a) / 2),
+		Time: at(xb),
 		OK:   true,
 	}
 }
+
+// Predicate reports whether some condition holds at time t.
+type Predicate func(t time.Time) bool
+
+// Interval is a continuous time span [Start, End].
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// FindIntervals samples pred across [start, end] and returns the maximal
+// intervals where it holds. Each transition between samples is refined by
+// bisection down to tol. Intervals touching start or end are clipped there.
+//
+// Unlike an altitude, a predicate gives no hint of a turning point, so
+// conditions that flip on and off between two samples can be missed and
+// steps should be chosen with that in mind.
+func FindIntervals(pred Predicate, start, end time.Time, steps int, tol time.Duration) []Interval {
+	if !start.Before(end) {
+		return nil
+	}
+	if steps < 2 {
+		steps = 2
+	}
+
+	interval := end.Sub(start) / time.Duration(steps-1)
+
+	var (
+		out     []Interval
+		inside  = pred(start)
+		curFrom = start
+		prevT   = start
+	)
+
+	for i := 1; i < steps; i++ {
+		t := start.Add(time.Duration(i) * interval)
+		if i == steps-1 {
+			t = end
+		}
+		now := pred(t)
+
+		if now != inside {
+			edge := refineEdge(pred, prevT, t, inside, tol)
+			if inside {
+				out = append(out, Interval{Start: curFrom, End: edge})
+			} else {
+				curFrom = edge
+			}
+			inside = now
+		}
+
+		prevT = t
+	}
+
+	if inside {
+		out = append(out, Interval{Start: curFrom, End: end})
+	}
+
+	return out
+}
+
+// refineEdge bisects [a, b] for the point where pred changes from stateA.
+func refineEdge(pred Predicate, a, b time.Time, stateA bool, tol time.Duration) time.Time {
+	for b.Sub(a) > tol {
+		mid := a.Add(b.Sub(a) / 2)
+		if pred(mid) == stateA {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+	return a.Add(b.Sub(a) / 2)
+}
@@ -0,0 +1,68 @@
+package sun
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCacheEntries bounds a Cache. The solvers sample the same instants
+// (every 30 minutes from local midnight) for every target altitude, so a
+// few days' worth of samples and refinement points is plenty.
+const maxCacheEntries = 4096
+
+// Cache memoizes the solar position by instant, so that several searches
+// over the same day (rise/set, twilight, golden and blue hour) share their
+// ephemeris evaluations. Positions are geocentric, so one Cache serves any
+// observer. It is safe for concurrent use.
+//
+// A nil *Cache is valid and computes every position directly.
+type Cache struct {
+	mu sync.Mutex
+	m  map[int64]Equatorial
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{m: make(map[int64]Equatorial)}
+}
+
+// direct is the nil Cache used by the package-level functions.
+var direct *Cache
+
+// Equatorial returns GeocentricEquatorialApprox(t), from the cache when
+// possible.
+func (c *Cache) Equatorial(t time.Time) Equatorial {
+	if c == nil {
+		return GeocentricEquatorialApprox(t)
+	}
+
+	key := t.UnixNano()
+
+	c.mu.Lock()
+	eq, ok := c.m[key]
+	c.mu.Unlock()
+	if ok {
+		return eq
+	}
+
+	eq = GeocentricEquatorialApprox(t)
+
+	c.mu.Lock()
+	if len(c.m) >= maxCacheEntries {
+		c.m = make(map[int64]Equatorial)
+	}
+	c.m[key] = eq
+	c.mu.Unlock()
+
+	return eq
+}
+
+// Len returns the number of cached positions.
+func (c *Cache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.m)
+}
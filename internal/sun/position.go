@@ -27,21 +27,10 @@ type Equatorial struct {
 //	L  = ecliptic longitude of the Sun
 //	eps = obliquity of the ecliptic
 func GeocentricEquatorialApprox(t time.Time) Equatorial {
-	d := timeutil.DaysSinceJ2000(t)
-
-	// Mean anomaly of the Sun (deg)
-	g := timeutil.Deg2Rad(357.529 + 0.98560028*d)
-
-	// Mean longitude of the Sun (deg)
-	q := timeutil.Deg2Rad(280.459 + 0.98564736*d)
-
-	// Ecliptic longitude with equation of center
-	L := q +
-		timeutil.Deg2Rad(1.915)*math.Sin(g) +
-		timeutil.Deg2Rad(0.020)*math.Sin(2*g)
+	L := timeutil.Deg2Rad(EclipticLongitude(t))
 
 	// Obliquity of the ecliptic (deg)
-	eps := timeutil.Deg2Rad(23.439 - 0.00000036*d)
+	eps := timeutil.Deg2Rad(timeutil.MeanObliquity(t))
 
 	// Convert to equatorial
 	x := math.Cos(L)
@@ -59,3 +48,29 @@ func GeocentricEquatorialApprox(t time.Time) Equatorial {
 		Dec: timeutil.Rad2Deg(dec),
 	}
 }
+
+// EclipticLongitude returns the Sun's approximate apparent geocentric
+// ecliptic longitude (degrees, [0, 360)) at time t, using the same model
+// as GeocentricEquatorialApprox.
+func EclipticLongitude(t time.Time) float64 {
+	d := timeutil.DaysSinceJ2000(t)
+
+	// Mean anomaly of the Sun (deg)
+	g := timeutil.Deg2Rad(357.529 + 0.98560028*d)
+
+	// Mean longitude of the Sun (deg)
+	q := 280.459 + 0.98564736*d
+
+	// Ecliptic longitude with equation of center
+	L := q + 1.915*math.Sin(g) + 0.020*math.Sin(2*g)
+
+	return timeutil.Normalize360(L)
+}
+
+// DistanceAU returns the approximate Earth–Sun distance in astronomical
+// units at time t.
+func DistanceAU(t time.Time) float64 {
+	d := timeutil.DaysSinceJ2000(t)
+	g := timeutil.Deg2Rad(357.529 + 0.98560028*d)
+	return 1.00014 - 0.01671*math.Cos(g) - 0.00014*math.Cos(2*g)
+}
@@ -23,34 +23,37 @@ const ApparentHorizonAltitudeSun = -0.833
 func RiseSetForDate(lat, lon float64, date time.Time, zenith float64) (sunriseUTC, sunsetUTC time.Time, okRise, okSet bool) {
 	// Target altitude: h = 90° - Z.
 	targetAlt := 90.0 - zenith
-	return eventsForDateAtAltitude(lat, lon, date, targetAlt)
+	return direct.TwilightForDate(lat, lon, date, targetAlt)
 }
 
 // TwilightForDate computes the times when the Sun crosses a given altitude
 // (in degrees) during the local calendar day: "dawn" as the upward crossing,
 // "dusk" as the downward crossing. Returned times are in UTC.
 func TwilightForDate(lat, lon float64, date time.Time, targetAlt float64) (dawnUTC, duskUTC time.Time, okDawn, okDusk bool) {
-	return eventsForDateAtAltitude(lat, lon, date, targetAlt)
+	return direct.TwilightForDate(lat, lon, date, targetAlt)
+}
+
+// TwilightForDate is the package-level TwilightForDate using positions from c.
+func (c *Cache) TwilightForDate(lat, lon float64, date time.Time, targetAlt float64) (dawnUTC, duskUTC time.Time, okDawn, okDusk bool) {
+	return c.eventsForDateAtAltitude(lat, lon, date, targetAlt)
 }
 
 // eventsForDateAtAltitude finds the times when the Sun's apparent altitude crosses
 // targetAlt (degrees) during the local calendar day of `date` at (lat, lon).
 // It returns the upward crossing (rise-like) and downward crossing (set-like)
 // in UTC, along with booleans indicating if each event was found.
-func eventsForDateAtAltitude(lat, lon float64, date time.Time, targetAlt float64) (riseUTC, setUTC time.Time, okRise, okSet bool) {
-	loc := date.Location()
-	year, month, day := date.Date()
-
-	startLocal := time.Date(year, month, day, 0, 0, 0, 0, loc)
-	endLocal := startLocal.Add(24 * time.Hour)
+func (c *Cache) eventsForDateAtAltitude(lat, lon float64, date time.Time, targetAlt float64) (riseUTC, setUTC time.Time, okRise, okSet bool) {
+	startLocal, endLocal := timeutil.LocalDay(date)
 
 	altFunc := func(t time.Time) float64 {
-		return apparentAltitude(lat, lon, t)
+		return c.apparentAltitude(lat, lon, t)
 	}
 
 	const (
 		steps = 48 // samples across the day (every 30 minutes)
-		tol   = 30 * time.Second
+		// Day lengths change by seconds a day near the solstices, so a
+		// coarser tolerance makes them jitter from one day to the next.
+		tol = time.Second
 	)
 
 	// Upward crossing (dawn/sunrise-type event)
@@ -70,12 +73,43 @@ func eventsForDateAtAltitude(lat, lon float64, date time.Time, targetAlt float64
 	return riseUTC, setUTC, okRise, okSet
 }
 
+// AllCrossingsForDate returns every upward and downward crossing (UTC) of
+// targetAlt by the Sun during the local calendar day of `date`, in time
+// order.
+func AllCrossingsForDate(lat, lon float64, date time.Time, targetAlt float64) (upUTC, downUTC []time.Time) {
+	return direct.AllCrossingsForDate(lat, lon, date, targetAlt)
+}
+
+// AllCrossingsForDate is the package-level AllCrossingsForDate using
+// positions from c.
+func (c *Cache) AllCrossingsForDate(lat, lon float64, date time.Time, targetAlt float64) (upUTC, downUTC []time.Time) {
+	startLocal, endLocal := timeutil.LocalDay(date)
+
+	altFunc := func(t time.Time) float64 {
+		return c.apparentAltitude(lat, lon, t)
+	}
+
+	const (
+		steps = 48 // samples across the day (every 30 minutes)
+		tol   = time.Second
+	)
+
+	for _, c := range solver.FindAllAltitudeEvents(altFunc, startLocal, endLocal, targetAlt, steps, tol) {
+		if c.Type == solver.CrossingUp {
+			upUTC = append(upUTC, c.Time.UTC())
+		} else {
+			downUTC = append(downUTC, c.Time.UTC())
+		}
+	}
+	return upUTC, downUTC
+}
+
 // apparentAltitude computes the Sun's approximate geometric altitude (in degrees)
 // at geographic location (lat, lon) at time t, using the solar RA/Dec model and
 // a simple sidereal time approximation.
-func apparentAltitude(lat, lon float64, t time.Time) float64 {
+func (c *Cache) apparentAltitude(lat, lon float64, t time.Time) float64 {
 	// Geocentric equatorial coordinates of the Sun
-	eq := GeocentricEquatorialApprox(t)
+	eq := c.Equatorial(t)
 
 	raRad := timeutil.Deg2Rad(eq.RA)
 	decRad := timeutil.Deg2Rad(eq.Dec)
@@ -111,3 +145,108 @@ func apparentAltitude(lat, lon float64, t time.Time) float64 {
 
 	return geomAlt
 }
+
+// AltitudeAt returns the Sun's altitude (in degrees) as seen from (lat, lon)
+// at time t, using the same model as the rise/set and twilight solvers.
+func AltitudeAt(lat, lon float64, t time.Time) float64 {
+	return direct.apparentAltitude(lat, lon, t)
+}
+
+// TransitForDate returns the time (UTC) of the Sun's upper transit across
+// the meridian (local apparent noon) at longitude lon on the local calendar
+// date of `date`.
+func TransitForDate(lon float64, date time.Time) time.Time {
+	year, month, day := date.Date()
+
+	// Start from local clock noon and walk the hour angle to zero. The Sun's
+	// hour angle advances ~360° per day, so a few Newton steps converge to
+	// well under a second.
+	t := time.Date(year, month, day, 12, 0, 0, 0, date.Location())
+	for i := 0; i < 4; i++ {
+		h := hourAngle(lon, t)
+		t = t.Add(-time.Duration(h / 360.0 * 24 * float64(time.Hour)))
+	}
+
+	return t.UTC()
+}
+
+// hourAngle returns the Sun's local hour angle (degrees, in (-180, 180]) at
+// longitude lon and time t. Negative values are east of the meridian.
+func hourAngle(lon float64, t time.Time) float64 {
+	eq := GeocentricEquatorialApprox(t)
+
+	d := timeutil.DaysSinceJ2000(t)
+	gmst := 280.46061837 + 360.98564736629*d
+
+	h := timeutil.Normalize360(gmst + lon - eq.RA)
+	if h > 180 {
+		h -= 360
+	}
+	return h
+}
+
+// HourAngleEventsForDate is a fast analytic alternative to TwilightForDate.
+// Starting from local clock noon it solves the hour-angle equation
+//
+//	cos H0 = (sin h - sin φ sin δ) / (cos φ cos δ)
+//
+// for the upward and downward crossings of targetAlt nearest that noon,
+// refining δ at the event time twice (the NOAA approach). It needs about a
+// tenth of the ephemeris evaluations of the sampled solver and agrees with
+// it to well under a minute away from the polar circles. Crossings outside
+// the local calendar day of `date` are reported as not found.
+func HourAngleEventsForDate(lat, lon float64, date time.Time, targetAlt float64) (riseUTC, setUTC time.Time, okRise, okSet bool) {
+	return direct.HourAngleEventsForDate(lat, lon, date, targetAlt)
+}
+
+// HourAngleEventsForDate is the package-level HourAngleEventsForDate using
+// positions from c.
+func (c *Cache) HourAngleEventsForDate(lat, lon float64, date time.Time, targetAlt float64) (riseUTC, setUTC time.Time, okRise, okSet bool) {
+	startLocal, endLocal := timeutil.LocalDay(date)
+
+	year, month, day := date.Date()
+	noon := time.Date(year, month, day, 12, 0, 0, 0, date.Location())
+	inDay := func(t time.Time) bool {
+		return !t.Before(startLocal) && t.Before(endLocal)
+	}
+
+	riseUTC, okRise = c.hourAngleEvent(lat, lon, noon, targetAlt, -1)
+	okRise = okRise && inDay(riseUTC)
+
+	setUTC, okSet = c.hourAngleEvent(lat, lon, noon, targetAlt, +1)
+	okSet = okSet && inDay(setUTC)
+
+	return riseUTC, setUTC, okRise, okSet
+}
+
+// hourAngleEvent iterates from start to the time when the Sun's hour angle
+// equals sign·H0 for targetAlt (sign -1 for the rising, +1 for the setting
+// crossing). It returns false when the Sun does not reach targetAlt.
+func (c *Cache) hourAngleEvent(lat, lon float64, start time.Time, targetAlt, sign float64) (time.Time, bool) {
+	const iterations = 2
+
+	t := start
+	for i := 0; i < iterations; i++ {
+		eq := c.Equatorial(t)
+
+		cosH0 := (timeutil.SinD(targetAlt) - timeutil.SinD(lat)*timeutil.SinD(eq.Dec)) /
+			(timeutil.CosD(lat) * timeutil.CosD(eq.Dec))
+		if cosH0 < -1 || cosH0 > 1 {
+			return time.Time{}, false
+		}
+		h0 := timeutil.Rad2Deg(math.Acos(cosH0))
+
+		d := timeutil.DaysSinceJ2000(t)
+		gmst := 280.46061837 + 360.98564736629*d
+		h := timeutil.Normalize360(gmst + lon - eq.RA)
+
+		// The Sun's hour angle advances ~360° per day.
+		diff := timeutil.Normalize360(sign*h0 - h)
+		if diff > 180 {
+			diff -= 360
+		}
+		t = t.Add(time.Duration(diff / 360.0 * 24 * float64(time.Hour)))
+	}
+
+	return t.UTC(), true
+}
@@ -0,0 +1,76 @@
+package timeutil
+
+import "time"
+
+// LocalDay returns the local calendar day of date in date's time zone as
+// the half-open interval [start, end): from its first instant to the first
+// instant of the next day. The day is 24 hours long except across daylight
+// saving transitions, when it is 23, 25 or (Lord Howe Island) 23.5 or 24.5
+// hours, so end must not be computed as start plus 24 hours.
+func LocalDay(date time.Time) (start, end time.Time) {
+	y, m, d := date.Date()
+	tz := date.Location()
+	return WallClock(y, m, d, 0, tz), WallClock(y, m, d+1, 0, tz)
+}
+
+// WallClock returns the instant the clocks in tz read clock after midnight
+// on the given date. A time skipped by a daylight saving transition is
+// moved forward by the length of the gap, so 02:30 on a spring-forward
+// night is 03:30, and midnight in zones that change at midnight (Chile,
+// Paraguay, Cuba) is 01:00; time.Date alone would move it back by the gap,
+// into the day before. A time that occurs twice is the first occurrence.
+func WallClock(year int, month time.Month, day int, clock time.Duration, tz *time.Location) time.Time {
+	h, minute := int(clock/time.Hour), int(clock%time.Hour/time.Minute)
+	t := time.Date(year, month, day, h, minute, 0, 0, tz)
+
+	want := time.Date(year, month, day, h, minute, 0, 0, time.UTC)
+	wall := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	}
+	if gap := want.Sub(wall(t)); gap > 0 {
+		return t.Add(gap)
+	}
+	// Go does not promise which of two occurrences time.Date picks.
+	for _, back := range []time.Duration{30 * time.Minute, time.Hour, 2 * time.Hour} {
+		if earlier := t.Add(-back); wall(earlier).Equal(want) {
+			return earlier
+		}
+	}
+	return t
+}
+
+// StartOfDay returns the first instant of the local calendar day of date,
+// normally midnight.
+func StartOfDay(date time.Time) time.Time {
+	start, _ := LocalDay(date)
+	return start
+}
+
+// RoundWallClock rounds t to the nearest multiple of d on the local clock,
+// counted from midnight, with halfway values rounding up: 05:08:34 in
+// Kathmandu (+05:45) rounds to 05:00 by the half hour, where t.Round, which
+// counts from Go's zero time in UTC, would give 05:15. A result in a
+// repeated hour stays in the occurrence of t; one in a daylight saving gap
+// is moved forward as by WallClock. A d of zero or less returns t
+// unchanged.
+func RoundWallClock(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+	y, m, day := t.Date()
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	rounded := (clock + d/2) / d * d
+
+	want := time.Date(y, m, day, 0, 0, 0, 0, time.UTC).Add(rounded)
+	if r := t.Add(rounded - clock); wallTime(r).Equal(want) {
+		return r
+	}
+	sub := rounded % time.Minute
+	return WallClock(y, m, day, rounded-sub, t.Location()).Add(sub)
+}
+
+// wallTime returns the local clock reading of t as a UTC time.
+func wallTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
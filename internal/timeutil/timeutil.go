@@ -36,36 +36,94 @@ var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
 //
 // This is an approximation suitable for low/medium-precision astronomy.
 // For high-precision work you might want a true TT-based Julian day, but
-// this is fine for our current purposes.
+// this is fine for our current purposes. It counts whole seconds apart
+// from the fraction, since a time.Duration (and so t.Sub) saturates at
+// about 292 years.
 func DaysSinceJ2000(t time.Time) float64 {
-	return t.UTC().Sub(j2000).Hours() / 24.0
+	sec := t.Unix() - j2000.Unix()
+	return (float64(sec) + float64(t.Nanosecond())/1e9) / 86400.0
 }
 
+// JulianDay returns the Julian Day of t, reading its date, like time.Time,
+// in the proleptic Gregorian calendar: for any year, including year 0 and
+// negative (astronomical) years, and before the Gregorian reform of 1582.
+// Dates in the Julian calendar go through JulianCalendarDayNumber instead.
+//
+// Days are counted as 86400 seconds of UTC. A day ending in a leap second
+// is 86401 SI seconds long, but time.Time cannot represent 23:59:60, so
+// the leap second has no Julian Day of its own and the count simply
+// continues at the next midnight.
 func JulianDay(t time.Time) float64 {
-	u := t.UTC()
-	year, month, day := u.Date()
-	hour := float64(u.Hour()) +
-		float64(u.Minute())/60.0 +
-		float64(u.Second())/3600.0 +
-		float64(u.Nanosecond())/(3600.0*1e9)
-
-	y := year
-	m := int(month)
-
-	if m <= 2 {
-		y -= 1
-		m += 12
-	}
+	sec := t.Unix()
+	days := floorDiv(sec, 86400)
+	frac := (float64(sec-days*86400) + float64(t.Nanosecond())/1e9) / 86400
+	return unixEpochJD + float64(days) + frac
+}
 
-	A := y / 100
-	B := 2 - A + A/4
+// unixEpochJDN is the Julian Day Number of 1970-01-01, the Julian Day at
+// its noon.
+const unixEpochJDN = 2440588
+
+// JulianCalendarDayNumber returns the Julian Day Number (the Julian Day at
+// noon) of a date in the Julian calendar, with astronomical year numbering
+// (year 0 is 1 BC). Month and day may be out of range and are normalized
+// like time.Date does.
+func JulianCalendarDayNumber(year int, month time.Month, day int) int64 {
+	y, m := normalizeMonth(int64(year), int64(month))
+	// Count from March so the leap day ends the year (Richards' algorithm).
+	a := floorDiv(14-m, 12)
+	yy := y + 4800 - a
+	mm := m + 12*a - 3
+	return 1 + (153*mm+2)/5 + 365*yy + floorDiv(yy, 4) - 32083 + int64(day) - 1
+}
 
-	jd := math.Floor(365.25*float64(y+4716)) +
-		math.Floor(30.6001*float64(m+1)) +
-		float64(day) + float64(B) - 1524.5 +
-		hour/24.0
+// JulianCalendarDate returns the Julian calendar date of Julian Day Number
+// jdn, with astronomical year numbering.
+func JulianCalendarDate(jdn int64) (year int, month time.Month, day int) {
+	c := jdn + 32082
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := (5*e + 2) / 153
+	return int(d - 4800 + m/10), time.Month(m + 3 - 12*(m/10)), int(e - (153*m+2)/5 + 1)
+}
+
+// TimeFromDayNumber returns the UTC time at the given clock time on the day
+// with Julian Day Number jdn.
+func TimeFromDayNumber(jdn int64, clock time.Duration) time.Time {
+	return time.Unix((jdn-unixEpochJDN)*86400, 0).UTC().Add(clock)
+}
+
+// DayNumber returns the Julian Day Number of the UTC date of t.
+func DayNumber(t time.Time) int64 {
+	return floorDiv(t.Unix(), 86400) + unixEpochJDN
+}
 
-	return jd
+// normalizeMonth carries months outside 1..12 into the year.
+func normalizeMonth(year, month int64) (int64, int64) {
+	carry := floorDiv(month-1, 12)
+	return year + carry, month - 12*carry
+}
+
+// floorDiv divides rounding toward negative infinity, which the calendar
+// arithmetic needs for dates before its epochs.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// unixEpochJD is the Julian Day of 1970-01-01 00:00:00 UTC.
+const unixEpochJD = 2440587.5
+
+// TimeFromJulianDay is the inverse of JulianDay, returning a UTC time. A
+// float64 Julian Day resolves about 40 microseconds in this era, so times
+// do not round-trip to the nanosecond.
+func TimeFromJulianDay(jd float64) time.Time {
+	days := math.Floor(jd - unixEpochJD)
+	ns := math.Round((jd - unixEpochJD - days) * 86400e9)
+	return time.Unix(int64(days)*86400, int64(ns)).UTC()
 }
 
 // JulianCenturies returns centuries since J2000.0.
@@ -74,6 +132,14 @@ func JulianCenturies(t time.Time) float64 {
 	return (jd - 2451545.0) / 36525.0
 }
 
+// MeanObliquity returns the mean obliquity of the ecliptic (degrees) at t,
+// using the IAU polynomial (Meeus 22.2), which stays accurate to well under
+// an arcsecond for several centuries either side of J2000.
+func MeanObliquity(t time.Time) float64 {
+	T := JulianCenturies(t)
+	return 23.439291111 - 0.013004167*T - 1.639e-7*T*T + 5.036e-7*T*T*T
+}
+
 // -----------------------------
 // Basic degree/radian helpers and trig with degree inputs.
 // -----------------------------
@@ -0,0 +1,153 @@
+package astroglide
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// HoursRule defines legal hours, such as hunting or fishing hours, as a
+// start and end on each local calendar date. Parse one with ParseHoursRule.
+type HoursRule struct {
+	Name       string
+	Start, End HoursBound
+}
+
+// HoursBound is one end of an HoursRule: an event expression such as
+// "sunrise-30m", or a fixed local clock time such as 12:00.
+type HoursBound struct {
+	Event EventExpr
+
+	// Clock, when IsClock is set, is the time after local midnight.
+	Clock   time.Duration
+	IsClock bool
+}
+
+// Common legal hours. Check current regulations: they change from season
+// to season and often differ by species, zone and weapon.
+var (
+	// HoursMigratoryBirds is the US federal rule for migratory game birds
+	// (50 CFR 20.101): half an hour before sunrise to sunset.
+	HoursMigratoryBirds = mustParseHoursRule("US migratory birds", "sunrise-30m..sunset")
+
+	// HoursHalfHour is the most common big-game rule, used by Texas,
+	// Michigan and many other states: half an hour before sunrise to half
+	// an hour after sunset.
+	HoursHalfHour = mustParseHoursRule("half hour before sunrise to half hour after sunset", "sunrise-30m..sunset+30m")
+
+	// HoursWisconsinDeer is Wisconsin's deer rule: half an hour before
+	// sunrise to 20 minutes after sunset.
+	HoursWisconsinDeer = mustParseHoursRule("Wisconsin deer", "sunrise-30m..sunset+20m")
+
+	// HoursSpringTurkeyNoon is the spring turkey rule of Pennsylvania and
+	// several other states: half an hour before sunrise to noon.
+	HoursSpringTurkeyNoon = mustParseHoursRule("spring turkey, until noon", "sunrise-30m..12:00")
+)
+
+// ParseHoursRule parses rule text of the form "START..END", where each end
+// is an event expression (see ParseEventExpr), e.g. "sunset+30m", or a
+// 24-hour local clock time "HH:MM". The rule is named name.
+func ParseHoursRule(name, s string) (HoursRule, error) {
+	startS, endS, ok := strings.Cut(s, "..")
+	if !ok {
+		return HoursRule{}, fmt.Errorf("invalid hours rule %q: want START..END", s)
+	}
+	start, err := parseHoursBound(startS)
+	if err != nil {
+		return HoursRule{}, fmt.Errorf("invalid hours rule %q: %w", s, err)
+	}
+	end, err := parseHoursBound(endS)
+	if err != nil {
+		return HoursRule{}, fmt.Errorf("invalid hours rule %q: %w", s, err)
+	}
+	return HoursRule{Name: name, Start: start, End: end}, nil
+}
+
+func mustParseHoursRule(name, s string) HoursRule {
+	r, err := ParseHoursRule(name, s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func parseHoursBound(s string) (HoursBound, error) {
+	s = strings.TrimSpace(s)
+	if c, err := time.Parse("15:04", s); err == nil {
+		return HoursBound{Clock: time.Duration(c.Hour())*time.Hour + time.Duration(c.Minute())*time.Minute, IsClock: true}, nil
+	}
+	e, err := ParseEventExpr(s)
+	if err != nil {
+		return HoursBound{}, err
+	}
+	return HoursBound{Event: e}, nil
+}
+
+// String formats the rule text in the form ParseHoursRule accepts.
+func (r HoursRule) String() string {
+	return r.Start.String() + ".." + r.End.String()
+}
+
+// String formats the bound as an event expression or "HH:MM".
+func (b HoursBound) String() string {
+	if b.IsClock {
+		return fmt.Sprintf("%02d:%02d", int(b.Clock.Hours()), int(b.Clock.Minutes())%60)
+	}
+	return b.Event.String()
+}
+
+// resolve returns the bound on the local calendar date of midnight.
+func (b HoursBound) resolve(loc Coordinates, midnight time.Time) (time.Time, error) {
+	if b.IsClock {
+		y, m, d := midnight.Date()
+		return timeutil.WallClock(y, m, d, b.Clock, midnight.Location()), nil
+	}
+	return b.Event.Resolve(loc, midnight)
+}
+
+// LegalHours is an HoursRule applied to one local calendar date.
+type LegalHours struct {
+	Date       time.Time // local midnight starting the day
+	Start, End time.Time
+	Rule       HoursRule
+}
+
+// Contains reports whether t falls within the legal hours.
+func (h LegalHours) Contains(t time.Time) bool {
+	return !t.Before(h.Start) && !t.After(h.End)
+}
+
+// LegalHoursFor applies rule at loc on the local calendar date of date, in
+// date's time zone. Clock times are wall-clock times on that date, so they
+// follow daylight saving time; event offsets are elapsed time from the
+// event. If an event of the rule does not occur on the date, the
+// *NoEventError of EventExpr.Resolve is returned; if the hours would end
+// before they start, an error is returned.
+//
+// Official regulations often publish tables for a zone rather than exact
+// coordinates and round to the minute; expect differences of a minute or
+// two.
+func LegalHoursFor(loc Coordinates, date time.Time, rule HoursRule) (LegalHours, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return LegalHours{}, err
+	}
+
+	midnight := timeutil.StartOfDay(date)
+
+	start, err := rule.Start.resolve(loc, midnight)
+	if err != nil {
+		return LegalHours{}, err
+	}
+	end, err := rule.End.resolve(loc, midnight)
+	if err != nil {
+		return LegalHours{}, err
+	}
+	if !end.After(start) {
+		return LegalHours{}, fmt.Errorf("hours rule %q ends at %s before it starts at %s on %s",
+			rule, end.Format("15:04"), start.Format("15:04"), midnight.Format("2006-01-02"))
+	}
+
+	return LegalHours{Date: midnight, Start: start, End: end, Rule: rule}, nil
+}
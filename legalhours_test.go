@@ -0,0 +1,66 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHoursRule(t *testing.T) {
+	for _, s := range []string{"sunrise-30m..sunset+30m", "sunrise-30m..sunset", "civil_dawn..12:00", "06:30..sunset-1h15m"} {
+		r, err := ParseHoursRule("test", s)
+		if err != nil {
+			t.Errorf("ParseHoursRule(%q) error: %v", s, err)
+			continue
+		}
+		if got := r.String(); got != s {
+			t.Errorf("ParseHoursRule(%q).String() = %q", s, got)
+		}
+	}
+	for _, s := range []string{"sunrise-30m", "sunrise..noonish", "25:00..sunset", "..sunset"} {
+		if _, err := ParseHoursRule("bad", s); err == nil {
+			t.Errorf("ParseHoursRule(%q) accepted", s)
+		}
+	}
+}
+
+func TestLegalHoursFor(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	loc := Coordinates{Lat: 40.79, Lon: -77.86} // State College, PA
+
+	// The day daylight saving time starts.
+	date := time.Date(2025, 3, 9, 0, 0, 0, 0, ny)
+	rs, err := RiseSetFor(Sun, loc, date)
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+
+	h, err := LegalHoursFor(loc, date, HoursHalfHour)
+	if err != nil {
+		t.Fatalf("LegalHoursFor error: %v", err)
+	}
+	if !h.Start.Equal(rs.Rise.Add(-30*time.Minute)) || !h.End.Equal(rs.Set.Add(30*time.Minute)) {
+		t.Errorf("hours %v..%v, want half an hour around %v..%v", h.Start, h.End, rs.Rise, rs.Set)
+	}
+	if !h.Contains(rs.Rise) || h.Contains(rs.Set.Add(time.Hour)) {
+		t.Error("Contains disagrees with the hours")
+	}
+
+	turkey, err := LegalHoursFor(loc, date, HoursSpringTurkeyNoon)
+	if err != nil {
+		t.Fatalf("LegalHoursFor error: %v", err)
+	}
+	if want := time.Date(2025, 3, 9, 12, 0, 0, 0, ny); !turkey.End.Equal(want) {
+		t.Errorf("End = %v, want %v", turkey.End, want)
+	}
+	if _, off := turkey.End.Zone(); off != -4*60*60 {
+		t.Errorf("noon offset = %d, want EDT", off)
+	}
+
+	early, _ := ParseHoursRule("ends too early", "sunrise..05:00")
+	if _, err := LegalHoursFor(loc, date, early); err == nil {
+		t.Error("expected error for hours ending before they start")
+	}
+}
@@ -0,0 +1,142 @@
+package astroglide
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// SwitchEvent is one switching of a dusk-to-dawn lighting controller.
+type SwitchEvent struct {
+	Time time.Time
+	On   bool // true to switch the lights on, false to switch them off
+}
+
+// LightingSchedule simulates a sunset/sunrise-driven controller at loc over
+// the local calendar dates from start through end (in start's time zone),
+// returning its switching events in order. Lights go on at sunset plus
+// onOffset and off at sunrise plus offOffset; a negative offOffset
+// switches off before sunrise.
+//
+// minDuration, if positive, suppresses short cycles as relay controllers
+// do: an off period shorter than it keeps the lights on, and an on period
+// shorter than it is skipped. Near the polar circles this removes the
+// brief summer nights.
+//
+// If the lights are on when the range starts, the schedule begins with an
+// on event at its first instant; if they are still on when it ends, the
+// last event is an on event. Through polar night the lights simply stay on.
+func LightingSchedule(loc Coordinates, start, end time.Time, onOffset, offOffset, minDuration time.Duration, opts ...Option) ([]SwitchEvent, error) {
+	tz := start.Location()
+	from := timeutil.StartOfDay(start)
+	_, to := timeutil.LocalDay(end.In(tz))
+	if !to.After(from) {
+		return nil, errors.New("lighting schedule ends before it starts")
+	}
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, from); err != nil {
+		return nil, err
+	}
+	if err := cfg.checkRange(to); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	alt := 90 - cfg.sunZenith()
+
+	// Switching instants from a day either side of the range, so offsets
+	// can carry events across its ends.
+	var switches []SwitchEvent
+	for day := from.AddDate(0, 0, -1); day.Before(to.AddDate(0, 0, 1)); day = day.AddDate(0, 0, 1) {
+		rise, set, okRise, okSet := cfg.sunCrossings(loc, day, alt)
+		if okRise {
+			switches = append(switches, SwitchEvent{Time: rise.Add(offOffset).In(tz), On: false})
+		}
+		if okSet {
+			switches = append(switches, SwitchEvent{Time: set.Add(onOffset).In(tz), On: true})
+		}
+	}
+	sort.Slice(switches, func(i, j int) bool { return switches[i].Time.Before(switches[j].Time) })
+
+	// The state at the start: the last switch before it, or in polar day
+	// or night whether the Sun is down.
+	on := false
+	if i := sort.Search(len(switches), func(i int) bool { return !switches[i].Time.Before(from) }); i > 0 {
+		on = switches[i-1].On
+	} else {
+		on = !cfg.sunUpAllDay(loc, from, alt)
+	}
+
+	// Build on periods within [from, to).
+	type period struct{ start, end time.Time }
+	var periods []period
+	if on {
+		periods = append(periods, period{start: from})
+	}
+	for _, s := range switches {
+		if s.Time.Before(from) || !s.Time.Before(to) || s.On == on {
+			continue
+		}
+		on = s.On
+		if on {
+			periods = append(periods, period{start: s.Time})
+		} else {
+			periods[len(periods)-1].end = s.Time
+		}
+	}
+	if on {
+		periods[len(periods)-1].end = to
+	}
+
+	if minDuration > 0 {
+		merged := periods[:0]
+		for _, p := range periods {
+			if n := len(merged); n > 0 && p.start.Sub(merged[n-1].end) < minDuration {
+				merged[n-1].end = p.end
+				continue
+			}
+			merged = append(merged, p)
+		}
+		periods = merged[:0]
+		for _, p := range merged {
+			clipped := p.start.Equal(from) || p.end.Equal(to)
+			if clipped || p.end.Sub(p.start) >= minDuration {
+				periods = append(periods, p)
+			}
+		}
+	}
+
+	var events []SwitchEvent
+	for _, p := range periods {
+		events = append(events, SwitchEvent{Time: p.start, On: true})
+		if !p.end.Equal(to) {
+			events = append(events, SwitchEvent{Time: p.end, On: false})
+		}
+	}
+	return events, nil
+}
+
+// WriteLightingCSV writes events as CSV with a header row: the RFC 3339
+// time and "ON" or "OFF".
+func WriteLightingCSV(w io.Writer, events []SwitchEvent) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "state"}); err != nil {
+		return err
+	}
+	for _, e := range events {
+		state := "OFF"
+		if e.On {
+			state = "ON"
+		}
+		if err := cw.Write([]string{e.Time.Format(time.RFC3339), state}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
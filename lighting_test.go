@@ -0,0 +1,81 @@
+package astroglide
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLightingSchedule(t *testing.T) {
+	loc := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*60*60)
+	start := time.Date(2025, 6, 20, 0, 0, 0, 0, mst)
+	end := time.Date(2025, 6, 22, 0, 0, 0, 0, mst)
+
+	events, err := LightingSchedule(loc, start, end, 15*time.Minute, -10*time.Minute, 0)
+	if err != nil {
+		t.Fatalf("LightingSchedule error: %v", err)
+	}
+	// On at midnight, then off and on again on each of the three days.
+	if len(events) != 7 {
+		t.Fatalf("got %d events, want 7: %v", len(events), events)
+	}
+	if !events[0].On || !events[0].Time.Equal(start) {
+		t.Errorf("first event = %+v, want ON at %v", events[0], start)
+	}
+	for i := 1; i < len(events); i++ {
+		if events[i].On == events[i-1].On || !events[i].Time.After(events[i-1].Time) {
+			t.Errorf("events %d and %d do not alternate: %+v, %+v", i-1, i, events[i-1], events[i])
+		}
+	}
+
+	rs, err := RiseSetFor(Sun, loc, time.Date(2025, 6, 21, 0, 0, 0, 0, mst), WithTrueInstants())
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	if off := events[3]; off.On || !off.Time.Equal(rs.Rise.Add(-10*time.Minute)) {
+		t.Errorf("June 21 off = %+v, want OFF at %v", off, rs.Rise.Add(-10*time.Minute))
+	}
+	if on := events[4]; !on.On || !on.Time.Equal(rs.Set.Add(15*time.Minute)) {
+		t.Errorf("June 21 on = %+v, want ON at %v", on, rs.Set.Add(15*time.Minute))
+	}
+
+	var b strings.Builder
+	if err := WriteLightingCSV(&b, events[:2]); err != nil {
+		t.Fatalf("WriteLightingCSV error: %v", err)
+	}
+	want := "time,state\n2025-06-20T00:00:00-07:00,ON\n" + events[1].Time.Format(time.RFC3339) + ",OFF\n"
+	if b.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestLightingSchedule_Polar(t *testing.T) {
+	tromso := Coordinates{Lat: 69.65, Lon: 18.96}
+
+	// Polar night: on throughout.
+	events, err := LightingSchedule(tromso, time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 23, 0, 0, 0, 0, time.UTC), 0, 0, 0)
+	if err != nil {
+		t.Fatalf("LightingSchedule error: %v", err)
+	}
+	if len(events) != 1 || !events[0].On {
+		t.Errorf("polar night: %v, want a single ON", events)
+	}
+
+	// Midnight sun: never on.
+	events, err = LightingSchedule(tromso, time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 23, 0, 0, 0, 0, time.UTC), 0, 0, 0)
+	if err != nil {
+		t.Fatalf("LightingSchedule error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("midnight sun: %v, want none", events)
+	}
+
+	// Around the end of the midnight sun, short nights are skipped.
+	start := time.Date(2025, 7, 22, 0, 0, 0, 0, time.UTC)
+	all, _ := LightingSchedule(tromso, start, start.AddDate(0, 0, 5), 0, 0, 0)
+	long, _ := LightingSchedule(tromso, start, start.AddDate(0, 0, 5), 0, 0, 2*time.Hour)
+	if len(all) == 0 || len(long) >= len(all) {
+		t.Errorf("minDuration kept %d of %d events", len(long), len(all))
+	}
+}
@@ -0,0 +1,175 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+const (
+	// lunarInclination is the mean inclination of the Moon's orbit to the
+	// ecliptic, degrees.
+	lunarInclination = 5.145
+
+	// meanNodeRate is the regression of the Moon's mean node, degrees per
+	// day: one revolution in 18.6 years.
+	meanNodeRate = -0.0529539
+
+	// standstillTolerance bounds a standstill period: the Moon's monthly
+	// declination extremes are within this many degrees of their extreme.
+	standstillTolerance = 0.5
+)
+
+// LunarNode gives the ecliptic longitudes where the Moon's orbit crosses
+// the ecliptic.
+type LunarNode struct {
+	Time time.Time
+
+	Ascending  float64 // true ascending node, degrees [0, 360)
+	Descending float64 // true descending node, Ascending + 180
+
+	// MeanAscending is the mean ascending node, free of the periodic
+	// wobble of up to 1.7° in the true node.
+	MeanAscending float64
+}
+
+// LunarNodeAt returns the Moon's nodes at t (Meeus, Astronomical
+// Algorithms, 47.7 and ch. 47 true node terms). The nodes regress westward
+// around the ecliptic every 18.6 years; eclipses happen when the Sun is
+// near one of them.
+func LunarNodeAt(t time.Time) (LunarNode, error) {
+	if err := checkRange(t); err != nil {
+		return LunarNode{}, err
+	}
+
+	utc := t.UTC()
+	mean := meanAscendingNode(utc)
+	a := moon.FundamentalArguments(utc)
+	asc := timeutil.Normalize360(mean -
+		1.4979*timeutil.SinD(2*(a.D-a.F)) -
+		0.1500*timeutil.SinD(a.M) -
+		0.1226*timeutil.SinD(2*a.D) +
+		0.1176*timeutil.SinD(2*a.F) -
+		0.0801*timeutil.SinD(2*(a.Mm-a.F)))
+
+	return LunarNode{
+		Time:          t,
+		Ascending:     asc,
+		Descending:    timeutil.Normalize360(asc + 180),
+		MeanAscending: mean,
+	}, nil
+}
+
+// meanAscendingNode returns the longitude of the Moon's mean ascending node
+// (degrees, [0, 360)) at t (Meeus 47.7).
+func meanAscendingNode(t time.Time) float64 {
+	T := timeutil.JulianCenturies(t)
+	return timeutil.Normalize360(125.0445479 - 1934.1362891*T + 0.0020754*T*T +
+		T*T*T/467441 - T*T*T*T/60616000)
+}
+
+// StandstillKind distinguishes the two extremes of the 18.6-year cycle of
+// the Moon's declination range.
+type StandstillKind int
+
+const (
+	// MajorStandstill: the ascending node is at the March equinox point
+	// and the Moon swings between about ±28.6° declination each month,
+	// rising and setting farther north and south than the Sun ever does.
+	MajorStandstill StandstillKind = iota
+	// MinorStandstill: the ascending node is at the September equinox
+	// point and the monthly range narrows to about ±18.3°.
+	MinorStandstill
+)
+
+func (k StandstillKind) String() string {
+	switch k {
+	case MajorStandstill:
+		return "major standstill"
+	case MinorStandstill:
+		return "minor standstill"
+	default:
+		return fmt.Sprintf("StandstillKind(%d)", int(k))
+	}
+}
+
+// LunarStandstill is a major or minor lunar standstill.
+type LunarStandstill struct {
+	Kind StandstillKind
+
+	// Time is the peak: the mean ascending node at longitude 0 (major) or
+	// 180 (minor), UTC.
+	Time time.Time
+
+	// Start and End bound the standstill period, when the Moon's monthly
+	// declination extremes stay within half a degree of MaxDeclination;
+	// it lasts about two and a half years.
+	Start, End time.Time
+
+	// MaxDeclination is the Moon's declination extreme at the peak,
+	// degrees: the obliquity of the ecliptic plus (major) or minus (minor)
+	// the inclination of the lunar orbit. Individual months may exceed it
+	// by a few tenths of a degree.
+	MaxDeclination float64
+}
+
+// LunarStandstills returns the lunar standstills whose peak falls in
+// [start, end), in chronological order. They alternate every 9.3 years;
+// the major standstill of 2024-25 peaked in early 2025.
+func LunarStandstills(start, end time.Time) ([]LunarStandstill, error) {
+	for _, t := range []time.Time{start, end} {
+		if err := checkRange(t); err != nil {
+			return nil, err
+		}
+	}
+
+	// Half-width of a standstill period in node longitude: the declination
+	// extreme falls off as the inclination times (1 - cos ΔΩ).
+	halfWidth := daysToDuration(math.Acos(1-standstillTolerance/lunarInclination) * timeutil.Rad2Deg(1) / -meanNodeRate)
+	halfCycle := daysToDuration(180 / -meanNodeRate)
+
+	// The node regresses, so the next target longitude is the multiple of
+	// 180° just below the current one.
+	target := 0.0
+	if meanAscendingNode(start.UTC()) > 180 {
+		target = 180
+	}
+	t := timeOfMeanNode(target, start.UTC())
+
+	var out []LunarStandstill
+	for ; t.Before(end); t = timeOfMeanNode(target, t.Add(halfCycle)) {
+		if !t.Before(start) {
+			kind, dec := MajorStandstill, timeutil.MeanObliquity(t)+lunarInclination
+			if target == 180 {
+				kind, dec = MinorStandstill, timeutil.MeanObliquity(t)-lunarInclination
+			}
+			out = append(out, LunarStandstill{
+				Kind:           kind,
+				Time:           t,
+				Start:          t.Add(-halfWidth),
+				End:            t.Add(halfWidth),
+				MaxDeclination: dec,
+			})
+		}
+		target = 180 - target
+	}
+	return out, nil
+}
+
+// timeOfMeanNode returns the first instant at or after about `after` when
+// the mean ascending node reaches longitude target.
+func timeOfMeanNode(target float64, after time.Time) time.Time {
+	delta := timeutil.Normalize360(meanAscendingNode(after) - target)
+	t := after.Add(daysToDuration(delta / -meanNodeRate))
+	for i := 0; i < 5; i++ {
+		diff := wrap180(meanAscendingNode(t) - target)
+		t = t.Add(daysToDuration(diff / -meanNodeRate))
+		if math.Abs(diff) < 1e-7 {
+			break
+		}
+	}
+	return t
+}
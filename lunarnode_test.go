@@ -0,0 +1,77 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLunarNodeAt(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 22.a: Ω = 11.2531° on
+	// 1987-04-10 0h TD.
+	node, err := LunarNodeAt(time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("LunarNodeAt error: %v", err)
+	}
+	if math.Abs(node.MeanAscending-11.2531) > 0.001 {
+		t.Errorf("MeanAscending = %.4f, want 11.2531", node.MeanAscending)
+	}
+	if math.Abs(wrap180(node.Ascending-node.MeanAscending)) > 2 {
+		t.Errorf("Ascending = %.4f, too far from the mean node", node.Ascending)
+	}
+	if math.Abs(wrap180(node.Descending-node.Ascending)) != 180 {
+		t.Errorf("Descending = %.4f, want Ascending + 180", node.Descending)
+	}
+}
+
+func TestLunarStandstills(t *testing.T) {
+	got, err := LunarStandstills(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("LunarStandstills error: %v", err)
+	}
+	want := []struct {
+		kind StandstillKind
+		year int
+		mon  time.Month
+	}{
+		{MajorStandstill, 2006, time.June},
+		{MinorStandstill, 2015, time.October},
+		{MajorStandstill, 2025, time.January},
+		{MinorStandstill, 2034, time.May},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d standstills, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		s := got[i]
+		if s.Kind != w.kind || s.Time.Year() != w.year || s.Time.Month() != w.mon {
+			t.Errorf("standstill %d = %v at %v, want %v in %v %d", i, s.Kind, s.Time, w.kind, w.mon, w.year)
+		}
+		if !s.Start.Before(s.Time) || !s.End.After(s.Time) {
+			t.Errorf("standstill %d period %v..%v does not contain %v", i, s.Start, s.End, s.Time)
+		}
+	}
+	if d := got[2].MaxDeclination; math.Abs(d-28.58) > 0.05 {
+		t.Errorf("major MaxDeclination = %.2f, want ~28.58", d)
+	}
+	if d := got[1].MaxDeclination; math.Abs(d-18.29) > 0.05 {
+		t.Errorf("minor MaxDeclination = %.2f, want ~18.29", d)
+	}
+}
+
+func TestLunarStandstills_MoonDeclination(t *testing.T) {
+	// During the 2024-25 major standstill the Moon passes beyond 28°
+	// declination every month.
+	var extreme float64
+	start := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	for h := 0; h < 28*24; h += 2 {
+		eq, err := EquatorialOfDate(Moon, start.Add(time.Duration(h)*time.Hour))
+		if err != nil {
+			t.Fatalf("EquatorialOfDate error: %v", err)
+		}
+		extreme = math.Max(extreme, math.Abs(eq.Dec))
+	}
+	if extreme < 28 {
+		t.Errorf("Moon declination extreme = %.2f, want > 28", extreme)
+	}
+}
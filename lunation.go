@@ -0,0 +1,86 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Mean rates used to seed the Newton iterations below (degrees per day).
+const (
+	meanElongationRate     = 12.190749 // Moon relative to the Sun
+	meanSolarLongitudeRate = 0.985647
+)
+
+// eclipticElongation returns the Moon's geocentric ecliptic longitude minus
+// the Sun's (degrees, [0, 360)): 0 at New Moon, 90 at First Quarter, 180 at
+// Full Moon and 270 at Last Quarter.
+func eclipticElongation(t time.Time) float64 {
+	moonLon, _ := moon.EclipticApprox(t.UTC())
+	return timeutil.Normalize360(moonLon - sun.EclipticLongitude(t.UTC()))
+}
+
+// nextLunarPhase returns the first instant strictly after `after` when the
+// ecliptic elongation equals target (degrees).
+func nextLunarPhase(after time.Time, target float64) time.Time {
+	delta := timeutil.Normalize360(target - eclipticElongation(after))
+	if delta < 1e-6 {
+		delta += 360
+	}
+	return refineLunarPhase(after.Add(daysToDuration(delta/meanElongationRate)), target)
+}
+
+// prevLunarPhase returns the last instant at or before `before` when the
+// ecliptic elongation equals target (degrees).
+func prevLunarPhase(before time.Time, target float64) time.Time {
+	delta := timeutil.Normalize360(eclipticElongation(before) - target)
+	t := refineLunarPhase(before.Add(-daysToDuration(delta/meanElongationRate)), target)
+	if t.After(before) {
+		// The mean-rate guess landed on the wrong side of `before`.
+		t = refineLunarPhase(t.Add(-daysToDuration(360/meanElongationRate)), target)
+	}
+	return t
+}
+
+// refineLunarPhase polishes an estimate of when the elongation equals target.
+func refineLunarPhase(t time.Time, target float64) time.Time {
+	for i := 0; i < 10; i++ {
+		diff := wrap180(target - eclipticElongation(t))
+		t = t.Add(daysToDuration(diff / meanElongationRate))
+		if math.Abs(diff) < 1e-6 {
+			break
+		}
+	}
+	return t.UTC()
+}
+
+// timeOfSolarLongitude returns the instant near `near` (within about half a
+// year) when the Sun's apparent ecliptic longitude equals target (degrees).
+func timeOfSolarLongitude(target float64, near time.Time) time.Time {
+	t := near.UTC()
+	for i := 0; i < 10; i++ {
+		diff := wrap180(target - sun.EclipticLongitude(t))
+		t = t.Add(daysToDuration(diff / meanSolarLongitudeRate))
+		if math.Abs(diff) < 1e-7 {
+			break
+		}
+	}
+	return t
+}
+
+// wrap180 maps an angle in degrees to (-180, 180].
+func wrap180(deg float64) float64 {
+	deg = timeutil.Normalize360(deg)
+	if deg > 180 {
+		deg -= 360
+	}
+	return deg
+}
+
+// daysToDuration converts fractional days to a time.Duration.
+func daysToDuration(days float64) time.Duration {
+	return time.Duration(days * 24 * float64(time.Hour))
+}
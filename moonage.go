@@ -0,0 +1,43 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+)
+
+// synodicMonth is the mean length of a lunation in days.
+const synodicMonth = 29.530588861
+
+// brownLunation953 is the New Moon of 2000-01-06, which starts Brown
+// lunation 953 (lunation 1 began on 1923-01-17).
+var brownLunation953 = time.Date(2000, time.January, 6, 18, 14, 0, 0, time.UTC)
+
+// LunarAge describes where an instant falls within the current lunation.
+type LunarAge struct {
+	Time    time.Time // the instant evaluated (as passed in)
+	NewMoon time.Time // the preceding New Moon, UTC
+	Days    float64   // days elapsed since NewMoon
+
+	// Lunation is the Brown lunation number of the lunation that began at
+	// NewMoon (lunation 1 began on 1923-01-17).
+	Lunation int
+}
+
+// MoonAge returns the Moon's age at t: the time since the preceding New
+// Moon, together with the Brown lunation number. The New Moon is found with
+// the same elongation solver as the phase functions and is accurate to a
+// few minutes.
+func MoonAge(t time.Time) (LunarAge, error) {
+	if err := checkRange(t); err != nil {
+		return LunarAge{}, err
+	}
+
+	nm := prevLunarPhase(t.UTC(), 0)
+
+	return LunarAge{
+		Time:     t,
+		NewMoon:  nm,
+		Days:     t.Sub(nm).Hours() / 24,
+		Lunation: 953 + int(math.Round(nm.Sub(brownLunation953).Hours()/24/synodicMonth)),
+	}, nil
+}
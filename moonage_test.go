@@ -0,0 +1,40 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoonAge(t *testing.T) {
+	tests := []struct {
+		name         string
+		t            time.Time
+		wantNewMoon  time.Time
+		wantLunation int
+	}{
+		// Reference New Moons (UTC) from published tables.
+		{"lunation 1", time.Date(1923, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(1923, 1, 17, 2, 41, 0, 0, time.UTC), 1},
+		{"mid April 2025", time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 29, 10, 58, 0, 0, time.UTC), 1265},
+		{"just after new moon", time.Date(2025, 4, 27, 21, 0, 0, 0, time.UTC), time.Date(2025, 4, 27, 19, 31, 0, 0, time.UTC), 1266},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MoonAge(tt.t)
+			if err != nil {
+				t.Fatalf("MoonAge error: %v", err)
+			}
+			if diff := got.NewMoon.Sub(tt.wantNewMoon); diff.Abs() > 30*time.Minute {
+				t.Errorf("NewMoon = %v, want %v (diff %v)", got.NewMoon, tt.wantNewMoon, diff)
+			}
+			if got.Lunation != tt.wantLunation {
+				t.Errorf("Lunation = %d, want %d", got.Lunation, tt.wantLunation)
+			}
+			wantDays := tt.t.Sub(got.NewMoon).Hours() / 24
+			if math.Abs(got.Days-wantDays) > 1e-9 || got.Days < 0 || got.Days > synodicMonth+1 {
+				t.Errorf("Days = %.4f, want %.4f", got.Days, wantDays)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package astroglide
+
+import "time"
+
+// MoonNameTradition is a set of traditional names for the Full Moons of the
+// year.
+type MoonNameTradition struct {
+	Name string
+
+	// Months names the Full Moon of each calendar month, January first.
+	Months [12]string
+
+	// Harvest names the Full Moon nearest the autumn equinox and Hunter the
+	// one after it; they take the place of the monthly names. Leave them
+	// empty to use the monthly names only.
+	Harvest, Hunter string
+
+	// AutumnEquinox is the Sun's ecliptic longitude at the autumn equinox:
+	// 180 (September) in the northern hemisphere, 0 (March) in the southern.
+	AutumnEquinox float64
+}
+
+var (
+	// MoonNamesNorthAmerican are the names popularized by the Old Farmer's
+	// Almanac. The Harvest Moon falls in September or October; in a
+	// September Harvest year, the October Full Moon is the Hunter's Moon.
+	MoonNamesNorthAmerican = MoonNameTradition{
+		Name: "North American",
+		Months: [12]string{
+			"Wolf Moon", "Snow Moon", "Worm Moon", "Pink Moon", "Flower Moon", "Strawberry Moon",
+			"Buck Moon", "Sturgeon Moon", "Corn Moon", "Hunter's Moon", "Beaver Moon", "Cold Moon",
+		},
+		Harvest:       "Harvest Moon",
+		Hunter:        "Hunter's Moon",
+		AutumnEquinox: 180,
+	}
+
+	// MoonNamesSouthern shifts the North American names by six months to
+	// follow the seasons of the southern hemisphere, as Australian and New
+	// Zealand almanacs do: the Harvest Moon is the one nearest the March
+	// equinox.
+	MoonNamesSouthern = MoonNameTradition{
+		Name: "Southern Hemisphere",
+		Months: [12]string{
+			"Buck Moon", "Sturgeon Moon", "Corn Moon", "Hunter's Moon", "Beaver Moon", "Cold Moon",
+			"Wolf Moon", "Snow Moon", "Worm Moon", "Pink Moon", "Flower Moon", "Strawberry Moon",
+		},
+		Harvest:       "Harvest Moon",
+		Hunter:        "Hunter's Moon",
+		AutumnEquinox: 0,
+	}
+)
+
+// NamedFullMoon is a Full Moon with its traditional name.
+type NamedFullMoon struct {
+	Time time.Time // instant of the Full Moon, UTC
+	Name string    // e.g. "Harvest Moon"
+
+	// BlueMoon is true for the second Full Moon in a calendar month.
+	BlueMoon bool
+
+	// SeasonalBlueMoon is true for the third Full Moon of an astronomical
+	// season (equinox to solstice or back) that has four, the older
+	// almanac definition.
+	SeasonalBlueMoon bool
+}
+
+// FullMoonName returns the Full Moon nearest t, named according to
+// tradition. Calendar months, for the monthly names and BlueMoon, are taken
+// in t's location, so a Full Moon shortly after midnight UTC on the first of
+// a month may belong to the previous month in the Americas.
+//
+// The Harvest Moon is the Full Moon nearest the autumn equinox, whichever
+// month it falls in; the Hunter's Moon is the next one.
+func FullMoonName(t time.Time, tradition MoonNameTradition) (NamedFullMoon, error) {
+	if err := checkRange(t); err != nil {
+		return NamedFullMoon{}, err
+	}
+
+	fm := nearestFullMoon(t)
+	tz := t.Location()
+	_, month, _ := fm.In(tz).Date()
+
+	name := tradition.Months[month-1]
+	if tradition.Harvest != "" {
+		// The equinox nearest this Full Moon, and the Full Moon nearest it.
+		eq := timeOfSolarLongitude(tradition.AutumnEquinox, fm)
+		harvest := nearestFullMoon(eq)
+		switch {
+		case sameLunarEvent(fm, harvest):
+			name = tradition.Harvest
+		case tradition.Hunter != "" && sameLunarEvent(fm, nextLunarPhase(harvest.Add(24*time.Hour), 180)):
+			name = tradition.Hunter
+		}
+	}
+
+	_, prevMonth, _ := prevLunarPhase(fm.Add(-24*time.Hour), 180).In(tz).Date()
+
+	return NamedFullMoon{
+		Time:             fm,
+		Name:             name,
+		BlueMoon:         prevMonth == month,
+		SeasonalBlueMoon: isSeasonalBlueMoon(fm),
+	}, nil
+}
+
+// nearestFullMoon returns the Full Moon nearest t.
+func nearestFullMoon(t time.Time) time.Time {
+	prev := prevLunarPhase(t, 180)
+	next := nextLunarPhase(t, 180)
+	if t.Sub(prev) <= next.Sub(t) {
+		return prev
+	}
+	return next
+}
+
+// sameLunarEvent reports whether a and b are the same phase instant found
+// by different searches.
+func sameLunarEvent(a, b time.Time) bool {
+	return a.Sub(b).Abs() < 24*time.Hour
+}
+
+// isSeasonalBlueMoon reports whether the Full Moon fm is the third of four
+// in its astronomical season.
+func isSeasonalBlueMoon(fm time.Time) bool {
+	begin, end, _ := astronomicalSeason(fm)
+
+	var moons []time.Time
+	for m := nextLunarPhase(begin, 180); m.Before(end); m = nextLunarPhase(m.Add(24*time.Hour), 180) {
+		moons = append(moons, m)
+	}
+	return len(moons) == 4 && sameLunarEvent(moons[2], fm)
+}
@@ -0,0 +1,60 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFullMoonName(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        time.Time
+		trad     MoonNameTradition
+		wantName string
+		wantDay  int // UTC day of month of the Full Moon
+		blue     bool
+		seasonal bool
+	}{
+		{"Wolf 2025", time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Wolf Moon", 13, false, false},
+		{"Harvest in September 2024", time.Date(2024, 9, 20, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Harvest Moon", 18, false, false},
+		{"Hunter's after September Harvest", time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Hunter's Moon", 17, false, false},
+		{"Harvest in October 2020", time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Harvest Moon", 1, false, false},
+		{"Hunter's blue moon 2020", time.Date(2020, 10, 30, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Hunter's Moon", 31, true, false},
+		{"September Corn 2020", time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Corn Moon", 2, false, false},
+		{"calendar blue moon 2023", time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Sturgeon Moon", 31, true, false},
+		{"seasonal blue moon 2021", time.Date(2021, 8, 22, 0, 0, 0, 0, time.UTC), MoonNamesNorthAmerican, "Sturgeon Moon", 22, false, true},
+		{"southern Harvest 2025", time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), MoonNamesSouthern, "Harvest Moon", 14, false, false},
+		{"southern Hunter's 2025", time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC), MoonNamesSouthern, "Hunter's Moon", 13, false, false},
+		{"southern Wolf 2025", time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), MoonNamesSouthern, "Wolf Moon", 10, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FullMoonName(tt.t, tt.trad)
+			if err != nil {
+				t.Fatalf("FullMoonName error: %v", err)
+			}
+			if got.Name != tt.wantName || got.Time.Day() != tt.wantDay {
+				t.Errorf("got %q on %v, want %q on day %d", got.Name, got.Time, tt.wantName, tt.wantDay)
+			}
+			if got.BlueMoon != tt.blue || got.SeasonalBlueMoon != tt.seasonal {
+				t.Errorf("BlueMoon = %v, SeasonalBlueMoon = %v; want %v, %v", got.BlueMoon, got.SeasonalBlueMoon, tt.blue, tt.seasonal)
+			}
+		})
+	}
+}
+
+func TestFullMoonName_LocalMonth(t *testing.T) {
+	// The Full Moon of 2020-10-31 14:49 UTC, a blue moon in UTC, falls on
+	// November 1 in Sydney.
+	aedt := time.FixedZone("AEDT", 11*60*60)
+	got, err := FullMoonName(time.Date(2020, 11, 1, 12, 0, 0, 0, aedt), MoonNamesSouthern)
+	if err != nil {
+		t.Fatalf("FullMoonName error: %v", err)
+	}
+	if got.Name != "Flower Moon" || got.BlueMoon {
+		t.Errorf("got %q, BlueMoon = %v; want Flower Moon, false", got.Name, got.BlueMoon)
+	}
+	if got.Time.Location() != time.UTC || got.Time.Day() != 31 {
+		t.Errorf("Time = %v, want 2020-10-31 UTC", got.Time)
+	}
+}
@@ -0,0 +1,81 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// MoonOrientation describes how the lit part of the Moon is turned, for
+// drawing it as it appears to an observer.
+type MoonOrientation struct {
+	Time time.Time
+
+	// BrightLimbAngle is the position angle of the midpoint of the bright
+	// limb, in degrees from the north point of the disk through east
+	// [0, 360). It points towards the Sun and does not depend on the
+	// observer.
+	BrightLimbAngle float64
+
+	// ParallacticAngle is the angle at the Moon between celestial north
+	// and the zenith, in degrees from north through east (-180, 180].
+	ParallacticAngle float64
+
+	// Tilt is the direction of the bright limb as the observer sees it, in
+	// degrees counterclockwise from straight up [0, 360): 0 means the lit
+	// side faces up, 90 left, 180 down and 270 right. Rotate a drawing
+	// whose lit side faces up by Tilt to match the sky.
+	Tilt float64
+
+	Fraction float64 // illuminated fraction [0..1], as MoonPhase.Fraction
+}
+
+// MoonOrientationAt returns the orientation of the Moon's lit side as seen
+// from loc at t (Meeus, Astronomical Algorithms, 14.1 and 48.5), using the
+// topocentric position of the Moon.
+//
+// A waxing crescent after sunset in the northern hemisphere, for instance,
+// has a Tilt between 180 and 360 (lit on the right, towards the set Sun);
+// seen from the southern hemisphere it is lit on the left.
+func MoonOrientationAt(loc Coordinates, t time.Time) (MoonOrientation, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return MoonOrientation{}, err
+	}
+
+	sunEq, err := EquatorialOfDate(Sun, t)
+	if err != nil {
+		return MoonOrientation{}, err
+	}
+	pos, err := PositionAt(Moon, loc, t, Topocentric)
+	if err != nil {
+		return MoonOrientation{}, err
+	}
+	phase, err := MoonPhaseAt(t)
+	if err != nil {
+		return MoonOrientation{}, err
+	}
+
+	chi := brightLimbAngle(sunEq, pos.Equatorial)
+	h := coord.LocalSiderealTime(loc.Lon, t.UTC()) - pos.RA
+	q := coord.ParallacticAngle(loc.Lat, h, pos.Dec)
+
+	return MoonOrientation{
+		Time:             t,
+		BrightLimbAngle:  chi,
+		ParallacticAngle: q,
+		Tilt:             timeutil.Normalize360(chi - q),
+		Fraction:         phase.Fraction,
+	}, nil
+}
+
+// brightLimbAngle returns the position angle (degrees, [0, 360)) of the
+// bright limb of the Moon at moonEq lit by the Sun at sunEq (Meeus 48.5).
+func brightLimbAngle(sunEq, moonEq Equatorial) float64 {
+	dRA := sunEq.RA - moonEq.RA
+	return timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(
+		timeutil.CosD(sunEq.Dec)*timeutil.SinD(dRA),
+		timeutil.SinD(sunEq.Dec)*timeutil.CosD(moonEq.Dec)-timeutil.CosD(sunEq.Dec)*timeutil.SinD(moonEq.Dec)*timeutil.CosD(dRA),
+	)))
+}
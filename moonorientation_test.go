@@ -0,0 +1,66 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+)
+
+func TestBrightLimbAngle(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 48.a (1992 April 12).
+	sun := Equatorial{RA: 20.6579, Dec: 8.6964}
+	moon := Equatorial{RA: 134.6885, Dec: 13.7684}
+	if got := brightLimbAngle(sun, moon); math.Abs(got-285.0) > 0.1 {
+		t.Errorf("brightLimbAngle = %.2f, want 285.0", got)
+	}
+
+	// On the meridian the zenith is due north of an object south of it.
+	if q := coord.ParallacticAngle(40, 0, 10); math.Abs(q) > 1e-9 {
+		t.Errorf("parallactic angle on the meridian = %v, want 0", q)
+	}
+	// East of the meridian (negative hour angle) the angle is negative.
+	if q := coord.ParallacticAngle(40, -30, 10); q >= 0 {
+		t.Errorf("parallactic angle before transit = %v, want negative", q)
+	}
+}
+
+func TestMoonOrientationAt(t *testing.T) {
+	// Two days after the 2025-03-29 New Moon, shortly after sunset.
+	cases := []struct {
+		name     string
+		loc      Coordinates
+		t        time.Time
+		min, max float64 // expected Tilt range
+	}{
+		{"Phoenix", Coordinates{Lat: 33.4484, Lon: -112.0740}, time.Date(2025, 4, 1, 2, 30, 0, 0, time.UTC), 180, 360},
+		{"Sydney", Coordinates{Lat: -33.8688, Lon: 151.2093}, time.Date(2025, 3, 31, 7, 45, 0, 0, time.UTC), 0, 180},
+	}
+	for _, c := range cases {
+		o, err := MoonOrientationAt(c.loc, c.t)
+		if err != nil {
+			t.Fatalf("%s: MoonOrientationAt error: %v", c.name, err)
+		}
+		if o.Tilt <= c.min || o.Tilt >= c.max {
+			t.Errorf("%s: evening crescent Tilt = %.1f, want between %v and %v", c.name, o.Tilt, c.min, c.max)
+		}
+		if o.Fraction <= 0 || o.Fraction > 0.15 {
+			t.Errorf("%s: Fraction = %.3f, want a thin crescent", c.name, o.Fraction)
+		}
+		// The bright limb points at the Sun, which is below the horizon.
+		if o.Tilt <= 90 || o.Tilt >= 270 {
+			t.Errorf("%s: Tilt %.1f points the lit side upwards after sunset", c.name, o.Tilt)
+		}
+	}
+
+	// The bright limb faces west (position angle about 270) around First
+	// Quarter, when the Sun is 90° west of the Moon.
+	o, err := MoonOrientationAt(Coordinates{}, time.Date(2025, 4, 5, 2, 15, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("MoonOrientationAt error: %v", err)
+	}
+	if o.BrightLimbAngle < 225 || o.BrightLimbAngle > 315 {
+		t.Errorf("First Quarter bright limb angle = %.1f, want about 270", o.BrightLimbAngle)
+	}
+}
@@ -0,0 +1,271 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Atmosphere describes local air conditions for refraction near the
+// horizon. Standard conditions are 1010 hPa and 10 °C.
+type Atmosphere struct {
+	PressureHPa  float64 // station pressure, hPa
+	TemperatureC float64 // air temperature, °C
+}
+
+// refraction returns the refraction (degrees) at geometric altitude alt,
+// scaled from standard conditions (Meeus 16.4).
+func (a Atmosphere) refraction(alt float64) float64 {
+	return timeutil.ApproxRefraction(alt) * (a.PressureHPa / 1010) * (283 / (273 + a.TemperatureC))
+}
+
+// HorizonPoint is the altitude of the visible horizon at one azimuth.
+type HorizonPoint struct {
+	Azimuth  float64 // degrees from north through east
+	Altitude float64 // degrees; positive for hills, negative for a sea horizon seen from height
+}
+
+// HorizonProfile is the local skyline as points sorted by azimuth. Between
+// points the altitude is interpolated linearly, wrapping around north.
+type HorizonProfile []HorizonPoint
+
+// AltitudeAt returns the altitude of the horizon at azimuth az (degrees).
+// An empty profile is a flat horizon at 0°.
+func (p HorizonProfile) AltitudeAt(az float64) float64 {
+	switch len(p) {
+	case 0:
+		return 0
+	case 1:
+		return p[0].Altitude
+	}
+
+	az = timeutil.Normalize360(az)
+	for i := range p {
+		a, b := p[i], p[(i+1)%len(p)]
+		span := timeutil.Normalize360(b.Azimuth - a.Azimuth)
+		off := timeutil.Normalize360(az - a.Azimuth)
+		if span == 0 || off > span {
+			continue
+		}
+		return a.Altitude + (b.Altitude-a.Altitude)*off/span
+	}
+	return p[0].Altitude
+}
+
+// Observer is a location with its time zone, and optionally local
+// atmospheric conditions and skyline, with methods for the common events.
+//
+// An Observer precomputes what depends only on the location and shares
+// solar positions between calls like a Session. Dates passed to its methods
+// are taken as calendar dates in the observer's time zone. It is safe for
+// concurrent use once its fields are set.
+type Observer struct {
+	// Atmosphere, if set, replaces the standard refraction at the horizon.
+	Atmosphere *Atmosphere
+
+	// Horizon, if set, makes rise and set refer to the local skyline
+	// instead of a flat horizon.
+	Horizon HorizonProfile
+
+	loc            Coordinates
+	tz             *time.Location
+	sinLat, cosLat float64
+	session        *Session
+}
+
+// NewObserver returns an Observer at loc whose dates and results use the
+// time zone tz (UTC if nil).
+func NewObserver(loc Coordinates, tz *time.Location) *Observer {
+	if tz == nil {
+		tz = time.UTC
+	}
+	return &Observer{
+		loc:     loc,
+		tz:      tz,
+		sinLat:  timeutil.SinD(loc.Lat),
+		cosLat:  timeutil.CosD(loc.Lat),
+		session: NewSession(loc),
+	}
+}
+
+// Coordinates returns the observer's location.
+func (o *Observer) Coordinates() Coordinates { return o.loc }
+
+// Location returns the observer's time zone.
+func (o *Observer) Location() *time.Location { return o.tz }
+
+// day returns local midnight, in the observer's time zone, of date's
+// calendar date.
+func (o *Observer) day(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return timeutil.WallClock(y, m, d, 0, o.tz)
+}
+
+// RiseSet returns the rise and set of body on date. Without Atmosphere or
+// Horizon it is RiseSetFor; otherwise the body's upper limb (or the limb
+// chosen with WithLimb) is tracked against the skyline with the given
+// refraction. WithZenith fixes the geometry and ignores both.
+func (o *Observer) RiseSet(body Body, date time.Time, opts ...Option) (RiseSet, error) {
+	date = o.day(date)
+	cfg := newConfig(opts)
+	if (o.Atmosphere == nil && o.Horizon == nil) || cfg.hasZenith {
+		return o.session.RiseSetFor(body, date, opts...)
+	}
+
+	if err := checkInputs(o.loc, date); err != nil {
+		return RiseSet{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return RiseSet{}, err
+	}
+	if body != Sun && body != Moon {
+		return RiseSet{}, fmt.Errorf("unknown body %v", body)
+	}
+
+	// Upper limb: +1 semi-diameter above the center, lower limb: -1.
+	limb := 1 - float64(cfg.limb)
+	f := func(t time.Time) float64 {
+		alt, az, semi := o.apparent(body, t)
+		return alt + limb*semi - o.Horizon.AltitudeAt(az)
+	}
+
+	year, month, day := date.Date()
+	_, end := timeutil.LocalDay(date)
+
+	const (
+		steps = 48
+		tol   = 30 * time.Second
+	)
+	up := solver.FindAltitudeEvent(f, date, end, 0, solver.CrossingUp, steps, tol)
+	down := solver.FindAltitudeEvent(f, date, end, 0, solver.CrossingDown, steps, tol)
+	if !up.OK && !down.OK {
+		return RiseSet{}, noCrossing(body, "rise/set", o.loc, date, f(date.Add(12*time.Hour)) > 0)
+	}
+
+	rs := RiseSet{Date: date, HasRise: up.OK, HasSet: down.OK}
+	if up.OK {
+		rs.Rise = cfg.pin(up.Time.In(o.tz), year, month, day)
+	}
+	if down.OK {
+		rs.Set = cfg.pin(down.Time.In(o.tz), year, month, day)
+	}
+	rs.Uncertainty = cfg.uncertainty(body, o.loc, tol, foundEvents(up.Time, up.OK, down.Time, down.OK)...)
+	return rs, nil
+}
+
+// Sunrise returns the time of sunrise on date.
+func (o *Observer) Sunrise(date time.Time, opts ...Option) (time.Time, error) {
+	rs, err := o.RiseSet(Sun, date, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !rs.HasRise {
+		return time.Time{}, noEvent(Sun, "rise", o.loc, rs.Date)
+	}
+	return rs.Rise, nil
+}
+
+// Sunset returns the time of sunset on date.
+func (o *Observer) Sunset(date time.Time, opts ...Option) (time.Time, error) {
+	rs, err := o.RiseSet(Sun, date, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !rs.HasSet {
+		return time.Time{}, noEvent(Sun, "set", o.loc, rs.Date)
+	}
+	return rs.Set, nil
+}
+
+// SolarNoon returns local apparent noon on date.
+func (o *Observer) SolarNoon(date time.Time) (time.Time, error) {
+	return SolarNoon(o.loc, o.day(date))
+}
+
+// Twilight returns dawn (Rise) and dusk (Set) of the given kind on date.
+func (o *Observer) Twilight(date time.Time, kind TwilightKind, opts ...Option) (RiseSet, error) {
+	return o.session.TwilightFor(o.day(date), kind, opts...)
+}
+
+// GoldenHour returns the golden hour windows on date.
+func (o *Observer) GoldenHour(date time.Time, opts ...Option) (DaylightPhases, error) {
+	return o.session.GoldenHourFor(o.day(date), opts...)
+}
+
+// BlueHour returns the blue hour windows on date.
+func (o *Observer) BlueHour(date time.Time, opts ...Option) (DaylightPhases, error) {
+	return o.session.BlueHourFor(o.day(date), opts...)
+}
+
+// MoonPhase returns the Moon's phase at t, reported in the observer's time
+// zone.
+func (o *Observer) MoonPhase(t time.Time, opts ...Option) (MoonPhase, error) {
+	return MoonPhaseAt(t.In(o.tz), opts...)
+}
+
+// Position returns the position of body at t as seen by the observer.
+func (o *Observer) Position(body Body, t time.Time, frame PositionFrame) (Position, error) {
+	return PositionAt(body, o.loc, t, frame)
+}
+
+// Altitude returns the apparent altitude (degrees) of body's center at t:
+// topocentric, with refraction under the observer's atmosphere (standard
+// conditions if unset).
+func (o *Observer) Altitude(body Body, t time.Time) (float64, error) {
+	if err := checkInputs(o.loc, t); err != nil {
+		return 0, err
+	}
+	if body != Sun && body != Moon {
+		return 0, fmt.Errorf("unknown body %v", body)
+	}
+	alt, _, _ := o.apparent(body, t)
+	return alt, nil
+}
+
+// apparent returns the topocentric apparent altitude and azimuth of body's
+// center at t, and its semi-diameter, all in degrees.
+func (o *Observer) apparent(body Body, t time.Time) (alt, az, semi float64) {
+	utc := t.UTC()
+	eq, _ := geocentricEquatorial(body, utc)
+	dist := geocentricDistanceKm(body, utc)
+	lst := coord.LocalSiderealTime(o.loc.Lon, utc)
+
+	ra, dec := eq.RA, eq.Dec
+	if body == Moon {
+		ra, dec, dist = coord.Topocentric(ra, dec, dist, o.loc.Lat, o.loc.Elevation, lst)
+		semi = moon.SemiDiameter(dist)
+	} else {
+		semi = sunSemiDiameter
+	}
+
+	alt, az = o.horizontal(ra, dec, lst)
+
+	atm := Atmosphere{PressureHPa: 1010, TemperatureC: 10}
+	if o.Atmosphere != nil {
+		atm = *o.Atmosphere
+	}
+	return alt + atm.refraction(alt), az, semi
+}
+
+// horizontal is coord.Horizontal using the observer's precomputed latitude
+// terms.
+func (o *Observer) horizontal(ra, dec, lst float64) (alt, az float64) {
+	H := lst - ra
+	sinDec, cosDec := timeutil.SinD(dec), timeutil.CosD(dec)
+	cosH := timeutil.CosD(H)
+
+	sinAlt := o.sinLat*sinDec + o.cosLat*cosDec*cosH
+	alt = timeutil.Rad2Deg(math.Asin(math.Max(-1, math.Min(1, sinAlt))))
+
+	// Azimuth from the south (Meeus 13.5), shifted to north.
+	y := timeutil.SinD(H)
+	x := cosH*o.sinLat - sinDec/cosDec*o.cosLat
+	az = timeutil.Normalize360(timeutil.Rad2Deg(math.Atan2(y, x)) + 180)
+
+	return alt, az
+}
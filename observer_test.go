@@ -0,0 +1,110 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestObserver_MatchesFreeFunctions(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	obs := NewObserver(phx, loc)
+
+	// The date's own zone is ignored; the calendar date is read in loc.
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+
+	rise, err := obs.Sunrise(date)
+	if err != nil {
+		t.Fatalf("Sunrise error: %v", err)
+	}
+	want, _ := SlideIntoSunset(phx, time.Date(2025, 6, 21, 0, 0, 0, 0, loc))
+	if !rise.Equal(want.Rise) || rise.Location() != loc {
+		t.Errorf("Sunrise = %v, want %v", rise, want.Rise)
+	}
+
+	at := time.Date(2025, 11, 5, 13, 19, 0, 0, time.UTC)
+	phase, err := obs.MoonPhase(at)
+	if err != nil {
+		t.Fatalf("MoonPhase error: %v", err)
+	}
+	wantPhase, _ := MoonPhaseAt(at)
+	if phase.Fraction != wantPhase.Fraction || phase.Time.Location() != loc {
+		t.Errorf("MoonPhase = %+v, want %+v in %v", phase, wantPhase, loc)
+	}
+}
+
+func TestObserver_AtmosphereAndHorizon(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 3, 20, 0, 0, 0, 0, loc)
+
+	flat := NewObserver(phx, loc)
+	base, err := flat.RiseSet(Sun, date)
+	if err != nil {
+		t.Fatalf("RiseSet error: %v", err)
+	}
+
+	// Standard conditions through the skyline solver reproduce the
+	// default sunrise.
+	std := NewObserver(phx, loc)
+	std.Atmosphere = &Atmosphere{PressureHPa: 1010, TemperatureC: 10}
+	rs, err := std.RiseSet(Sun, date)
+	if err != nil {
+		t.Fatalf("RiseSet (standard atmosphere) error: %v", err)
+	}
+	if d := rs.Rise.Sub(base.Rise); d < -time.Minute || d > time.Minute {
+		t.Errorf("standard-atmosphere sunrise %v, default %v", rs.Rise, base.Rise)
+	}
+
+	// A 5° ridge to the east delays sunrise by roughly 25 minutes and
+	// leaves sunset over the flat west untouched.
+	hills := NewObserver(phx, loc)
+	hills.Horizon = HorizonProfile{
+		{Azimuth: 0, Altitude: 0},
+		{Azimuth: 60, Altitude: 5},
+		{Azimuth: 120, Altitude: 5},
+		{Azimuth: 180, Altitude: 0},
+	}
+	rs, err = hills.RiseSet(Sun, date)
+	if err != nil {
+		t.Fatalf("RiseSet (horizon) error: %v", err)
+	}
+	if d := rs.Rise.Sub(base.Rise); d < 15*time.Minute || d > 35*time.Minute {
+		t.Errorf("sunrise behind ridge is %v after flat sunrise, want ~25m", d)
+	}
+	if d := rs.Set.Sub(base.Set); d < -time.Minute || d > time.Minute {
+		t.Errorf("sunset with flat west horizon moved by %v", d)
+	}
+}
+
+func TestHorizonProfile_AltitudeAt(t *testing.T) {
+	p := HorizonProfile{
+		{Azimuth: 10, Altitude: 2},
+		{Azimuth: 90, Altitude: 6},
+		{Azimuth: 350, Altitude: 0},
+	}
+	tests := []struct {
+		az, want float64
+	}{
+		{10, 2},
+		{50, 4},
+		{90, 6},
+		{220, 3},
+		{0, 1}, // wraps through north between 350° and 10°
+	}
+	for _, tt := range tests {
+		if got := p.AltitudeAt(tt.az); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("AltitudeAt(%v) = %v, want %v", tt.az, got, tt.want)
+		}
+	}
+	if got := HorizonProfile(nil).AltitudeAt(123); got != 0 {
+		t.Errorf("empty profile AltitudeAt = %v, want 0", got)
+	}
+}
@@ -0,0 +1,93 @@
+package astroglide
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Option customizes how a calculation is performed or reported. Options are
+// accepted as trailing variadic arguments, so existing calls keep their
+// behavior.
+type Option func(*config)
+
+// config collects the effect of the supplied Options.
+type config struct {
+	trueInstants bool
+	precision    Precision
+	round        time.Duration
+	historical   bool
+
+	limb      Limb
+	zenith    float64
+	hasZenith bool
+
+	// sunCache, when set by a Session, memoizes solar positions.
+	sunCache *sun.Cache
+}
+
+// newConfig applies opts over the defaults.
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return c
+}
+
+// validate checks the supplied options for values no calculation accepts.
+func (c config) validate() error {
+	if err := c.validatePrecision(); err != nil {
+		return err
+	}
+	if c.round < 0 || c.round > time.Hour {
+		return fmt.Errorf("invalid rounding %v: must be between 0 and 1h", c.round)
+	}
+	return c.validateHorizon()
+}
+
+// WithTrueInstants returns event times exactly as found instead of forcing
+// their calendar date onto the requested local date.
+//
+// By default the library keeps the clock time of each event but rewrites
+// its date to the requested one. Each date is searched over exactly its own
+// local hours (23 or 25 of them when daylight saving time starts or ends),
+// so the events found already lie on it and the two settings agree; with
+// this option the instant is correct by construction. RiseSet.Date records
+// which local date was requested.
+func WithTrueInstants() Option {
+	return func(c *config) {
+		c.trueInstants = true
+	}
+}
+
+// WithRounding rounds event times to the nearest multiple of d on the local
+// clock, e.g. time.Minute to compare with published HH:MM tables. Halfway
+// values round up, so 06:12:30 becomes 06:13 rather than being truncated to
+// 06:12. Multiples count from local midnight, so a half hour is :00 or :30
+// in Kathmandu (+05:45) too. Zero, the default, leaves times unrounded; d
+// may be at most an hour.
+//
+// Rounding is applied last, after the date policy of WithTrueInstants, so
+// an event at 23:59:45 rounds to midnight of the following day.
+func WithRounding(d time.Duration) Option {
+	return func(c *config) {
+		c.round = d
+	}
+}
+
+// pin applies the date policy and rounding of c to an event time t found
+// for the local calendar date (year, month, day).
+func (c config) pin(t time.Time, year int, month time.Month, day int) time.Time {
+	if !c.trueInstants {
+		t = withLocalDate(t, year, month, day)
+	}
+	if c.round > 0 {
+		t = timeutil.RoundWallClock(t, c.round)
+	}
+	return t
+}
@@ -0,0 +1,135 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/orbit"
+)
+
+// OrbitalElements are the osculating heliocentric elements of a comet or
+// asteroid, referred to the J2000.0 ecliptic and equinox, as published by
+// the Minor Planet Center. They implement EphemerisProvider by two-body
+// (Keplerian) propagation, so ObjectRiseSetFor answers "when does comet X
+// rise tonight".
+//
+// Planetary perturbations are ignored: positions are good to an arcminute
+// or so within a few months of the elements' epoch and degrade after that.
+// Elliptic, parabolic and hyperbolic orbits are supported.
+type OrbitalElements struct {
+	Name string // e.g. "C/2020 F3 (NEOWISE)"
+
+	Perihelion         time.Time // time of perihelion passage (TT; used as UTC)
+	PerihelionDistance float64   // q, AU
+	Eccentricity       float64   // e; 1 for a parabola
+
+	ArgPerihelion float64 // ω, degrees
+	AscendingNode float64 // Ω, longitude of the ascending node, degrees
+	Inclination   float64 // i, degrees
+}
+
+// Validate reports whether the elements describe an orbit: a positive
+// perihelion distance, a non-negative eccentricity and a perihelion time.
+func (o OrbitalElements) Validate() error {
+	if err := o.elements().Validate(); err != nil {
+		return fmt.Errorf("invalid orbital elements for %q: q = %g AU, e = %g", o.Name, o.PerihelionDistance, o.Eccentricity)
+	}
+	return nil
+}
+
+// Equatorial returns the object's geocentric right ascension and
+// declination (degrees, equinox of date) and distance (km) at t, allowing
+// for light time. Invalid elements give NaN; check them with Validate.
+func (o OrbitalElements) Equatorial(t time.Time) (ra, dec, distKm float64) {
+	el := o.elements()
+	if el.Validate() != nil {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	return el.Geocentric(t)
+}
+
+func (o OrbitalElements) elements() orbit.Elements {
+	return orbit.Elements{
+		Perihelion:    o.Perihelion,
+		Q:             o.PerihelionDistance,
+		E:             o.Eccentricity,
+		ArgPerihelion: o.ArgPerihelion,
+		Node:          o.AscendingNode,
+		Inclination:   o.Inclination,
+	}
+}
+
+// ParseMPCCometElements parses one line of the Minor Planet Center's
+// one-line comet element format, as in CometEls.txt:
+//
+//	0001P         1986 02  9.4590  0.587104  0.967277  111.8657   58.8601  162.2422  19860205   4.0  6.0  1P/Halley
+//
+// Only the fixed columns holding the elements and the name are read.
+func ParseMPCCometElements(line string) (OrbitalElements, error) {
+	line = strings.TrimRight(line, " \r\n")
+	if len(line) < 79 {
+		return OrbitalElements{}, fmt.Errorf("malformed MPC comet elements %q", line)
+	}
+
+	c := columns{line: line, format: "MPC comet elements"}
+	year := c.num("perihelion year", 15, 18)
+	month := c.num("perihelion month", 20, 21)
+	day := c.num("perihelion day", 23, 29)
+	o := OrbitalElements{
+		PerihelionDistance: c.num("perihelion distance", 31, 39),
+		Eccentricity:       c.num("eccentricity", 42, 49),
+		ArgPerihelion:      c.num("argument of perihelion", 52, 59),
+		AscendingNode:      c.num("ascending node", 62, 69),
+		Inclination:        c.num("inclination", 72, 79),
+		Name:               c.str(103, 158),
+	}
+	if c.err != nil {
+		return OrbitalElements{}, c.err
+	}
+	if o.Name == "" {
+		o.Name = c.str(1, 12)
+	}
+
+	o.Perihelion = time.Date(int(year), time.Month(month), 0, 0, 0, 0, 0, time.UTC).
+		Add(time.Duration(day * 24 * float64(time.Hour)))
+	if err := o.Validate(); err != nil {
+		return OrbitalElements{}, err
+	}
+	return o, nil
+}
+
+// columns reads the fixed-column fields of an element file line,
+// remembering the first malformed number.
+type columns struct {
+	line   string
+	format string // for errors, e.g. "MPC comet elements"
+	err    error
+}
+
+// str returns the trimmed text of columns from to to, 1-based and
+// inclusive as in the format descriptions.
+func (c *columns) str(from, to int) string {
+	if to > len(c.line) {
+		to = len(c.line)
+	}
+	if from > to {
+		return ""
+	}
+	return strings.TrimSpace(c.line[from-1 : to])
+}
+
+// num parses columns from to to as a number.
+func (c *columns) num(name string, from, to int) float64 {
+	if c.err != nil {
+		return 0
+	}
+	s := c.str(from, to)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		c.err = fmt.Errorf("malformed %s: invalid %s %q", c.format, name, s)
+	}
+	return v
+}
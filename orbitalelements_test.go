@@ -0,0 +1,117 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOrbitalElementsEncke(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 33.a: comet Encke on
+	// 1990 October 6.0 at α = 10h34m13.7s, δ = +19°09'31" (J2000),
+	// Δ = 0.8242 AU.
+	const a, e = 2.2091404, 0.8502196
+	encke := OrbitalElements{
+		Name:               "2P/Encke",
+		Perihelion:         time.Date(1990, time.October, 28, 0, 0, 0, 0, time.UTC).Add(time.Duration(0.54502 * 24 * float64(time.Hour))),
+		PerihelionDistance: a * (1 - e),
+		Eccentricity:       e,
+		ArgPerihelion:      186.23352,
+		AscendingNode:      334.75006,
+		Inclination:        11.94524,
+	}
+	at := time.Date(1990, time.October, 6, 0, 0, 0, 0, time.UTC)
+
+	ra, dec, dist := encke.Equatorial(at)
+	j2000 := PrecessToJ2000(Equatorial{RA: ra, Dec: dec}, at)
+	if d := AngularSeparationEquatorial(j2000, Equatorial{RA: 158.5571, Dec: 19.1586}); d > 0.02 {
+		t.Errorf("Encke at %.4f/%.4f, %.3f° from Meeus", j2000.RA, j2000.Dec, d)
+	}
+	if au := dist / 149597870.7; math.Abs(au-0.8242) > 0.0005 {
+		t.Errorf("Encke distance = %.4f AU, want 0.8242", au)
+	}
+}
+
+func TestOrbitalElementsNearParabolic(t *testing.T) {
+	// Positions are continuous through e = 1.
+	base := OrbitalElements{
+		Perihelion:         time.Date(2020, time.July, 3, 16, 18, 0, 0, time.UTC),
+		PerihelionDistance: 0.2947,
+		ArgPerihelion:      37.28,
+		AscendingNode:      61.01,
+		Inclination:        128.94,
+	}
+	at := time.Date(2020, time.July, 20, 0, 0, 0, 0, time.UTC)
+
+	var pos []Equatorial
+	for _, e := range []float64{0.999999, 1, 1.000001} {
+		o := base
+		o.Eccentricity = e
+		ra, dec, _ := o.Equatorial(at)
+		pos = append(pos, Equatorial{RA: ra, Dec: dec})
+	}
+	for _, p := range pos[1:] {
+		if d := AngularSeparationEquatorial(pos[0], p); d > 0.01 {
+			t.Errorf("near-parabolic positions differ by %.4f°", d)
+		}
+	}
+}
+
+func TestParseMPCCometElements(t *testing.T) {
+	line := "0001P         1986 02  9.4590  0.587104  0.967277  111.8657   58.8601  162.2422  19860205   4.0  6.0  1P/Halley"
+	o, err := ParseMPCCometElements(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Name != "1P/Halley" {
+		t.Errorf("Name = %q, want 1P/Halley", o.Name)
+	}
+	wantT := time.Date(1986, time.February, 9, 11, 0, 58, 0, time.UTC)
+	if d := o.Perihelion.Sub(wantT); d.Abs() > time.Second {
+		t.Errorf("Perihelion = %v, want %v", o.Perihelion, wantT)
+	}
+	if o.PerihelionDistance != 0.587104 || o.Eccentricity != 0.967277 || o.Inclination != 162.2422 {
+		t.Errorf("got %+v", o)
+	}
+
+	for _, bad := range []string{
+		"",
+		"0001P         1986 02  9.4590",
+		"0001P         1986 02  9.4590  0.000000  0.967277  111.8657   58.8601  162.2422",
+		"0001P         1986 02  9.4590  0.5871x4  0.967277  111.8657   58.8601  162.2422",
+	} {
+		if _, err := ParseMPCCometElements(bad); err == nil {
+			t.Errorf("ParseMPCCometElements(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestCometRiseSet(t *testing.T) {
+	// Comet NEOWISE was circumpolar from mid-northern latitudes in late
+	// July 2020 and rose and set from the southern United States.
+	neowise := OrbitalElements{
+		Name:               "C/2020 F3 (NEOWISE)",
+		Perihelion:         time.Date(2020, time.July, 3, 16, 18, 0, 0, time.UTC),
+		PerihelionDistance: 0.29466,
+		Eccentricity:       0.99918,
+		ArgPerihelion:      37.28,
+		AscendingNode:      61.01,
+		Inclination:        128.94,
+	}
+	mst := time.FixedZone("MST", -7*3600)
+	date := time.Date(2020, time.July, 20, 0, 0, 0, 0, mst)
+
+	rs, err := ObjectRiseSetFor(neowise, Coordinates{Lat: 33.4484, Lon: -112.0740}, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rs.HasSet || rs.Set.Hour() != 23 {
+		t.Errorf("NEOWISE set at %v from Phoenix, want about 23:30", rs.Set)
+	}
+
+	_, err = ObjectRiseSetFor(neowise, Coordinates{Lat: 60, Lon: 10}, date)
+	if !errors.Is(err, ErrAlwaysUp) {
+		t.Errorf("from 60°N err = %v, want ErrAlwaysUp", err)
+	}
+}
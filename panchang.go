@@ -0,0 +1,120 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Paksha is the lunar fortnight a tithi falls in.
+type Paksha int
+
+const (
+	// Shukla is the bright (waxing) fortnight, tithis 1–15.
+	Shukla Paksha = iota
+	// Krishna is the dark (waning) fortnight, tithis 16–30.
+	Krishna
+)
+
+// String returns the paksha name.
+func (p Paksha) String() string {
+	if p == Krishna {
+		return "Krishna"
+	}
+	return "Shukla"
+}
+
+// Tithi is a lunar day: one of 30 steps of 12° in the Moon's ecliptic
+// elongation east of the Sun.
+type Tithi struct {
+	Number     int     // 1..30 (1 = Shukla Pratipada, 15 = Purnima, 30 = Amavasya)
+	Name       string  // e.g. "Pratipada", "Purnima", "Amavasya"
+	Paksha     Paksha  // Shukla (1–15) or Krishna (16–30)
+	Elongation float64 // Moon minus Sun ecliptic longitude, degrees [0, 360)
+}
+
+// Nakshatra is one of the 27 lunar mansions of 13°20' along the sidereal
+// ecliptic.
+type Nakshatra struct {
+	Number    int     // 1..27 (1 = Ashwini)
+	Name      string  // e.g. "Rohini"
+	Pada      int     // quarter within the nakshatra, 1..4
+	Longitude float64 // Moon's sidereal (Lahiri) ecliptic longitude, degrees
+}
+
+var tithiNames = [15]string{
+	"Pratipada", "Dwitiya", "Tritiya", "Chaturthi", "Panchami",
+	"Shashthi", "Saptami", "Ashtami", "Navami", "Dashami",
+	"Ekadashi", "Dwadashi", "Trayodashi", "Chaturdashi", "Purnima",
+}
+
+var nakshatraNames = [27]string{
+	"Ashwini", "Bharani", "Krittika", "Rohini", "Mrigashira", "Ardra",
+	"Punarvasu", "Pushya", "Ashlesha", "Magha", "Purva Phalguni",
+	"Uttara Phalguni", "Hasta", "Chitra", "Swati", "Vishakha", "Anuradha",
+	"Jyeshtha", "Mula", "Purva Ashadha", "Uttara Ashadha", "Shravana",
+	"Dhanishta", "Shatabhisha", "Purva Bhadrapada", "Uttara Bhadrapada",
+	"Revati",
+}
+
+// TithiAt returns the tithi in effect at time t. Like the Moon's phase,
+// the tithi is the same for every observer.
+func TithiAt(t time.Time) (Tithi, error) {
+	if err := checkRange(t); err != nil {
+		return Tithi{}, err
+	}
+
+	elong := eclipticElongation(t)
+
+	n := int(math.Floor(elong/12)) + 1
+	if n > 30 {
+		n = 30
+	}
+
+	tithi := Tithi{Number: n, Elongation: elong}
+	switch {
+	case n <= 15:
+		tithi.Paksha = Shukla
+		tithi.Name = tithiNames[n-1]
+	case n == 30:
+		tithi.Paksha = Krishna
+		tithi.Name = "Amavasya"
+	default:
+		tithi.Paksha = Krishna
+		tithi.Name = tithiNames[n-16]
+	}
+
+	return tithi, nil
+}
+
+// NakshatraAt returns the nakshatra occupied by the Moon at time t, using
+// the sidereal zodiac with the Lahiri (Chitrapaksha) ayanamsa.
+func NakshatraAt(t time.Time) (Nakshatra, error) {
+	if err := checkRange(t); err != nil {
+		return Nakshatra{}, err
+	}
+
+	utc := t.UTC()
+
+	moonLon, _ := moon.EclipticApprox(utc)
+	sidereal := timeutil.Normalize360(moonLon - Lahiri.At(utc))
+
+	const span = 360.0 / 27.0
+	idx := int(math.Floor(sidereal / span))
+	if idx > 26 {
+		idx = 26
+	}
+	pada := int(math.Floor(math.Mod(sidereal, span)/(span/4))) + 1
+	if pada > 4 {
+		pada = 4
+	}
+
+	return Nakshatra{
+		Number:    idx + 1,
+		Name:      nakshatraNames[idx],
+		Pada:      pada,
+		Longitude: sidereal,
+	}, nil
+}
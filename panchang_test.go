@@ -0,0 +1,54 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTithiAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		t          time.Time
+		wantNumber int
+		wantName   string
+		wantPaksha Paksha
+	}{
+		// New Moon 2025-04-27 19:31 UTC.
+		{"after new moon", time.Date(2025, 4, 27, 23, 0, 0, 0, time.UTC), 1, "Pratipada", Shukla},
+		{"before new moon", time.Date(2025, 4, 27, 16, 0, 0, 0, time.UTC), 30, "Amavasya", Krishna},
+		// Full Moon 2025-05-12 16:56 UTC.
+		{"before full moon", time.Date(2025, 5, 12, 13, 0, 0, 0, time.UTC), 15, "Purnima", Shukla},
+		{"after full moon", time.Date(2025, 5, 12, 21, 0, 0, 0, time.UTC), 16, "Pratipada", Krishna},
+		// First quarter 2025-05-04 13:52 UTC (90°) falls mid-Ashtami; a day
+		// later the Moon has moved ~12° further, into Navami.
+		{"day after first quarter", time.Date(2025, 5, 5, 14, 0, 0, 0, time.UTC), 9, "Navami", Shukla},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TithiAt(tt.t)
+			if err != nil {
+				t.Fatalf("TithiAt error: %v", err)
+			}
+			if got.Number != tt.wantNumber || got.Name != tt.wantName || got.Paksha != tt.wantPaksha {
+				t.Errorf("TithiAt = %d %s (%s, elongation %.2f°), want %d %s (%s)",
+					got.Number, got.Name, got.Paksha, got.Elongation,
+					tt.wantNumber, tt.wantName, tt.wantPaksha)
+			}
+		})
+	}
+}
+
+func TestNakshatraAt(t *testing.T) {
+	// The full Moon of Vaishakha (2025-05-12) falls in Vishakha nakshatra.
+	n, err := NakshatraAt(time.Date(2025, 5, 12, 16, 56, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NakshatraAt error: %v", err)
+	}
+	if n.Name != "Vishakha" || n.Number != 16 {
+		t.Errorf("NakshatraAt = %d %s (%.2f°), want 16 Vishakha", n.Number, n.Name, n.Longitude)
+	}
+	if n.Pada < 1 || n.Pada > 4 {
+		t.Errorf("pada %d out of range", n.Pada)
+	}
+}
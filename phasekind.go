@@ -0,0 +1,91 @@
+package astroglide
+
+import "fmt"
+
+// PhaseKind is one of the eight traditional phases of the Moon.
+type PhaseKind int
+
+const (
+	PhaseNewMoon PhaseKind = iota
+	PhaseWaxingCrescent
+	PhaseFirstQuarter
+	PhaseWaxingGibbous
+	PhaseFullMoon
+	PhaseWaningGibbous
+	PhaseLastQuarter
+	PhaseWaningCrescent
+)
+
+var phaseKindNames = [...]string{
+	PhaseNewMoon:        "New Moon",
+	PhaseWaxingCrescent: "Waxing Crescent",
+	PhaseFirstQuarter:   "First Quarter",
+	PhaseWaxingGibbous:  "Waxing Gibbous",
+	PhaseFullMoon:       "Full Moon",
+	PhaseWaningGibbous:  "Waning Gibbous",
+	PhaseLastQuarter:    "Last Quarter",
+	PhaseWaningCrescent: "Waning Crescent",
+}
+
+// The Unicode Moon symbols, drawn as seen from the northern hemisphere:
+// the waxing Moon is lit on the right.
+var phaseKindEmoji = [...]string{
+	PhaseNewMoon:        "🌑",
+	PhaseWaxingCrescent: "🌒",
+	PhaseFirstQuarter:   "🌓",
+	PhaseWaxingGibbous:  "🌔",
+	PhaseFullMoon:       "🌕",
+	PhaseWaningGibbous:  "🌖",
+	PhaseLastQuarter:    "🌗",
+	PhaseWaningCrescent: "🌘",
+}
+
+// String returns the phase name, e.g. "Waxing Crescent", as in
+// MoonPhase.Name.
+func (k PhaseKind) String() string {
+	if k < PhaseNewMoon || k > PhaseWaningCrescent {
+		return fmt.Sprintf("PhaseKind(%d)", int(k))
+	}
+	return phaseKindNames[k]
+}
+
+// Emoji returns the Unicode symbol of the phase as seen from the northern
+// hemisphere, e.g. "🌒" for a waxing crescent. Use EmojiFor to draw it for
+// a southern observer.
+func (k PhaseKind) Emoji() string {
+	if k < PhaseNewMoon || k > PhaseWaningCrescent {
+		return ""
+	}
+	return phaseKindEmoji[k]
+}
+
+// EmojiFor returns the Unicode symbol of the phase as seen from latitude
+// lat. South of the equator the Moon appears upside down, so the lit side
+// of a waxing Moon is on the left and the symbols of the waxing and waning
+// phases swap: a waxing crescent is "🌘".
+func (k PhaseKind) EmojiFor(lat float64) string {
+	if lat < 0 && k != PhaseNewMoon && k != PhaseFullMoon {
+		// Mirror across New/Full Moon: 1<->7, 2<->6, 3<->5.
+		k = 8 - k
+	}
+	return k.Emoji()
+}
+
+// Kind returns the phase in the eight-phase classification that also gives
+// Name. New and Full Moon cover illuminated fractions within 1% of 0 and 1,
+// and the quarters fractions within 5% of one half.
+func (p MoonPhase) Kind() PhaseKind {
+	return classifyMoonPhase(p.Fraction, p.Waxing)
+}
+
+// Emoji returns the Unicode symbol of the phase as seen from the northern
+// hemisphere.
+func (p MoonPhase) Emoji() string {
+	return p.Kind().Emoji()
+}
+
+// EmojiFor returns the Unicode symbol of the phase as seen from latitude
+// lat (see PhaseKind.EmojiFor).
+func (p MoonPhase) EmojiFor(lat float64) string {
+	return p.Kind().EmojiFor(lat)
+}
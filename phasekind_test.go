@@ -0,0 +1,53 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoonPhase_Kind(t *testing.T) {
+	seen := map[string]bool{}
+	start := time.Date(2025, 4, 27, 0, 0, 0, 0, time.UTC)
+	for h := 0; h < 30*24; h += 3 {
+		p, err := MoonPhaseAt(start.Add(time.Duration(h) * time.Hour))
+		if err != nil {
+			t.Fatalf("MoonPhaseAt error: %v", err)
+		}
+		if got := p.Kind().String(); got != p.Name {
+			t.Errorf("%v: Kind() = %q, Name = %q", p.Time, got, p.Name)
+		}
+		seen[p.Emoji()] = true
+	}
+	for k := PhaseNewMoon; k <= PhaseWaningCrescent; k++ {
+		if !seen[k.Emoji()] {
+			t.Errorf("%v (%s) never seen over a lunation", k, k.Emoji())
+		}
+	}
+}
+
+func TestPhaseKind_EmojiFor(t *testing.T) {
+	tests := []struct {
+		kind         PhaseKind
+		north, south string
+	}{
+		{PhaseNewMoon, "🌑", "🌑"},
+		{PhaseWaxingCrescent, "🌒", "🌘"},
+		{PhaseFirstQuarter, "🌓", "🌗"},
+		{PhaseWaxingGibbous, "🌔", "🌖"},
+		{PhaseFullMoon, "🌕", "🌕"},
+		{PhaseWaningGibbous, "🌖", "🌔"},
+		{PhaseLastQuarter, "🌗", "🌓"},
+		{PhaseWaningCrescent, "🌘", "🌒"},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.EmojiFor(40); got != tt.north {
+			t.Errorf("%v.EmojiFor(40) = %s, want %s", tt.kind, got, tt.north)
+		}
+		if got := tt.kind.EmojiFor(-33.9); got != tt.south {
+			t.Errorf("%v.EmojiFor(-33.9) = %s, want %s", tt.kind, got, tt.south)
+		}
+	}
+	if got := PhaseKind(8).String(); got != "PhaseKind(8)" {
+		t.Errorf("PhaseKind(8).String() = %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSunAltitudeWindowFor_MatchesGoldenHour(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load America/Phoenix: %v", err)
+	}
+
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.November, 28, 0, 0, 0, 0, loc)
+
+	golden, err := GoldenHourFor(coords, date)
+	if err != nil {
+		t.Fatalf("GoldenHourFor error: %v", err)
+	}
+	band, err := SunAltitudeWindowFor(coords, date, -4, 6)
+	if err != nil {
+		t.Fatalf("SunAltitudeWindowFor error: %v", err)
+	}
+
+	if golden != band {
+		t.Errorf("golden hour %+v differs from -4°..+6° band %+v", golden, band)
+	}
+}
+
+func TestSunAltitudeWindowFor_DeepBlueHour(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load America/Phoenix: %v", err)
+	}
+
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, loc)
+
+	phases, err := SunAltitudeWindowFor(coords, date, -8, -4)
+	if err != nil {
+		t.Fatalf("SunAltitudeWindowFor error: %v", err)
+	}
+	if !phases.HasMorning || !phases.HasEvening {
+		t.Fatalf("expected both windows, got %+v", phases)
+	}
+
+	// The four degrees from -8° to -4° take roughly 20-25 minutes at ~33°N.
+	for name, w := range map[string]PhaseWindow{"morning": phases.Morning, "evening": phases.Evening} {
+		d := w.End.Sub(w.Start)
+		if d < 10*time.Minute || d > 30*time.Minute {
+			t.Errorf("%s deep blue hour lasts %v, expected ~10-30 minutes", name, d)
+		}
+	}
+
+	if !phases.Morning.End.Before(phases.Evening.Start) {
+		t.Errorf("morning window %v should precede evening window %v", phases.Morning, phases.Evening)
+	}
+}
+
+func TestSunAltitudeWindowFor_InvalidBand(t *testing.T) {
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	if _, err := SunAltitudeWindowFor(coords, date, 6, -4); err == nil {
+		t.Error("expected error for inverted altitude band")
+	}
+	if _, err := SunAltitudeWindowFor(coords, date, 0, 0); err == nil {
+		t.Error("expected error for empty altitude band")
+	}
+}
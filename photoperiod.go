@@ -0,0 +1,110 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Sun altitudes (degrees, Sun's center) that start and end the day for
+// Photoperiod.
+const (
+	// PhotoperiodSunrise counts from sunrise to sunset, as DaylightHours.
+	PhotoperiodSunrise = sun.ApparentHorizonAltitudeSun
+	// PhotoperiodCivil counts from civil dawn to civil dusk. Plants respond
+	// to the dim light of civil twilight, so agronomy (and most published
+	// photoperiod tables for crops) uses this definition.
+	PhotoperiodCivil = -6.0
+)
+
+// Photoperiod returns how long the Sun's center is above threshold
+// (degrees, e.g. PhotoperiodCivil) during the given local calendar date.
+//
+// Days on which the Sun never crosses the threshold are not an error: the
+// photoperiod is then zero or the whole day.
+func Photoperiod(loc Coordinates, date time.Time, threshold float64) (time.Duration, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return 0, err
+	}
+	if threshold < -90 || threshold > 90 || math.IsNaN(threshold) {
+		return 0, fmt.Errorf("photoperiod threshold %v outside [-90, 90]", threshold)
+	}
+	return sunUpDuration(loc, date, threshold)
+}
+
+// PhotoperiodMonth summarizes the photoperiod over one calendar month.
+type PhotoperiodMonth struct {
+	Month time.Month
+	Mean  time.Duration
+	Min   time.Duration // shortest day of the month
+	Max   time.Duration // longest day of the month
+	Start time.Duration // first day of the month
+	End   time.Duration // last day of the month
+}
+
+// Change returns how much the photoperiod grew over the month (negative
+// while the days shorten).
+func (m PhotoperiodMonth) Change() time.Duration {
+	return m.End - m.Start
+}
+
+// PhotoperiodByMonth returns the photoperiod summary of each month of year
+// (dates in tz, UTC if nil), January first, for a threshold as in
+// Photoperiod.
+func PhotoperiodByMonth(loc Coordinates, year int, tz *time.Location, threshold float64) ([]PhotoperiodMonth, error) {
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	months := make([]PhotoperiodMonth, 0, 12)
+	for m := time.January; m <= time.December; m++ {
+		pm := PhotoperiodMonth{Month: m}
+		var total time.Duration
+		n := 0
+		for day := time.Date(year, m, 1, 0, 0, 0, 0, tz); day.Month() == m; day = time.Date(year, m, day.Day()+1, 0, 0, 0, 0, tz) {
+			p, err := Photoperiod(loc, day, threshold)
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				pm.Start, pm.Min, pm.Max = p, p, p
+			}
+			if p < pm.Min {
+				pm.Min = p
+			}
+			if p > pm.Max {
+				pm.Max = p
+			}
+			pm.End = p
+			total += p
+			n++
+		}
+		pm.Mean = total / time.Duration(n)
+		months = append(months, pm)
+	}
+	return months, nil
+}
+
+// sunUpDuration returns how long the Sun's center is above alt during the
+// local calendar day. When it never crosses alt, the whole day counts if
+// the Sun is above alt at noon and none of it otherwise.
+func sunUpDuration(loc Coordinates, day time.Time, alt float64) (time.Duration, error) {
+	rs, okUp, okDown := sunAltitudeCrossings(loc, day, alt, WithTrueInstants())
+	if okUp || okDown {
+		return timeUp(rs, day), nil
+	}
+
+	noon := sun.TransitForDate(loc.Lon, day)
+	pos, err := PositionAt(Sun, loc, noon, Geocentric)
+	if err != nil {
+		return 0, err
+	}
+	if pos.Altitude < alt {
+		return 0, nil
+	}
+	start, end := timeutil.LocalDay(day)
+	return end.Sub(start), nil
+}
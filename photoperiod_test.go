@@ -0,0 +1,67 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPhotoperiod(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.FixedZone("MST", -7*3600))
+
+	day, err := Photoperiod(phx, date, PhotoperiodSunrise)
+	if err != nil {
+		t.Fatalf("Photoperiod error: %v", err)
+	}
+	hours, _ := DaylightHours(phx, date)
+	if d := day.Hours() - hours; d < -1e-9 || d > 1e-9 {
+		t.Errorf("PhotoperiodSunrise = %v, DaylightHours = %.4fh", day, hours)
+	}
+
+	civil, err := Photoperiod(phx, date, PhotoperiodCivil)
+	if err != nil {
+		t.Fatalf("Photoperiod error: %v", err)
+	}
+	// Civil twilight lasts about 29 minutes at each end in June.
+	if extra := civil - day; extra < 55*time.Minute || extra > 65*time.Minute {
+		t.Errorf("civil photoperiod %v is %v longer than sunrise-to-sunset, want about 58m", civil, extra)
+	}
+
+	// In the Tromsø polar night there is civil twilight around noon.
+	tromso := Coordinates{Lat: 69.6496, Lon: 18.9560}
+	dec := time.Date(2025, time.December, 21, 0, 0, 0, 0, time.UTC)
+	if p, err := Photoperiod(tromso, dec, PhotoperiodSunrise); err != nil || p != 0 {
+		t.Errorf("polar night sunrise photoperiod = %v, %v; want 0", p, err)
+	}
+	if p, err := Photoperiod(tromso, dec, PhotoperiodCivil); err != nil || p < 3*time.Hour || p > 5*time.Hour {
+		t.Errorf("polar night civil photoperiod = %v, %v; want 3h..5h", p, err)
+	}
+
+	if _, err := Photoperiod(phx, date, 95); err == nil {
+		t.Error("threshold 95°: want an error")
+	}
+}
+
+func TestPhotoperiodByMonth(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	months, err := PhotoperiodByMonth(phx, 2025, nil, PhotoperiodCivil)
+	if err != nil {
+		t.Fatalf("PhotoperiodByMonth error: %v", err)
+	}
+	if len(months) != 12 {
+		t.Fatalf("got %d months, want 12", len(months))
+	}
+	for _, m := range months {
+		if m.Min > m.Mean || m.Mean > m.Max {
+			t.Errorf("%s: min %v, mean %v, max %v out of order", m.Month, m.Min, m.Mean, m.Max)
+		}
+		growing := m.Month <= time.May
+		if growing && m.Change() <= 0 || m.Month >= time.July && m.Month <= time.November && m.Change() >= 0 {
+			t.Errorf("%s: change %v has the wrong sign", m.Month, m.Change())
+		}
+	}
+	if months[5].Max < months[11].Max+4*time.Hour {
+		t.Errorf("June max %v should exceed December max %v by over 4h", months[5].Max, months[11].Max)
+	}
+}
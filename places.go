@@ -0,0 +1,194 @@
+package astroglide
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Place is a named location from the embedded place database.
+type Place struct {
+	Name       string // e.g. "Phoenix"
+	Region     string // state or province code, e.g. "AZ" (may be empty)
+	RegionName string // e.g. "Arizona" (may be empty)
+	Country    string // ISO 3166 code, e.g. "US"
+	Coordinates
+	TimeZone   string // IANA zone name, e.g. "America/Phoenix"
+	Population int    // 0 when unknown
+}
+
+// String formats the place as "Name, Region, Country".
+func (p Place) String() string {
+	parts := []string{p.Name}
+	if p.Region != "" {
+		parts = append(parts, p.Region)
+	}
+	return strings.Join(append(parts, p.Country), ", ")
+}
+
+// ErrPlaceNotFound is returned by LookupPlace when no place matches.
+var ErrPlaceNotFound = errors.New("place not found")
+
+// placesData is generated by cmd/astroglide-places. It is decoded on the
+// first lookup; programs that never call LookupPlace do not link it in.
+//
+//go:embed data/places.tsv.gz
+var placesData []byte
+
+var (
+	placesOnce sync.Once
+	places     []placeEntry      // most populous first
+	countries  map[string]string // ISO code -> name
+	placesErr  error
+)
+
+// placeEntry is a Place with its folded names for matching.
+type placeEntry struct {
+	Place
+	keys  []string // folded name and ASCII name
+	quals []string // folded region code/name and country code/name
+}
+
+// LookupPlace finds a place by name in the embedded offline database, e.g.
+// "Phoenix", "Phoenix, AZ", "Sydney, Australia" or "London, GB". The part
+// before the first comma is the place name; each further part must match
+// the place's region code or name, or its country code or name. Matching
+// ignores case and accents. When several places match, the most populous
+// is returned.
+//
+// The database is built by cmd/astroglide-places; see its documentation for
+// the sources. Load the returned TimeZone with the time package's
+// LoadLocation; the library itself never does (see the package docs).
+func LookupPlace(name string) (Place, error) {
+	placesOnce.Do(loadPlaces)
+	if placesErr != nil {
+		return Place{}, placesErr
+	}
+
+	parts := strings.Split(name, ",")
+	want := foldName(parts[0])
+	if want == "" {
+		return Place{}, fmt.Errorf("%w: empty name", ErrPlaceNotFound)
+	}
+	var quals []string
+	for _, q := range parts[1:] {
+		if q = foldName(q); q != "" {
+			quals = append(quals, q)
+		}
+	}
+
+	// places is sorted by population, so the first match wins.
+	for _, e := range places {
+		if contains(e.keys, want) && e.matches(quals) {
+			return e.Place, nil
+		}
+	}
+	return Place{}, fmt.Errorf("%w: %q", ErrPlaceNotFound, strings.TrimSpace(name))
+}
+
+// matches reports whether every qualifier names the place's region or
+// country.
+func (e placeEntry) matches(quals []string) bool {
+	for _, q := range quals {
+		if !contains(e.quals, q) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func loadPlaces() {
+	zr, err := gzip.NewReader(bytes.NewReader(placesData))
+	if err != nil {
+		placesErr = fmt.Errorf("place database: %w", err)
+		return
+	}
+
+	countries = map[string]string{}
+	sc := bufio.NewScanner(zr)
+	for n := 1; sc.Scan(); n++ {
+		f := strings.Split(sc.Text(), "\t")
+		if len(f) == 3 && f[0] == "C" {
+			countries[f[1]] = f[2]
+			continue
+		}
+		if len(f) != 9 {
+			placesErr = fmt.Errorf("place database line %d: %d fields, want 9", n, len(f))
+			return
+		}
+		lat, err1 := strconv.ParseFloat(f[5], 64)
+		lon, err2 := strconv.ParseFloat(f[6], 64)
+		pop, err3 := strconv.Atoi(f[8])
+		if err1 != nil || err2 != nil || err3 != nil {
+			placesErr = fmt.Errorf("place database line %d: bad number", n)
+			return
+		}
+		p := Place{
+			Name:        f[0],
+			Region:      f[2],
+			RegionName:  f[3],
+			Country:     f[4],
+			Coordinates: Coordinates{Lat: lat, Lon: lon},
+			TimeZone:    f[7],
+			Population:  pop,
+		}
+		// The ASCII spelling matches too ("Tromso" for "Tromsø").
+		places = append(places, placeEntry{
+			Place: p,
+			keys:  []string{foldName(f[0]), foldName(f[1])},
+			quals: []string{foldName(f[2]), foldName(f[3]), foldName(f[4]), foldName(countries[f[4]])},
+		})
+	}
+	if err := sc.Err(); err != nil {
+		placesErr = fmt.Errorf("place database: %w", err)
+	}
+}
+
+// foldName lowercases s, strips accents from common Latin letters and
+// collapses punctuation and spaces, so "St. John's" matches "st johns".
+func foldName(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if f, ok := accentFold[r]; ok {
+			r = f
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case r == '\'' || r == '.':
+			// dropped: "St. John's" -> "st johns"
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+var accentFold = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'ç': 'c', 'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ñ': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ý': 'y', 'ÿ': 'y',
+}
@@ -0,0 +1,70 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLookupPlace(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantName string
+		wantTZ   string
+		lat, lon float64
+	}{
+		{"Phoenix, AZ", "Phoenix", "America/Phoenix", 33.45, -112.07},
+		{"phoenix", "Phoenix", "America/Phoenix", 33.45, -112.07},
+		{"Phoenix, Arizona, United States", "Phoenix", "America/Phoenix", 33.45, -112.07},
+		{"Sydney, Australia", "Sydney", "Australia/Sydney", -33.87, 151.21},
+		{"SYDNEY, nsw", "Sydney", "Australia/Sydney", -33.87, 151.21},
+		{"St. John's", "St Johns", "America/St_Johns", 47.57, -52.72},
+		{"Ushuaia", "Ushuaia", "America/Argentina/Ushuaia", -54.80, -68.30},
+		{"Seattle, WA", "Seattle", "America/Los_Angeles", 47.61, -122.33},
+		{"Houston", "Houston", "America/Chicago", 29.76, -95.37},
+		{"Boston, Massachusetts", "Boston", "America/New_York", 42.36, -71.06},
+		{"London", "London", "Europe/London", 51.51, -0.13},
+		{"London, ON", "London", "America/Toronto", 42.98, -81.25},
+		{"Portland", "Portland", "America/Los_Angeles", 45.52, -122.68},
+		{"Portland, ME", "Portland", "America/New_York", 43.66, -70.26},
+		{"st louis, mo", "St. Louis", "America/Chicago", 38.63, -90.20},
+		{"Sao Paulo, Brazil", "São Paulo", "America/Sao_Paulo", -23.55, -46.63},
+	}
+
+	for _, tt := range tests {
+		p, err := LookupPlace(tt.query)
+		if err != nil {
+			t.Errorf("LookupPlace(%q) error: %v", tt.query, err)
+			continue
+		}
+		if p.Name != tt.wantName || p.TimeZone != tt.wantTZ {
+			t.Errorf("LookupPlace(%q) = %v (%s), want %s (%s)", tt.query, p, p.TimeZone, tt.wantName, tt.wantTZ)
+		}
+		if math.Abs(p.Lat-tt.lat) > 0.05 || math.Abs(p.Lon-tt.lon) > 0.05 {
+			t.Errorf("LookupPlace(%q) at %.4f,%.4f, want about %.2f,%.2f", tt.query, p.Lat, p.Lon, tt.lat, tt.lon)
+		}
+		if _, err := time.LoadLocation(p.TimeZone); err != nil {
+			t.Errorf("LookupPlace(%q) time zone %q: %v", tt.query, p.TimeZone, err)
+		}
+	}
+
+	for _, bad := range []string{"", " , ", "Atlantis", "Phoenix, CA"} {
+		if _, err := LookupPlace(bad); !errors.Is(err, ErrPlaceNotFound) {
+			t.Errorf("LookupPlace(%q) error = %v, want ErrPlaceNotFound", bad, err)
+		}
+	}
+}
+
+func TestFoldName(t *testing.T) {
+	for in, want := range map[string]string{
+		"Tromsø":         "tromso",
+		"  São  Paulo":   "sao paulo",
+		"St. John's":     "st johns",
+		"Port-au-Prince": "port au prince",
+	} {
+		if got := foldName(in); got != want {
+			t.Errorf("foldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package astroglide
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestNoTimeZoneLoading keeps the library free of time.LoadLocation: js/wasm
+// and TinyGo builds usually have no tz database, so callers pass dates in a
+// location of their own choosing instead.
+func TestNoTimeZoneLoading(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{"*.go", "internal/*/*.go"} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(src), "time.LoadLocation") {
+			t.Errorf("%s calls time.LoadLocation", name)
+		}
+	}
+}
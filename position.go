@@ -0,0 +1,203 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// Equatorial holds equatorial coordinates in degrees: right ascension in
+// [0, 360) and declination in [-90, 90].
+type Equatorial struct {
+	RA  float64 // right ascension, degrees
+	Dec float64 // declination, degrees
+}
+
+// AngularSeparation returns the geocentric angular distance (degrees,
+// 0..180) between two bodies at time t. For the Sun and Moon this is the
+// lunar elongation also reported by MoonPhaseAt.
+//
+// NaN is returned if either body is not supported.
+func AngularSeparation(body1, body2 Body, t time.Time) float64 {
+	a, ok1 := geocentricEquatorial(body1, t)
+	b, ok2 := geocentricEquatorial(body2, t)
+	if !ok1 || !ok2 {
+		return math.NaN()
+	}
+	return AngularSeparationEquatorial(a, b)
+}
+
+// AngularSeparationEquatorial returns the angular distance (degrees,
+// 0..180) between two points given by right ascension and declination.
+func AngularSeparationEquatorial(a, b Equatorial) float64 {
+	return coord.Separation(a.RA, a.Dec, b.RA, b.Dec)
+}
+
+// ParallacticAngle returns the parallactic angle (degrees, (-180, 180]) of
+// an object at ra, dec (degrees, equinox of date) seen from loc at t: the
+// angle at the object between celestial north and the zenith, measured
+// through east. It is negative before the object crosses the meridian and
+// positive after, and gives the field rotation of an alt-azimuth mount.
+// It is undefined at the poles and for an object at the zenith.
+func ParallacticAngle(loc Coordinates, ra, dec float64, t time.Time) (float64, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return 0, err
+	}
+	ha := coord.LocalSiderealTime(loc.Lon, t.UTC()) - ra
+	return coord.ParallacticAngle(loc.Lat, ha, dec), nil
+}
+
+// J2000 is the standard epoch J2000.0 (2000-01-01 12:00 TT, taken here as
+// UTC), the reference equinox of modern star catalogs.
+var J2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// EquatorialOfDate returns the geocentric RA/Dec of body at t referred to
+// the equinox of date, the frame in which the Sun and Moon models (and all
+// rise/set and altitude calculations) work.
+func EquatorialOfDate(body Body, t time.Time) (Equatorial, error) {
+	if err := checkRange(t); err != nil {
+		return Equatorial{}, err
+	}
+
+	eq, ok := geocentricEquatorial(body, t)
+	if !ok {
+		return Equatorial{}, fmt.Errorf("unknown body %v", body)
+	}
+	return eq, nil
+}
+
+// EquatorialJ2000 returns the geocentric RA/Dec of body at t precessed to
+// the J2000.0 mean equinox, for comparison with catalogs and J2000-based
+// ephemerides.
+func EquatorialJ2000(body Body, t time.Time) (Equatorial, error) {
+	eq, err := EquatorialOfDate(body, t)
+	if err != nil {
+		return Equatorial{}, err
+	}
+	return PrecessToJ2000(eq, t), nil
+}
+
+// PrecessToDate converts J2000.0 mean coordinates (e.g. from a star
+// catalog) to the mean equinox of date t, so they can be compared with
+// EquatorialOfDate or used for rise/set work. Proper motion and nutation
+// are not applied.
+func PrecessToDate(eq Equatorial, t time.Time) Equatorial {
+	ra, dec := coord.Precess(eq.RA, eq.Dec, J2000, t)
+	return Equatorial{RA: ra, Dec: dec}
+}
+
+// PrecessToJ2000 converts coordinates referred to the equinox of date t to
+// the J2000.0 mean equinox. It is the inverse of PrecessToDate.
+func PrecessToJ2000(eq Equatorial, t time.Time) Equatorial {
+	ra, dec := coord.Precess(eq.RA, eq.Dec, t, J2000)
+	return Equatorial{RA: ra, Dec: dec}
+}
+
+// PositionFrame selects the viewpoint of PositionAt.
+type PositionFrame int
+
+const (
+	// Geocentric positions are seen from the Earth's centre.
+	Geocentric PositionFrame = iota
+	// Topocentric positions are seen from the observer's location on the
+	// surface, including elevation. The shift (parallax) is up to ~1° for
+	// the Moon and under 9" for the Sun.
+	Topocentric
+)
+
+// String returns the frame name.
+func (f PositionFrame) String() string {
+	if f == Topocentric {
+		return "topocentric"
+	}
+	return "geocentric"
+}
+
+// Position is the location of a body in the sky at one instant, in the
+// frame it was requested in. RA/Dec are referred to the equinox of date.
+type Position struct {
+	Equatorial
+	Altitude   float64 // degrees above the horizon, geometric (no refraction)
+	Azimuth    float64 // degrees from north through east
+	DistanceKm float64 // distance from the Earth's centre or the observer
+	Frame      PositionFrame
+}
+
+// PositionAt returns the equatorial and horizontal position of body as seen
+// from loc at time t, either geocentric or topocentric.
+//
+// Internally the Moon's rise/set code always works topocentrically while
+// the Sun's uses geocentric altitudes; PositionAt applies one convention to
+// both so results can be compared directly with ephemerides such as JPL
+// Horizons (which reports airless topocentric values by default).
+func PositionAt(body Body, loc Coordinates, t time.Time, frame PositionFrame) (Position, error) {
+	if err := loc.Validate(); err != nil {
+		return Position{}, err
+	}
+	if err := checkRange(t); err != nil {
+		return Position{}, err
+	}
+	pos, ok := positionAt(body, loc, t, frame)
+	if !ok {
+		return Position{}, fmt.Errorf("unknown body %v", body)
+	}
+	return pos, nil
+}
+
+// positionAt is PositionAt without the input checks. It reports false for
+// an unknown body.
+func positionAt(body Body, loc Coordinates, t time.Time, frame PositionFrame) (Position, bool) {
+	eq, ok := geocentricEquatorial(body, t)
+	if !ok {
+		return Position{}, false
+	}
+	dist := geocentricDistanceKm(body, t)
+	lst := coord.LocalSiderealTime(loc.Lon, t.UTC())
+
+	if frame == Topocentric {
+		eq.RA, eq.Dec, dist = coord.Topocentric(eq.RA, eq.Dec, dist, loc.Lat, loc.Elevation, lst)
+	}
+
+	alt, az := coord.Horizontal(eq.RA, eq.Dec, loc.Lat, lst)
+
+	return Position{
+		Equatorial: eq,
+		Altitude:   alt,
+		Azimuth:    az,
+		DistanceKm: dist,
+		Frame:      frame,
+	}, true
+}
+
+// geocentricDistanceKm returns the distance of a supported body from the
+// Earth's centre at t.
+func geocentricDistanceKm(body Body, t time.Time) float64 {
+	const auKm = 149597870.7
+	switch body {
+	case Sun:
+		return sun.DistanceAU(t.UTC()) * auKm
+	case Moon:
+		return moon.GeocentricEquatorialWithDistanceApprox(t.UTC()).Distance
+	default:
+		return math.NaN()
+	}
+}
+
+// geocentricEquatorial returns the apparent geocentric RA/Dec of body at t,
+// referred to the equinox of date.
+func geocentricEquatorial(body Body, t time.Time) (Equatorial, bool) {
+	switch body {
+	case Sun:
+		eq := sun.GeocentricEquatorialApprox(t.UTC())
+		return Equatorial{RA: eq.RA, Dec: eq.Dec}, true
+	case Moon:
+		eq := moon.GeocentricEquatorialApprox(t.UTC())
+		return Equatorial{RA: eq.RA, Dec: eq.Dec}, true
+	default:
+		return Equatorial{}, false
+	}
+}
@@ -0,0 +1,202 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+func TestAngularSeparation_MatchesElongation(t *testing.T) {
+	tm := time.Date(2025, time.May, 4, 13, 52, 0, 0, time.UTC)
+
+	phase, err := MoonPhaseAt(tm)
+	if err != nil {
+		t.Fatalf("MoonPhaseAt error: %v", err)
+	}
+
+	sep := AngularSeparation(Sun, Moon, tm)
+	if math.Abs(sep-phase.Elongation) > 1e-9 {
+		t.Errorf("AngularSeparation(Sun, Moon) = %.6f, elongation = %.6f", sep, phase.Elongation)
+	}
+	if rev := AngularSeparation(Moon, Sun, tm); math.Abs(rev-sep) > 1e-12 {
+		t.Errorf("separation not symmetric: %.9f vs %.9f", rev, sep)
+	}
+	if self := AngularSeparation(Sun, Sun, tm); self > 1e-6 {
+		t.Errorf("AngularSeparation(Sun, Sun) = %.9f, want 0", self)
+	}
+	if bad := AngularSeparation(Body(99), Sun, tm); !math.IsNaN(bad) {
+		t.Errorf("unknown body separation = %v, want NaN", bad)
+	}
+}
+
+func TestAngularSeparationEquatorial(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Equatorial
+		want float64
+	}{
+		{"same point", Equatorial{RA: 10, Dec: 20}, Equatorial{RA: 10, Dec: 20}, 0},
+		{"poles", Equatorial{RA: 0, Dec: 90}, Equatorial{RA: 123, Dec: -90}, 180},
+		{"equator quarter", Equatorial{RA: 0, Dec: 0}, Equatorial{RA: 90, Dec: 0}, 90},
+		{"RA wraps", Equatorial{RA: 359, Dec: 0}, Equatorial{RA: 1, Dec: 0}, 2},
+		// Arcturus to Spica, a classic textbook example (Meeus ex. 17.a).
+		{"Arcturus-Spica", Equatorial{RA: 213.9154, Dec: 19.1825}, Equatorial{RA: 201.2983, Dec: -11.1614}, 32.7930},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AngularSeparationEquatorial(tt.a, tt.b)
+			if math.Abs(got-tt.want) > 1e-3 {
+				t.Errorf("AngularSeparationEquatorial = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrecessToDate_Meeus(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 21.b: θ Persei (J2000 position
+	// with proper motion applied) precessed to 2028 Nov 13.19.
+	j2000 := Equatorial{RA: 41.054063, Dec: 49.227750}
+	tm := time.Date(2028, time.November, 13, 4, 33, 36, 0, time.UTC)
+
+	got := PrecessToDate(j2000, tm)
+	if math.Abs(got.RA-41.547214) > 1e-4 || math.Abs(got.Dec-49.348483) > 1e-4 {
+		t.Errorf("PrecessToDate = (%.6f, %.6f), want (41.547214, 49.348483)", got.RA, got.Dec)
+	}
+
+	back := PrecessToJ2000(got, tm)
+	if AngularSeparationEquatorial(back, j2000) > 1e-6 {
+		t.Errorf("round trip = (%.6f, %.6f), want (%.6f, %.6f)", back.RA, back.Dec, j2000.RA, j2000.Dec)
+	}
+}
+
+func TestEquatorialJ2000_Sun(t *testing.T) {
+	// 25 years after J2000 general precession has moved the equinox by
+	// about 25 * 50.3" ≈ 0.35°; near the March equinox this shows up almost
+	// entirely in RA.
+	tm := time.Date(2025, time.March, 20, 9, 1, 0, 0, time.UTC)
+
+	ofDate, err := EquatorialOfDate(Sun, tm)
+	if err != nil {
+		t.Fatalf("EquatorialOfDate error: %v", err)
+	}
+	j2000, err := EquatorialJ2000(Sun, tm)
+	if err != nil {
+		t.Fatalf("EquatorialJ2000 error: %v", err)
+	}
+
+	shift := AngularSeparationEquatorial(ofDate, j2000)
+	if math.Abs(shift-0.35) > 0.02 {
+		t.Errorf("J2000 vs of-date shift = %.4f°, want ~0.35°", shift)
+	}
+
+	if _, err := EquatorialJ2000(Body(99), tm); err == nil {
+		t.Error("expected error for unknown body")
+	}
+}
+
+func TestPositionAt_Frames(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740, Elevation: 331}
+	tm := time.Date(2025, time.May, 4, 3, 0, 0, 0, time.UTC) // Moon high in the evening sky
+
+	geo, err := PositionAt(Moon, phx, tm, Geocentric)
+	if err != nil {
+		t.Fatalf("PositionAt error: %v", err)
+	}
+	topo, err := PositionAt(Moon, phx, tm, Topocentric)
+	if err != nil {
+		t.Fatalf("PositionAt error: %v", err)
+	}
+
+	// Lunar parallax lowers the Moon by roughly HP·cos(alt), HP ≈ 0.9–1.0°.
+	drop := geo.Altitude - topo.Altitude
+	wantDrop := 0.95 * math.Cos(geo.Altitude*math.Pi/180)
+	if math.Abs(drop-wantDrop) > 0.1 {
+		t.Errorf("parallax drop = %.3f°, want ~%.3f° (geo alt %.2f°)", drop, wantDrop, geo.Altitude)
+	}
+	if topo.DistanceKm >= geo.DistanceKm {
+		t.Errorf("topocentric distance %.0f km not less than geocentric %.0f km for a risen Moon",
+			topo.DistanceKm, geo.DistanceKm)
+	}
+
+	// Geocentric altitudes match the Sun model used by the rise/set code.
+	sunGeo, err := PositionAt(Sun, phx, tm, Geocentric)
+	if err != nil {
+		t.Fatalf("PositionAt error: %v", err)
+	}
+	if want := sun.AltitudeAt(phx.Lat, phx.Lon, tm); math.Abs(sunGeo.Altitude-want) > 1e-6 {
+		t.Errorf("Sun geocentric altitude = %.6f, want %.6f", sunGeo.Altitude, want)
+	}
+	sunTopo, _ := PositionAt(Sun, phx, tm, Topocentric)
+	if d := AngularSeparationEquatorial(sunGeo.Equatorial, sunTopo.Equatorial); d > 9.0/3600 {
+		t.Errorf("solar parallax = %.2f\", want under 9\"", d*3600)
+	}
+}
+
+func TestPositionAt_AzimuthAtSolarNoon(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	noon, err := SolarNoon(phx, time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SolarNoon error: %v", err)
+	}
+
+	pos, err := PositionAt(Sun, phx, noon, Topocentric)
+	if err != nil {
+		t.Fatalf("PositionAt error: %v", err)
+	}
+	if math.Abs(pos.Azimuth-180) > 0.1 {
+		t.Errorf("azimuth at solar noon = %.3f°, want 180°", pos.Azimuth)
+	}
+	// Noon altitude at the June solstice is 90 - (lat - 23.44).
+	if want := 90 - (phx.Lat - 23.44); math.Abs(pos.Altitude-want) > 0.1 {
+		t.Errorf("altitude at solar noon = %.3f°, want %.3f°", pos.Altitude, want)
+	}
+}
+
+func TestParallacticAngle(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	noon, err := SolarNoon(phx, time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SolarNoon error: %v", err)
+	}
+	for _, c := range []struct {
+		offset   time.Duration
+		min, max float64
+	}{
+		{-3 * time.Hour, -180, -1},
+		{0, -1, 1},
+		{3 * time.Hour, 1, 180},
+	} {
+		tm := noon.Add(c.offset)
+		eq, _ := EquatorialOfDate(Sun, tm)
+		q, err := ParallacticAngle(phx, eq.RA, eq.Dec, tm)
+		if err != nil {
+			t.Fatalf("ParallacticAngle error: %v", err)
+		}
+		if q < c.min || q > c.max {
+			t.Errorf("parallactic angle %v from noon = %.3f°, want in [%v, %v]", c.offset, q, c.min, c.max)
+		}
+	}
+
+	// It agrees with the angle MoonOrientationAt reports.
+	tm := time.Date(2025, 4, 1, 2, 30, 0, 0, time.UTC)
+	o, err := MoonOrientationAt(phx, tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos, _ := PositionAt(Moon, phx, tm, Topocentric)
+	if q, _ := ParallacticAngle(phx, pos.RA, pos.Dec, tm); math.Abs(q-o.ParallacticAngle) > 1e-9 {
+		t.Errorf("ParallacticAngle = %.6f°, MoonOrientationAt = %.6f°", q, o.ParallacticAngle)
+	}
+
+	// At the pole the angle is undefined but stays finite.
+	pole := Coordinates{Lat: 90, Lon: 0}
+	if q, err := ParallacticAngle(pole, 10, 20, tm); err != nil || math.IsNaN(q) || math.IsInf(q, 0) {
+		t.Errorf("ParallacticAngle at the pole = %v, %v", q, err)
+	}
+	if _, err := ParallacticAngle(Coordinates{Lat: 91}, 0, 0, tm); err == nil {
+		t.Error("ParallacticAngle accepted latitude 91")
+	}
+}
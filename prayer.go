@@ -0,0 +1,145 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// AsrJuristic selects the shadow ratio that defines the start of Asr.
+type AsrJuristic int
+
+const (
+	// AsrStandard (Shafi'i, Maliki, Hanbali): an object's shadow equals its
+	// length plus its shadow at noon.
+	AsrStandard AsrJuristic = iota
+
+	// AsrHanafi: an object's shadow equals twice its length plus its shadow
+	// at noon.
+	AsrHanafi
+)
+
+// CalculationMethod describes how Fajr, Maghrib and Isha are defined.
+// Angles are solar depressions below the horizon in degrees.
+type CalculationMethod struct {
+	Name string
+
+	FajrAngle float64 // Sun's depression at Fajr
+	IshaAngle float64 // Sun's depression at Isha; ignored if IshaOffset is set
+
+	// IshaOffset, when non-zero, places Isha a fixed interval after Maghrib
+	// (e.g. 90 minutes for Umm al-Qura).
+	IshaOffset time.Duration
+
+	// MaghribAngle, when non-zero, places Maghrib at this solar depression
+	// instead of at sunset (used by Shia methods).
+	MaghribAngle float64
+
+	Asr AsrJuristic
+}
+
+// Common calculation method presets. Asr defaults to AsrStandard; copy a
+// preset and set Asr to AsrHanafi where appropriate.
+var (
+	MethodMWL = CalculationMethod{Name: "Muslim World League", FajrAngle: 18, IshaAngle: 17}
+
+	MethodISNA = CalculationMethod{Name: "Islamic Society of North America", FajrAngle: 15, IshaAngle: 15}
+
+	MethodEgypt = CalculationMethod{Name: "Egyptian General Authority of Survey", FajrAngle: 19.5, IshaAngle: 17.5}
+
+	MethodUmmAlQura = CalculationMethod{Name: "Umm al-Qura University, Makkah", FajrAngle: 18.5, IshaOffset: 90 * time.Minute}
+
+	MethodKarachi = CalculationMethod{Name: "University of Islamic Sciences, Karachi", FajrAngle: 18, IshaAngle: 18}
+
+	MethodTehran = CalculationMethod{Name: "Institute of Geophysics, University of Tehran", FajrAngle: 17.7, IshaAngle: 14, MaghribAngle: 4.5}
+
+	MethodJafari = CalculationMethod{Name: "Shia Ithna-Ashari, Leva Institute, Qum", FajrAngle: 16, IshaAngle: 14, MaghribAngle: 4}
+)
+
+// PrayerTimes holds the daily prayer times for one local calendar date.
+// A time whose defining solar depression is not reached on that date (e.g.
+// Fajr and Isha during high-latitude summers) is left as the zero time.
+type PrayerTimes struct {
+	Fajr    time.Time
+	Sunrise time.Time
+	Dhuhr   time.Time
+	Asr     time.Time
+	Maghrib time.Time
+	Isha    time.Time
+}
+
+// PrayerTimesFor computes Fajr, Sunrise, Dhuhr, Asr, Maghrib and Isha for
+// the given local calendar date and location using method.
+//
+// Dhuhr is solar noon. Asr is the afternoon moment when the Sun's altitude
+// makes shadows reach the juristic ratio. If the Sun does not rise or set on
+// the date, ErrNoRiseNoSet is returned.
+func PrayerTimesFor(loc Coordinates, date time.Time, method CalculationMethod) (PrayerTimes, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return PrayerTimes{}, err
+	}
+
+	rs, err := SlideIntoSunset(loc, date)
+	if err != nil {
+		return PrayerTimes{}, err
+	}
+	if !rs.HasRise || !rs.HasSet {
+		return PrayerTimes{}, noEvent(Sun, "rise/set", loc, date)
+	}
+
+	noon, err := SolarNoon(loc, date)
+	if err != nil {
+		return PrayerTimes{}, err
+	}
+
+	pt := PrayerTimes{
+		Sunrise: rs.Rise,
+		Dhuhr:   noon,
+		Maghrib: rs.Set,
+	}
+
+	if fajr, ok, _ := sunAltitudeCrossings(loc, date, -method.FajrAngle); ok {
+		pt.Fajr = fajr.Rise
+	}
+
+	if asr, _, ok := sunAltitudeCrossings(loc, date, asrAltitude(loc, noon, method.Asr)); ok {
+		pt.Asr = asr.Set
+	}
+
+	if method.MaghribAngle != 0 {
+		pt.Maghrib = time.Time{}
+		if m, _, ok := sunAltitudeCrossings(loc, date, -method.MaghribAngle); ok {
+			pt.Maghrib = m.Set
+		}
+	}
+
+	switch {
+	case method.IshaOffset != 0:
+		if !pt.Maghrib.IsZero() {
+			pt.Isha = pt.Maghrib.Add(method.IshaOffset)
+		}
+	default:
+		if isha, _, ok := sunAltitudeCrossings(loc, date, -method.IshaAngle); ok {
+			pt.Isha = isha.Set
+		}
+	}
+
+	return pt, nil
+}
+
+// asrAltitude returns the solar altitude (degrees) at which Asr begins: the
+// altitude where an object's shadow is `factor` times its length plus its
+// noon shadow.
+func asrAltitude(loc Coordinates, noon time.Time, juristic AsrJuristic) float64 {
+	factor := 1.0
+	if juristic == AsrHanafi {
+		factor = 2.0
+	}
+
+	dec := sun.GeocentricEquatorialApprox(noon.UTC()).Dec
+	noonShadow := math.Tan(timeutil.Deg2Rad(math.Abs(loc.Lat - dec)))
+
+	return timeutil.Rad2Deg(math.Atan(1 / (factor + noonShadow)))
+}
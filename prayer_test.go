@@ -0,0 +1,95 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPrayerTimesFor_Makkah checks Umm al-Qura times for Makkah against
+// published timetables for 2025-03-20, rounded to the minute (local time,
+// Asia/Riyadh):
+//
+//	Fajr 05:08, Sunrise 06:24, Dhuhr 12:27, Asr 15:51, Maghrib 18:31, Isha 20:01
+func TestPrayerTimesFor_Makkah(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Riyadh")
+	if err != nil {
+		t.Fatalf("failed to load Asia/Riyadh: %v", err)
+	}
+	coords := Coordinates{Lat: 21.4225, Lon: 39.8262}
+	date := time.Date(2025, time.March, 20, 0, 0, 0, 0, loc)
+
+	pt, err := PrayerTimesFor(coords, date, MethodUmmAlQura)
+	if err != nil {
+		t.Fatalf("PrayerTimesFor error: %v", err)
+	}
+
+	hm := func(h, m int) time.Time { return time.Date(2025, time.March, 20, h, m, 0, 0, loc) }
+	cases := []struct {
+		name      string
+		got, want time.Time
+	}{
+		{"Fajr", pt.Fajr, hm(5, 8)},
+		{"Sunrise", pt.Sunrise, hm(6, 24)},
+		{"Dhuhr", pt.Dhuhr, hm(12, 27)},
+		{"Asr", pt.Asr, hm(15, 51)},
+		{"Maghrib", pt.Maghrib, hm(18, 31)},
+		{"Isha", pt.Isha, hm(20, 1)},
+	}
+
+	const tolMinutes = 3.0
+	for _, c := range cases {
+		if d := diffMinutes(c.got, c.want); d > tolMinutes {
+			t.Errorf("%s = %s, want ~%s (off by %.1f min)", c.name, c.got.Format("15:04"), c.want.Format("15:04"), d)
+		}
+	}
+
+	if got := pt.Isha.Sub(pt.Maghrib); got != 90*time.Minute {
+		t.Errorf("Umm al-Qura Isha is %v after Maghrib, want 90m", got)
+	}
+}
+
+func TestPrayerTimesFor_HanafiAsrIsLater(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Karachi")
+	if err != nil {
+		t.Fatalf("failed to load Asia/Karachi: %v", err)
+	}
+	coords := Coordinates{Lat: 24.8607, Lon: 67.0011}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, loc)
+
+	standard, err := PrayerTimesFor(coords, date, MethodKarachi)
+	if err != nil {
+		t.Fatalf("PrayerTimesFor error: %v", err)
+	}
+
+	hanafi := MethodKarachi
+	hanafi.Asr = AsrHanafi
+	later, err := PrayerTimesFor(coords, date, hanafi)
+	if err != nil {
+		t.Fatalf("PrayerTimesFor(Hanafi) error: %v", err)
+	}
+
+	if d := later.Asr.Sub(standard.Asr); d < 30*time.Minute || d > 2*time.Hour {
+		t.Errorf("Hanafi Asr is %v after standard Asr, expected roughly an hour", d)
+	}
+}
+
+func TestPrayerTimesFor_HighLatitudeSummer(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed to load Europe/London: %v", err)
+	}
+	coords := Coordinates{Lat: 51.5074, Lon: -0.1278}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, loc)
+
+	// At 51.5°N the Sun never gets 18° below the horizon around midsummer.
+	pt, err := PrayerTimesFor(coords, date, MethodMWL)
+	if err != nil {
+		t.Fatalf("PrayerTimesFor error: %v", err)
+	}
+	if !pt.Fajr.IsZero() || !pt.Isha.IsZero() {
+		t.Errorf("expected no Fajr/Isha at 18°/17° in London midsummer, got %v / %v", pt.Fajr, pt.Isha)
+	}
+	if pt.Sunrise.IsZero() || pt.Asr.IsZero() || pt.Maghrib.IsZero() {
+		t.Errorf("daytime prayers should still be defined: %+v", pt)
+	}
+}
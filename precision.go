@@ -0,0 +1,81 @@
+package astroglide
+
+import (
+	"fmt"
+	"time"
+)
+
+// Precision selects the trade-off between speed and accuracy of a
+// calculation.
+type Precision int
+
+const (
+	// Level1 uses closed-form solutions where they exist, such as the
+	// NOAA hour-angle formula for solar rise/set and twilight. It is
+	// roughly ten times faster than Level2 and good to about a minute
+	// outside the polar regions; it is meant for bulk work such as
+	// year-long tables.
+	Level1 Precision = iota + 1
+
+	// Level2 samples the altitude across the day and refines each crossing
+	// numerically. It is the default.
+	Level2
+
+	// Level3 is reserved for the planned high-precision (ephemeris-grade)
+	// series. Until they land it runs the Level2 models, so code can ask
+	// for it today and become more precise later without changes.
+	Level3
+)
+
+// String returns the level name, e.g. "Level1".
+func (p Precision) String() string {
+	switch p {
+	case Level1:
+		return "Level1"
+	case Level2:
+		return "Level2"
+	case Level3:
+		return "Level3"
+	default:
+		return fmt.Sprintf("Precision(%d)", int(p))
+	}
+}
+
+// WithPrecision selects the calculation level for the functions that accept
+// options: the rise/set, twilight and golden/blue hour families and
+// MoonPhaseAt. The Moon has no closed-form rise/set solution at this
+// accuracy, so lunar rise and set always use Level2.
+func WithPrecision(p Precision) Option {
+	return func(c *config) {
+		c.precision = p
+	}
+}
+
+// level returns the effective precision of c.
+func (c config) level() Precision {
+	if c.precision == 0 {
+		return Level2
+	}
+	return c.precision
+}
+
+// validatePrecision rejects unknown precision levels.
+func (c config) validatePrecision() error {
+	if c.precision != 0 && (c.precision < Level1 || c.precision > Level3) {
+		return fmt.Errorf("unknown %v", c.precision)
+	}
+	return nil
+}
+
+// sunCrossings returns the upward and downward crossings (UTC) of targetAlt
+// by the Sun's center on the local calendar date, using the solver that
+// matches c's precision.
+func (c config) sunCrossings(loc Coordinates, date time.Time, targetAlt float64) (up, down time.Time, okUp, okDown bool) {
+	loc, date, dt := c.ephemerisFrame(loc, date)
+	if c.level() == Level1 {
+		up, down, okUp, okDown = c.sunCache.HourAngleEventsForDate(loc.Lat, loc.Lon, date, targetAlt)
+	} else {
+		up, down, okUp, okDown = c.sunCache.TwilightForDate(loc.Lat, loc.Lon, date, targetAlt)
+	}
+	return fromEphemeris(up, okUp, dt), fromEphemeris(down, okDown, dt), okUp, okDown
+}
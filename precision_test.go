@@ -0,0 +1,113 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithPrecision_Level1MatchesSolver(t *testing.T) {
+	places := []struct {
+		name string
+		tz   string
+		loc  Coordinates
+	}{
+		{"Phoenix", "America/Phoenix", Coordinates{Lat: 33.4484, Lon: -112.0740}},
+		{"Sydney", "Australia/Sydney", Coordinates{Lat: -33.8688, Lon: 151.2093}},
+		{"Quito", "America/Guayaquil", Coordinates{Lat: -0.1807, Lon: -78.4678}},
+		{"Oslo", "Europe/Oslo", Coordinates{Lat: 59.9139, Lon: 10.7522}},
+	}
+
+	for _, p := range places {
+		tz, err := time.LoadLocation(p.tz)
+		if err != nil {
+			t.Fatalf("failed to load location %s: %v", p.tz, err)
+		}
+
+		for m := time.January; m <= time.December; m++ {
+			date := time.Date(2025, m, 15, 0, 0, 0, 0, tz)
+
+			want, err := SlideIntoSunset(p.loc, date)
+			if err != nil {
+				t.Fatalf("%s %s: Level2 error: %v", p.name, date.Format("2006-01-02"), err)
+			}
+			got, err := SlideIntoSunset(p.loc, date, WithPrecision(Level1))
+			if err != nil {
+				t.Fatalf("%s %s: Level1 error: %v", p.name, date.Format("2006-01-02"), err)
+			}
+
+			if got.HasRise != want.HasRise || got.HasSet != want.HasSet {
+				t.Errorf("%s %s: flags %v/%v, want %v/%v", p.name, date.Format("2006-01-02"),
+					got.HasRise, got.HasSet, want.HasRise, want.HasSet)
+				continue
+			}
+			// The sampled solver stops within 30s of the crossing.
+			if d := got.Rise.Sub(want.Rise); d < -time.Minute || d > time.Minute {
+				t.Errorf("%s %s: Level1 sunrise %v vs %v", p.name, date.Format("2006-01-02"), got.Rise, want.Rise)
+			}
+			if d := got.Set.Sub(want.Set); d < -time.Minute || d > time.Minute {
+				t.Errorf("%s %s: Level1 sunset %v vs %v", p.name, date.Format("2006-01-02"), got.Set, want.Set)
+			}
+		}
+	}
+}
+
+func TestWithPrecision_Level1PolarDay(t *testing.T) {
+	tromso := Coordinates{Lat: 69.6492, Lon: 18.9553}
+	_, err := SlideIntoSunset(tromso, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC), WithPrecision(Level1))
+	if err == nil {
+		t.Fatal("Level1 found sunrise/sunset at Tromsø midsummer, want error")
+	}
+}
+
+func BenchmarkSlideIntoSunset_Level2(b *testing.B) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = SlideIntoSunset(phx, date)
+	}
+}
+
+func BenchmarkSlideIntoSunset_Level1(b *testing.B) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = SlideIntoSunset(phx, date, WithPrecision(Level1))
+	}
+}
+
+func TestWithPrecision_MoonPhaseAt(t *testing.T) {
+	start := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	for h := 0; h < 30*24; h += 7 {
+		at := start.Add(time.Duration(h) * time.Hour)
+
+		want, err := MoonPhaseAt(at)
+		if err != nil {
+			t.Fatalf("MoonPhaseAt error: %v", err)
+		}
+		fast, err := MoonPhaseAt(at, WithPrecision(Level1))
+		if err != nil {
+			t.Fatalf("MoonPhaseAt(Level1) error: %v", err)
+		}
+		if d := fast.Fraction - want.Fraction; d < -0.01 || d > 0.01 {
+			t.Errorf("%s: Level1 fraction %.4f, Level2 %.4f", at.Format(time.RFC3339), fast.Fraction, want.Fraction)
+		}
+		// Waxing flips at new and full moon; allow disagreement right there.
+		if fast.Waxing != want.Waxing && want.Fraction > 0.02 && want.Fraction < 0.98 {
+			t.Errorf("%s: Level1 waxing %v, Level2 %v", at.Format(time.RFC3339), fast.Waxing, want.Waxing)
+		}
+
+		// Level3 currently falls back to Level2.
+		full, err := MoonPhaseAt(at, WithPrecision(Level3))
+		if err != nil {
+			t.Fatalf("MoonPhaseAt(Level3) error: %v", err)
+		}
+		if full != want {
+			t.Errorf("%s: Level3 = %+v, want Level2 %+v", at.Format(time.RFC3339), full, want)
+		}
+	}
+
+	if _, err := MoonPhaseAt(start, WithPrecision(Precision(9))); err == nil {
+		t.Error("unknown precision accepted, want error")
+	}
+}
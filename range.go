@@ -0,0 +1,107 @@
+package astroglide
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// The Sun and Moon models are truncated series fitted around J2000. Within
+// this range their errors stay at the documented level (about a minute for
+// sunrise/sunset); outside it they degrade without any visible symptom, so
+// public functions refuse such dates instead.
+var (
+	MinSupportedTime = time.Date(1800, time.January, 1, 0, 0, 0, 0, time.UTC)
+	MaxSupportedTime = time.Date(2200, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// ErrOutOfRange is returned (wrapped in an *OutOfRangeError) when a time
+// lies outside [MinSupportedTime, MaxSupportedTime).
+var ErrOutOfRange = errors.New("time outside supported range")
+
+// OutOfRangeError reports a time outside the supported range. It matches
+// ErrOutOfRange with errors.Is.
+type OutOfRangeError struct {
+	Time     time.Time
+	Min, Max time.Time
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("%s: %s is not in [%s, %s)", ErrOutOfRange,
+		e.Time.Format(time.RFC3339), e.Min.Format("2006-01-02"), e.Max.Format("2006-01-02"))
+}
+
+// Unwrap returns ErrOutOfRange.
+func (e *OutOfRangeError) Unwrap() error {
+	return ErrOutOfRange
+}
+
+// checkRange returns an *OutOfRangeError if t is outside the supported range.
+func checkRange(t time.Time) error {
+	if t.Before(MinSupportedTime) || !t.Before(MaxSupportedTime) {
+		return &OutOfRangeError{Time: t, Min: MinSupportedTime, Max: MaxSupportedTime}
+	}
+	return nil
+}
+
+// Elevations accepted by Coordinates.Validate: from well below the Dead Sea
+// shore (about -430 m) up to the edge of space.
+const (
+	MinElevation = -1000.0
+	MaxElevation = 100000.0
+)
+
+// ErrInvalidCoordinates is returned (wrapped in a *CoordinateError) when an
+// observer's coordinates are not finite or are out of range.
+var ErrInvalidCoordinates = errors.New("invalid coordinates")
+
+// CoordinateError reports the first invalid field of a Coordinates value.
+// It matches ErrInvalidCoordinates with errors.Is.
+type CoordinateError struct {
+	Field string  // "Lat", "Lon" or "Elevation"
+	Value float64 // the offending value (may be NaN or ±Inf)
+	Min   float64 // allowed range, inclusive
+	Max   float64
+}
+
+func (e *CoordinateError) Error() string {
+	if math.IsNaN(e.Value) || math.IsInf(e.Value, 0) {
+		return fmt.Sprintf("%s: %s is %v", ErrInvalidCoordinates, e.Field, e.Value)
+	}
+	return fmt.Sprintf("%s: %s %g is not in [%g, %g]", ErrInvalidCoordinates, e.Field, e.Value, e.Min, e.Max)
+}
+
+// Unwrap returns ErrInvalidCoordinates.
+func (e *CoordinateError) Unwrap() error {
+	return ErrInvalidCoordinates
+}
+
+// Validate returns a *CoordinateError if Lat is outside [-90, 90], Lon is
+// outside [-180, 180], Elevation is outside [MinElevation, MaxElevation], or
+// any of them is NaN or infinite. Public functions call it before doing any
+// work, since out-of-range coordinates otherwise give plausible-looking but
+// meaningless times.
+func (c Coordinates) Validate() error {
+	for _, f := range []CoordinateError{
+		{Field: "Lat", Value: c.Lat, Min: -90, Max: 90},
+		{Field: "Lon", Value: c.Lon, Min: -180, Max: 180},
+		{Field: "Elevation", Value: c.Elevation, Min: MinElevation, Max: MaxElevation},
+	} {
+		// NaN fails both comparisons, so test for being inside the range.
+		if !(f.Value >= f.Min && f.Value <= f.Max) {
+			f := f
+			return &f
+		}
+	}
+	return nil
+}
+
+// checkInputs validates loc and then checks that t is in the supported
+// range.
+func checkInputs(loc Coordinates, t time.Time) error {
+	if err := loc.Validate(); err != nil {
+		return err
+	}
+	return checkRange(t)
+}
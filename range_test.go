@@ -0,0 +1,115 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOutOfRange(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	early := time.Date(1750, 6, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	calls := map[string]func(time.Time) error{
+		"RiseSetFor": func(d time.Time) error { _, err := RiseSetFor(Moon, phx, d); return err },
+		"TwilightFor": func(d time.Time) error {
+			_, err := TwilightFor(phx, d, TwilightCivil)
+			return err
+		},
+		"MoonPhaseAt": func(d time.Time) error { _, err := MoonPhaseAt(d); return err },
+		"ZmanimFor": func(d time.Time) error {
+			_, err := ZmanimFor(phx, d, DefaultZmanimConfig())
+			return err
+		},
+		"PositionAt":    func(d time.Time) error { _, err := PositionAt(Sun, phx, d, Topocentric); return err },
+		"SolarTermsFor": func(d time.Time) error { _, err := SolarTermsFor(d.Year()); return err },
+	}
+
+	for name, call := range calls {
+		for _, d := range []time.Time{early, late} {
+			err := call(d)
+			if !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("%s(%s): err = %v, want ErrOutOfRange", name, d.Format("2006-01-02"), err)
+				continue
+			}
+			var rangeErr *OutOfRangeError
+			if !errors.As(err, &rangeErr) || rangeErr.Time.Year() != d.Year() {
+				t.Errorf("%s(%s): err = %#v, want *OutOfRangeError for that year", name, d.Format("2006-01-02"), err)
+			}
+		}
+	}
+
+	// Both ends of the range are usable.
+	for _, d := range []time.Time{MinSupportedTime, MaxSupportedTime.Add(-24 * time.Hour)} {
+		if _, err := SlideIntoSunset(phx, d); err != nil {
+			t.Errorf("SlideIntoSunset(%s) error: %v", d.Format("2006-01-02"), err)
+		}
+	}
+}
+
+func TestCoordinatesValidate(t *testing.T) {
+	valid := []Coordinates{
+		{Lat: 33.4484, Lon: -112.0740, Elevation: 331},
+		{Lat: 90, Lon: 180},
+		{Lat: -90, Lon: -180},
+		{Lat: 31.5, Lon: 35.5, Elevation: -430}, // Dead Sea shore
+		{Lat: 27.9881, Lon: 86.9250, Elevation: 8849},
+	}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%+v.Validate() = %v, want nil", c, err)
+		}
+	}
+
+	invalid := []struct {
+		c     Coordinates
+		field string
+	}{
+		{Coordinates{Lat: 91, Lon: 0}, "Lat"},
+		{Coordinates{Lat: -90.5, Lon: 0}, "Lat"},
+		{Coordinates{Lat: 33, Lon: 248}, "Lon"},
+		{Coordinates{Lat: 33, Lon: -180.01}, "Lon"},
+		{Coordinates{Lat: math.NaN(), Lon: 0}, "Lat"},
+		{Coordinates{Lat: 0, Lon: math.Inf(1)}, "Lon"},
+		{Coordinates{Lat: 0, Lon: 0, Elevation: math.NaN()}, "Elevation"},
+		{Coordinates{Lat: 0, Lon: 0, Elevation: 331000}, "Elevation"},
+		{Coordinates{Lat: 0, Lon: 0, Elevation: -5000}, "Elevation"},
+	}
+	for _, tc := range invalid {
+		err := tc.c.Validate()
+		if !errors.Is(err, ErrInvalidCoordinates) {
+			t.Errorf("%+v.Validate() = %v, want ErrInvalidCoordinates", tc.c, err)
+			continue
+		}
+		var cerr *CoordinateError
+		if !errors.As(err, &cerr) || cerr.Field != tc.field {
+			t.Errorf("%+v.Validate() = %#v, want *CoordinateError for %s", tc.c, err, tc.field)
+		}
+	}
+}
+
+func TestInvalidCoordinatesRejected(t *testing.T) {
+	bad := Coordinates{Lat: 133.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+
+	calls := map[string]func() error{
+		"RiseSetFor":       func() error { _, err := RiseSetFor(Sun, bad, date); return err },
+		"TwilightFor":      func() error { _, err := TwilightFor(bad, date, TwilightCivil); return err },
+		"SolarNoon":        func() error { _, err := SolarNoon(bad, date); return err },
+		"GoldenHourFor":    func() error { _, err := GoldenHourFor(bad, date); return err },
+		"DaylightHours":    func() error { _, err := DaylightHours(bad, date); return err },
+		"NextEvent":        func() error { _, err := NextEvent(Moon, bad, date); return err },
+		"PositionAt":       func() error { _, err := PositionAt(Sun, bad, date, Topocentric); return err },
+		"PrayerTimesFor":   func() error { _, err := PrayerTimesFor(bad, date, MethodMWL); return err },
+		"ZmanimFor":        func() error { _, err := ZmanimFor(bad, date, DefaultZmanimConfig()); return err },
+		"SkyDarknessScore": func() error { _, err := SkyDarknessScore(bad, date); return err },
+		"Observer.RiseSet": func() error { _, err := NewObserver(bad, time.UTC).RiseSet(Sun, date); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrInvalidCoordinates) {
+			t.Errorf("%s: err = %v, want ErrInvalidCoordinates", name, err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package astroglide
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRiseSet_PresenceFlags(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	// 2025-01-20: the Moon sets before noon but its next rise slips past
+	// midnight, so only the set exists on this date.
+	rs, err := RiseSetFor(Moon, phx, time.Date(2025, 1, 20, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	if rs.HasRise || !rs.HasSet {
+		t.Fatalf("HasRise=%v HasSet=%v, want false/true", rs.HasRise, rs.HasSet)
+	}
+
+	b, err := json.Marshal(rs)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+	if got := string(b); !strings.Contains(got, `"Rise":null`) || strings.Contains(got, "0001-01-01") {
+		t.Errorf("JSON = %s, want null Rise and no zero time", got)
+	}
+	if want := `"Uncertainty":"` + rs.Uncertainty.String() + `"`; !strings.Contains(string(b), want) {
+		t.Errorf("JSON = %s, want %s", b, want)
+	}
+
+	var back RiseSet
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	if back.HasRise != rs.HasRise || back.HasSet != rs.HasSet || !back.Rise.IsZero() ||
+		!back.Set.Equal(rs.Set) || !back.Date.Equal(rs.Date) || back.Uncertainty != rs.Uncertainty {
+		t.Errorf("round trip = %+v, want %+v", back, rs)
+	}
+	if err := json.Unmarshal([]byte(`{"Uncertainty":"soon"}`), &back); err == nil {
+		t.Error("json.Unmarshal accepted an invalid Uncertainty")
+	}
+
+	// A normal sunrise/sunset day has both.
+	rs, err = SlideIntoSunset(phx, time.Date(2025, 1, 20, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("SlideIntoSunset error: %v", err)
+	}
+	if !rs.HasRise || !rs.HasSet {
+		t.Errorf("sunrise/sunset flags = %v/%v, want true/true", rs.HasRise, rs.HasSet)
+	}
+}
+
+func TestRiseSetFor_TrueInstants(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	nyc := Coordinates{Lat: 40.7128, Lon: -74.0060}
+
+	// 2026-03-08 is only 23 hours long (DST starts). The search covers
+	// just those 23 hours, so the 00:41 moonrise just after it belongs to
+	// the 9th and the 8th has only a moonset.
+	date := time.Date(2026, 3, 8, 0, 0, 0, 0, loc)
+
+	pinned, err := RiseSetFor(Moon, nyc, date)
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	exact, err := RiseSetFor(Moon, nyc, date, WithTrueInstants())
+	if err != nil {
+		t.Fatalf("RiseSetFor(WithTrueInstants) error: %v", err)
+	}
+
+	if exact.HasRise || pinned.HasRise {
+		t.Errorf("moonrise on 2026-03-08 = %v / %v, want none", exact.Rise, pinned.Rise)
+	}
+	if !exact.HasSet || !exact.Set.Equal(pinned.Set) {
+		t.Errorf("moonset differs: pinned %v, exact %v", pinned.Set, exact.Set)
+	}
+
+	next, err := RiseSetFor(Moon, nyc, date.AddDate(0, 0, 1), WithTrueInstants())
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	if got := next.Rise.Format("2006-01-02 15:04"); !next.HasRise || got != "2026-03-09 00:40" {
+		t.Errorf("moonrise on 2026-03-09 = %v, want about 00:41", next.Rise)
+	}
+	for _, rs := range []RiseSet{pinned, exact} {
+		if !rs.Date.Equal(date) {
+			t.Errorf("Date = %v, want %v", rs.Date, date)
+		}
+	}
+}
+
+func TestRiseSetFor_LimbAndZenith(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, loc)
+
+	upper, err := SlideIntoSunset(phx, date)
+	if err != nil {
+		t.Fatalf("SlideIntoSunset error: %v", err)
+	}
+	explicit, err := SlideIntoSunset(phx, date, WithZenith(90.833))
+	if err != nil {
+		t.Fatalf("WithZenith error: %v", err)
+	}
+	if !explicit.Rise.Equal(upper.Rise) || !explicit.Set.Equal(upper.Set) {
+		t.Errorf("WithZenith(90.833) = %v/%v, want default %v/%v", explicit.Rise, explicit.Set, upper.Rise, upper.Set)
+	}
+
+	center, err := SlideIntoSunset(phx, date, WithLimb(LimbCenter))
+	if err != nil {
+		t.Fatalf("WithLimb(LimbCenter) error: %v", err)
+	}
+	lower, err := SlideIntoSunset(phx, date, WithLimb(LimbLower))
+	if err != nil {
+		t.Fatalf("WithLimb(LimbLower) error: %v", err)
+	}
+	// Each step down the disk moves sunrise later and sunset earlier by
+	// roughly a minute at this latitude.
+	if !(upper.Rise.Before(center.Rise) && center.Rise.Before(lower.Rise)) {
+		t.Errorf("sunrise order upper/center/lower = %v/%v/%v", upper.Rise, center.Rise, lower.Rise)
+	}
+	if !(upper.Set.After(center.Set) && center.Set.After(lower.Set)) {
+		t.Errorf("sunset order upper/center/lower = %v/%v/%v", upper.Set, center.Set, lower.Set)
+	}
+	if d := lower.Rise.Sub(upper.Rise); d < time.Minute || d > 5*time.Minute {
+		t.Errorf("lower-limb sunrise is %v after upper-limb, want a few minutes", d)
+	}
+
+	// A 96° zenith is the end of civil twilight.
+	civil, err := TwilightFor(phx, date, TwilightCivil)
+	if err != nil {
+		t.Fatalf("TwilightFor error: %v", err)
+	}
+	z96, err := SlideIntoSunset(phx, date, WithZenith(96))
+	if err != nil {
+		t.Fatalf("WithZenith(96) error: %v", err)
+	}
+	if d := z96.Set.Sub(civil.Set); d < -time.Minute || d > time.Minute {
+		t.Errorf("WithZenith(96) set = %v, civil dusk = %v", z96.Set, civil.Set)
+	}
+
+	moonUpper, err := RiseSetFor(Moon, phx, date)
+	if err != nil {
+		t.Fatalf("RiseSetFor(Moon) error: %v", err)
+	}
+	moonCenter, err := RiseSetFor(Moon, phx, date, WithLimb(LimbCenter))
+	if err != nil {
+		t.Fatalf("RiseSetFor(Moon, LimbCenter) error: %v", err)
+	}
+	if moonUpper.HasRise && moonCenter.HasRise && !moonCenter.Rise.After(moonUpper.Rise) {
+		t.Errorf("Moon center rise %v not after upper-limb rise %v", moonCenter.Rise, moonUpper.Rise)
+	}
+
+	if _, err := SlideIntoSunset(phx, date, WithZenith(200)); err == nil {
+		t.Error("WithZenith(200) succeeded, want error")
+	}
+}
+
+func TestRiseSetFor_Rounding(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.FixedZone("MST", -7*3600))
+
+	exact, err := RiseSetFor(Sun, phx, date)
+	if err != nil {
+		t.Fatalf("RiseSetFor error: %v", err)
+	}
+	for _, d := range []time.Duration{time.Second, time.Minute} {
+		rounded, err := RiseSetFor(Sun, phx, date, WithRounding(d))
+		if err != nil {
+			t.Fatalf("WithRounding(%v) error: %v", d, err)
+		}
+		for _, p := range [][2]time.Time{{exact.Rise, rounded.Rise}, {exact.Set, rounded.Set}} {
+			if !p[1].Equal(p[0].Round(d)) || p[1].Location() != p[0].Location() {
+				t.Errorf("WithRounding(%v): %v, want %v", d, p[1], p[0].Round(d))
+			}
+		}
+	}
+
+	// Rounding is to the nearest minute, not truncation.
+	byMinute, _ := RiseSetFor(Sun, phx, date, WithRounding(time.Minute))
+	for _, p := range [][2]time.Time{{exact.Rise, byMinute.Rise}, {exact.Set, byMinute.Set}} {
+		if d := p[1].Sub(p[0]); d < -30*time.Second || d > 30*time.Second {
+			t.Errorf("%v rounded to %v", p[0], p[1])
+		}
+	}
+
+	win, err := GoldenHourFor(phx, date, WithRounding(time.Minute))
+	if err != nil {
+		t.Fatalf("GoldenHourFor error: %v", err)
+	}
+	for _, tm := range []time.Time{win.Morning.Start, win.Morning.End, win.Evening.Start, win.Evening.End} {
+		if tm.Second() != 0 || tm.Nanosecond() != 0 {
+			t.Errorf("golden hour bound %v not on a whole minute", tm)
+		}
+	}
+
+	for _, d := range []time.Duration{-time.Second, 2 * time.Hour} {
+		if _, err := RiseSetFor(Sun, phx, date, WithRounding(d)); err == nil {
+			t.Errorf("WithRounding(%v) accepted", d)
+		}
+	}
+}
+
+func TestRiseSetFor_Uncertainty(t *testing.T) {
+	date := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	uncertainty := func(body Body, lat float64, opts ...Option) time.Duration {
+		t.Helper()
+		rs, err := RiseSetFor(body, Coordinates{Lat: lat, Lon: -112.074}, date, opts...)
+		if err != nil {
+			t.Fatalf("RiseSetFor(%v, lat %v) error: %v", body, lat, err)
+		}
+		return rs.Uncertainty
+	}
+
+	sun := uncertainty(Sun, 33.4484)
+	if sun < time.Second || sun > 10*time.Second {
+		t.Errorf("Level2 sunrise uncertainty = %v, want a few seconds", sun)
+	}
+	if l1 := uncertainty(Sun, 33.4484, WithPrecision(Level1)); l1 < 30*time.Second || l1 > 2*time.Minute {
+		t.Errorf("Level1 sunrise uncertainty = %v, want about a minute", l1)
+	}
+	if moon := uncertainty(Moon, 33.4484); moon < 30*time.Second {
+		t.Errorf("moonrise uncertainty = %v, want at least the 30s solver tolerance", moon)
+	}
+	// The Sun rises at a shallow angle far from the equator.
+	if north := uncertainty(Sun, 65); north <= sun {
+		t.Errorf("uncertainty at 65°N = %v, want more than %v at 33°N", north, sun)
+	}
+	if rounded := uncertainty(Sun, 33.4484, WithRounding(time.Minute)); rounded != sun+30*time.Second {
+		t.Errorf("uncertainty with WithRounding(1m) = %v, want %v", rounded, sun+30*time.Second)
+	}
+
+	civil, err := TwilightFor(Coordinates{Lat: 33.4484, Lon: -112.074}, date, TwilightCivil)
+	if err != nil {
+		t.Fatalf("TwilightFor error: %v", err)
+	}
+	if civil.Uncertainty <= 0 || civil.Uncertainty > 10*time.Second {
+		t.Errorf("civil twilight uncertainty = %v, want a few seconds", civil.Uncertainty)
+	}
+}
@@ -0,0 +1,132 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/satellite"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// TLE is a NORAD two-line element set describing an Earth satellite's orbit,
+// as published by CelesTrak or Space-Track.
+type TLE struct {
+	Name  string // optional title line, e.g. "ISS (ZARYA)"
+	Line1 string
+	Line2 string
+}
+
+// SatellitePass describes one pass of a satellite above the observer's
+// geometric horizon.
+type SatellitePass struct {
+	Rise        time.Time // satellite crosses 0° elevation going up
+	RiseAzimuth float64   // degrees from north
+
+	Culmination        time.Time // instant of maximum elevation
+	MaxElevation       float64   // degrees
+	CulminationAzimuth float64   // degrees from north
+
+	Set        time.Time // satellite crosses 0° elevation going down
+	SetAzimuth float64   // degrees from north
+
+	// Visible is true when, at some point during the pass, the satellite is
+	// sunlit while the observer's sky is dark (Sun below -6°), i.e. it can
+	// be seen with the naked eye, weather permitting.
+	Visible bool
+}
+
+// SatellitePasses predicts the passes of a satellite over loc between start
+// and end using the SGP4 propagator. Passes already in progress at start or
+// still in progress at end are clipped to the search range. Returned times
+// are in start's time zone.
+//
+// Only near-Earth orbits (period under 225 minutes, which includes the ISS
+// and most LEO satellites) are supported; deep-space element sets return an
+// error.
+func SatellitePasses(tle TLE, loc Coordinates, start, end time.Time) ([]SatellitePass, error) {
+	if err := loc.Validate(); err != nil {
+		return nil, err
+	}
+	for _, t := range []time.Time{start, end} {
+		if err := checkRange(t); err != nil {
+			return nil, err
+		}
+	}
+
+	el, err := satellite.ParseElements(tle.Line1, tle.Line2)
+	if err != nil {
+		return nil, err
+	}
+	prop, err := satellite.NewPropagator(el)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := prop.Propagate(start); err != nil {
+		return nil, fmt.Errorf("satellite %q: %w", tle.Name, err)
+	}
+
+	tz := start.Location()
+
+	look := func(t time.Time) (satellite.LookAngles, satellite.Vector, bool) {
+		pos, _, err := prop.Propagate(t)
+		if err != nil {
+			return satellite.LookAngles{}, satellite.Vector{}, false
+		}
+		return satellite.Look(pos, t, loc.Lat, loc.Lon, loc.Elevation), pos, true
+	}
+
+	above := func(t time.Time) bool {
+		la, _, ok := look(t)
+		return ok && la.Elevation > 0
+	}
+
+	// LEO passes last a few minutes, so sample every 20 seconds.
+	const (
+		sampleStep = 20 * time.Second
+		tol        = time.Second
+	)
+	steps := int(end.Sub(start)/sampleStep) + 2
+
+	var passes []SatellitePass
+	for _, iv := range solver.FindIntervals(above, start, end, steps, tol) {
+		pass := SatellitePass{
+			Rise: iv.Start.In(tz),
+			Set:  iv.End.In(tz),
+		}
+
+		if la, _, ok := look(iv.Start); ok {
+			pass.RiseAzimuth = la.Azimuth
+		}
+		if la, _, ok := look(iv.End); ok {
+			pass.SetAzimuth = la.Azimuth
+		}
+
+		// Scan the pass for culmination and visibility.
+		pass.MaxElevation = math.Inf(-1)
+		for t := iv.Start; !t.After(iv.End); t = t.Add(5 * time.Second) {
+			la, pos, ok := look(t)
+			if !ok {
+				continue
+			}
+			if la.Elevation > pass.MaxElevation {
+				pass.MaxElevation = la.Elevation
+				pass.CulminationAzimuth = la.Azimuth
+				pass.Culmination = t.In(tz)
+			}
+			if !pass.Visible && sun.AltitudeAt(loc.Lat, loc.Lon, t) < -6 {
+				sEq := sun.GeocentricEquatorialApprox(t)
+				pass.Visible = satellite.Sunlit(pos, sEq.RA, sEq.Dec)
+			}
+		}
+		if math.IsInf(pass.MaxElevation, -1) {
+			return nil, fmt.Errorf("satellite %q: propagation failed during pass at %s", tle.Name, iv.Start)
+		}
+
+		passes = append(passes, pass)
+	}
+
+	return passes, nil
+}
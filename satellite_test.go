@@ -0,0 +1,90 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/satellite"
+)
+
+// vanguard1 is the SGP4 verification element set 00005 from Vallado et al.,
+// "Revisiting Spacetrack Report #3".
+var vanguard1 = TLE{
+	Name:  "VANGUARD 1",
+	Line1: "1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753",
+	Line2: "2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667",
+}
+
+func TestSGP4_VerificationVectors(t *testing.T) {
+	el, err := satellite.ParseElements(vanguard1.Line1, vanguard1.Line2)
+	if err != nil {
+		t.Fatalf("ParseElements error: %v", err)
+	}
+	prop, err := satellite.NewPropagator(el)
+	if err != nil {
+		t.Fatalf("NewPropagator error: %v", err)
+	}
+
+	// Reference TEME states (km, km/s) from the published tcppver.out.
+	cases := []struct {
+		tsince float64
+		r, v   satellite.Vector
+	}{
+		{0, satellite.Vector{X: 7022.46529266, Y: -1400.08296755, Z: 0.03995155},
+			satellite.Vector{X: 1.893841015, Y: 6.405893759, Z: 4.534807250}},
+		{360, satellite.Vector{X: -7154.03120202, Y: -3783.17682504, Z: -3536.19412294},
+			satellite.Vector{X: 4.741887409, Y: -4.151817765, Z: -2.093935425}},
+	}
+
+	for _, tc := range cases {
+		r, v, err := prop.PropagateMinutes(tc.tsince)
+		if err != nil {
+			t.Fatalf("t=%v: propagate error: %v", tc.tsince, err)
+		}
+		if d := math.Hypot(math.Hypot(r.X-tc.r.X, r.Y-tc.r.Y), r.Z-tc.r.Z); d > 1e-3 {
+			t.Errorf("t=%v: position %+v off by %.6f km from %+v", tc.tsince, r, d, tc.r)
+		}
+		if d := math.Hypot(math.Hypot(v.X-tc.v.X, v.Y-tc.v.Y), v.Z-tc.v.Z); d > 1e-6 {
+			t.Errorf("t=%v: velocity %+v off by %.9f km/s from %+v", tc.tsince, v, d, tc.v)
+		}
+	}
+}
+
+func TestSatellitePasses(t *testing.T) {
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740, Elevation: 331}
+	start := time.Date(2000, time.June, 28, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+
+	passes, err := SatellitePasses(vanguard1, coords, start, end)
+	if err != nil {
+		t.Fatalf("SatellitePasses error: %v", err)
+	}
+	if len(passes) == 0 {
+		t.Fatal("expected at least one pass in 48 hours")
+	}
+
+	for i, p := range passes {
+		if p.Rise.After(p.Culmination) || p.Culmination.After(p.Set) {
+			t.Errorf("pass %d out of order: rise %v, culmination %v, set %v", i, p.Rise, p.Culmination, p.Set)
+		}
+		if p.MaxElevation <= 0 || p.MaxElevation > 90 {
+			t.Errorf("pass %d has max elevation %.2f°", i, p.MaxElevation)
+		}
+		if i > 0 && !passes[i-1].Set.Before(p.Rise) {
+			t.Errorf("pass %d overlaps the previous one", i)
+		}
+	}
+}
+
+func TestSatellitePasses_DeepSpaceRejected(t *testing.T) {
+	// A geostationary element set (about one revolution per day).
+	geo := TLE{
+		Line1: "1 28884U 05041A   06176.50000000 -.00000148  00000-0  10000-3 0  1234",
+		Line2: "2 28884   0.0147 281.3500 0002143 115.6500 217.9800  1.00273000  1234",
+	}
+	start := time.Date(2006, time.June, 26, 0, 0, 0, 0, time.UTC)
+	if _, err := SatellitePasses(geo, Coordinates{}, start, start.Add(time.Hour)); err == nil {
+		t.Error("expected an error for a deep-space element set")
+	}
+}
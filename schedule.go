@@ -0,0 +1,119 @@
+package astroglide
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Routine is a daily routine of named steps fixed to the Sun or the clock,
+// such as "wake at civil dawn, warm the lights an hour before sunset, bed
+// at 22:30". Parse one with ParseRoutine and turn it into timestamps with
+// SolarSchedule.
+type Routine []RoutineStep
+
+// RoutineStep is one step of a Routine. At is an event expression such as
+// "sunset-1h" or a local clock time, as the ends of an HoursRule.
+type RoutineStep struct {
+	Name string
+	At   HoursBound
+}
+
+// ParseRoutine parses a routine written as comma- or semicolon-separated
+// NAME=WHEN steps, where WHEN is an event expression (see ParseEventExpr)
+// or a 24-hour local clock time "HH:MM":
+//
+//	wake=civil_dawn, lights_warm=sunset-1h, lights_off=23:00
+func ParseRoutine(s string) (Routine, error) {
+	var r Routine
+	for _, part := range strings.FieldsFunc(s, func(c rune) bool { return c == ',' || c == ';' }) {
+		name, when, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid routine step %q: want NAME=WHEN", strings.TrimSpace(part))
+		}
+		at, err := parseHoursBound(when)
+		if err != nil {
+			return nil, fmt.Errorf("invalid routine step %q: %w", name, err)
+		}
+		r = append(r, RoutineStep{Name: name, At: at})
+	}
+	if len(r) == 0 {
+		return nil, errors.New("empty routine")
+	}
+	return r, nil
+}
+
+// String formats the routine in the form ParseRoutine accepts.
+func (r Routine) String() string {
+	steps := make([]string, len(r))
+	for i, s := range r {
+		steps[i] = s.Name + "=" + s.At.String()
+	}
+	return strings.Join(steps, ", ")
+}
+
+// ScheduledStep is a RoutineStep resolved on one local calendar date.
+type ScheduledStep struct {
+	Date time.Time // local midnight (or the first instant) of the date
+	Name string
+	Time time.Time
+}
+
+// SolarSchedule resolves routine at loc on every local calendar date from
+// start through end (in start's time zone) and returns the steps in time
+// order.
+//
+// Daylight saving time is handled the way people expect rather than the
+// way 24-hour arithmetic gives: event offsets are elapsed time from the
+// event, clock times are wall-clock times on the date, a clock time that a
+// spring-forward transition skips moves forward by the gap (02:30 becomes
+// 03:30), and one repeated by a fall-back transition is its first
+// occurrence. Each date is a calendar date, so 23- and 25-hour days get
+// their steps once each.
+//
+// A step whose event does not occur on a date, such as sunset in polar
+// day, is left out for that date.
+func SolarSchedule(loc Coordinates, start, end time.Time, routine Routine) ([]ScheduledStep, error) {
+	if len(routine) == 0 {
+		return nil, errors.New("empty routine")
+	}
+	tz := start.Location()
+	y, m, d := start.Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = end.In(tz).Date()
+	last := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	if last.Before(first) {
+		return nil, errors.New("solar schedule ends before it starts")
+	}
+	if err := checkInputs(loc, start); err != nil {
+		return nil, err
+	}
+	if err := checkRange(end); err != nil {
+		return nil, err
+	}
+
+	var steps []ScheduledStep
+	// Count calendar dates in UTC, where every day has 24 hours, and build
+	// each local date from its year, month and day.
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		midnight := timeutil.WallClock(day.Year(), day.Month(), day.Day(), 0, tz)
+		for _, s := range routine {
+			t, err := s.At.resolve(loc, midnight)
+			var noEv *NoEventError
+			switch {
+			case errors.As(err, &noEv):
+				continue
+			case err != nil:
+				return nil, fmt.Errorf("routine step %q on %s: %w", s.Name, midnight.Format("2006-01-02"), err)
+			}
+			steps = append(steps, ScheduledStep{Date: midnight, Name: s.Name, Time: t})
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Time.Before(steps[j].Time) })
+	return steps, nil
+}
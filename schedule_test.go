@@ -0,0 +1,127 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+func TestParseRoutine(t *testing.T) {
+	r, err := ParseRoutine("wake=civil_dawn; lights_warm = sunset-1h, lights_off=23:00")
+	if err != nil {
+		t.Fatalf("ParseRoutine error: %v", err)
+	}
+	if len(r) != 3 || r[1].Name != "lights_warm" || !r[2].At.IsClock || r[2].At.Clock != 23*time.Hour {
+		t.Errorf("ParseRoutine = %+v", r)
+	}
+	if got, want := r.String(), "wake=civil_dawn, lights_warm=sunset-1h, lights_off=23:00"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	for _, s := range []string{"", "wake", "=sunrise", "wake=noonish"} {
+		if _, err := ParseRoutine(s); err == nil {
+			t.Errorf("ParseRoutine(%q) accepted", s)
+		}
+	}
+}
+
+func TestSolarSchedule_DST(t *testing.T) {
+	den, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	loc := Coordinates{Lat: 39.7392, Lon: -104.9903}
+	r, err := ParseRoutine("wake=civil_dawn, warm=sunset-1h, late=02:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Daylight saving time starts on 9 March, a 23-hour day.
+	steps, err := SolarSchedule(loc, time.Date(2025, 3, 8, 12, 0, 0, 0, den), time.Date(2025, 3, 10, 0, 0, 0, 0, den), r)
+	if err != nil {
+		t.Fatalf("SolarSchedule error: %v", err)
+	}
+	if len(steps) != 9 {
+		t.Fatalf("%d steps, want 3 a day for 3 days: %v", len(steps), steps)
+	}
+	for i := 1; i < len(steps); i++ {
+		if steps[i].Time.Before(steps[i-1].Time) {
+			t.Errorf("steps out of order at %d", i)
+		}
+	}
+
+	byDay := map[string]map[string]time.Time{}
+	for _, s := range steps {
+		day := s.Date.Format("2006-01-02")
+		if byDay[day] == nil {
+			byDay[day] = map[string]time.Time{}
+		}
+		byDay[day][s.Name] = s.Time
+		if got := s.Time.In(den).Format("2006-01-02"); s.Name != "late" && got != day {
+			t.Errorf("%s on %s falls on %s", s.Name, day, got)
+		}
+	}
+
+	// 02:30 does not exist on the 9th; it moves to 03:30 MDT.
+	if got := byDay["2025-03-09"]["late"].In(den).Format("15:04 MST"); got != "03:30 MDT" {
+		t.Errorf("02:30 on the DST night = %s, want 03:30 MDT", got)
+	}
+	if got := byDay["2025-03-10"]["late"].In(den).Format("15:04 MST"); got != "02:30 MDT" {
+		t.Errorf("02:30 the next night = %s, want 02:30 MDT", got)
+	}
+
+	// Offsets are elapsed time from the event on each side of the change.
+	for _, day := range []string{"2025-03-08", "2025-03-09", "2025-03-10"} {
+		date, _ := time.ParseInLocation("2006-01-02", day, den)
+		rs, err := RiseSetFor(Sun, loc, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := byDay[day]["warm"], rs.Set.Add(-time.Hour); !got.Equal(want) {
+			t.Errorf("%s: warm at %s, want sunset-1h = %s", day, got.In(den).Format("15:04"), want.In(den).Format("15:04"))
+		}
+	}
+}
+
+func TestSolarSchedule_PolarDay(t *testing.T) {
+	tromso := Coordinates{Lat: 69.6492, Lon: 18.9553}
+	cet := time.FixedZone("CEST", 2*3600)
+	r, err := ParseRoutine("warm=sunset-1h, off=23:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2025, 6, 21, 0, 0, 0, 0, cet)
+	steps, err := SolarSchedule(tromso, day, day, r)
+	if err != nil {
+		t.Fatalf("SolarSchedule error: %v", err)
+	}
+	if len(steps) != 1 || steps[0].Name != "off" {
+		t.Errorf("midnight-sun schedule = %v, want only the clock step", steps)
+	}
+
+	if _, err := SolarSchedule(tromso, day, day.AddDate(0, 0, -1), r); err == nil {
+		t.Error("SolarSchedule accepted an end before the start")
+	}
+}
+
+func TestWallClock(t *testing.T) {
+	den, err1 := time.LoadLocation("America/Denver")
+	scl, err2 := time.LoadLocation("America/Santiago")
+	if err1 != nil || err2 != nil {
+		t.Skip("tzdata unavailable")
+	}
+	cases := []struct {
+		got  time.Time
+		want string
+	}{
+		{timeutil.WallClock(2025, 11, 2, 90*time.Minute, den), "2025-11-02 01:30 MDT"}, // first of two
+		{timeutil.WallClock(2025, 3, 9, 150*time.Minute, den), "2025-03-09 03:30 MDT"}, // skipped
+		{timeutil.WallClock(2025, 9, 7, 0, scl), "2025-09-07 01:00 -03"},               // Chile skips midnight
+		{timeutil.WallClock(2025, 7, 1, 12*time.Hour, den), "2025-07-01 12:00 MDT"},
+	}
+	for _, c := range cases {
+		if got := c.got.Format("2006-01-02 15:04 MST"); got != c.want {
+			t.Errorf("WallClock = %s, want %s", got, c.want)
+		}
+	}
+}
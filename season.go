@@ -0,0 +1,141 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Hemisphere selects which half of the Earth's seasons to report.
+type Hemisphere int
+
+const (
+	NorthernHemisphere Hemisphere = iota
+	SouthernHemisphere
+)
+
+// HemisphereOf returns the hemisphere of loc; the equator counts as
+// northern.
+func HemisphereOf(loc Coordinates) Hemisphere {
+	if loc.Lat < 0 {
+		return SouthernHemisphere
+	}
+	return NorthernHemisphere
+}
+
+// SeasonKind is one of the four astronomical seasons.
+type SeasonKind int
+
+const (
+	SeasonSpring SeasonKind = iota
+	SeasonSummer
+	SeasonAutumn
+	SeasonWinter
+)
+
+func (k SeasonKind) String() string {
+	switch k {
+	case SeasonSpring:
+		return "spring"
+	case SeasonSummer:
+		return "summer"
+	case SeasonAutumn:
+		return "autumn"
+	case SeasonWinter:
+		return "winter"
+	default:
+		return fmt.Sprintf("SeasonKind(%d)", int(k))
+	}
+}
+
+// Season is the astronomical season containing an instant: the interval
+// between an equinox and the following solstice, or a solstice and the
+// following equinox.
+type Season struct {
+	Kind  SeasonKind
+	Start time.Time // equinox or solstice opening the season, UTC
+	End   time.Time // equinox or solstice closing it, UTC
+
+	Progress float64 // fraction of the season elapsed [0, 1)
+
+	// Day is the 1-based day of the season (24-hour periods since Start)
+	// and Days its length in started days, for "day 42 of 93 of winter".
+	Day, Days int
+}
+
+// SeasonAt returns the astronomical season at t for the given hemisphere,
+// where the seasons are six months apart: the March equinox opens spring
+// in the north and autumn in the south. Equinoxes and solstices are found
+// to within about a quarter of an hour.
+func SeasonAt(t time.Time, hemisphere Hemisphere) (Season, error) {
+	if err := checkRange(t); err != nil {
+		return Season{}, err
+	}
+	if hemisphere != NorthernHemisphere && hemisphere != SouthernHemisphere {
+		return Season{}, fmt.Errorf("unknown Hemisphere: %d", hemisphere)
+	}
+
+	start, end, quarter := astronomicalSeason(t)
+	if hemisphere == SouthernHemisphere {
+		quarter = (quarter + 2) % 4
+	}
+
+	length := end.Sub(start)
+	elapsed := t.Sub(start)
+	progress := math.Min(math.Max(elapsed.Seconds()/length.Seconds(), 0), math.Nextafter(1, 0))
+
+	return Season{
+		Kind:     SeasonKind(quarter),
+		Start:    start,
+		End:      end,
+		Progress: progress,
+		Day:      int(progress*length.Hours()/24) + 1,
+		Days:     int(math.Ceil(length.Hours() / 24)),
+	}, nil
+}
+
+// astronomicalSeason returns the equinox or solstice at or before t, the
+// next one, and which quarter of the tropical year they bound: 0 from the
+// March equinox, 1 from the June solstice, 2 from the September equinox and
+// 3 from the December solstice.
+func astronomicalSeason(t time.Time) (start, end time.Time, quarter int) {
+	lon := sun.EclipticLongitude(t.UTC())
+	from := math.Floor(lon/90) * 90
+	start = timeOfSolarLongitude(from, t.Add(-daysToDuration((lon-from)/meanSolarLongitudeRate)))
+	end = timeOfSolarLongitude(math.Mod(from+90, 360), start.Add(daysToDuration(90/meanSolarLongitudeRate)))
+	return start, end, int(from / 90)
+}
+
+// TimeOfSolarLongitude returns the instant in the Gregorian year approxYear
+// (UTC) when the Sun's apparent geocentric ecliptic longitude reaches
+// targetDeg: 0 for the March equinox, 90 for the June solstice, 45, 135,
+// 225 and 315 for the cross-quarter days, multiples of 15 for the solar
+// terms. The result is accurate to about a quarter of an hour. Longitudes
+// near 280°, reached around New Year, may fall just outside approxYear when
+// the year holds no such instant.
+func TimeOfSolarLongitude(targetDeg float64, approxYear int) (time.Time, error) {
+	if math.IsNaN(targetDeg) || math.IsInf(targetDeg, 0) {
+		return time.Time{}, fmt.Errorf("invalid solar longitude %v", targetDeg)
+	}
+	if err := checkRange(time.Date(approxYear, time.January, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		return time.Time{}, err
+	}
+
+	lon := timeutil.Normalize360(targetDeg)
+	t := approxSolarLongitudeDate(lon, approxYear)
+	// The guess may converge to the crossing a year early or late.
+	switch {
+	case t.Year() > approxYear:
+		if prev := timeOfSolarLongitude(lon, t.AddDate(-1, 0, 0)); prev.Year() == approxYear {
+			t = prev
+		}
+	case t.Year() < approxYear:
+		if next := timeOfSolarLongitude(lon, t.AddDate(1, 0, 0)); next.Year() == approxYear {
+			t = next
+		}
+	}
+	return t, nil
+}
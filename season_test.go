@@ -0,0 +1,104 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSeasonAt(t *testing.T) {
+	// Winter 2024-25: December solstice 2024-12-21 09:20 UTC to March
+	// equinox 2025-03-20 09:01 UTC.
+	tm := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	north, err := SeasonAt(tm, NorthernHemisphere)
+	if err != nil {
+		t.Fatalf("SeasonAt error: %v", err)
+	}
+	if north.Kind != SeasonWinter {
+		t.Errorf("Kind = %v, want winter", north.Kind)
+	}
+	wantStart := time.Date(2024, 12, 21, 9, 20, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC)
+	if d := north.Start.Sub(wantStart).Abs(); d > 10*time.Minute {
+		t.Errorf("Start = %v, want ~%v", north.Start, wantStart)
+	}
+	if d := north.End.Sub(wantEnd).Abs(); d > 10*time.Minute {
+		t.Errorf("End = %v, want ~%v", north.End, wantEnd)
+	}
+	if north.Day != 42 || north.Days != 89 {
+		t.Errorf("day %d of %d, want day 42 of 89", north.Day, north.Days)
+	}
+	if north.Progress < 0.46 || north.Progress > 0.47 {
+		t.Errorf("Progress = %.3f, want ~0.467", north.Progress)
+	}
+
+	south, err := SeasonAt(tm, HemisphereOf(Coordinates{Lat: -33.9, Lon: 151.2}))
+	if err != nil {
+		t.Fatalf("SeasonAt error: %v", err)
+	}
+	if south.Kind != SeasonSummer || !south.Start.Equal(north.Start) {
+		t.Errorf("southern season = %v from %v, want summer from %v", south.Kind, south.Start, north.Start)
+	}
+
+	if _, err := SeasonAt(tm, Hemisphere(2)); err == nil {
+		t.Error("expected error for an unknown hemisphere")
+	}
+}
+
+func TestSeasonAt_Boundaries(t *testing.T) {
+	// Just after the 2025 June solstice (02:42 UTC) summer has begun.
+	s, err := SeasonAt(time.Date(2025, 6, 21, 3, 0, 0, 0, time.UTC), NorthernHemisphere)
+	if err != nil {
+		t.Fatalf("SeasonAt error: %v", err)
+	}
+	if s.Kind != SeasonSummer || s.Day != 1 {
+		t.Errorf("got %v day %d, want summer day 1", s.Kind, s.Day)
+	}
+	if s.Progress < 0 || s.Progress >= 1 {
+		t.Errorf("Progress = %v out of [0, 1)", s.Progress)
+	}
+}
+
+func TestTimeOfSolarLongitude(t *testing.T) {
+	tests := []struct {
+		lon  float64
+		want time.Time
+	}{
+		{0, time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC)},
+		{90, time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC)},
+		{180, time.Date(2025, 9, 22, 18, 19, 0, 0, time.UTC)},
+		{270, time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC)},
+		{-90, time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC)},
+		{315, time.Date(2025, 2, 3, 14, 10, 0, 0, time.UTC)}, // Imbolc cross-quarter
+	}
+	for _, tt := range tests {
+		got, err := TimeOfSolarLongitude(tt.lon, 2025)
+		if err != nil {
+			t.Fatalf("TimeOfSolarLongitude(%v) error: %v", tt.lon, err)
+		}
+		if d := got.Sub(tt.want).Abs(); d > 15*time.Minute {
+			t.Errorf("TimeOfSolarLongitude(%v, 2025) = %v, want ~%v", tt.lon, got, tt.want)
+		}
+	}
+
+	// Every solar term agrees with SolarTermsFor.
+	terms, err := SolarTermsFor(2025)
+	if err != nil {
+		t.Fatalf("SolarTermsFor error: %v", err)
+	}
+	for _, term := range terms {
+		if got, _ := TimeOfSolarLongitude(term.Longitude, 2025); !got.Equal(term.Time) {
+			t.Errorf("%s: %v, SolarTermsFor has %v", term.Name, got, term.Time)
+		}
+	}
+
+	for y := 2000; y < 2030; y++ {
+		if got, _ := TimeOfSolarLongitude(280, y); got.Year() != y && got.Year() != y+1 {
+			t.Errorf("TimeOfSolarLongitude(280, %d) = %v", y, got)
+		}
+	}
+	if _, err := TimeOfSolarLongitude(math.NaN(), 2025); err == nil {
+		t.Error("expected error for NaN longitude")
+	}
+}
@@ -0,0 +1,77 @@
+package astroglide
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// Session computes events for one location and shares work between calls.
+//
+// Asking for sunrise, twilight, golden hour and blue hour on the same date
+// searches the Sun's altitude over the same day several times; a Session
+// memoizes the solar positions those searches sample, so later calls reuse
+// them. Results are identical to the free functions. A Session is safe for
+// concurrent use and its cache is bounded, so it can live as long as the
+// program.
+type Session struct {
+	loc Coordinates
+	sun *sun.Cache
+}
+
+// NewSession returns a Session for the given location.
+func NewSession(loc Coordinates) *Session {
+	return &Session{loc: loc, sun: sun.NewCache()}
+}
+
+// Coordinates returns the location of the session.
+func (s *Session) Coordinates() Coordinates {
+	return s.loc
+}
+
+// options appends the session's cache to the caller's options.
+func (s *Session) options(opts []Option) []Option {
+	out := make([]Option, 0, len(opts)+1)
+	out = append(out, opts...)
+	return append(out, func(c *config) { c.sunCache = s.sun })
+}
+
+// RiseSetFor is RiseSetFor at the session's location.
+func (s *Session) RiseSetFor(body Body, date time.Time, opts ...Option) (RiseSet, error) {
+	return RiseSetFor(body, s.loc, date, s.options(opts)...)
+}
+
+// SlideIntoSunset is SlideIntoSunset at the session's location.
+func (s *Session) SlideIntoSunset(date time.Time, opts ...Option) (RiseSet, error) {
+	return SlideIntoSunset(s.loc, date, s.options(opts)...)
+}
+
+// RiseSetEventsFor is RiseSetEventsFor at the session's location.
+func (s *Session) RiseSetEventsFor(body Body, date time.Time, opts ...Option) ([]Event, error) {
+	return RiseSetEventsFor(body, s.loc, date, s.options(opts)...)
+}
+
+// TwilightFor is TwilightFor at the session's location.
+func (s *Session) TwilightFor(date time.Time, kind TwilightKind, opts ...Option) (RiseSet, error) {
+	return TwilightFor(s.loc, date, kind, s.options(opts)...)
+}
+
+// AviationTwilightFor is AviationTwilightFor at the session's location.
+func (s *Session) AviationTwilightFor(date time.Time, opts ...Option) (AviationTwilight, error) {
+	return AviationTwilightFor(s.loc, date, s.options(opts)...)
+}
+
+// GoldenHourFor is GoldenHourFor at the session's location.
+func (s *Session) GoldenHourFor(date time.Time, opts ...Option) (DaylightPhases, error) {
+	return GoldenHourFor(s.loc, date, s.options(opts)...)
+}
+
+// BlueHourFor is BlueHourFor at the session's location.
+func (s *Session) BlueHourFor(date time.Time, opts ...Option) (DaylightPhases, error) {
+	return BlueHourFor(s.loc, date, s.options(opts)...)
+}
+
+// SunAltitudeWindowFor is SunAltitudeWindowFor at the session's location.
+func (s *Session) SunAltitudeWindowFor(date time.Time, lowAlt, highAlt float64, opts ...Option) (DaylightPhases, error) {
+	return SunAltitudeWindowFor(s.loc, date, lowAlt, highAlt, s.options(opts)...)
+}
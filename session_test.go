@@ -0,0 +1,80 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSession_MatchesFreeFunctions(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, loc)
+
+	s := NewSession(phx)
+
+	rs, err := s.SlideIntoSunset(date)
+	if err != nil {
+		t.Fatalf("Session.SlideIntoSunset error: %v", err)
+	}
+	want, _ := SlideIntoSunset(phx, date)
+	if rs != want {
+		t.Errorf("Session.SlideIntoSunset = %+v, want %+v", rs, want)
+	}
+
+	afterRise := s.sun.Len()
+	if afterRise == 0 {
+		t.Fatal("session cached no solar positions")
+	}
+
+	golden, err := s.GoldenHourFor(date)
+	if err != nil {
+		t.Fatalf("Session.GoldenHourFor error: %v", err)
+	}
+	wantGolden, _ := GoldenHourFor(phx, date)
+	if golden != wantGolden {
+		t.Errorf("Session.GoldenHourFor = %+v, want %+v", golden, wantGolden)
+	}
+
+	civil, err := s.TwilightFor(date, TwilightCivil)
+	if err != nil {
+		t.Fatalf("Session.TwilightFor error: %v", err)
+	}
+	wantCivil, _ := TwilightFor(phx, date, TwilightCivil)
+	if civil != wantCivil {
+		t.Errorf("Session.TwilightFor = %+v, want %+v", civil, wantCivil)
+	}
+
+	// Repeating a call is served entirely from the cache.
+	before := s.sun.Len()
+	if _, err := s.GoldenHourFor(date); err != nil {
+		t.Fatalf("Session.GoldenHourFor error: %v", err)
+	}
+	if got := s.sun.Len(); got != before {
+		t.Errorf("repeated call grew the cache from %d to %d entries", before, got)
+	}
+}
+
+func BenchmarkDayOfEvents_Free(b *testing.B) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = SlideIntoSunset(phx, date)
+		_, _ = TwilightFor(phx, date, TwilightCivil)
+		_, _ = GoldenHourFor(phx, date)
+		_, _ = BlueHourFor(phx, date)
+	}
+}
+
+func BenchmarkDayOfEvents_Session(b *testing.B) {
+	s := NewSession(Coordinates{Lat: 33.4484, Lon: -112.0740})
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_, _ = s.SlideIntoSunset(date)
+		_, _ = s.TwilightFor(date, TwilightCivil)
+		_, _ = s.GoldenHourFor(date)
+		_, _ = s.BlueHourFor(date)
+	}
+}
@@ -0,0 +1,125 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Obstruction is a building, wall or hedge near the observer, modelled as a
+// vertical rectangle facing the observer: a parametric stand-in for a
+// HorizonProfile in the common "the neighbour's house shades my yard" case.
+type Obstruction struct {
+	Bearing  float64 // degrees from true north to the nearest point of its face
+	Distance float64 // metres, horizontally, to that point
+	Height   float64 // metres of its top above the observer's eye (or the spot to be lit)
+	Width    float64 // metres along its face, centred on Bearing; 0 for an endless wall
+}
+
+// Validate checks that b describes something that can cast a shadow.
+func (b Obstruction) Validate() error {
+	switch {
+	case !(b.Distance > 0):
+		return fmt.Errorf("invalid obstruction distance %.3f m: must be positive", b.Distance)
+	case !(b.Height > 0):
+		return fmt.Errorf("invalid obstruction height %.3f m: must be above the observer", b.Height)
+	case !(b.Width >= 0):
+		return fmt.Errorf("invalid obstruction width %.3f m", b.Width)
+	case math.IsNaN(b.Bearing) || math.IsInf(b.Bearing, 0):
+		return fmt.Errorf("invalid obstruction bearing %v", b.Bearing)
+	}
+	return nil
+}
+
+// halfWidth returns the largest angle (degrees) off Bearing that the face
+// covers.
+func (b Obstruction) halfWidth() float64 {
+	if b.Width == 0 {
+		return 90
+	}
+	return timeutil.Rad2Deg(math.Atan2(b.Width/2, b.Distance))
+}
+
+// AltitudeAt returns the altitude (degrees) of the obstruction's top edge
+// at azimuth az, or 0 where it does not reach. Off-centre the face is
+// farther away, so its edge is lower than straight ahead.
+func (b Obstruction) AltitudeAt(az float64) float64 {
+	off := relativeAngle(az, b.Bearing)
+	if math.Abs(off) > b.halfWidth() || math.Abs(off) >= 90 {
+		return 0
+	}
+	return timeutil.Rad2Deg(math.Atan2(b.Height*timeutil.CosD(off), b.Distance))
+}
+
+// Profile returns the obstruction as a HorizonProfile, sampled every few
+// degrees across its face, for use as an Observer's Horizon so that
+// Sunrise and Sunset refer to its top edge.
+func (b Obstruction) Profile() HorizonProfile {
+	hw := math.Min(b.halfWidth(), 89.9)
+	const samples = 16
+	p := HorizonProfile{{Azimuth: timeutil.Normalize360(b.Bearing - hw), Altitude: 0}}
+	for i := 0; i <= samples; i++ {
+		az := b.Bearing - hw + 2*hw*float64(i)/samples
+		p = append(p, HorizonPoint{Azimuth: timeutil.Normalize360(az), Altitude: b.AltitudeAt(az)})
+	}
+	return append(p, HorizonPoint{Azimuth: timeutil.Normalize360(b.Bearing + hw), Altitude: 0})
+}
+
+// ShadeWindows returns the intervals on the given local calendar date when
+// the Sun is up but hidden from the observer by one of the obstructions.
+// The ends of the windows are the moments the Sun clears them; an empty
+// result with a nil error means no shade that day. Times are in the date's
+// time zone. Call it for a run of dates to see the season's shading.
+//
+// The Sun's center is tested against the top edge, without refraction,
+// which matters only for obstructions within a degree or so of the horizon.
+// The obstructions' own shadows on each other are not modelled.
+func ShadeWindows(loc Coordinates, date time.Time, obstructions ...Obstruction) ([]PhaseWindow, error) {
+	for _, b := range obstructions {
+		if err := b.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkInputs(loc, date); err != nil {
+		return nil, err
+	}
+
+	tz := date.Location()
+	start, end := timeutil.LocalDay(date)
+
+	shaded := func(t time.Time) bool {
+		eq := sun.GeocentricEquatorialApprox(t)
+		alt, az := coord.Horizontal(eq.RA, eq.Dec, loc.Lat, coord.LocalSiderealTime(loc.Lon, t))
+		if alt <= sun.ApparentHorizonAltitudeSun {
+			return false
+		}
+		for _, b := range obstructions {
+			if top := b.AltitudeAt(az); top > 0 && alt < top {
+				return true
+			}
+		}
+		return false
+	}
+
+	const (
+		steps = 24*60 + 1 // every minute
+		tol   = 10 * time.Second
+	)
+
+	intervals := solver.FindIntervals(shaded, start.UTC(), end.UTC(), steps, tol)
+
+	windows := make([]PhaseWindow, 0, len(intervals))
+	for _, iv := range intervals {
+		windows = append(windows, PhaseWindow{
+			Start: iv.Start.In(tz),
+			End:   iv.End.In(tz),
+		})
+	}
+
+	return windows, nil
+}
@@ -0,0 +1,83 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestShadeWindows(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+
+	// A two-storey house 10 m south of the yard, 20 m wide: its roof line
+	// stands 45° high straight ahead.
+	house := Obstruction{Bearing: 180, Distance: 10, Height: 10, Width: 20}
+	if alt := house.AltitudeAt(180); math.Abs(alt-45) > 1e-9 {
+		t.Errorf("altitude straight ahead = %v, want 45", alt)
+	}
+	if alt := house.AltitudeAt(90); alt != 0 {
+		t.Errorf("altitude beside the house = %v, want 0", alt)
+	}
+
+	// In December the noon Sun is only 33° high and sits behind it.
+	winter := time.Date(2025, 12, 21, 0, 0, 0, 0, mst)
+	w, err := ShadeWindows(phx, winter, house)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noon, _ := SolarNoon(phx, winter)
+	if len(w) != 1 || noon.Before(w[0].Start) || noon.After(w[0].End) {
+		t.Fatalf("December shade %v does not cover solar noon %s", w, noon.Format("15:04"))
+	}
+	// ±45° of azimuth takes the low winter Sun about six hours.
+	if d := w[0].End.Sub(w[0].Start); d < 5*time.Hour || d > 7*time.Hour {
+		t.Errorf("December shade lasts %v", d)
+	}
+
+	// In June it passes high overhead.
+	summer, err := ShadeWindows(phx, time.Date(2025, 6, 21, 0, 0, 0, 0, mst), house)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summer) != 0 {
+		t.Errorf("June shade %v, want none", summer)
+	}
+
+	if _, err := ShadeWindows(phx, winter, Obstruction{Bearing: 180, Distance: 0, Height: 10}); err == nil {
+		t.Error("zero distance accepted")
+	}
+}
+
+func TestObstructionProfile(t *testing.T) {
+	// A wall to the east delays sunrise over it as much as the shade ends.
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+	date := time.Date(2025, 3, 20, 0, 0, 0, 0, mst)
+	wall := Obstruction{Bearing: 90, Distance: 20, Height: 5}
+
+	p := wall.Profile()
+	for _, az := range []float64{60, 90, 100} {
+		if got, want := p.AltitudeAt(az), wall.AltitudeAt(az); math.Abs(got-want) > 0.1 {
+			t.Errorf("profile at %v° = %.3f, want %.3f", az, got, want)
+		}
+	}
+
+	w, err := ShadeWindows(phx, date, wall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w) != 1 {
+		t.Fatalf("shade %v, want one morning window", w)
+	}
+	obs := NewObserver(phx, mst)
+	obs.Horizon = p
+	rs, err := obs.RiseSet(Sun, date, WithLimb(LimbCenter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Observer adds refraction, a few minutes' worth at 14°.
+	if d := w[0].End.Sub(rs.Rise); d < 0 || d > 3*time.Minute {
+		t.Errorf("shade ends %s, Sun rises over the wall %s", w[0].End.Format("15:04:05"), rs.Rise.Format("15:04:05"))
+	}
+}
@@ -0,0 +1,178 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/sun"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// NaturalSkyBrightness is the V-band zenith brightness of a moonless sky
+// far from artificial light at solar minimum, in magnitudes per square
+// arcsecond. Sky quality meters read about 21.5 at good rural sites and 18
+// or less in suburbs.
+const NaturalSkyBrightness = 21.8
+
+// Constants of the sky brightness model.
+const (
+	// Patat et al. (2006) fit of the V-band zenith twilight brightness
+	// against solar zenith distance z, valid for 95° <= z <= 105°:
+	// m = a0 + a1*(z-95) + a2*(z-95)^2.
+	twilightA0 = 11.84
+	twilightA1 = 1.518
+	twilightA2 = -0.057
+
+	// daylightBrightness is the clear zenith sky with the Sun well up,
+	// about 3000 cd/m².
+	daylightBrightness = 3.7
+
+	// vExtinction is the V-band extinction coefficient (magnitudes per
+	// airmass) of a clear site.
+	vExtinction = 0.172
+
+	// meanMoonDistanceKm normalizes the Krisciunas & Schaefer moonlight
+	// term, which assumes the Moon at its mean distance.
+	meanMoonDistanceKm = 384400.0
+)
+
+// SkyBrightness is an estimate of the brightness of the zenith sky, in
+// V-band magnitudes per square arcsecond: smaller numbers are brighter.
+// A clear daytime sky is about 4, the sky at the end of civil twilight
+// about 13, and a dark site 21.5–22.
+type SkyBrightness struct {
+	Time time.Time
+
+	// Zenith is the total brightness of the zenith sky.
+	Zenith float64
+
+	// Twilight and Moonlight are the contributions of scattered sunlight and
+	// moonlight alone; +Inf when there is none.
+	Twilight  float64
+	Moonlight float64
+
+	SunAltitude  float64 // degrees
+	MoonAltitude float64 // degrees, topocentric; negative below the horizon
+}
+
+// SkyBrightnessAt estimates the zenith sky brightness above loc at t, for
+// planning exposures through twilight (the "holy grail" day-to-night
+// timelapse). darkSky is the site's moonless night sky brightness, e.g. a
+// sky quality meter reading; zero uses NaturalSkyBrightness.
+//
+// Twilight follows the empirical zenith fit of Patat et al. (2006, A&A 455,
+// 385) for the Sun 5° to 15° below the horizon, joined to a daylight sky of
+// about 3.7 above the horizon and extrapolated until it vanishes by
+// astronomical night. Moonlight uses the model of Krisciunas & Schaefer
+// (1991, PASP 103, 1033) for a clear sky. The three sources are added as
+// fluxes.
+//
+// Expect errors of half a magnitude or more: real skies vary with haze,
+// altitude and the solar cycle. Exposure changes should be taken from the
+// differences between estimates rather than their absolute values.
+func SkyBrightnessAt(loc Coordinates, t time.Time, darkSky float64) (SkyBrightness, error) {
+	if err := checkInputs(loc, t); err != nil {
+		return SkyBrightness{}, err
+	}
+	if darkSky == 0 {
+		darkSky = NaturalSkyBrightness
+	}
+
+	sunAlt := sun.AltitudeAt(loc.Lat, loc.Lon, t)
+	moonAlt := moon.AltitudeAt(loc.Lat, loc.Lon, t)
+
+	twilight := twilightBrightness(sunAlt)
+	moonlight := math.Inf(1)
+	if moonAlt > 0 {
+		phase, err := MoonPhaseAt(t)
+		if err != nil {
+			return SkyBrightness{}, err
+		}
+		dist := geocentricDistanceKm(Moon, t)
+		moonlight = moonlightBrightness(180-phase.Elongation, moonAlt, dist)
+	}
+
+	return SkyBrightness{
+		Time:         t,
+		Zenith:       addSurfaceBrightness(darkSky, twilight, moonlight),
+		Twilight:     twilight,
+		Moonlight:    moonlight,
+		SunAltitude:  sunAlt,
+		MoonAltitude: moonAlt,
+	}, nil
+}
+
+// twilightBrightness returns the zenith brightness (mag/arcsec²) of
+// scattered sunlight with the Sun at altitude sunAlt, or +Inf once it no
+// longer contributes.
+func twilightBrightness(sunAlt float64) float64 {
+	x := -sunAlt - 5 // z - 95
+	switch {
+	case x < 0:
+		// From the edge of the fit, brightening at its slope there, up to
+		// daylight.
+		return math.Max(twilightA0+twilightA1*x, daylightBrightness)
+	case x <= 10:
+		return twilightA0 + twilightA1*x + twilightA2*x*x
+	case sunAlt > -24:
+		// The fit turns over past its range; let the remaining twilight
+		// keep fading at the fit's initial rate, negligible by -18°.
+		edge := twilightA0 + twilightA1*10 + twilightA2*100
+		return edge + twilightA1*(x-10)
+	default:
+		return math.Inf(1)
+	}
+}
+
+// moonlightBrightness returns the zenith brightness (mag/arcsec²) of
+// moonlight scattered by a clear sky, from the Moon at phase angle alpha
+// (0 at Full Moon) and altitude moonAlt, distKm from the Earth (Krisciunas &
+// Schaefer 1991, eqs. 8, 15, 20 and 21).
+func moonlightBrightness(alpha, moonAlt, distKm float64) float64 {
+	// Illuminance of the Moon outside the atmosphere, and the brightness of
+	// the sky it produces in nanolamberts at the zenith, where the
+	// scattering angle rho is the Moon's zenith distance.
+	istar := math.Pow(10, -0.4*(3.84+0.026*math.Abs(alpha)+4e-9*math.Pow(alpha, 4)))
+	istar *= (meanMoonDistanceKm / distKm) * (meanMoonDistanceKm / distKm)
+
+	rho := 90 - moonAlt
+	cosRho := timeutil.CosD(rho)
+	f := math.Pow(10, 5.36)*(1.06+cosRho*cosRho) + math.Pow(10, 6.15-rho/40)
+
+	xMoon := airmassKS(rho)
+	const xZenith = 1.0
+	b := f * istar * math.Pow(10, -0.4*vExtinction*xMoon) * (1 - math.Pow(10, -0.4*vExtinction*xZenith))
+	if b <= 0 {
+		return math.Inf(1)
+	}
+	return nanolambertsToMag(b)
+}
+
+// airmassKS is the Krisciunas & Schaefer airmass at zenith distance z
+// (degrees), which stays finite at the horizon.
+func airmassKS(z float64) float64 {
+	s := timeutil.SinD(z)
+	return 1 / math.Sqrt(1-0.96*s*s)
+}
+
+// nanolambertsToMag converts a surface brightness in nanolamberts to V
+// magnitudes per square arcsecond (Garstang 1989).
+func nanolambertsToMag(b float64) float64 {
+	return (20.7233 - math.Log(b/34.08)) / 0.92104
+}
+
+// addSurfaceBrightness combines surface brightnesses (mag/arcsec²) by adding
+// their fluxes. +Inf terms contribute nothing.
+func addSurfaceBrightness(mags ...float64) float64 {
+	var flux float64
+	for _, m := range mags {
+		if !math.IsInf(m, 1) {
+			flux += math.Pow(10, -0.4*m)
+		}
+	}
+	if flux == 0 {
+		return math.Inf(1)
+	}
+	return -2.5 * math.Log10(flux)
+}
@@ -0,0 +1,101 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTwilightBrightness(t *testing.T) {
+	cases := []struct {
+		sunAlt, want, tol float64
+	}{
+		{30, daylightBrightness, 0},
+		{-5, 11.84, 0.01},
+		{-10, 18.0, 0.05},
+		{-15, 21.3, 0.1},
+		{-30, math.Inf(1), 0},
+	}
+	for _, c := range cases {
+		got := twilightBrightness(c.sunAlt)
+		if got != c.want && math.Abs(got-c.want) > c.tol {
+			t.Errorf("twilightBrightness(%v) = %.2f, want %.2f", c.sunAlt, got, c.want)
+		}
+	}
+
+	// Darker all the way down.
+	prev := twilightBrightness(10)
+	for alt := 9.5; alt > -24; alt -= 0.5 {
+		m := twilightBrightness(alt)
+		if m < prev {
+			t.Fatalf("twilight brightens from %.2f to %.2f at %v°", prev, m, alt)
+		}
+		prev = m
+	}
+}
+
+func TestMoonlightBrightness(t *testing.T) {
+	// A Full Moon at 45° brightens the zenith to about 18 mag/arcsec²;
+	// a quarter Moon is some 2 magnitudes fainter.
+	full := moonlightBrightness(0, 45, meanMoonDistanceKm)
+	if full < 17.5 || full > 18.8 {
+		t.Errorf("Full Moon at 45° gives %.2f mag/arcsec², want ~18.3", full)
+	}
+	quarter := moonlightBrightness(90, 45, meanMoonDistanceKm)
+	if d := quarter - full; d < 1.5 || d > 3 {
+		t.Errorf("quarter Moon is %.2f mag fainter than full, want ~2.2", d)
+	}
+	if perigee := moonlightBrightness(0, 45, 357000); perigee >= full {
+		t.Errorf("Full Moon at perigee %.2f not brighter than at mean distance %.2f", perigee, full)
+	}
+}
+
+func TestSkyBrightnessAt(t *testing.T) {
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+
+	// 2025-11-20, a day before New Moon: the thin crescent sets with the
+	// Sun and adds nothing.
+	sunset := time.Date(2025, time.November, 21, 0, 22, 0, 0, time.UTC)
+	var prev float64
+	for i, d := range []time.Duration{-time.Hour, 0, 25 * time.Minute, 50 * time.Minute, 80 * time.Minute, 3 * time.Hour} {
+		sb, err := SkyBrightnessAt(coords, sunset.Add(d), 0)
+		if err != nil {
+			t.Fatalf("SkyBrightnessAt error: %v", err)
+		}
+		if sb.MoonAltitude <= 0 && !math.IsInf(sb.Moonlight, 1) {
+			t.Errorf("%v: Moonlight = %.2f with the Moon at %.1f°, want +Inf", sb.Time, sb.Moonlight, sb.MoonAltitude)
+		}
+		if sb.Moonlight < 25 {
+			t.Errorf("%v: crescent Moonlight = %.2f, want negligible", sb.Time, sb.Moonlight)
+		}
+		if i > 0 && sb.Zenith < prev {
+			t.Errorf("%v: sky brightened to %.2f from %.2f after sunset", sb.Time, sb.Zenith, prev)
+		}
+		prev = sb.Zenith
+	}
+	if math.Abs(prev-NaturalSkyBrightness) > 0.05 {
+		t.Errorf("night sky = %.2f, want %.1f", prev, NaturalSkyBrightness)
+	}
+
+	// A suburban site never gets darker than its own baseline.
+	sb, err := SkyBrightnessAt(coords, sunset.Add(3*time.Hour), 19)
+	if err != nil {
+		t.Fatalf("SkyBrightnessAt error: %v", err)
+	}
+	if math.Abs(sb.Zenith-19) > 0.05 {
+		t.Errorf("suburban night sky = %.2f, want 19", sb.Zenith)
+	}
+
+	// 2025-11-05 Full Moon high in the sky near midnight.
+	full, err := SkyBrightnessAt(coords, time.Date(2025, time.November, 5, 7, 0, 0, 0, time.UTC), 0)
+	if err != nil {
+		t.Fatalf("SkyBrightnessAt error: %v", err)
+	}
+	if full.MoonAltitude < 45 || full.Zenith > 18.8 || full.Zenith < 17 {
+		t.Errorf("Full Moon night: moon %.1f°, zenith %.2f; want a high Moon and ~18", full.MoonAltitude, full.Zenith)
+	}
+
+	if _, err := SkyBrightnessAt(Coordinates{Lat: 91}, sunset, 0); err == nil {
+		t.Error("expected error for latitude out of range")
+	}
+}
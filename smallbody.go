@@ -0,0 +1,240 @@
+package astroglide
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/orbit"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// ErrUnknownSmallBody is returned by LookupElements when no line of the
+// element file names the requested object.
+var ErrUnknownSmallBody = errors.New("no orbital elements for this object")
+
+// ParseMPCORBElements parses one line of the Minor Planet Center's MPCORB
+// format, used by MPCORB.DAT, NEA.txt and the other asteroid element
+// files:
+//
+//	00001    3.34  0.15 K2555 188.70269   73.27343   80.25221   10.58780  0.0789010  0.21424651   2.7660512  0 E2024-V47  7330 125 1801-2024 0.65 M-v 30k MPCLINUX   4000      (1) Ceres              20241101
+//
+// The elements are the mean anomaly and semimajor axis at the epoch; the
+// name is the readable designation, or the packed one if that is missing.
+func ParseMPCORBElements(line string) (OrbitalElements, error) {
+	line = strings.TrimRight(line, " \r\n")
+	if len(line) < 103 {
+		return OrbitalElements{}, fmt.Errorf("malformed MPCORB elements %q", line)
+	}
+
+	c := columns{line: line, format: "MPCORB elements"}
+	packed := c.str(21, 25)
+	m := c.num("mean anomaly", 27, 35)
+	argPeri := c.num("argument of perihelion", 38, 46)
+	node := c.num("ascending node", 49, 57)
+	incl := c.num("inclination", 60, 68)
+	e := c.num("eccentricity", 71, 79)
+	a := c.num("semimajor axis", 93, 103)
+	if c.err != nil {
+		return OrbitalElements{}, c.err
+	}
+	epoch, err := unpackEpoch(packed)
+	if err != nil {
+		return OrbitalElements{}, err
+	}
+	if !(a > 0) || !(e >= 0 && e < 1) {
+		return OrbitalElements{}, fmt.Errorf("malformed MPCORB elements: a = %g AU, e = %g is not an elliptic orbit", a, e)
+	}
+
+	name := c.str(167, 194)
+	if name == "" {
+		name = c.str(1, 7)
+	}
+	return fromOrbit(name, orbit.FromMeanAnomaly(epoch, a, e, m, argPeri, node, incl)), nil
+}
+
+// unpackEpoch decodes an MPC packed date such as "K2555" (2025 May 5.0):
+// a century letter (I = 1800, J = 1900, K = 2000), two year digits, and
+// month and day as 1-9 then A = 10, B = 11 and so on.
+func unpackEpoch(s string) (time.Time, error) {
+	digit := func(c byte) int {
+		switch {
+		case c >= '1' && c <= '9':
+			return int(c - '0')
+		case c >= 'A' && c <= 'V':
+			return int(c-'A') + 10
+		}
+		return 0
+	}
+	if len(s) != 5 || s[0] < 'I' || s[0] > 'L' {
+		return time.Time{}, fmt.Errorf("malformed MPC packed epoch %q", s)
+	}
+	yy, err := strconv.Atoi(s[1:3])
+	month, day := digit(s[3]), digit(s[4])
+	if err != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, fmt.Errorf("malformed MPC packed epoch %q", s)
+	}
+	year := 1800 + 100*int(s[0]-'I') + yy
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// fromOrbit converts internal elements to OrbitalElements.
+func fromOrbit(name string, el orbit.Elements) OrbitalElements {
+	return OrbitalElements{
+		Name:               name,
+		Perihelion:         el.Perihelion,
+		PerihelionDistance: el.Q,
+		Eccentricity:       el.E,
+		ArgPerihelion:      el.ArgPerihelion,
+		AscendingNode:      el.Node,
+		Inclination:        el.Inclination,
+	}
+}
+
+// ParseSBDBElements reads the JSON response of JPL's Small-Body Database
+// API (https://ssd-api.jpl.nasa.gov/sbdb.api?sstr=...), which covers both
+// comets and asteroids. The perihelion form (tp, q, e) is used when the
+// response has it, so hyperbolic orbits work; otherwise the mean anomaly
+// and semimajor axis at the epoch.
+func ParseSBDBElements(r io.Reader) (OrbitalElements, error) {
+	var resp struct {
+		Message string `json:"message"`
+		Object  struct {
+			FullName string `json:"fullname"`
+		} `json:"object"`
+		Orbit *struct {
+			Epoch    json.RawMessage `json:"epoch"`
+			Equinox  string          `json:"equinox"`
+			Elements []struct {
+				Name  string          `json:"name"`
+				Value json.RawMessage `json:"value"`
+			} `json:"elements"`
+		} `json:"orbit"`
+	}
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return OrbitalElements{}, fmt.Errorf("malformed SBDB response: %w", err)
+	}
+	if resp.Orbit == nil {
+		if resp.Message != "" {
+			return OrbitalElements{}, fmt.Errorf("SBDB: %s", resp.Message)
+		}
+		return OrbitalElements{}, errors.New("SBDB response has no orbit")
+	}
+	if resp.Orbit.Equinox != "" && resp.Orbit.Equinox != "J2000" {
+		return OrbitalElements{}, fmt.Errorf("SBDB elements are referred to %s, want J2000", resp.Orbit.Equinox)
+	}
+
+	el := map[string]float64{}
+	for _, e := range resp.Orbit.Elements {
+		if v, ok := jsonNumber(e.Value); ok {
+			el[e.Name] = v
+		}
+	}
+	for _, name := range []string{"e", "i", "om", "w"} {
+		if _, ok := el[name]; !ok {
+			return OrbitalElements{}, fmt.Errorf("SBDB response has no %s element", name)
+		}
+	}
+	name := strings.TrimSpace(resp.Object.FullName)
+
+	if tp, ok := el["tp"]; ok {
+		if q, ok := el["q"]; ok {
+			o := OrbitalElements{
+				Name:               name,
+				Perihelion:         timeutil.TimeFromJulianDay(tp),
+				PerihelionDistance: q,
+				Eccentricity:       el["e"],
+				ArgPerihelion:      el["w"],
+				AscendingNode:      el["om"],
+				Inclination:        el["i"],
+			}
+			if err := o.Validate(); err != nil {
+				return OrbitalElements{}, err
+			}
+			return o, nil
+		}
+	}
+
+	epoch, okEpoch := jsonNumber(resp.Orbit.Epoch)
+	a, okA := el["a"]
+	m, okM := el["ma"]
+	if !okEpoch || !okA || !okM || !(a > 0) || el["e"] >= 1 {
+		return OrbitalElements{}, errors.New("SBDB response has neither tp and q nor epoch, a and ma")
+	}
+	return fromOrbit(name, orbit.FromMeanAnomaly(timeutil.TimeFromJulianDay(epoch), a, el["e"], m, el["w"], el["om"], el["i"])), nil
+}
+
+// jsonNumber decodes a number that the SBDB API may send as a JSON number
+// or a string; null gives false.
+func jsonNumber(raw json.RawMessage) (float64, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		s = string(raw)
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return v, err == nil
+}
+
+// LookupElements scans an MPC element file, in either the comet format of
+// CometEls.txt or the MPCORB format of MPCORB.DAT, and returns the elements
+// of the object with the given designation. The designation is matched
+// without regard to case against the full name ("1P/Halley", "(1) Ceres",
+// "C/2020 F3 (NEOWISE)") and its parts ("1P", "Halley", "1", "Ceres",
+// "C/2020 F3", "NEOWISE"). Header and other unparsable lines are skipped.
+func LookupElements(r io.Reader, designation string) (OrbitalElements, error) {
+	want := strings.ToLower(strings.TrimSpace(designation))
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 1024), 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.Contains(strings.ToLower(line), want) {
+			continue
+		}
+		var (
+			o   OrbitalElements
+			err error
+		)
+		if len(line) > 20 && line[20] >= 'A' && line[20] <= 'Z' {
+			o, err = ParseMPCORBElements(line)
+		} else {
+			o, err = ParseMPCCometElements(line)
+		}
+		if err != nil {
+			continue
+		}
+		for _, d := range designations(o.Name) {
+			if strings.ToLower(d) == want {
+				return o, nil
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return OrbitalElements{}, err
+	}
+	return OrbitalElements{}, fmt.Errorf("%w: %q", ErrUnknownSmallBody, designation)
+}
+
+// designations returns the ways an object's name may be written: the full
+// name, a parenthesised number or name on its own, the part before it,
+// and both halves of a numbered periodic comet's "1P/Halley".
+func designations(name string) []string {
+	ds := []string{name}
+	rest := name
+	if open := strings.Index(name, "("); open >= 0 {
+		if n := strings.Index(name[open:], ")"); n > 0 {
+			inner := name[open+1 : open+n]
+			rest = strings.TrimSpace(name[:open] + name[open+n+1:])
+			ds = append(ds, inner, rest)
+		}
+	}
+	if prefix, suffix, ok := strings.Cut(rest, "/"); ok && len(prefix) > 1 {
+		// "1P/Halley", but not "C/2020 F3".
+		ds = append(ds, prefix, suffix)
+	}
+	return ds
+}
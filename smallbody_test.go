@@ -0,0 +1,133 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const ceresMPCORB = "00001    3.34  0.15 K2555 188.70269   73.27343   80.25221   10.58780  0.0789010  0.21424651   2.7660512  0 E2024-V47  7330 125 1801-2024 0.65 M-v 30k MPCLINUX   4000      (1) Ceres              20241101"
+
+func TestParseMPCORBElements(t *testing.T) {
+	o, err := ParseMPCORBElements(ceresMPCORB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Name != "(1) Ceres" {
+		t.Errorf("Name = %q, want (1) Ceres", o.Name)
+	}
+	if want := 2.7660512 * (1 - 0.0789010); math.Abs(o.PerihelionDistance-want) > 1e-9 {
+		t.Errorf("PerihelionDistance = %v, want %v", o.PerihelionDistance, want)
+	}
+	// Mean anomaly 188.70269° is 171.29731° short of the next perihelion,
+	// at n = 0.21424651°/day.
+	epoch := time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC)
+	wantT := epoch.Add(time.Duration((360 - 188.70269) / 0.21424651 * 24 * float64(time.Hour)))
+	if d := o.Perihelion.Sub(wantT); d.Abs() > time.Hour {
+		t.Errorf("Perihelion = %v, want %v", o.Perihelion, wantT)
+	}
+
+	x, y, z := o.elements().Heliocentric(epoch)
+	if r := math.Sqrt(x*x + y*y + z*z); r < o.PerihelionDistance || r > 2.7660512*(1+0.0789010) {
+		t.Errorf("heliocentric distance %.3f AU outside the orbit", r)
+	}
+
+	if _, err := ParseMPCORBElements(ceresMPCORB[:90]); err == nil {
+		t.Error("short line parsed, want an error")
+	}
+	bad := strings.Replace(ceresMPCORB, "K2555", "K25Z5", 1)
+	if _, err := ParseMPCORBElements(bad); err == nil {
+		t.Error("bad epoch parsed, want an error")
+	}
+}
+
+func TestUnpackEpoch(t *testing.T) {
+	for packed, want := range map[string]time.Time{
+		"K2555": time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC),
+		"J9611": time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"K24CV": time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := unpackEpoch(packed)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("unpackEpoch(%q) = %v, %v; want %v", packed, got, err, want)
+		}
+	}
+}
+
+func TestParseSBDBElements(t *testing.T) {
+	// The same orbit in both forms the API can give.
+	const meanAnomaly = `{"object":{"fullname":"1 Ceres (A801 AA)"},"orbit":{"epoch":"2460800.5","equinox":"J2000","elements":[
+		{"name":"e","value":"0.0789010"},{"name":"a","value":"2.7660512"},{"name":"i","value":"10.58780"},
+		{"name":"om","value":"80.25221"},{"name":"w","value":"73.27343"},{"name":"ma","value":"188.70269"},
+		{"name":"tp","value":null}]}}`
+	ceres, err := ParseSBDBElements(strings.NewReader(meanAnomaly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ceres.Name != "1 Ceres (A801 AA)" {
+		t.Errorf("Name = %q", ceres.Name)
+	}
+	mpc, _ := ParseMPCORBElements(ceresMPCORB)
+	if d := ceres.Perihelion.Sub(mpc.Perihelion); d.Abs() > time.Minute {
+		t.Errorf("Perihelion = %v, want %v as from MPCORB", ceres.Perihelion, mpc.Perihelion)
+	}
+
+	const perihelion = `{"object":{"fullname":"C/2020 F3 (NEOWISE)"},"orbit":{"epoch":"2459034.5","elements":[
+		{"name":"e","value":"0.9991780"},{"name":"q","value":"0.2946"},{"name":"i","value":128.94},
+		{"name":"om","value":"61.01"},{"name":"w","value":"37.28"},{"name":"tp","value":"2459034.1799"}]}}`
+	neowise, err := ParseSBDBElements(strings.NewReader(perihelion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2020, time.July, 3, 16, 19, 3, 0, time.UTC); neowise.Perihelion.Sub(want).Abs() > time.Second {
+		t.Errorf("Perihelion = %v, want %v", neowise.Perihelion, want)
+	}
+	if neowise.Inclination != 128.94 {
+		t.Errorf("Inclination = %v, want 128.94 from a JSON number", neowise.Inclination)
+	}
+
+	if _, err := ParseSBDBElements(strings.NewReader(`{"message":"specified object was not found"}`)); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want the API's message", err)
+	}
+}
+
+func TestLookupElements(t *testing.T) {
+	file := strings.Join([]string{
+		"MPCORB.DAT header, not elements",
+		"Des'n     H     G   Epoch     M        Peri.      Node       Incl.       e            n           a",
+		"----------------------------------------------------------------------------------------------------",
+		ceresMPCORB,
+		"0001P         1986 02  9.4590  0.587104  0.967277  111.8657   58.8601  162.2422  19860205   4.0  6.0  1P/Halley",
+	}, "\n")
+
+	for des, want := range map[string]string{
+		"ceres":     "(1) Ceres",
+		"1":         "(1) Ceres",
+		"(1) Ceres": "(1) Ceres",
+		"1P":        "1P/Halley",
+		"Halley":    "1P/Halley",
+	} {
+		o, err := LookupElements(strings.NewReader(file), des)
+		if err != nil {
+			t.Errorf("LookupElements(%q): %v", des, err)
+			continue
+		}
+		if o.Name != want {
+			t.Errorf("LookupElements(%q) = %q, want %q", des, o.Name, want)
+		}
+	}
+
+	if _, err := LookupElements(strings.NewReader(file), "Vesta"); !errors.Is(err, ErrUnknownSmallBody) {
+		t.Errorf("err = %v, want ErrUnknownSmallBody", err)
+	}
+}
+
+func TestDesignations(t *testing.T) {
+	got := designations("C/2020 F3 (NEOWISE)")
+	want := []string{"C/2020 F3 (NEOWISE)", "NEOWISE", "C/2020 F3"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("designations = %q, want %q", got, want)
+	}
+}
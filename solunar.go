@@ -0,0 +1,138 @@
+package astroglide
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// SolunarPeriod is one of the solunar feeding periods of fishing and hunting
+// tables, centred on a lunar event.
+type SolunarPeriod struct {
+	Start  time.Time
+	End    time.Time
+	Center time.Time // the lunar event the period is built around
+	Major  bool      // true for a major (transit) period
+	Event  string    // "lunar noon", "lunar midnight", "moonrise" or "moonset"
+}
+
+// SolunarDay holds the solunar periods of a local calendar date.
+type SolunarDay struct {
+	Date time.Time // local midnight starting the day
+
+	// Periods lists the major and minor periods in order of their centre.
+	// A date usually has two of each, but the Moon's daily delay means one
+	// is sometimes missing.
+	Periods []SolunarPeriod
+
+	// Phase is the Moon's phase at local noon.
+	Phase MoonPhase
+
+	// Rating grades the day from 1 (poor) to 4 (best). Days within about
+	// a day and a half of New or Full Moon start at 3, those within about
+	// four days at 2, and the rest at 1; a period containing sunrise or
+	// sunset adds one more.
+	Rating int
+}
+
+// Half-widths of the solunar periods around their lunar events.
+const (
+	solunarMajorHalf = time.Hour
+	solunarMinorHalf = 30 * time.Minute
+)
+
+// SolunarPeriods computes the solunar periods for the given local calendar
+// date, in the date's time zone: major periods of two hours centred on the
+// Moon's upper and lower transits (LunarTransitFor) and minor periods of an
+// hour centred on moonrise and moonset (RiseSetFor). The periods are
+// tradition rather than science; the times themselves are as accurate as
+// the underlying events.
+func SolunarPeriods(loc Coordinates, date time.Time) (SolunarDay, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return SolunarDay{}, err
+	}
+
+	tz := date.Location()
+	year, month, day := date.Date()
+	sd := SolunarDay{Date: timeutil.StartOfDay(date)}
+
+	add := func(center time.Time, major bool, event string) {
+		half := solunarMinorHalf
+		if major {
+			half = solunarMajorHalf
+		}
+		sd.Periods = append(sd.Periods, SolunarPeriod{
+			Start:  center.Add(-half),
+			End:    center.Add(half),
+			Center: center,
+			Major:  major,
+			Event:  event,
+		})
+	}
+
+	lt, err := LunarTransitFor(loc, date)
+	if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+		return SolunarDay{}, err
+	}
+	if lt.HasUpper {
+		add(lt.Upper, true, "lunar noon")
+	}
+	if lt.HasLower {
+		add(lt.Lower, true, "lunar midnight")
+	}
+
+	rs, err := RiseSetFor(Moon, loc, date)
+	if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+		return SolunarDay{}, err
+	}
+	if rs.HasRise {
+		add(rs.Rise, false, "moonrise")
+	}
+	if rs.HasSet {
+		add(rs.Set, false, "moonset")
+	}
+
+	sort.Slice(sd.Periods, func(i, j int) bool {
+		return sd.Periods[i].Center.Before(sd.Periods[j].Center)
+	})
+
+	sd.Phase, err = MoonPhaseAt(time.Date(year, month, day, 12, 0, 0, 0, tz))
+	if err != nil {
+		return SolunarDay{}, err
+	}
+
+	// The Moon moves about 12° a day from the Sun.
+	switch e := sd.Phase.Elongation; {
+	case e < 20 || e > 160:
+		sd.Rating = 3
+	case e < 50 || e > 130:
+		sd.Rating = 2
+	default:
+		sd.Rating = 1
+	}
+
+	sunRS, err := RiseSetFor(Sun, loc, date)
+	if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+		return SolunarDay{}, err
+	}
+	if sd.contains(sunRS.Rise, sunRS.HasRise) || sd.contains(sunRS.Set, sunRS.HasSet) {
+		sd.Rating++
+	}
+
+	return sd, nil
+}
+
+// contains reports whether t, if ok, falls within one of the periods.
+func (sd SolunarDay) contains(t time.Time, ok bool) bool {
+	if !ok {
+		return false
+	}
+	for _, p := range sd.Periods {
+		if !t.Before(p.Start) && !t.After(p.End) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolunarPeriods(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+
+	ratings := map[int]int{}
+	for d := 0; d < 30; d++ {
+		date := time.Date(2025, time.March, 1+d, 0, 0, 0, 0, mst)
+		sd, err := SolunarPeriods(phx, date)
+		if err != nil {
+			t.Fatalf("%s: SolunarPeriods error: %v", date.Format("2006-01-02"), err)
+		}
+		ratings[sd.Rating]++
+
+		var majors, minors int
+		for i, p := range sd.Periods {
+			if i > 0 && p.Center.Before(sd.Periods[i-1].Center) {
+				t.Errorf("%s: periods out of order", date.Format("2006-01-02"))
+			}
+			want := time.Hour
+			if p.Major {
+				majors++
+				want = 2 * time.Hour
+			} else {
+				minors++
+			}
+			if got := p.End.Sub(p.Start); got != want {
+				t.Errorf("%s: %s period lasts %v, want %v", date.Format("2006-01-02"), p.Event, got, want)
+			}
+			if p.Center.Sub(p.Start) != p.End.Sub(p.Center) {
+				t.Errorf("%s: %s period not centred on its event", date.Format("2006-01-02"), p.Event)
+			}
+		}
+		if majors < 1 || majors > 2 || minors < 1 || minors > 2 {
+			t.Errorf("%s: %d major and %d minor periods", date.Format("2006-01-02"), majors, minors)
+		}
+		if sd.Rating < 1 || sd.Rating > 4 {
+			t.Errorf("%s: rating %d out of range", date.Format("2006-01-02"), sd.Rating)
+		}
+	}
+
+	// Mar 14 2025 is a Full Moon and Mar 29 a New Moon; the quarters rate 1.
+	for _, c := range []struct {
+		day     int
+		atLeast int
+		atMost  int
+	}{{14, 3, 4}, {29, 3, 4}, {6, 1, 2}, {22, 1, 2}} {
+		sd, _ := SolunarPeriods(phx, time.Date(2025, time.March, c.day, 0, 0, 0, 0, mst))
+		if sd.Rating < c.atLeast || sd.Rating > c.atMost {
+			t.Errorf("Mar %d: rating %d, want %d..%d (elongation %.1f)", c.day, sd.Rating, c.atLeast, c.atMost, sd.Phase.Elongation)
+		}
+	}
+	if ratings[1] == 0 || ratings[3]+ratings[4] == 0 {
+		t.Errorf("ratings over a month = %v, want a spread", ratings)
+	}
+}
@@ -0,0 +1,204 @@
+// Package stars is a small embedded catalog of named bright stars, for
+// questions like "when does Sirius rise". Each Star is an
+// astroglide.EphemerisProvider:
+//
+//	sirius, err := stars.Lookup("Sirius")
+//	rs, err := astroglide.ObjectRiseSetFor(sirius, loc, date)
+//
+// The catalog holds 177 stars with Hipparcos positions and proper motions:
+// the IAU-named stars down to about magnitude 3, plus a few fainter
+// favourites such as the Pleiades. It is not a complete list of named
+// stars to magnitude 3.5 (about 300); for a star it lacks, pass catalog
+// coordinates to astroglide.FixedObject.
+package stars
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/thurmanmarka/astroglide"
+)
+
+// Star is a catalog star.
+type Star struct {
+	Name        string // IAU name, e.g. "Sirius"
+	Designation string // Bayer or Flamsteed designation, e.g. "α CMa"
+
+	// RA and Dec are the ICRS (J2000.0) position in degrees at epoch
+	// J2000.0.
+	RA, Dec float64
+
+	// PMRA and PMDec are the proper motion in milliarcseconds per year;
+	// PMRA includes the cos(Dec) factor, as catalogs give it.
+	PMRA, PMDec float64
+
+	Mag float64 // visual (V) magnitude; variable for Betelgeuse, Algol, Mira
+}
+
+// ErrNotFound is returned by Lookup when no star matches.
+var ErrNotFound = errors.New("star not found")
+
+//go:embed data/stars.tsv
+var catalogData []byte
+
+var (
+	catalogOnce sync.Once
+	catalog     []entry // brightest first
+	catalogErr  error
+)
+
+// entry is a Star with its folded names for matching.
+type entry struct {
+	Star
+	keys []string
+}
+
+// Lookup finds a star by IAU name ("Sirius", "Kaus Australis") or by
+// designation, with the Greek letter written as a symbol, a name or its
+// three-letter abbreviation ("α CMa", "alpha CMa", "alf CMa"). Matching
+// ignores case, spaces and punctuation.
+func Lookup(name string) (Star, error) {
+	catalogOnce.Do(loadCatalog)
+	if catalogErr != nil {
+		return Star{}, catalogErr
+	}
+	want := fold(name)
+	if want == "" {
+		return Star{}, fmt.Errorf("%w: empty name", ErrNotFound)
+	}
+	for _, e := range catalog {
+		for _, k := range e.keys {
+			if k == want {
+				return e.Star, nil
+			}
+		}
+	}
+	return Star{}, fmt.Errorf("%w: %q", ErrNotFound, strings.TrimSpace(name))
+}
+
+// All returns every star in the catalog, brightest first.
+func All() []Star {
+	catalogOnce.Do(loadCatalog)
+	stars := make([]Star, len(catalog))
+	for i, e := range catalog {
+		stars[i] = e.Star
+	}
+	return stars
+}
+
+// Constellation returns the IAU abbreviation of the star's constellation,
+// taken from its designation, e.g. "CMa" for Sirius.
+func (s Star) Constellation() string {
+	f := strings.Fields(s.Designation)
+	if len(f) < 2 {
+		return ""
+	}
+	return f[1]
+}
+
+// At returns the star's J2000.0 position at t, with proper motion applied
+// (linearly, which is good to well under an arcsecond for centuries).
+func (s Star) At(t time.Time) astroglide.Equatorial {
+	years := t.Sub(astroglide.J2000).Hours() / (24 * 365.25)
+	const masPerDegree = 3.6e6
+	dec := s.Dec + s.PMDec*years/masPerDegree
+	ra := s.RA
+	if c := math.Cos(s.Dec * math.Pi / 180); c > 1e-9 {
+		ra += s.PMRA * years / masPerDegree / c
+	}
+	return astroglide.Equatorial{RA: ra, Dec: dec}
+}
+
+// Equatorial implements astroglide.EphemerisProvider: the star's position
+// at t with proper motion applied, precessed to the equinox of date. Stars
+// are too far away for parallax, so the distance is 0.
+func (s Star) Equatorial(t time.Time) (ra, dec, distKm float64) {
+	eq := astroglide.PrecessToDate(s.At(t), t)
+	return eq.RA, eq.Dec, 0
+}
+
+func loadCatalog() {
+	sc := bufio.NewScanner(bytes.NewReader(catalogData))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "name\t") {
+			continue
+		}
+		f := strings.Split(line, "\t")
+		if len(f) != 7 {
+			catalogErr = fmt.Errorf("star catalog line %d: %d fields, want 7", n, len(f))
+			return
+		}
+		var nums [5]float64
+		for i := range nums {
+			v, err := strconv.ParseFloat(f[2+i], 64)
+			if err != nil {
+				catalogErr = fmt.Errorf("star catalog line %d: bad number %q", n, f[2+i])
+				return
+			}
+			nums[i] = v
+		}
+		s := Star{
+			Name:        f[0],
+			Designation: f[1],
+			RA:          nums[0],
+			Dec:         nums[1],
+			PMRA:        nums[2],
+			PMDec:       nums[3],
+			Mag:         nums[4],
+		}
+		catalog = append(catalog, entry{Star: s, keys: keys(s)})
+	}
+	if err := sc.Err(); err != nil {
+		catalogErr = fmt.Errorf("star catalog: %w", err)
+	}
+}
+
+// keys returns the folded spellings a star matches: its name and its
+// designation with the Greek letter as a symbol, a name and an
+// abbreviation.
+func keys(s Star) []string {
+	ks := []string{fold(s.Name), fold(s.Designation)}
+	letter, rest, _ := strings.Cut(s.Designation, " ")
+	for _, g := range greek {
+		if base, ok := strings.CutPrefix(letter, g.symbol); ok {
+			ks = append(ks, fold(g.name+base+" "+rest), fold(g.abbrev+base+" "+rest))
+			break
+		}
+	}
+	return ks
+}
+
+// fold lowercases s and drops everything but letters and digits, so
+// "Kaus Australis", "kaus-australis" and "KausAustralis" all match.
+func fold(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// greek lists the Bayer letters with their names and the three-letter
+// abbreviations used by SIMBAD and the Yale Bright Star Catalogue.
+var greek = []struct{ symbol, name, abbrev string }{
+	{"α", "alpha", "alf"}, {"β", "beta", "bet"}, {"γ", "gamma", "gam"},
+	{"δ", "delta", "del"}, {"ε", "epsilon", "eps"}, {"ζ", "zeta", "zet"},
+	{"η", "eta", "eta"}, {"θ", "theta", "tet"}, {"ι", "iota", "iot"},
+	{"κ", "kappa", "kap"}, {"λ", "lambda", "lam"}, {"μ", "mu", "mu."},
+	{"ν", "nu", "nu."}, {"ξ", "xi", "ksi"}, {"ο", "omicron", "omi"},
+	{"π", "pi", "pi."}, {"ρ", "rho", "rho"}, {"σ", "sigma", "sig"},
+	{"τ", "tau", "tau"}, {"υ", "upsilon", "ups"}, {"φ", "phi", "phi"},
+	{"χ", "chi", "chi"}, {"ψ", "psi", "psi"}, {"ω", "omega", "ome"},
+}
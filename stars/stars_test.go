@@ -0,0 +1,112 @@
+package stars
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thurmanmarka/astroglide"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"Sirius", "sirius", "α CMa", "alpha CMa", "alf cma", " SIRIUS "} {
+		s, err := Lookup(name)
+		if err != nil {
+			t.Errorf("Lookup(%q): %v", name, err)
+			continue
+		}
+		if s.Name != "Sirius" || s.Constellation() != "CMa" {
+			t.Errorf("Lookup(%q) = %+v", name, s)
+		}
+	}
+	if s, err := Lookup("kaus-australis"); err != nil || s.Designation != "ε Sgr" {
+		t.Errorf("Lookup(kaus-australis) = %+v, %v", s, err)
+	}
+	if s, err := Lookup("gamma1 Leo"); err != nil || s.Name != "Algieba" {
+		t.Errorf("Lookup(gamma1 Leo) = %+v, %v", s, err)
+	}
+	for _, name := range []string{"", "Vulcan"} {
+		if _, err := Lookup(name); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Lookup(%q) err = %v, want ErrNotFound", name, err)
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	all := All()
+	if len(all) < 150 {
+		t.Fatalf("catalog has %d stars", len(all))
+	}
+	seen := map[string]bool{}
+	for i, s := range all {
+		if seen[s.Name] {
+			t.Errorf("%s listed twice", s.Name)
+		}
+		seen[s.Name] = true
+		if i > 0 && s.Mag < all[i-1].Mag {
+			t.Errorf("%s (%.2f) after fainter %s", s.Name, s.Mag, all[i-1].Name)
+		}
+		if s.RA < 0 || s.RA >= 360 || s.Dec < -90 || s.Dec > 90 || s.Constellation() == "" {
+			t.Errorf("bad entry %+v", s)
+		}
+	}
+}
+
+func TestProperMotion(t *testing.T) {
+	// Arcturus moves 2.28"/yr, about a Moon diameter in 800 years.
+	arcturus, err := Lookup("Arcturus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	then := astroglide.J2000.AddDate(100, 0, 0)
+	d := astroglide.AngularSeparationEquatorial(arcturus.At(astroglide.J2000), arcturus.At(then)) * 3600
+	if math.Abs(d-228) > 1 {
+		t.Errorf("Arcturus moved %.1f\" in a century, want 228\"", d)
+	}
+}
+
+func TestSiriusRiseSet(t *testing.T) {
+	sirius, err := Lookup("Sirius")
+	if err != nil {
+		t.Fatal(err)
+	}
+	phx := astroglide.Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, mst)
+
+	rs, err := astroglide.ObjectRiseSetFor(sirius, phx, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := astroglide.ObjectTransitFor(sirius, phx, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// In mid-January Sirius rises at dusk and culminates near midnight,
+	// 40 degrees up from Phoenix.
+	if !rs.HasRise || rs.Rise.Hour() < 17 || rs.Rise.Hour() > 19 {
+		t.Errorf("rise = %v, want early evening", rs.Rise)
+	}
+	if !tr.HasUpper || tr.Upper.Hour() != 23 && tr.Upper.Hour() != 0 {
+		t.Errorf("upper transit = %v, want near midnight", tr.Upper)
+	}
+	if math.Abs(tr.UpperAltitude-(90-phx.Lat-16.75)) > 0.2 {
+		t.Errorf("transit altitude = %.2f", tr.UpperAltitude)
+	}
+}
+
+func TestConstellations(t *testing.T) {
+	// The IAU boundaries must put every star in the constellation of its
+	// designation.
+	for _, s := range All() {
+		eq := s.At(astroglide.J2000)
+		c, err := astroglide.ConstellationAt(eq.RA, eq.Dec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Abbrev != s.Constellation() {
+			t.Errorf("%s (%s) is in %s", s.Name, s.Designation, c.Abbrev)
+		}
+	}
+}
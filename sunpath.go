@@ -0,0 +1,142 @@
+package astroglide
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/sun"
+)
+
+// SunPathPoint is the Sun's geometric position (no refraction) at one
+// instant.
+type SunPathPoint struct {
+	Time     time.Time
+	Altitude float64 // degrees above the horizon
+	Azimuth  float64 // degrees from north through east
+}
+
+// SunPathArc is a run of positions above the horizon, in time order.
+type SunPathArc struct {
+	Label  string // e.g. "Jun 21" or "15h"
+	Points []SunPathPoint
+}
+
+// SunPath holds the curves of a sun-path diagram for one location and year.
+type SunPath struct {
+	Coordinates Coordinates
+	Year        int
+
+	// DateArcs trace the Sun across the sky on the 21st of each month,
+	// except that March, June, September and December use the day of the
+	// equinox or solstice. Each arc starts and ends on the horizon unless
+	// the Sun stays up all day; days when it stays down have no arc.
+	DateArcs []SunPathArc
+
+	// HourLines join the Sun's positions at each hour of local apparent
+	// (sundial) time from the June to the December solstice. An hour that
+	// dips below the horizon part of the way gives more than one arc with
+	// the same label.
+	HourLines []SunPathArc
+}
+
+// sunPathStep is the sampling interval of the date arcs.
+const sunPathStep = 5 * time.Minute
+
+// SunPathData computes the curves of a sun-path diagram (the Sun's daily
+// arcs and the hour lines) for loc in the given year. Dates are taken in tz
+// (UTC if nil) and the returned times are in tz.
+//
+// Positions are geometric and seen from the Earth's centre, as on printed
+// sun-path charts; the difference from the observer's view is under 9".
+func SunPathData(loc Coordinates, year int, tz *time.Location) (SunPath, error) {
+	if tz == nil {
+		tz = time.UTC
+	}
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, tz)
+	if err := checkInputs(loc, jan1); err != nil {
+		return SunPath{}, err
+	}
+	if err := checkRange(jan1.AddDate(1, 0, 0).Add(-time.Nanosecond)); err != nil {
+		return SunPath{}, err
+	}
+
+	sp := SunPath{Coordinates: loc, Year: year}
+
+	// The day of each equinox or solstice, by solar longitude.
+	cardinal := map[time.Month]float64{
+		time.March: 0, time.June: 90, time.September: 180, time.December: 270,
+	}
+	for m := time.January; m <= time.December; m++ {
+		day := time.Date(year, m, 21, 0, 0, 0, 0, tz)
+		if lon, ok := cardinal[m]; ok {
+			y, mm, d := approxSolarLongitudeDate(lon, year).In(tz).Date()
+			day = time.Date(y, mm, d, 0, 0, 0, 0, tz)
+		}
+
+		var samples []SunPathPoint
+		end := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, tz)
+		for t := day; !t.After(end); t = t.Add(sunPathStep) {
+			samples = append(samples, sunPathPoint(loc, t))
+		}
+		for _, run := range aboveHorizon(loc, samples) {
+			sp.DateArcs = append(sp.DateArcs, SunPathArc{Label: day.Format("Jan 2"), Points: run})
+		}
+	}
+
+	// Hour lines run between the solstices, so each crosses the full range
+	// of declination once.
+	june := approxSolarLongitudeDate(90, year)
+	december := approxSolarLongitudeDate(270, year)
+	var noons []time.Time
+	for d := june; d.Before(december); d = d.AddDate(0, 0, 3) {
+		noons = append(noons, sun.TransitForDate(loc.Lon, d.In(tz)))
+	}
+	noons = append(noons, sun.TransitForDate(loc.Lon, december.In(tz)))
+
+	for h := 0; h < 24; h++ {
+		samples := make([]SunPathPoint, len(noons))
+		for i, noon := range noons {
+			samples[i] = sunPathPoint(loc, noon.Add(time.Duration(h-12)*time.Hour).In(tz))
+		}
+		label := time.Date(2000, 1, 1, h, 0, 0, 0, time.UTC).Format("15h")
+		for _, run := range aboveHorizon(loc, samples) {
+			sp.HourLines = append(sp.HourLines, SunPathArc{Label: label, Points: run})
+		}
+	}
+
+	return sp, nil
+}
+
+// sunPathPoint returns the Sun's geocentric position at t.
+func sunPathPoint(loc Coordinates, t time.Time) SunPathPoint {
+	pos, _ := PositionAt(Sun, loc, t, Geocentric)
+	return SunPathPoint{Time: t, Altitude: pos.Altitude, Azimuth: pos.Azimuth}
+}
+
+// aboveHorizon splits samples into runs above the horizon. Where a run
+// starts or ends between two samples, the horizon crossing (interpolated in
+// time) is added so the curve meets the horizon.
+func aboveHorizon(loc Coordinates, samples []SunPathPoint) [][]SunPathPoint {
+	var runs [][]SunPathPoint
+	var cur []SunPathPoint
+	for i, p := range samples {
+		if i > 0 && (p.Altitude >= 0) != (samples[i-1].Altitude >= 0) {
+			q := samples[i-1]
+			f := q.Altitude / (q.Altitude - p.Altitude)
+			at := q.Time.Add(time.Duration(f * float64(p.Time.Sub(q.Time))))
+			x := sunPathPoint(loc, at)
+			x.Altitude = 0
+			cur = append(cur, x)
+			if q.Altitude >= 0 {
+				runs = append(runs, cur)
+				cur = nil
+			}
+		}
+		if p.Altitude >= 0 {
+			cur = append(cur, p)
+		}
+	}
+	if len(cur) > 0 {
+		runs = append(runs, cur)
+	}
+	return runs
+}
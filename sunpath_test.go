@@ -0,0 +1,84 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSunPathData_Phoenix(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	tz := time.FixedZone("MST", -7*3600)
+
+	sp, err := SunPathData(phx, 2025, tz)
+	if err != nil {
+		t.Fatalf("SunPathData error: %v", err)
+	}
+	if len(sp.DateArcs) != 12 {
+		t.Fatalf("got %d date arcs, want 12", len(sp.DateArcs))
+	}
+
+	// Noon altitude is 90° - lat + declination.
+	want := map[string]float64{
+		"Mar 20": 90 - 33.4484,
+		"Jun 20": 90 - 33.4484 + 23.44,
+		"Dec 21": 90 - 33.4484 - 23.44,
+	}
+	for _, arc := range sp.DateArcs {
+		first, last := arc.Points[0], arc.Points[len(arc.Points)-1]
+		if first.Altitude != 0 || last.Altitude != 0 {
+			t.Errorf("%s: arc does not start and end on the horizon", arc.Label)
+		}
+		if first.Azimuth > 180 || last.Azimuth < 180 {
+			t.Errorf("%s: rises at %.1f°, sets at %.1f°", arc.Label, first.Azimuth, last.Azimuth)
+		}
+		alt, ok := want[arc.Label]
+		if !ok {
+			continue
+		}
+		delete(want, arc.Label)
+		top := 0.0
+		for _, p := range arc.Points {
+			top = math.Max(top, p.Altitude)
+		}
+		if math.Abs(top-alt) > 0.3 {
+			t.Errorf("%s: highest altitude %.2f°, want %.2f°", arc.Label, top, alt)
+		}
+	}
+	for label := range want {
+		t.Errorf("no arc labelled %q", label)
+	}
+
+	// The noon line lies on the meridian, due south.
+	for _, line := range sp.HourLines {
+		if line.Label != "12h" {
+			continue
+		}
+		for _, p := range line.Points {
+			if math.Abs(p.Azimuth-180) > 0.5 {
+				t.Errorf("noon line at %s: azimuth %.2f°, want 180°", p.Time.Format("2006-01-02"), p.Azimuth)
+			}
+		}
+	}
+}
+
+func TestSunPathData_Polar(t *testing.T) {
+	tromso := Coordinates{Lat: 69.6496, Lon: 18.9560}
+
+	sp, err := SunPathData(tromso, 2025, nil)
+	if err != nil {
+		t.Fatalf("SunPathData error: %v", err)
+	}
+
+	for _, arc := range sp.DateArcs {
+		switch arc.Label {
+		case "Dec 21":
+			t.Errorf("polar night: got an arc with %d points", len(arc.Points))
+		case "Jun 21":
+			// Midnight sun: the whole day is above the horizon.
+			if n := len(arc.Points); n != 24*12+1 {
+				t.Errorf("midnight sun: got %d points, want %d", n, 24*12+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// highCloudKm is the typical height of the high clouds an afterglow
+// lights.
+const highCloudKm = 8.0
+
+// Weather is the cloud and aerosol state at one place and time, as
+// supplied by a WeatherProvider.
+type Weather struct {
+	// Cloud cover fractions [0, 1] of the low (below ~2 km), mid (2-6 km)
+	// and high (above ~6 km) layers.
+	LowCloud, MidCloud, HighCloud float64
+
+	// AerosolOpticalDepth is the aerosol optical depth at 550 nm: about
+	// 0.05 in very clean air, 0.1-0.3 in ordinary haze and above 1 in
+	// smoke or dust.
+	AerosolOpticalDepth float64
+}
+
+// WeatherProvider supplies forecast or observed conditions to
+// SunsetQuality. astroglide does no network access; implement it on top
+// of a weather API or model output.
+type WeatherProvider interface {
+	Conditions(loc Coordinates, t time.Time) (Weather, error)
+}
+
+// SunsetForecast is a sunset-quality estimate and the geometry behind it.
+type SunsetForecast struct {
+	Sunset  time.Time // sunset, true instant in the date's time zone
+	Azimuth float64   // the Sun's azimuth at sunset, degrees
+
+	// Upstream is the point towards the sunset, LightPathKm away, over
+	// which the last sunlight passes on its way to high clouds overhead;
+	// low cloud there shades them.
+	Upstream    Coordinates
+	LightPathKm float64
+
+	// GlowEnd is when the Sun sinks too low to light high clouds overhead,
+	// the end of the afterglow; zero if not reached on the date.
+	GlowEnd time.Time
+
+	Local, Horizon Weather // conditions at the observer and at Upstream
+
+	// Score is the estimated quality, 0 (grey or nothing to see) to 100
+	// (a vivid, long-lasting afterglow).
+	Score int
+}
+
+// SunsetQuality estimates how colourful the sunset at loc on the local
+// calendar date of date will be, from weather supplied by weather at the
+// observer and at the upstream point under the light path.
+//
+// The best sunsets light a broken deck of mid and high cloud overhead from
+// below, through a clear horizon and moderately hazy air; a low overcast
+// at either place, a cloudless sky or heavy smoke spoil them. The score is
+// a heuristic built on that rule, not a calibrated forecast. If the Sun
+// does not set, the error matches ErrNoRiseNoSet. A nil weather is an
+// error.
+func SunsetQuality(loc Coordinates, date time.Time, weather WeatherProvider) (SunsetForecast, error) {
+	if weather == nil {
+		return SunsetForecast{}, errors.New("sunset quality needs a WeatherProvider, got nil")
+	}
+	if err := checkInputs(loc, date); err != nil {
+		return SunsetForecast{}, err
+	}
+
+	cfg := newConfig(nil)
+	_, set, _, okSet := cfg.sunCrossings(loc, date, 90-cfg.sunZenith())
+	if !okSet {
+		return SunsetForecast{}, noCrossing(Sun, "sunset", loc, date, cfg.sunUpAllDay(loc, date, 90-cfg.sunZenith()))
+	}
+
+	pos, err := PositionAt(Sun, loc, set, Geocentric)
+	if err != nil {
+		return SunsetForecast{}, err
+	}
+
+	// Sunlight grazing the surface reaches cloud at height h from
+	// sqrt(2Rh) away, and lights it until the Sun is acos(R/(R+h)) down.
+	pathKm := math.Sqrt(2 * coord.MeanEarthRadiusKm * highCloudKm)
+	upLat, upLon := coord.Destination(loc.Lat, loc.Lon, pos.Azimuth, pathKm)
+	glowDepression := timeutil.Rad2Deg(math.Acos(coord.MeanEarthRadiusKm / (coord.MeanEarthRadiusKm + highCloudKm)))
+
+	f := SunsetForecast{
+		Sunset:      set.In(date.Location()),
+		Azimuth:     pos.Azimuth,
+		Upstream:    Coordinates{Lat: upLat, Lon: upLon},
+		LightPathKm: pathKm,
+	}
+	if _, end, _, ok := cfg.sunCrossings(loc, date, -glowDepression); ok && end.After(set) {
+		f.GlowEnd = end.In(date.Location())
+	}
+
+	if f.Local, err = weather.Conditions(loc, set); err != nil {
+		return SunsetForecast{}, err
+	}
+	if f.Horizon, err = weather.Conditions(f.Upstream, set); err != nil {
+		return SunsetForecast{}, err
+	}
+
+	glow := time.Duration(0)
+	if !f.GlowEnd.IsZero() {
+		glow = f.GlowEnd.Sub(set)
+	}
+	f.Score = sunsetScore(f.Local, f.Horizon, glow)
+	return f, nil
+}
+
+// sunsetScore combines the conditions into a 0-100 score.
+func sunsetScore(local, horizon Weather, glow time.Duration) int {
+	clamp := func(x float64) float64 { return math.Min(math.Max(x, 0), 1) }
+
+	// A canvas of mid/high cloud is best around half cover.
+	canvas := clamp(local.HighCloud + 0.7*local.MidCloud)
+	s := 1 - math.Abs(canvas-0.5)/0.5*0.8
+
+	// Low cloud overhead hides the canvas; low or mid cloud upstream
+	// blocks the light reaching it.
+	s *= 1 - 0.8*clamp(local.LowCloud)
+	s *= 1 - 0.9*clamp(horizon.LowCloud+0.5*horizon.MidCloud)
+
+	// Some haze deepens the reds; heavy aerosol mutes everything.
+	switch aod := local.AerosolOpticalDepth; {
+	case aod > 0.6:
+		s *= clamp(1 - (aod-0.6)/1.4)
+	case aod >= 0.1:
+		s *= 1.1
+	}
+
+	// A slow sunset (high latitude, near the solstices) keeps the colours
+	// up longer: 20 minutes or more of glow counts fully.
+	s *= 0.8 + 0.2*clamp(glow.Minutes()/20)
+
+	return int(math.Round(100 * clamp(s)))
+}
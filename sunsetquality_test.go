@@ -0,0 +1,80 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// stubWeather returns local conditions at the observer and horizon
+// conditions everywhere else.
+type stubWeather struct {
+	observer       Coordinates
+	local, horizon Weather
+	asked          []Coordinates
+}
+
+func (w *stubWeather) Conditions(loc Coordinates, t time.Time) (Weather, error) {
+	w.asked = append(w.asked, loc)
+	if loc == w.observer {
+		return w.local, nil
+	}
+	return w.horizon, nil
+}
+
+func TestSunsetQuality(t *testing.T) {
+	loc := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.FixedZone("MST", -7*60*60))
+
+	good := &stubWeather{observer: loc, local: Weather{HighCloud: 0.4, MidCloud: 0.2, AerosolOpticalDepth: 0.15}}
+	f, err := SunsetQuality(loc, date, good)
+	if err != nil {
+		t.Fatalf("SunsetQuality error: %v", err)
+	}
+	if f.Score < 80 {
+		t.Errorf("broken high cloud, clear horizon: Score = %d, want >= 80", f.Score)
+	}
+
+	// The June Sun sets in the north-west; the upstream point lies that
+	// way, about 320 km off.
+	if f.Azimuth < 290 || f.Azimuth > 305 {
+		t.Errorf("Azimuth = %.1f, want ~299", f.Azimuth)
+	}
+	if math.Abs(f.LightPathKm-319) > 2 || f.Upstream.Lat <= loc.Lat || f.Upstream.Lon >= loc.Lon {
+		t.Errorf("Upstream = %+v at %.0f km", f.Upstream, f.LightPathKm)
+	}
+	if len(good.asked) != 2 || good.asked[1] != f.Upstream {
+		t.Errorf("provider asked about %v", good.asked)
+	}
+	if glow := f.GlowEnd.Sub(f.Sunset); glow < 10*time.Minute || glow > 20*time.Minute {
+		t.Errorf("afterglow lasts %v", glow)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		weather *stubWeather
+	}{
+		{"blocked horizon", &stubWeather{observer: loc, local: good.local, horizon: Weather{LowCloud: 1}}},
+		{"low overcast", &stubWeather{observer: loc, local: Weather{LowCloud: 1, HighCloud: 0.5}}},
+		{"clear sky", &stubWeather{observer: loc}},
+		{"smoke", &stubWeather{observer: loc, local: Weather{HighCloud: 0.5, AerosolOpticalDepth: 2}}},
+	} {
+		got, err := SunsetQuality(loc, date, tt.weather)
+		if err != nil {
+			t.Fatalf("%s: SunsetQuality error: %v", tt.name, err)
+		}
+		if got.Score >= f.Score/2 {
+			t.Errorf("%s: Score = %d, want well below %d", tt.name, got.Score, f.Score)
+		}
+	}
+
+	_, err = SunsetQuality(Coordinates{Lat: 78.22, Lon: 15.65}, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC), good)
+	if !errors.Is(err, ErrAlwaysUp) {
+		t.Errorf("midnight sun: err = %v, want ErrAlwaysUp", err)
+	}
+
+	if _, err := SunsetQuality(loc, date, nil); err == nil {
+		t.Error("nil WeatherProvider: no error")
+	}
+}
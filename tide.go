@@ -0,0 +1,99 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/moon"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// TideTendency is the qualitative spring/neap state of the tides.
+type TideTendency int
+
+const (
+	TideIntermediate TideTendency = iota // between spring and neap
+	TideSpring                           // near New or Full Moon: larger range
+	TideNeap                             // near the quarters: smaller range
+)
+
+// String returns a human-readable name for the tendency.
+func (k TideTendency) String() string {
+	switch k {
+	case TideSpring:
+		return "Spring"
+	case TideNeap:
+		return "Neap"
+	default:
+		return "Intermediate"
+	}
+}
+
+// perigeanDistanceKm is the Moon distance below which a spring tide is
+// flagged as perigean. Perigees range from roughly 356,000 to 370,000 km.
+const perigeanDistanceKm = 362000.0
+
+// TideHint is a qualitative indication of tidal range derived only from the
+// Sun–Moon geometry. It is not a tide prediction: local tides lag the
+// astronomical forcing by a day or more and depend heavily on coastline and
+// weather.
+type TideHint struct {
+	Time     time.Time
+	Tendency TideTendency
+
+	// Strength is the combined solar and lunar tide-raising force relative
+	// to a mean spring tide: about 1.0 at an average New or Full Moon,
+	// about 0.37 at an average quarter, and up to ~1.3 at perigee.
+	Strength float64
+
+	// Perigean is true for a spring tide with the Moon near perigee
+	// (closer than 362,000 km), when ranges are noticeably larger.
+	Perigean bool
+
+	Elongation     float64 // ecliptic Moon − Sun longitude, degrees [0, 360)
+	MoonDistanceKm float64 // geocentric Earth–Moon distance
+}
+
+// TideCoefficientHint classifies the spring/neap tendency at t from the
+// Sun–Moon elongation and the lunar distance.
+//
+// The lunar and solar tidal forces are combined as vectors 2·elongation
+// apart, with the lunar term scaled by the inverse cube of distance and the
+// solar term fixed at 0.46 of the mean lunar one. Within 30° of syzygy the
+// tendency is Spring; within 30° of quadrature it is Neap.
+func TideCoefficientHint(t time.Time) (TideHint, error) {
+	if err := checkRange(t); err != nil {
+		return TideHint{}, err
+	}
+
+	const (
+		meanDistKm = 384400.0
+		solarRatio = 0.46
+	)
+
+	elong := eclipticElongation(t)
+	dist := moon.GeocentricEquatorialWithDistanceApprox(t.UTC()).Distance
+
+	lunar := math.Pow(meanDistKm/dist, 3)
+	c := timeutil.CosD(2 * elong)
+	combined := math.Sqrt(lunar*lunar + solarRatio*solarRatio + 2*lunar*solarRatio*c)
+
+	h := TideHint{
+		Time:           t,
+		Strength:       combined / (1 + solarRatio),
+		Elongation:     elong,
+		MoonDistanceKm: dist,
+	}
+
+	switch {
+	case c >= timeutil.CosD(60):
+		h.Tendency = TideSpring
+		h.Perigean = dist < perigeanDistanceKm
+	case c <= -timeutil.CosD(60):
+		h.Tendency = TideNeap
+	default:
+		h.Tendency = TideIntermediate
+	}
+
+	return h, nil
+}
@@ -0,0 +1,47 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTideCoefficientHint(t *testing.T) {
+	tests := []struct {
+		name         string
+		t            time.Time
+		wantTendency TideTendency
+		wantPerigean bool
+	}{
+		// Full Moon 2025-05-12 16:56 UTC, Moon near apogee.
+		{"full moon near apogee", time.Date(2025, 5, 12, 17, 0, 0, 0, time.UTC), TideSpring, false},
+		// First quarter 2025-05-04 13:52 UTC.
+		{"first quarter", time.Date(2025, 5, 4, 14, 0, 0, 0, time.UTC), TideNeap, false},
+		// Full Moon 2024-10-17 11:26 UTC, a day after perigee (357,175 km).
+		{"perigean full moon", time.Date(2024, 10, 17, 11, 0, 0, 0, time.UTC), TideSpring, true},
+		// Midway between New Moon (2025-04-27) and first quarter (2025-05-04).
+		{"waxing crescent", time.Date(2025, 4, 30, 22, 0, 0, 0, time.UTC), TideIntermediate, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TideCoefficientHint(tt.t)
+			if err != nil {
+				t.Fatalf("TideCoefficientHint error: %v", err)
+			}
+			if got.Tendency != tt.wantTendency || got.Perigean != tt.wantPerigean {
+				t.Errorf("got %v (perigean %v, elongation %.1f°, %.0f km), want %v (perigean %v)",
+					got.Tendency, got.Perigean, got.Elongation, got.MoonDistanceKm,
+					tt.wantTendency, tt.wantPerigean)
+			}
+		})
+	}
+}
+
+func TestTideCoefficientHint_StrengthOrdering(t *testing.T) {
+	spring, _ := TideCoefficientHint(time.Date(2024, 10, 17, 11, 0, 0, 0, time.UTC))
+	neap, _ := TideCoefficientHint(time.Date(2025, 5, 4, 14, 0, 0, 0, time.UTC))
+
+	if !(spring.Strength > 1 && neap.Strength < 0.6) {
+		t.Errorf("perigean spring strength %.2f, neap strength %.2f", spring.Strength, neap.Strength)
+	}
+}
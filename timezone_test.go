@@ -0,0 +1,298 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Zones offset from UTC by a fraction of an hour, or by more than 12
+// hours, are where converting between UTC and local dates goes wrong: the
+// UTC date of a local evening in Kiribati is the day before, and a clock
+// truncated to whole hours is off by 45 minutes in Nepal.
+
+var oddZoneSites = []struct {
+	name   string
+	coords Coordinates
+	tz     string
+}{
+	{"Kathmandu", Coordinates{Lat: 27.7172, Lon: 85.3240}, "Asia/Kathmandu"},        // +05:45
+	{"Eucla", Coordinates{Lat: -31.6771, Lon: 128.8892}, "Australia/Eucla"},         // +08:45
+	{"Nuku'alofa", Coordinates{Lat: -21.1394, Lon: -175.2018}, "Pacific/Tongatapu"}, // +13
+	{"Kiritimati", Coordinates{Lat: 1.8721, Lon: -157.4278}, "Pacific/Kiritimati"},  // +14
+	{"Chatham Islands", Coordinates{Lat: -43.95, Lon: -176.55}, "Pacific/Chatham"},  // +12:45, +13:45 in summer
+	{"Pago Pago", Coordinates{Lat: -14.2756, Lon: -170.7020}, "Pacific/Pago_Pago"},  // -11
+}
+
+// oddZoneReference holds 2025 sunrises and sunsets from NOAA's solar
+// calculator algorithm (refSunEvent), rounded to the minute, with the UTC
+// offset printed on the local clock.
+var oddZoneReference = []struct {
+	site      string
+	month     time.Month
+	day       int
+	rise, set string // "15:04 -0700"
+}{
+	{"Kathmandu", time.January, 1, "06:55 +0545", "17:20 +0545"},
+	{"Kathmandu", time.June, 21, "05:09 +0545", "19:02 +0545"},
+	{"Kathmandu", time.September, 22, "05:52 +0545", "18:00 +0545"},
+	{"Eucla", time.January, 1, "05:08 +0845", "19:18 +0845"},
+	{"Eucla", time.June, 21, "07:09 +0845", "17:14 +0845"},
+	{"Eucla", time.March, 20, "06:13 +0845", "18:21 +0845"},
+	{"Nuku'alofa", time.January, 1, "06:02 +1300", "19:26 +1300"},
+	{"Nuku'alofa", time.June, 21, "07:17 +1300", "18:08 +1300"},
+	{"Kiritimati", time.January, 1, "06:33 +1400", "18:34 +1400"},
+	{"Kiritimati", time.June, 21, "06:25 +1400", "18:38 +1400"},
+	{"Kiritimati", time.December, 31, "06:32 +1400", "18:33 +1400"},
+	{"Chatham Islands", time.January, 1, "05:52 +1345", "21:17 +1345"},
+	{"Chatham Islands", time.June, 21, "08:06 +1245", "17:00 +1245"},
+	{"Chatham Islands", time.September, 22, "06:21 +1245", "18:28 +1245"},
+}
+
+func loadOddZone(t *testing.T, name string) (Coordinates, *time.Location) {
+	t.Helper()
+	for _, s := range oddZoneSites {
+		if s.name == name {
+			tz, err := time.LoadLocation(s.tz)
+			if err != nil {
+				t.Skipf("tzdata unavailable: %v", err)
+			}
+			return s.coords, tz
+		}
+	}
+	t.Fatalf("unknown site %q", name)
+	return Coordinates{}, nil
+}
+
+func TestOddZones_SunAgainstReference(t *testing.T) {
+	for _, ref := range oddZoneReference {
+		loc, tz := loadOddZone(t, ref.site)
+		date := time.Date(2025, ref.month, ref.day, 0, 0, 0, 0, tz)
+		rs, err := SlideIntoSunset(loc, date)
+		if err != nil {
+			t.Fatalf("%s %s: %v", ref.site, date.Format("2006-01-02"), err)
+		}
+		for _, ev := range []struct {
+			what string
+			got  time.Time
+			want string
+		}{{"sunrise", rs.Rise, ref.rise}, {"sunset", rs.Set, ref.set}} {
+			want, err := time.ParseInLocation("2006-01-02 15:04 -0700", date.Format("2006-01-02 ")+ev.want, tz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, off := ev.got.Zone(); off != offsetOf(want) {
+				t.Errorf("%s %s %s: got %s, want offset of %s", ref.site, date.Format("2006-01-02"), ev.what, ev.got.Format("15:04 -0700"), ev.want)
+			}
+			if d := diffMinutes(ev.got, want); d > 1 {
+				t.Errorf("%s %s %s: got %s, reference %s (off by %.1f min)",
+					ref.site, date.Format("2006-01-02"), ev.what, ev.got.Format("15:04:05 -0700"), ev.want, d)
+			}
+		}
+	}
+}
+
+func offsetOf(t time.Time) int {
+	_, off := t.Zone()
+	return off
+}
+
+// TestOddZones_EventsOnTheirLocalDate walks a year of dates in each zone
+// and checks that every rise and set lies on the date it is reported for,
+// and that pinning to the date leaves the instants alone.
+func TestOddZones_EventsOnTheirLocalDate(t *testing.T) {
+	for _, s := range oddZoneSites {
+		loc, tz := loadOddZone(t, s.name)
+		for i := 0; i < 365; i += 3 {
+			date := time.Date(2025, time.January, 1+i, 12, 0, 0, 0, tz)
+			want := date.Format("2006-01-02")
+			for _, body := range []Body{Sun, Moon} {
+				pinned, err := RiseSetFor(body, loc, date)
+				if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+					t.Fatalf("%s %s %v: %v", s.name, want, body, err)
+				}
+				exact, _ := RiseSetFor(body, loc, date, WithTrueInstants())
+				if !pinned.Rise.Equal(exact.Rise) || !pinned.Set.Equal(exact.Set) {
+					t.Errorf("%s %s %v: pinned %v, true instants %v", s.name, want, body, pinned, exact)
+				}
+				if got := pinned.Date.Format("2006-01-02"); err == nil && got != want {
+					t.Errorf("%s %s %v: Date is %s", s.name, want, body, pinned.Date)
+				}
+				for _, ev := range []struct {
+					ok bool
+					t  time.Time
+				}{{exact.HasRise, exact.Rise}, {exact.HasSet, exact.Set}} {
+					if ev.ok && ev.t.Format("2006-01-02") != want {
+						t.Errorf("%s %s %v: event at %s is on another date", s.name, want, body, ev.t)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestWithLocalDate_RepeatedHour checks that pinning an event already on
+// the date keeps it in the right one of the two hours that read the same.
+func TestWithLocalDate_RepeatedHour(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	est := time.Date(2025, 11, 2, 1, 30, 0, 0, ny).Add(time.Hour) // 01:30 EST, the second 01:30
+	if got := withLocalDate(est, 2025, time.November, 2); !got.Equal(est) {
+		t.Errorf("withLocalDate(%s) = %s", est, got)
+	}
+
+	// Moving to another date keeps the clock reading, even in Kiribati.
+	kiri, err := time.LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	ev := time.Date(2025, 3, 9, 0, 20, 15, 0, kiri)
+	got := withLocalDate(ev, 2025, time.March, 8)
+	if want := time.Date(2025, 3, 8, 0, 20, 15, 0, kiri); !got.Equal(want) {
+		t.Errorf("withLocalDate(%s) = %s, want %s", ev, got, want)
+	}
+}
+
+// TestPreviousEvent_SunsetAfterMidnight covers a sunset that genuinely
+// falls on the next local date: in Reykjavik around the June solstice the
+// Sun sets just after midnight, so each date's RiseSet has the sunset that
+// ended the previous date's daylight, before its own sunrise.
+func TestPreviousEvent_SunsetAfterMidnight(t *testing.T) {
+	ice, err := time.LoadLocation("Atlantic/Reykjavik")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	reykjavik := Coordinates{Lat: 64.1466, Lon: -21.9426}
+
+	rs, err := SlideIntoSunset(reykjavik, time.Date(2025, 6, 21, 0, 0, 0, 0, ice), WithTrueInstants())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rs.HasRise || !rs.HasSet || !rs.Set.Before(rs.Rise) {
+		t.Fatalf("expected the sunset before the sunrise, got %v", rs)
+	}
+
+	prev, err := PreviousEvent(Sun, reykjavik, rs.Rise, EventSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !prev.Time.Equal(rs.Set) {
+		t.Errorf("PreviousEvent = %s, want %s", prev.Time, rs.Set)
+	}
+
+	next, err := NextEvent(Sun, reykjavik, rs.Rise, EventSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := next.Time.Format("2006-01-02 15"); got != "2025-06-22 00" {
+		t.Errorf("sunset after the 21st's sunrise is %s, want just after midnight on the 22nd", next.Time)
+	}
+}
+
+func TestPreviousEvent_MirrorsNextEvent(t *testing.T) {
+	tz, err := time.LoadLocation("Asia/Kathmandu")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	loc := Coordinates{Lat: 27.7172, Lon: 85.3240}
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, tz)
+
+	for _, kinds := range [][]EventKind{{EventRise}, {EventSet}, {EventTransit}, {EventFullMoon}, nil} {
+		t0 := start
+		for i := 0; i < 5; i++ {
+			next, err := NextEvent(Moon, loc, t0, kinds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev, err := PreviousEvent(Moon, loc, next.Time.Add(time.Second), kinds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prev.Kind != next.Kind || prev.Time.Sub(next.Time).Abs() > time.Second {
+				t.Errorf("%v: NextEvent %v, PreviousEvent back from it %v", kinds, next, prev)
+			}
+			if !prev.Time.Before(next.Time.Add(time.Second)) {
+				t.Errorf("%v: PreviousEvent %v not before %v", kinds, prev, next.Time)
+			}
+			t0 = next.Time
+		}
+	}
+
+	// A date without a moonrise: the neighbouring ones are on the dates on
+	// either side.
+	for i := 0; i < 40; i++ {
+		date := start.AddDate(0, 0, i)
+		rs, err := RiseSetFor(Moon, loc, date, WithTrueInstants())
+		if err != nil && !errors.Is(err, ErrNoRiseNoSet) {
+			t.Fatal(err)
+		}
+		if rs.HasRise {
+			continue
+		}
+		midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, tz)
+		prev, err := PreviousEvent(Moon, loc, midnight, EventRise)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := NextEvent(Moon, loc, midnight, EventRise)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prev.Time.YearDay() != midnight.AddDate(0, 0, -1).YearDay() || next.Time.YearDay() != midnight.AddDate(0, 0, 1).YearDay() {
+			t.Errorf("no moonrise on %s, but neighbours are %s and %s", midnight.Format("2006-01-02"), prev.Time, next.Time)
+		}
+		return
+	}
+	t.Error("no date without a moonrise in 40 days")
+}
+
+// TestWithRounding_FractionalOffset checks that rounding counts from local
+// midnight in zones a fraction of an hour from UTC: sunrise and sunset in
+// Kathmandu on 2025-06-21 are 05:08:34 and 19:02:27, and in Eucla (+08:45)
+// rounding by the hour must still land on whole local hours.
+func TestWithRounding_FractionalOffset(t *testing.T) {
+	cases := []struct {
+		zone  string
+		loc   Coordinates
+		round time.Duration
+		rise  string
+		set   string
+	}{
+		{"Asia/Kathmandu", Coordinates{Lat: 27.7172, Lon: 85.3240}, 30 * time.Minute, "05:00", "19:00"},
+		{"Asia/Kathmandu", Coordinates{Lat: 27.7172, Lon: 85.3240}, time.Hour, "05:00", "19:00"},
+		{"Asia/Kathmandu", Coordinates{Lat: 27.7172, Lon: 85.3240}, time.Minute, "05:09", "19:02"},
+	}
+	for _, c := range cases {
+		tz, err := time.LoadLocation(c.zone)
+		if err != nil {
+			t.Skipf("tzdata unavailable: %v", err)
+		}
+		rs, err := SlideIntoSunset(c.loc, time.Date(2025, 6, 21, 0, 0, 0, 0, tz), WithRounding(c.round))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rs.Rise.Format("15:04:05"); got != c.rise+":00" {
+			t.Errorf("%s sunrise rounded to %v = %s, want %s", c.zone, c.round, got, c.rise)
+		}
+		if got := rs.Set.Format("15:04:05"); got != c.set+":00" {
+			t.Errorf("%s sunset rounded to %v = %s, want %s", c.zone, c.round, got, c.set)
+		}
+	}
+
+	eucla := time.FixedZone("+0845", 8*3600+45*60)
+	rs, err := SlideIntoSunset(Coordinates{Lat: -31.6770, Lon: 128.8830}, time.Date(2025, 6, 21, 0, 0, 0, 0, eucla), WithRounding(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exact, err := SlideIntoSunset(Coordinates{Lat: -31.6770, Lon: 128.8830}, time.Date(2025, 6, 21, 0, 0, 0, 0, eucla))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range [][2]time.Time{{exact.Rise, rs.Rise}, {exact.Set, rs.Set}} {
+		if p[1].Minute() != 0 || p[1].Second() != 0 || p[1].Sub(p[0]).Abs() > 30*time.Minute {
+			t.Errorf("Eucla %v rounded to the hour = %v", p[0].Format("15:04:05"), p[1].Format("15:04:05"))
+		}
+	}
+}
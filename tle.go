@@ -0,0 +1,149 @@
+package astroglide
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/satellite"
+)
+
+// ErrTLEChecksum is returned, wrapped, for a TLE line whose modulo-10
+// checksum in column 69 does not match its content.
+var ErrTLEChecksum = satellite.ErrChecksum
+
+// ParseTLE parses a two-line element set, optionally preceded by a title
+// line (the three-line format). A title in Space-Track's "0 NAME" form has
+// the leading "0 " removed. The element set is checked as by Validate.
+func ParseTLE(s string) (TLE, error) {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimRight(l, " \r"); l != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	var tle TLE
+	switch len(lines) {
+	case 2:
+		tle = TLE{Line1: lines[0], Line2: lines[1]}
+	case 3:
+		tle = TLE{Name: tleTitle(lines[0]), Line1: lines[1], Line2: lines[2]}
+	default:
+		return TLE{}, fmt.Errorf("TLE has %d lines, want 2 or 3", len(lines))
+	}
+	if err := tle.Validate(); err != nil {
+		return TLE{}, err
+	}
+	return tle, nil
+}
+
+// tleTitle trims a title line and the "0 " that Space-Track puts before
+// the name.
+func tleTitle(line string) string {
+	line = strings.TrimSpace(line)
+	if name, ok := strings.CutPrefix(line, "0 "); ok {
+		return strings.TrimSpace(name)
+	}
+	return line
+}
+
+// Validate checks that t is a well-formed element set: line numbers 1 and
+// 2, the same catalog number on both lines, valid checksums (wrapping
+// ErrTLEChecksum otherwise), and parsable elements. SatellitePasses does
+// not insist on checksums, as hand-edited element sets often lack them.
+func (t TLE) Validate() error {
+	line1 := strings.TrimRight(t.Line1, " \r\n")
+	line2 := strings.TrimRight(t.Line2, " \r\n")
+	if !strings.HasPrefix(line1, "1 ") || !strings.HasPrefix(line2, "2 ") {
+		return fmt.Errorf("TLE %q: lines do not start with 1 and 2", t.Name)
+	}
+	if len(line1) < 69 || len(line2) < 69 {
+		return fmt.Errorf("TLE %q: lines are %d and %d columns, want 69", t.Name, len(line1), len(line2))
+	}
+	if line1[2:7] != line2[2:7] {
+		return fmt.Errorf("TLE %q: catalog numbers %q and %q differ", t.Name, line1[2:7], line2[2:7])
+	}
+	for _, l := range []string{line1, line2} {
+		if err := satellite.VerifyChecksum(l); err != nil {
+			return fmt.Errorf("TLE %q: %w", t.Name, err)
+		}
+	}
+	if _, err := satellite.ParseElements(line1, line2); err != nil {
+		return fmt.Errorf("TLE %q: %w", t.Name, err)
+	}
+	return nil
+}
+
+// CatalogNumber returns the satellite's NORAD catalog number as written in
+// columns 3-7 of line 1, e.g. "25544". Numbers above 99999 use the Alpha-5
+// form ("A0000" for 100000) and are returned as is.
+func (t TLE) CatalogNumber() string {
+	if len(t.Line1) < 7 {
+		return ""
+	}
+	return strings.TrimSpace(t.Line1[2:7])
+}
+
+// Epoch returns the instant the elements are fitted to. SGP4 predictions
+// degrade by about a kilometre a day away from it, faster in low orbits,
+// so compare it with the prediction time before trusting a pass.
+func (t TLE) Epoch() (time.Time, error) {
+	el, err := satellite.ParseElements(t.Line1, t.Line2)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return el.Epoch, nil
+}
+
+// LoadTLEs reads a catalog of element sets in CelesTrak's format: each set
+// is two data lines, optionally preceded by a title line. Blank lines are
+// ignored. Every set is checked as by Validate; the first bad one stops
+// the load with an error giving its line number.
+func LoadTLEs(r io.Reader) ([]TLE, error) {
+	var (
+		tles    []TLE
+		title   string
+		line1   string
+		line1At int
+		n       int
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		n++
+		line := strings.TrimRight(sc.Text(), " \r")
+		switch {
+		case line == "":
+			continue
+
+		case line1 != "":
+			if !strings.HasPrefix(line, "2 ") {
+				return nil, fmt.Errorf("line %d: want line 2 of the element set on line %d", n, line1At)
+			}
+			tle := TLE{Name: title, Line1: line1, Line2: line}
+			if err := tle.Validate(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line1At, err)
+			}
+			tles = append(tles, tle)
+			title, line1 = "", ""
+
+		case strings.HasPrefix(line, "1 "):
+			line1, line1At = line, n
+
+		case strings.HasPrefix(line, "2 "):
+			return nil, fmt.Errorf("line %d: line 2 without line 1", n)
+
+		default:
+			title = tleTitle(line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if line1 != "" {
+		return nil, fmt.Errorf("line %d: element set ends after line 1", line1At)
+	}
+	return tles, nil
+}
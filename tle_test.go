@@ -0,0 +1,82 @@
+package astroglide
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const issTLE = `ISS (ZARYA)
+1 25544U 98067A   08264.51782528 -.00002182  00000-0 -11606-4 0  2927
+2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.72125391563537`
+
+func TestParseTLE(t *testing.T) {
+	tle, err := ParseTLE(issTLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tle.Name != "ISS (ZARYA)" || tle.CatalogNumber() != "25544" {
+		t.Errorf("got name %q, catalog number %q", tle.Name, tle.CatalogNumber())
+	}
+	epoch, err := tle.Epoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Day 264.51782528 of 2008 is September 20, 12:25:40.1 UTC.
+	want := time.Date(2008, time.September, 20, 12, 25, 40, 104e6, time.UTC)
+	if d := epoch.Sub(want); d.Abs() > time.Millisecond {
+		t.Errorf("Epoch = %v, want %v", epoch, want)
+	}
+
+	// Two-line form and Space-Track's "0 " title.
+	lines := strings.Split(issTLE, "\n")
+	if tle, err := ParseTLE(lines[1] + "\n" + lines[2]); err != nil || tle.Name != "" {
+		t.Errorf("two-line ParseTLE = %+v, %v", tle, err)
+	}
+	if tle, err := ParseTLE("0 ISS (ZARYA)\r\n" + lines[1] + "\r\n" + lines[2] + "\r\n"); err != nil || tle.Name != "ISS (ZARYA)" {
+		t.Errorf("three-line ParseTLE with 0 title = %+v, %v", tle, err)
+	}
+}
+
+func TestParseTLEErrors(t *testing.T) {
+	lines := strings.Split(issTLE, "\n")
+	badSum := lines[1][:68] + "8"
+	if _, err := ParseTLE(badSum + "\n" + lines[2]); !errors.Is(err, ErrTLEChecksum) {
+		t.Errorf("bad checksum: err = %v, want ErrTLEChecksum", err)
+	}
+
+	otherSat := strings.Replace(lines[2], "25544", "25545", 1)
+	for name, s := range map[string]string{
+		"one line":        lines[1],
+		"swapped lines":   lines[2] + "\n" + lines[1],
+		"catalog numbers": lines[1] + "\n" + otherSat,
+		"short line":      lines[1][:60] + "\n" + lines[2],
+	} {
+		if _, err := ParseTLE(s); err == nil {
+			t.Errorf("%s: ParseTLE succeeded, want an error", name)
+		}
+	}
+}
+
+func TestLoadTLEs(t *testing.T) {
+	catalog := issTLE + "\n\n" + vanguard1.Line1 + "\n" + vanguard1.Line2 + "\n"
+	tles, err := LoadTLEs(strings.NewReader(catalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tles) != 2 || tles[0].Name != "ISS (ZARYA)" || tles[1].Name != "" || tles[1].CatalogNumber() != "00005" {
+		t.Fatalf("LoadTLEs = %+v", tles)
+	}
+
+	lines := strings.Split(issTLE, "\n")
+	for name, s := range map[string]string{
+		"missing line 2": issTLE + "\nNEXT\n" + vanguard1.Line1 + "\n",
+		"stray line 2":   lines[0] + "\n" + lines[2],
+		"bad checksum":   lines[0] + "\n" + lines[1][:68] + "0\n" + lines[2],
+	} {
+		if _, err := LoadTLEs(strings.NewReader(s)); err == nil {
+			t.Errorf("%s: LoadTLEs succeeded, want an error", name)
+		}
+	}
+}
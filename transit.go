@@ -0,0 +1,76 @@
+package astroglide
+
+import (
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/coord"
+	"github.com/thurmanmarka/astroglide/internal/solver"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// LunarTransit holds the Moon's meridian transits on a local calendar date.
+//
+// The upper transit ("lunar noon") is the Moon's culmination, when it is
+// highest; the lower transit ("lunar midnight") is its crossing of the
+// meridian below the pole. The Moon transits about 50 minutes later each
+// day, so about once a month a date has no upper or no lower transit;
+// HasUpper and HasLower tell which exist.
+type LunarTransit struct {
+	Upper time.Time
+	Lower time.Time
+
+	HasUpper bool
+	HasLower bool
+
+	// Topocentric altitudes (degrees, geometric) at each transit. The
+	// lower transit is below the horizon unless the Moon is circumpolar.
+	UpperAltitude float64
+	LowerAltitude float64
+}
+
+// LunarTransitFor returns the Moon's upper and lower meridian transits on
+// the given local calendar date, in the date's time zone, with the Moon's
+// altitude at each. Transit times follow the topocentric hour angle, as
+// seen from loc; they are true instants (see WithTrueInstants).
+//
+// If neither transit falls on the date, a *NoEventError matching
+// ErrNoRiseNoSet is returned.
+func LunarTransitFor(loc Coordinates, date time.Time) (LunarTransit, error) {
+	if err := checkInputs(loc, date); err != nil {
+		return LunarTransit{}, err
+	}
+
+	tz := date.Location()
+	start, end := timeutil.LocalDay(date)
+
+	// sin(hour angle) rises through zero at the upper transit and falls
+	// through it at the lower one, with no jump where the angle wraps.
+	sinHA := func(t time.Time) float64 {
+		pos, _ := PositionAt(Moon, loc, t, Topocentric)
+		return timeutil.SinD(coord.LocalSiderealTime(loc.Lon, t.UTC()) - pos.RA)
+	}
+
+	const (
+		steps = 48 // samples across the day (every 30 minutes)
+		tol   = time.Second
+	)
+
+	var lt LunarTransit
+	for _, c := range solver.FindAllAltitudeEvents(sinHA, start, end, 0, steps, tol) {
+		if !c.Time.Before(end) {
+			continue
+		}
+		pos, _ := PositionAt(Moon, loc, c.Time, Topocentric)
+		switch {
+		case c.Type == solver.CrossingUp && !lt.HasUpper:
+			lt.Upper, lt.UpperAltitude, lt.HasUpper = c.Time.In(tz), pos.Altitude, true
+		case c.Type == solver.CrossingDown && !lt.HasLower:
+			lt.Lower, lt.LowerAltitude, lt.HasLower = c.Time.In(tz), pos.Altitude, true
+		}
+	}
+
+	if !lt.HasUpper && !lt.HasLower {
+		return LunarTransit{}, noEvent(Moon, "transit", loc, date)
+	}
+	return lt, nil
+}
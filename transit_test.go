@@ -0,0 +1,86 @@
+package astroglide
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLunarTransitFor(t *testing.T) {
+	phx := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	mst := time.FixedZone("MST", -7*3600)
+
+	for d := 0; d < 30; d++ {
+		date := time.Date(2025, time.March, 1+d, 0, 0, 0, 0, mst)
+		lt, err := LunarTransitFor(phx, date)
+		if err != nil {
+			t.Fatalf("%s: LunarTransitFor error: %v", date.Format("2006-01-02"), err)
+		}
+		if !lt.HasUpper {
+			continue
+		}
+		if y, m, dd := lt.Upper.Date(); y != 2025 || m != time.March || dd != 1+d {
+			t.Errorf("%s: upper transit %v is on another date", date.Format("2006-01-02"), lt.Upper)
+		}
+
+		// At the upper transit the Moon is due south, at 90 - lat + dec.
+		pos, _ := PositionAt(Moon, phx, lt.Upper, Topocentric)
+		if math.Abs(pos.Azimuth-180) > 0.1 {
+			t.Errorf("%s: azimuth at upper transit = %.3f, want 180", date.Format("2006-01-02"), pos.Azimuth)
+		}
+		if want := 90 - phx.Lat + pos.Dec; math.Abs(lt.UpperAltitude-want) > 0.05 {
+			t.Errorf("%s: upper transit altitude = %.3f, want %.3f", date.Format("2006-01-02"), lt.UpperAltitude, want)
+		}
+		if lt.LowerAltitude >= 0 && lt.HasLower {
+			t.Errorf("%s: lower transit altitude = %.3f, want below the horizon", date.Format("2006-01-02"), lt.LowerAltitude)
+		}
+		if lt.HasLower {
+			gap := lt.Upper.Sub(lt.Lower)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap < 12*time.Hour || gap > 13*time.Hour {
+				t.Errorf("%s: upper and lower transits %v apart, want about 12h25m", date.Format("2006-01-02"), gap)
+			}
+		}
+	}
+}
+
+func TestLunarTransitSkipsADay(t *testing.T) {
+	loc := Coordinates{Lat: 51.5, Lon: 0}
+	missing := 0
+	for d := 0; d < 30; d++ {
+		lt, err := LunarTransitFor(loc, time.Date(2025, time.January, 1+d, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("LunarTransitFor error: %v", err)
+		}
+		if !lt.HasUpper {
+			missing++
+		}
+	}
+	if missing != 1 {
+		t.Errorf("%d days without an upper transit in 30, want 1", missing)
+	}
+}
+
+func TestNextEventMoonTransit(t *testing.T) {
+	loc := Coordinates{Lat: 51.5, Lon: 0}
+	after := time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC)
+
+	ev, err := NextEvent(Moon, loc, after, EventTransit)
+	if err != nil {
+		t.Fatalf("NextEvent error: %v", err)
+	}
+	if !ev.Time.After(after) || ev.Time.Sub(after) > 25*time.Hour {
+		t.Errorf("next lunar transit %v not within a day after %v", ev.Time, after)
+	}
+	pos, _ := PositionAt(Moon, loc, ev.Time, Topocentric)
+	if math.Abs(pos.Azimuth-180) > 0.1 {
+		t.Errorf("azimuth at lunar transit = %.3f, want 180", pos.Azimuth)
+	}
+
+	if _, err := LunarTransitFor(Coordinates{Lat: 91}, after); !errors.Is(err, ErrInvalidCoordinates) {
+		t.Errorf("invalid coordinates: err = %v, want ErrInvalidCoordinates", err)
+	}
+}
@@ -0,0 +1,107 @@
+package astroglide
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// TwilightLength is how long twilight of one kind lasts on a local
+// calendar date: from dawn to sunrise, and from sunset to dusk.
+type TwilightLength struct {
+	Date time.Time // local midnight starting the day
+	Kind TwilightKind
+
+	Dawn time.Duration // from the kind's dawn to sunrise
+	Dusk time.Duration // from sunset to the kind's dusk
+
+	// HasDawn and HasDusk report whether both ends of each twilight were
+	// found. Near the poles the Sun may not set, or may not sink to the
+	// twilight altitude all night (the "white nights"); the duration is
+	// then zero rather than a difference of unrelated times.
+	HasDawn, HasDusk bool
+}
+
+// TwilightDuration returns how long dawn and dusk twilight of the given
+// kind last on the local calendar date of date, with sunrise and sunset
+// defined as for RiseSetFor. Dusk that ends after midnight, or dawn that
+// starts before it, is still counted against the date's sunset or
+// sunrise. Durations are measured between the true instants, whatever the
+// date policy.
+//
+// Twilight is shortest near the equator, where the Sun sets steeply, and
+// at mid-latitudes around the equinoxes.
+func TwilightDuration(loc Coordinates, date time.Time, kind TwilightKind, opts ...Option) (TwilightLength, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return TwilightLength{}, err
+	}
+	targetAlt, ok := kind.altitude()
+	if !ok {
+		return TwilightLength{}, fmt.Errorf("unknown TwilightKind: %d", kind)
+	}
+	if err := cfg.validate(); err != nil {
+		return TwilightLength{}, err
+	}
+
+	tl := TwilightLength{Date: timeutil.StartOfDay(date), Kind: kind}
+
+	rise, set, okRise, okSet := cfg.sunCrossings(loc, date, 90-cfg.sunZenith())
+	dawn, dusk, okDawn, okDusk := cfg.sunCrossings(loc, date, targetAlt)
+
+	// Twilight straddling midnight belongs to the neighbouring date.
+	if okSet && (!okDusk || dusk.Before(set)) {
+		_, dusk, _, okDusk = cfg.sunCrossings(loc, tl.Date.AddDate(0, 0, 1), targetAlt)
+	}
+	if okRise && (!okDawn || dawn.After(rise)) {
+		dawn, _, okDawn, _ = cfg.sunCrossings(loc, tl.Date.AddDate(0, 0, -1), targetAlt)
+	}
+
+	if okRise && okDawn && dawn.Before(rise) && rise.Sub(dawn) < 12*time.Hour {
+		tl.Dawn, tl.HasDawn = rise.Sub(dawn), true
+	}
+	if okSet && okDusk && dusk.After(set) && dusk.Sub(set) < 12*time.Hour {
+		tl.Dusk, tl.HasDusk = dusk.Sub(set), true
+	}
+	return tl, nil
+}
+
+// ShortestTwilightOfYear returns the day of year at loc, with dates in tz
+// (UTC if nil), on which dusk twilight of the given kind is shortest: the
+// "fastest sunset". Days without a complete dusk are skipped; if there are
+// none, the error matches ErrNoRiseNoSet.
+func ShortestTwilightOfYear(loc Coordinates, year int, tz *time.Location, kind TwilightKind, opts ...Option) (TwilightLength, error) {
+	return extremeTwilight(loc, year, tz, kind, func(a, b time.Duration) bool { return a < b }, opts)
+}
+
+// LongestTwilightOfYear is like ShortestTwilightOfYear but returns the
+// day of longest dusk.
+func LongestTwilightOfYear(loc Coordinates, year int, tz *time.Location, kind TwilightKind, opts ...Option) (TwilightLength, error) {
+	return extremeTwilight(loc, year, tz, kind, func(a, b time.Duration) bool { return a > b }, opts)
+}
+
+func extremeTwilight(loc Coordinates, year int, tz *time.Location, kind TwilightKind, better func(a, b time.Duration) bool, opts []Option) (TwilightLength, error) {
+	if tz == nil {
+		tz = time.UTC
+	}
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, tz)
+	if err := newConfig(opts).checkRange(jan1.AddDate(1, 0, 0).Add(-time.Nanosecond)); err != nil {
+		return TwilightLength{}, err
+	}
+
+	var best TwilightLength
+	for day := jan1; day.Year() == year; day = time.Date(year, day.Month(), day.Day()+1, 0, 0, 0, 0, tz) {
+		tl, err := TwilightDuration(loc, day, kind, opts...)
+		if err != nil {
+			return TwilightLength{}, err
+		}
+		if tl.HasDusk && (!best.HasDusk || better(tl.Dusk, best.Dusk)) {
+			best = tl
+		}
+	}
+	if !best.HasDusk {
+		return TwilightLength{}, noEvent(Sun, kind.String()+" dusk", loc, jan1)
+	}
+	return best, nil
+}
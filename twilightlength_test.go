@@ -0,0 +1,76 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTwilightDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		loc      Coordinates
+		date     time.Time
+		min, max time.Duration
+	}{
+		{"equator equinox", Coordinates{Lat: 0, Lon: 0}, time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC), 19 * time.Minute, 23 * time.Minute},
+		{"London equinox", Coordinates{Lat: 51.5, Lon: 0}, time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC), 32 * time.Minute, 36 * time.Minute},
+		{"London midsummer", Coordinates{Lat: 51.5, Lon: 0}, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC), 45 * time.Minute, 50 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tl, err := TwilightDuration(tt.loc, tt.date, TwilightCivil)
+			if err != nil {
+				t.Fatalf("TwilightDuration error: %v", err)
+			}
+			if !tl.HasDawn || !tl.HasDusk {
+				t.Fatalf("HasDawn = %v, HasDusk = %v; want both", tl.HasDawn, tl.HasDusk)
+			}
+			for _, d := range []time.Duration{tl.Dawn, tl.Dusk} {
+				if d < tt.min || d > tt.max {
+					t.Errorf("twilight %v, want %v..%v", d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestTwilightDuration_WhiteNight(t *testing.T) {
+	// At 65°N at midsummer the Sun sets but never reaches -6°.
+	tl, err := TwilightDuration(Coordinates{Lat: 65, Lon: 0}, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC), TwilightCivil)
+	if err != nil {
+		t.Fatalf("TwilightDuration error: %v", err)
+	}
+	if tl.HasDusk || tl.Dusk != 0 {
+		t.Errorf("Dusk = %v, HasDusk = %v; want none", tl.Dusk, tl.HasDusk)
+	}
+}
+
+func TestShortestTwilightOfYear(t *testing.T) {
+	loc := Coordinates{Lat: 51.5, Lon: 0}
+	shortest, err := ShortestTwilightOfYear(loc, 2025, nil, TwilightCivil)
+	if err != nil {
+		t.Fatalf("ShortestTwilightOfYear error: %v", err)
+	}
+	longest, err := LongestTwilightOfYear(loc, 2025, nil, TwilightCivil)
+	if err != nil {
+		t.Fatalf("LongestTwilightOfYear error: %v", err)
+	}
+
+	// Twilight is shortest a little before the March equinox or after the
+	// September one, and longest at midsummer.
+	if m := shortest.Date.Month(); m != time.March && m != time.September && m != time.October {
+		t.Errorf("shortest twilight on %v", shortest.Date.Format("2006-01-02"))
+	}
+	if m := longest.Date.Month(); m != time.June {
+		t.Errorf("longest twilight on %v", longest.Date.Format("2006-01-02"))
+	}
+	if shortest.Dusk >= longest.Dusk {
+		t.Errorf("shortest %v >= longest %v", shortest.Dusk, longest.Dusk)
+	}
+
+	_, err = ShortestTwilightOfYear(Coordinates{Lat: 89, Lon: 0}, 2025, nil, TwilightAstronomical)
+	if !errors.Is(err, ErrNoRiseNoSet) {
+		t.Errorf("near the pole: err = %v, want ErrNoRiseNoSet", err)
+	}
+}
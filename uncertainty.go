@@ -0,0 +1,118 @@
+package astroglide
+
+import (
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/astrotime"
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// Model errors in altitude (degrees) near the horizon, and the root
+// tolerances of the solvers that find the crossings.
+const (
+	sunAltErrLevel1 = 0.2  // closed-form hour angle with a fixed daily declination
+	sunAltErrLevel2 = 0.01 // sampled solar position
+	moonAltErr      = 0.1  // truncated lunar series and parallax
+
+	sunSolverTol  = time.Second
+	moonSolverTol = 30 * time.Second
+
+	maxUncertainty = time.Hour
+)
+
+// The share of an error in ΔT that reaches a rise or set time: a body
+// placed at the wrong TT is off only by its own motion against the stars
+// in that time, a 366th of the Earth's turn for the Sun and a 27th for
+// the Moon.
+const (
+	sunDeltaTShare  = 1 / 366.24
+	moonDeltaTShare = 1 / 27.32
+)
+
+// modelErrorGrowth returns the factor by which the Sun and Moon models'
+// errors are taken to grow at t: 1 within the supported range, and one
+// more for each thousand years outside it. The series were fitted around
+// J2000 and their truncated terms grow with time, but the rate is a
+// rough guess rather than a measured bound.
+func modelErrorGrowth(t time.Time) float64 {
+	var years float64
+	switch {
+	case t.Before(MinSupportedTime):
+		years = timeutil.DaysSinceJ2000(MinSupportedTime) - timeutil.DaysSinceJ2000(t)
+	case !t.Before(MaxSupportedTime):
+		years = timeutil.DaysSinceJ2000(t) - timeutil.DaysSinceJ2000(MaxSupportedTime)
+	}
+	return 1 + years/365.25/1000
+}
+
+// uncertainty estimates the largest error of the given events of body,
+// which are the true instants of altitude crossings: the model's altitude
+// error divided by how fast the altitude changes there, plus the solver
+// tolerance (at least solverTol) and half of any WithRounding step. Where
+// the body barely clears the horizon the altitude changes slowly and the
+// estimate grows, up to maxUncertainty.
+// In historical mode the altitude error grows outside the supported range
+// and the share of the ΔT uncertainty that reaches the event is added.
+func (c config) uncertainty(body Body, loc Coordinates, solverTol time.Duration, events ...time.Time) time.Duration {
+	altErr, tol, dtShare := sunAltErrLevel2, sunSolverTol, sunDeltaTShare
+	switch {
+	case body == Moon:
+		altErr, tol, dtShare = moonAltErr, moonSolverTol, moonDeltaTShare
+	case c.level() == Level1:
+		altErr = sunAltErrLevel1
+	}
+	if solverTol > tol {
+		tol = solverTol
+	}
+	tol += c.round / 2
+
+	var worst time.Duration
+	for _, t := range events {
+		e := altErr
+		if c.historical {
+			e *= modelErrorGrowth(t)
+		}
+		d := maxUncertainty
+		if rate := altitudeRate(body, loc, t); rate > 0 {
+			d = time.Duration(math.Min(e/rate*float64(time.Minute), float64(maxUncertainty)))
+		}
+		if c.historical {
+			d += time.Duration(dtShare * float64(astrotime.DeltaTUncertainty(t)))
+		}
+		if d += tol; d > maxUncertainty {
+			d = maxUncertainty
+		}
+		if d > worst {
+			worst = d
+		}
+	}
+	return worst.Round(time.Second)
+}
+
+// altitudeRate returns how fast body's altitude changes at t, in degrees
+// per minute.
+func altitudeRate(body Body, loc Coordinates, t time.Time) float64 {
+	frame := Geocentric
+	if body == Moon {
+		frame = Topocentric
+	}
+	before, ok1 := positionAt(body, loc, t.Add(-30*time.Second), frame)
+	after, ok2 := positionAt(body, loc, t.Add(30*time.Second), frame)
+	if !ok1 || !ok2 {
+		return 0
+	}
+	return math.Abs(after.Altitude - before.Altitude)
+}
+
+// foundEvents returns the rise and set instants that were found.
+func foundEvents(rise time.Time, okRise bool, set time.Time, okSet bool) []time.Time {
+	var ts []time.Time
+	if okRise {
+		ts = append(ts, rise)
+	}
+	if okSet {
+		ts = append(ts, set)
+	}
+	return ts
+}
@@ -0,0 +1,66 @@
+package astroglide
+
+import "time"
+
+// LightWindow is the part of a local calendar date with usable natural
+// light: from civil dawn to civil dusk, while the Sun is no more than 6°
+// below the horizon.
+type LightWindow struct {
+	Date time.Time // local midnight starting the day
+
+	// Start is civil dawn and End civil dusk. When the Sun is still above
+	// -6° at the start or end of the day (a summer night that never gets
+	// darker than civil twilight), the window runs from or to midnight.
+	Start, End time.Time
+
+	Duration time.Duration // End - Start
+
+	// AllDay is true when the Sun stays above -6° all day.
+	AllDay bool
+}
+
+// UsableLight returns the civil dawn to civil dusk window of the local
+// calendar date of date, the period pilots, drone operators and outdoor
+// crews schedule around. If the Sun stays below -6° all day, the error
+// matches ErrNoRiseNoSet and ErrAlwaysDown.
+//
+// Near the polar circles, dusk may fall after midnight; on the following
+// date the window then starts at dawn and leaves out the light before that
+// late dusk.
+func UsableLight(loc Coordinates, date time.Time, opts ...Option) (LightWindow, error) {
+	cfg := newConfig(opts)
+	if err := cfg.checkInputs(loc, date); err != nil {
+		return LightWindow{}, err
+	}
+	if err := cfg.validate(); err != nil {
+		return LightWindow{}, err
+	}
+
+	targetAlt, _ := TwilightCivil.altitude()
+	rs, okDawn, okDusk := sunAltitudeCrossings(loc, date, targetAlt, opts...)
+
+	midnight := rs.Date
+	next := midnight.AddDate(0, 0, 1)
+	w := LightWindow{Date: midnight, Start: midnight, End: next}
+
+	switch {
+	case !okDawn && !okDusk:
+		if !cfg.sunUpAllDay(loc, date, targetAlt) {
+			return LightWindow{}, noCrossing(Sun, "usable light", loc, date, false)
+		}
+		w.AllDay = true
+	case okDawn && okDusk && rs.Set.Before(rs.Rise):
+		// The previous evening's dusk fell after midnight; the day's light
+		// runs from dawn to the next midnight.
+		w.Start = rs.Rise
+	default:
+		if okDawn {
+			w.Start = rs.Rise
+		}
+		if okDusk {
+			w.End = rs.Set
+		}
+	}
+	w.Duration = w.End.Sub(w.Start)
+	return w, nil
+}
@@ -0,0 +1,48 @@
+package astroglide
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUsableLight(t *testing.T) {
+	loc := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	phx := time.FixedZone("MST", -7*60*60)
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, phx)
+
+	w, err := UsableLight(loc, date)
+	if err != nil {
+		t.Fatalf("UsableLight error: %v", err)
+	}
+	civil, err := TwilightFor(loc, date, TwilightCivil)
+	if err != nil {
+		t.Fatalf("TwilightFor error: %v", err)
+	}
+	if !w.Start.Equal(civil.Rise) || !w.End.Equal(civil.Set) {
+		t.Errorf("window %v..%v, want %v..%v", w.Start, w.End, civil.Rise, civil.Set)
+	}
+	if w.Duration != civil.Set.Sub(civil.Rise) || w.AllDay {
+		t.Errorf("Duration = %v, AllDay = %v", w.Duration, w.AllDay)
+	}
+	if w.Duration < 15*time.Hour || w.Duration > 15*time.Hour+30*time.Minute {
+		t.Errorf("Duration = %v, want about 15h", w.Duration)
+	}
+}
+
+func TestUsableLight_Polar(t *testing.T) {
+	// In Tromsø the Sun never drops below -6° at midsummer; in
+	// Longyearbyen it never rises above it at midwinter.
+	w, err := UsableLight(Coordinates{Lat: 69.65, Lon: 18.96}, time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("UsableLight error: %v", err)
+	}
+	if !w.AllDay || w.Duration != 24*time.Hour {
+		t.Errorf("midsummer: AllDay = %v, Duration = %v; want all day", w.AllDay, w.Duration)
+	}
+
+	_, err = UsableLight(Coordinates{Lat: 78.22, Lon: 15.65}, time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrAlwaysDown) {
+		t.Errorf("polar night: err = %v, want ErrAlwaysDown", err)
+	}
+}
@@ -0,0 +1,162 @@
+package astroglide
+
+import (
+	"time"
+)
+
+// ZmanDefinition defines a twilight zman either by the Sun's depression
+// below the horizon or by a fixed offset from sunrise/sunset. Exactly one of
+// the two fields is expected to be set; Offset takes precedence.
+type ZmanDefinition struct {
+	// Degrees is the solar depression below the horizon (positive, e.g.
+	// 16.1 for alot hashachar).
+	Degrees float64
+
+	// Offset is a fixed interval before sunrise (for dawn-type zmanim) or
+	// after sunset (for nightfall-type zmanim), e.g. 72 minutes.
+	Offset time.Duration
+}
+
+// ZmanDegrees returns a ZmanDefinition based on solar depression.
+func ZmanDegrees(deg float64) ZmanDefinition {
+	return ZmanDefinition{Degrees: deg}
+}
+
+// ZmanMinutes returns a ZmanDefinition based on a fixed number of minutes
+// from sunrise/sunset.
+func ZmanMinutes(minutes float64) ZmanDefinition {
+	return ZmanDefinition{Offset: time.Duration(minutes * float64(time.Minute))}
+}
+
+// SeasonalDay selects which interval is divided into twelve proportional
+// ("seasonal") hours.
+type SeasonalDay int
+
+const (
+	// SeasonalDayGRA divides sunrise to sunset (Vilna Gaon).
+	SeasonalDayGRA SeasonalDay = iota
+
+	// SeasonalDayMGA divides alot hashachar to tzeit (Magen Avraham), using
+	// the configured AlotHashachar and Tzeit definitions.
+	SeasonalDayMGA
+)
+
+// ZmanimConfig configures ZmanimFor. Use DefaultZmanimConfig for common
+// defaults and override individual fields as needed.
+type ZmanimConfig struct {
+	AlotHashachar ZmanDefinition // dawn
+	Misheyakir    ZmanDefinition // earliest tallit and tefillin
+	Tzeit         ZmanDefinition // nightfall
+	SeasonalDay   SeasonalDay    // basis for proportional hours
+}
+
+// DefaultZmanimConfig returns widely used definitions: alot hashachar at
+// 16.1°, misheyakir at 11.5°, tzeit at 8.5°, and GRA seasonal hours.
+func DefaultZmanimConfig() ZmanimConfig {
+	return ZmanimConfig{
+		AlotHashachar: ZmanDegrees(16.1),
+		Misheyakir:    ZmanDegrees(11.5),
+		Tzeit:         ZmanDegrees(8.5),
+		SeasonalDay:   SeasonalDayGRA,
+	}
+}
+
+// Zmanim holds halachic times for one local calendar date. A degree-based
+// zman that does not occur on the date (e.g. high-latitude summer) is left
+// as the zero time.
+type Zmanim struct {
+	AlotHashachar time.Time // dawn
+	Misheyakir    time.Time // earliest tallit and tefillin
+	Sunrise       time.Time // netz hachama
+	SofZmanShma   time.Time // 3 seasonal hours into the day
+	SofZmanTefila time.Time // 4 seasonal hours into the day
+	Chatzot       time.Time // solar noon
+	MinchaGedola  time.Time // 6.5 seasonal hours
+	MinchaKetana  time.Time // 9.5 seasonal hours
+	PlagHamincha  time.Time // 10.75 seasonal hours
+	Sunset        time.Time // shkiah
+	Tzeit         time.Time // nightfall
+
+	// SeasonalHour is one twelfth of the configured seasonal day.
+	SeasonalHour time.Duration
+}
+
+// ZmanimFor computes solar-depression and proportional-hour based zmanim
+// for the given local calendar date and location.
+//
+// Sunrise and sunset use the standard (sea-level, upper limb) definition.
+// If the Sun does not rise or set on the date, ErrNoRiseNoSet is returned.
+// With SeasonalDayMGA, the seasonal hours also require alot hashachar and
+// tzeit to exist.
+func ZmanimFor(loc Coordinates, date time.Time, cfg ZmanimConfig) (Zmanim, error) {
+	rs, err := SlideIntoSunset(loc, date)
+	if err != nil {
+		return Zmanim{}, err
+	}
+	if !rs.HasRise || !rs.HasSet {
+		return Zmanim{}, noEvent(Sun, "rise/set", loc, date)
+	}
+
+	noon, err := SolarNoon(loc, date)
+	if err != nil {
+		return Zmanim{}, err
+	}
+
+	z := Zmanim{
+		Sunrise: rs.Rise,
+		Sunset:  rs.Set,
+		Chatzot: noon,
+	}
+
+	z.AlotHashachar = zmanDawn(loc, date, rs.Rise, cfg.AlotHashachar)
+	z.Misheyakir = zmanDawn(loc, date, rs.Rise, cfg.Misheyakir)
+	z.Tzeit = zmanDusk(loc, date, rs.Set, cfg.Tzeit)
+
+	dayStart, dayEnd := z.Sunrise, z.Sunset
+	if cfg.SeasonalDay == SeasonalDayMGA {
+		if z.AlotHashachar.IsZero() || z.Tzeit.IsZero() {
+			return Zmanim{}, noEvent(Sun, "alot hashachar/tzeit", loc, date)
+		}
+		dayStart, dayEnd = z.AlotHashachar, z.Tzeit
+	}
+
+	z.SeasonalHour = dayEnd.Sub(dayStart) / 12
+
+	at := func(hours float64) time.Time {
+		return dayStart.Add(time.Duration(hours * float64(z.SeasonalHour)))
+	}
+
+	z.SofZmanShma = at(3)
+	z.SofZmanTefila = at(4)
+	z.MinchaGedola = at(6.5)
+	z.MinchaKetana = at(9.5)
+	z.PlagHamincha = at(10.75)
+
+	return z, nil
+}
+
+// zmanDawn resolves a dawn-type definition: a fixed interval before sunrise
+// or the upward crossing of the given solar depression.
+func zmanDawn(loc Coordinates, date time.Time, sunrise time.Time, def ZmanDefinition) time.Time {
+	if def.Offset != 0 {
+		return sunrise.Add(-def.Offset)
+	}
+	rs, ok, _ := sunAltitudeCrossings(loc, date, -def.Degrees)
+	if !ok {
+		return time.Time{}
+	}
+	return rs.Rise
+}
+
+// zmanDusk resolves a nightfall-type definition: a fixed interval after
+// sunset or the downward crossing of the given solar depression.
+func zmanDusk(loc Coordinates, date time.Time, sunset time.Time, def ZmanDefinition) time.Time {
+	if def.Offset != 0 {
+		return sunset.Add(def.Offset)
+	}
+	rs, _, ok := sunAltitudeCrossings(loc, date, -def.Degrees)
+	if !ok {
+		return time.Time{}
+	}
+	return rs.Set
+}
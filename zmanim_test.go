@@ -0,0 +1,103 @@
+package astroglide
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolarNoon_Phoenix(t *testing.T) {
+	loc, err := time.LoadLocation("America/Phoenix")
+	if err != nil {
+		t.Fatalf("failed to load America/Phoenix: %v", err)
+	}
+	coords := Coordinates{Lat: 33.4484, Lon: -112.0740}
+	date := time.Date(2025, time.November, 28, 0, 0, 0, 0, loc)
+
+	noon, err := SolarNoon(coords, date)
+	if err != nil {
+		t.Fatalf("SolarNoon error: %v", err)
+	}
+
+	// Reference: solar noon in Phoenix on 2025-11-28 is about 12:16 MST.
+	want := time.Date(2025, time.November, 28, 12, 16, 0, 0, loc)
+	if d := diffMinutes(noon, want); d > 2 {
+		t.Errorf("SolarNoon = %v, want ~%v (off by %.1f min)", noon, want, d)
+	}
+}
+
+func TestZmanimFor_Jerusalem(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Jerusalem")
+	if err != nil {
+		t.Fatalf("failed to load Asia/Jerusalem: %v", err)
+	}
+	coords := Coordinates{Lat: 31.7683, Lon: 35.2137}
+	date := time.Date(2025, time.March, 20, 0, 0, 0, 0, loc)
+
+	z, err := ZmanimFor(coords, date, DefaultZmanimConfig())
+	if err != nil {
+		t.Fatalf("ZmanimFor error: %v", err)
+	}
+
+	ordered := []struct {
+		name string
+		t    time.Time
+	}{
+		{"alot hashachar", z.AlotHashachar},
+		{"misheyakir", z.Misheyakir},
+		{"sunrise", z.Sunrise},
+		{"sof zman shma", z.SofZmanShma},
+		{"sof zman tefila", z.SofZmanTefila},
+		{"chatzot", z.Chatzot},
+		{"mincha gedola", z.MinchaGedola},
+		{"mincha ketana", z.MinchaKetana},
+		{"plag hamincha", z.PlagHamincha},
+		{"sunset", z.Sunset},
+		{"tzeit", z.Tzeit},
+	}
+	for i := 1; i < len(ordered); i++ {
+		if !ordered[i-1].t.Before(ordered[i].t) {
+			t.Errorf("%s (%v) should precede %s (%v)",
+				ordered[i-1].name, ordered[i-1].t, ordered[i].name, ordered[i].t)
+		}
+	}
+
+	// Near the equinox a seasonal hour is close to 60 minutes.
+	if z.SeasonalHour < 59*time.Minute || z.SeasonalHour > 62*time.Minute {
+		t.Errorf("seasonal hour = %v, want ~1h", z.SeasonalHour)
+	}
+
+	// Chatzot is the midpoint of the GRA day to within a couple of minutes.
+	mid := z.Sunrise.Add(z.Sunset.Sub(z.Sunrise) / 2)
+	if d := diffMinutes(z.Chatzot, mid); d > 2 {
+		t.Errorf("chatzot %v differs from day midpoint %v by %.1f min", z.Chatzot, mid, d)
+	}
+}
+
+func TestZmanimFor_FixedMinutesMGA(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load America/New_York: %v", err)
+	}
+	coords := Coordinates{Lat: 40.7128, Lon: -74.0060}
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, loc)
+
+	cfg := DefaultZmanimConfig()
+	cfg.AlotHashachar = ZmanMinutes(72)
+	cfg.Tzeit = ZmanMinutes(72)
+	cfg.SeasonalDay = SeasonalDayMGA
+
+	z, err := ZmanimFor(coords, date, cfg)
+	if err != nil {
+		t.Fatalf("ZmanimFor error: %v", err)
+	}
+
+	if got := z.Sunrise.Sub(z.AlotHashachar); got != 72*time.Minute {
+		t.Errorf("alot hashachar is %v before sunrise, want 72m", got)
+	}
+	if got := z.Tzeit.Sub(z.Sunset); got != 72*time.Minute {
+		t.Errorf("tzeit is %v after sunset, want 72m", got)
+	}
+	if want := z.AlotHashachar.Add(3 * z.SeasonalHour); !z.SofZmanShma.Equal(want) {
+		t.Errorf("MGA sof zman shma = %v, want %v", z.SofZmanShma, want)
+	}
+}
@@ -0,0 +1,103 @@
+package astroglide
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thurmanmarka/astroglide/internal/timeutil"
+)
+
+// ZodiacSign is one of the twelve 30° sectors of the ecliptic, counted from
+// the zodiac's zero point: the March equinox for the tropical zodiac, or a
+// fixed point among the stars for a sidereal one.
+type ZodiacSign int
+
+const (
+	Aries ZodiacSign = iota
+	Taurus
+	Gemini
+	Cancer
+	Leo
+	Virgo
+	Libra
+	Scorpio
+	Sagittarius
+	Capricorn
+	Aquarius
+	Pisces
+)
+
+var zodiacNames = [12]string{
+	"Aries", "Taurus", "Gemini", "Cancer", "Leo", "Virgo",
+	"Libra", "Scorpio", "Sagittarius", "Capricorn", "Aquarius", "Pisces",
+}
+
+// String returns the sign's English name, e.g. "Taurus".
+func (s ZodiacSign) String() string {
+	if s < Aries || s > Pisces {
+		return fmt.Sprintf("ZodiacSign(%d)", int(s))
+	}
+	return zodiacNames[s]
+}
+
+// Ayanamsa selects a zodiac by its offset from the tropical one: the
+// sidereal zero point's longitude west of the March equinox, AtJ2000
+// degrees at J2000.0 and growing with precession by Rate arcseconds a year.
+// The zero value, like Tropical, is the tropical zodiac.
+type Ayanamsa struct {
+	Name    string
+	AtJ2000 float64 // degrees
+	Rate    float64 // arcseconds per year
+}
+
+// Common zodiacs. For another sidereal ayanamsa, fill in an Ayanamsa with
+// its J2000.0 value and a Rate of 50.29.
+var (
+	Tropical     = Ayanamsa{Name: "Tropical"}
+	Lahiri       = Ayanamsa{Name: "Lahiri", AtJ2000: 23.853, Rate: 50.29}
+	FaganBradley = Ayanamsa{Name: "Fagan-Bradley", AtJ2000: 24.7403, Rate: 50.29}
+	Raman        = Ayanamsa{Name: "Raman", AtJ2000: 22.4108, Rate: 50.29}
+)
+
+// At returns the ayanamsa in degrees at t, using a linear precession rate.
+func (a Ayanamsa) At(t time.Time) float64 {
+	years := timeutil.DaysSinceJ2000(t) / 365.25
+	return a.AtJ2000 + years*a.Rate/3600.0
+}
+
+// ZodiacPosition is a body's place in the zodiac.
+type ZodiacPosition struct {
+	Sign      ZodiacSign
+	Degree    float64 // degrees into the sign, [0, 30)
+	Longitude float64 // ecliptic longitude in the chosen zodiac, [0, 360)
+}
+
+// String formats the position as e.g. "14°32' Taurus".
+func (p ZodiacPosition) String() string {
+	deg := math.Floor(p.Degree)
+	min := math.Floor((p.Degree - deg) * 60)
+	return fmt.Sprintf("%.0f°%02.0f' %v", deg, min, p.Sign)
+}
+
+// ZodiacAt returns the sign and degree of the Sun's or Moon's geocentric
+// ecliptic longitude at t in the zodiac a: Tropical, or a sidereal one
+// such as Lahiri. The Moon crosses a sign in about two and a half days,
+// and its longitude here is good to a few tenths of a degree, so
+// positions within that of a cusp are uncertain.
+func ZodiacAt(body Body, t time.Time, a Ayanamsa) (ZodiacPosition, error) {
+	ecl, err := EclipticOfDate(body, t)
+	if err != nil {
+		return ZodiacPosition{}, err
+	}
+	lon := timeutil.Normalize360(ecl.Lon - a.At(t.UTC()))
+	sign := ZodiacSign(int(lon / 30))
+	if sign > Pisces {
+		sign = Pisces
+	}
+	return ZodiacPosition{
+		Sign:      sign,
+		Degree:    lon - 30*float64(sign),
+		Longitude: lon,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package astroglide
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestZodiacAt(t *testing.T) {
+	cases := []struct {
+		body Body
+		when time.Time
+		a    Ayanamsa
+		want ZodiacSign
+	}{
+		{Sun, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Tropical, Aries},
+		{Sun, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Lahiri, Pisces},
+		{Sun, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Tropical, Leo},
+		{Sun, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), FaganBradley, Cancer},
+		{Sun, time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), Tropical, Capricorn},
+		{Moon, time.Date(2025, 1, 14, 3, 0, 0, 0, time.UTC), Tropical, Cancer},
+	}
+	for _, tc := range cases {
+		z, err := ZodiacAt(tc.body, tc.when, tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if z.Sign != tc.want {
+			t.Errorf("%v on %s in the %s zodiac: %v, want %v", tc.body, tc.when.Format("2006-01-02"), tc.a.Name, z, tc.want)
+		}
+		if z.Degree < 0 || z.Degree >= 30 || math.Abs(z.Longitude-30*float64(z.Sign)-z.Degree) > 1e-9 {
+			t.Errorf("%v on %s: inconsistent position %+v", tc.body, tc.when.Format("2006-01-02"), z)
+		}
+	}
+}
+
+func TestZodiacAyanamsaOffset(t *testing.T) {
+	when := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	trop, err := ZodiacAt(Moon, when, Tropical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sid, err := ZodiacAt(Moon, when, Lahiri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := math.Mod(trop.Longitude-sid.Longitude+360, 360)
+	if math.Abs(diff-Lahiri.At(when)) > 1e-9 {
+		t.Errorf("tropical - sidereal = %.6f°, want the ayanamsa %.6f°", diff, Lahiri.At(when))
+	}
+	// Lahiri is about 24°16' in 2030.
+	if a := Lahiri.At(when); math.Abs(a-24.27) > 0.02 {
+		t.Errorf("Lahiri ayanamsa in 2030 = %.4f°, want about 24.27°", a)
+	}
+	if Tropical.At(when) != 0 || (Ayanamsa{}).At(when) != 0 {
+		t.Error("tropical ayanamsa is not zero")
+	}
+}
+
+func TestZodiacPositionString(t *testing.T) {
+	p := ZodiacPosition{Sign: Taurus, Degree: 14.54, Longitude: 44.54}
+	if got, want := p.String(), "14°32' Taurus"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := ZodiacSign(12).String(); got != "ZodiacSign(12)" {
+		t.Errorf("ZodiacSign(12).String() = %q", got)
+	}
+}